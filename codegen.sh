@@ -40,6 +40,8 @@ function types {
 	./build/gen-type-set --types Chart       --output compose/types/chart.gen.go
 	./build/gen-type-set --types Record      --output compose/types/record.gen.go
 	./build/gen-type-set --types ModuleField --output compose/types/module_field.gen.go
+	./build/gen-type-set --types RecordRevision --output compose/types/record_revision.gen.go
+	./build/gen-type-set --types RecordView  --output compose/types/record_view.gen.go
 
 	./build/gen-type-set-test --types Namespace   --output compose/types/namespace.gen_test.go
 	./build/gen-type-set-test --types Attachment  --output compose/types/attachment.gen_test.go
@@ -48,6 +50,8 @@ function types {
 	./build/gen-type-set-test --types Chart       --output compose/types/chart.gen_test.go
 	./build/gen-type-set-test --types Record      --output compose/types/record.gen_test.go
 	./build/gen-type-set-test --types ModuleField --output compose/types/module_field.gen_test.go
+	./build/gen-type-set-test --types RecordRevision --output compose/types/record_revision.gen_test.go
+	./build/gen-type-set-test --types RecordView  --output compose/types/record_view.gen_test.go
 
 	./build/gen-type-set --with-primary-key=false --types RecordValue --output compose/types/record_value.gen.go
 	./build/gen-type-set-test --with-primary-key=false --types RecordValue --output compose/types/record_value.gen_test.go
@@ -62,8 +62,11 @@ func (app *App) Upgrade(ctx context.Context) (err error) {
 func (app *App) Initialize(ctx context.Context) (err error) {
 	// Connects to all services it needs to
 	err = service.Initialize(ctx, app.Log, service.Config{
-		ActionLog: app.Opts.ActionLog,
-		Storage:   app.Opts.Storage,
+		ActionLog:   app.Opts.ActionLog,
+		Storage:     app.Opts.Storage,
+		ReportCache: app.Opts.ReportCache,
+		UploadScan:  app.Opts.UploadScan,
+		Attachment:  app.Opts.Attachment,
 	})
 
 	if err != nil {
@@ -104,6 +107,7 @@ func (app *App) RegisterCliCommands(p *cobra.Command) {
 		commands.Importer(),
 		commands.Exporter(),
 		commands.NGImporter(),
+		commands.Attachments(),
 		// temp command, will be removed in 2020.6
 		automation.ScriptExporter(SERVICE),
 	)
@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/cortezaproject/corteza-server/compose/service"
+	"github.com/cortezaproject/corteza-server/pkg/cli"
+)
+
+func Attachments() *cobra.Command {
+	var (
+		days   int
+		dryRun bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "attachments",
+		Short: "Attachments",
+	}
+
+	retentionCmd := &cobra.Command{
+		Use:   "retention",
+		Short: "Removes orphaned attachments (deleted or of deleted records)",
+
+		Run: func(cmd *cobra.Command, args []string) {
+			if days == 0 {
+				days = service.DefaultAttachmentRetention.Days()
+			}
+
+			if days <= 0 {
+				cli.HandleError(errors.New("retention period not set, use --days or ATTACHMENT_RETENTION_DAYS"))
+			}
+
+			r, err := service.DefaultAttachmentRetention.Apply(cli.Context(), time.Now().AddDate(0, 0, -days), dryRun)
+			cli.HandleError(err)
+
+			for _, att := range r.Attachments {
+				cmd.Printf("%d\t%s\t%d\t%s\n", att.ID, att.CreatedAt.Format(time.RFC3339), att.Meta.Original.Size, att.Name)
+			}
+
+			if dryRun {
+				cmd.Printf("%d orphaned attachment(s), %d bytes (dry run, nothing removed)\n", len(r.Attachments), r.Size)
+			} else {
+				cmd.Printf("%d orphaned attachment(s), %d bytes, %d could not be removed\n", len(r.Attachments), r.Size, r.Failed)
+			}
+		},
+	}
+
+	retentionCmd.Flags().IntVar(
+		&days,
+		"days",
+		0,
+		"Remove attachments orphaned more than given number of days ago (defaults to ATTACHMENT_RETENTION_DAYS)")
+
+	retentionCmd.Flags().BoolVar(
+		&dryRun,
+		"dry-run",
+		false,
+		"Only report orphaned attachments")
+
+	cmd.AddCommand(retentionCmd)
+
+	return cmd
+}
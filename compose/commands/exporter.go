@@ -31,7 +31,7 @@ func Exporter() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "export",
 		Short: "Export",
-		Long:  `Specify one ("modules", "pages", "charts", "permissions") or more resources to export`,
+		Long:  `Specify one ("modules", "pages", "charts", "recordViews", "permissions") or more resources to export`,
 
 		Run: func(cmd *cobra.Command, args []string) {
 			var (
@@ -112,6 +112,9 @@ func nsExporter(ctx context.Context, out *Compose, nsFlag string, args []string)
 	charts, _, err := service.DefaultChart.Find(types.ChartFilter{NamespaceID: ns.ID})
 	cli.HandleError(err)
 
+	views, _, err := service.DefaultRecordView.Find(types.RecordViewFilter{NamespaceID: ns.ID})
+	cli.HandleError(err)
+
 	// nsOut.Name = ns.Name
 	// nsOut.Handle = ns.Slug
 	// nsOut.Always = ns.Always
@@ -126,6 +129,8 @@ func nsExporter(ctx context.Context, out *Compose, nsFlag string, args []string)
 			nsOut.Modules = expModules(modules)
 		case "chart", "charts":
 			nsOut.Charts = expCharts(charts, modules)
+		case "recordView", "recordViews":
+			nsOut.RecordViews = expRecordViews(views, modules)
 		case "page", "pages":
 			nsOut.Pages = expPages(0, pages, modules, charts)
 		}
@@ -182,6 +187,8 @@ type (
 		Charts  map[string]Chart  `yaml:",omitempty"`
 		Scripts map[string]Script `yaml:",omitempty"`
 
+		RecordViews map[string]RecordView `yaml:"recordViews,omitempty"`
+
 		Allow map[string][]string `yaml:",omitempty"`
 		Deny  map[string][]string `yaml:",omitempty"`
 	}
@@ -235,6 +242,17 @@ type (
 		Deny  map[string][]string `yaml:",omitempty"`
 	}
 
+	RecordView struct {
+		Name    string   `yaml:",omitempty"`
+		Module  string   `yaml:",omitempty"`
+		Filter  string   `yaml:",omitempty"`
+		Sort    string   `yaml:",omitempty"`
+		Columns []string `yaml:",omitempty"`
+
+		Allow map[string][]string `yaml:",omitempty"`
+		Deny  map[string][]string `yaml:",omitempty"`
+	}
+
 	Script struct {
 		Source   string `yaml:"source"`
 		Async    bool   `yaml:"async"`
@@ -665,6 +683,24 @@ func expCharts(charts types.ChartSet, modules types.ModuleSet) (o map[string]Cha
 				rOut["module"] = makeHandleFromName(module.Name, module.Handle, "module-%d", module.ID)
 			}
 
+			if len(r.Joins) > 0 {
+				jj := make([]map[string]interface{}, 0, len(r.Joins))
+				for _, j := range r.Joins {
+					jOut := map[string]interface{}{
+						"alias": j.Alias,
+						"field": j.Field,
+					}
+
+					if module := modules.FindByID(j.ModuleID); module != nil {
+						jOut["module"] = makeHandleFromName(module.Name, module.Handle, "module-%d", module.ID)
+					}
+
+					jj = append(jj, jOut)
+				}
+
+				rOut["joins"] = jj
+			}
+
 			chart.Config.Reports[i] = rOut
 		}
 
@@ -676,6 +712,32 @@ func expCharts(charts types.ChartSet, modules types.ModuleSet) (o map[string]Cha
 	return
 }
 
+func expRecordViews(views types.RecordViewSet, modules types.ModuleSet) (o map[string]RecordView) {
+	o = map[string]RecordView{}
+
+	for _, v := range views {
+		view := RecordView{
+			Name:    v.Name,
+			Filter:  v.Filter,
+			Sort:    v.Sort,
+			Columns: v.Columns,
+
+			Allow: sysExporter.ExportableResourcePermissions(roles, service.DefaultPermissions, permissions.Allow, types.RecordViewPermissionResource),
+			Deny:  sysExporter.ExportableResourcePermissions(roles, service.DefaultPermissions, permissions.Deny, types.RecordViewPermissionResource),
+		}
+
+		if module := modules.FindByID(v.ModuleID); module != nil {
+			view.Module = makeHandleFromName(module.Name, module.Handle, "module-%d", module.ID)
+		}
+
+		handle := makeHandleFromName(v.Name, v.Handle, "record-view-%d", v.ID)
+
+		o[handle] = view
+	}
+
+	return
+}
+
 func makeHandleFromName(name, currentHandle, def string, id uint64) string {
 	if currentHandle != "" {
 		return currentHandle
@@ -3,4 +3,4 @@
 // Package contains static assets.
 package mysql
 
-var Asset = "PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x1a\x00	\x0020180704080000.base.up.sqlUT\x05\x00\x01\x80Cm8CREATE TABLE `crm_content` (\n `id` bigint(20) unsigned NOT NULL,\n `module_id` bigint(20) unsigned NOT NULL,\n `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,\n `updated_at` datetime DEFAULT NULL,\n `deleted_at` datetime DEFAULT NULL,\n PRIMARY KEY (`id`,`module_id`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8;\n\nCREATE TABLE `crm_content_column` (\n `content_id` bigint(20) NOT NULL,\n `column_name` varchar(255) NOT NULL,\n `column_value` text NOT NULL,\n PRIMARY KEY (`content_id`,`column_name`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8;\n\nCREATE TABLE `crm_field` (\n `field_type` varchar(16) NOT NULL COMMENT 'Short field type (string, boolean,...)',\n `field_name` varchar(255) NOT NULL COMMENT 'Description of field contents',\n `field_template` varchar(255) NOT NULL COMMENT 'HTML template file for field',\n PRIMARY KEY (`field_type`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8;\n\nCREATE TABLE `crm_module` (\n `id` bigint(20) unsigned NOT NULL,\n `name` varchar(64) NOT NULL COMMENT 'The name of the module',\n `json` json NOT NULL COMMENT 'List of field definitions for the module',\n `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,\n `updated_at` datetime DEFAULT NULL,\n `deleted_at` datetime DEFAULT NULL,\n PRIMARY KEY (`id`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8;\n\nCREATE TABLE `crm_module_form` (\n `module_id` bigint(20) unsigned NOT NULL,\n `place` tinyint(3) unsigned NOT NULL,\n `kind` varchar(64) NOT NULL COMMENT 'The type of the form input field',\n `name` varchar(64) NOT NULL COMMENT 'The name of the field in the form',\n `label` varchar(255) NOT NULL COMMENT 'The label of the form input',\n `help_text` text NOT NULL COMMENT 'Help text',\n `default_value` text NOT NULL COMMENT 'Default value',\n `max_length` int(10) unsigned NOT NULL COMMENT 'Maximum input length',\n `is_private` tinyint(1) NOT NULL COMMENT 'Contains personal/sensitive data?',\n PRIMARY KEY (`module_id`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8;\n\nCREATE TABLE `crm_page` (\n `id` bigint(20) unsigned NOT NULL COMMENT 'Page ID',\n `self_id` bigint(20) unsigned NOT NULL COMMENT 'Parent Page ID',\n `module_id` bigint(20) unsigned NOT NULL COMMENT 'Module ID (optional)',\n `title` varchar(255) NOT NULL COMMENT 'Title (required)',\n `description` text NOT NULL COMMENT 'Description',\n `blocks` json NOT NULL COMMENT 'JSON array of blocks for the page',\n `visible` tinyint(4) NOT NULL COMMENT 'Is page visible in navigation?',\n `weight` int(11) NOT NULL COMMENT 'Order for navigation',\n PRIMARY KEY (`id`) USING BTREE,\n KEY `module_id` (`module_id`),\n KEY `self_id` (`self_id`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8;\n\nPK\x07\x08\xac\xe8\x19\x1d\x12\n\x00\x00\x12\n\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00%\x00	\x0020180704080001.crm_fields-data.up.sqlUT\x05\x00\x01\x80Cm8INSERT INTO `crm_field` VALUES ('bool','Boolean value (yes / no)','');\nINSERT INTO `crm_field` VALUES ('email','E-mail input','');\nINSERT INTO `crm_field` VALUES ('enum','Single option picker','');\nINSERT INTO `crm_field` VALUES ('hidden','Hidden value','');\nINSERT INTO `crm_field` VALUES ('stamp','Date/time input','');\nINSERT INTO `crm_field` VALUES ('text','Text input','');\nINSERT INTO `crm_field` VALUES ('textarea','Text input (multi-line)','');\nPK\x07\x08f\x18\x1e\x84\xc5\x01\x00\x00\xc5\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00+\x00	\x0020181109133134.crm_content-ownership.up.sqlUT\x05\x00\x01\x80Cm8ALTER TABLE `crm_content` ADD `user_id` BIGINT UNSIGNED NOT NULL AFTER `module_id`, ADD INDEX (`user_id`);\nPK\x07\x08\xeb!\x81\xc2k\x00\x00\x00k\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00.\x00	\x0020181109193047.crm_fields-related_types.up.sqlUT\x05\x00\x01\x80Cm8INSERT INTO `crm_field` (`field_type`, `field_name`, `field_template`) VALUES ('related', 'Related content', ''), ('related_multi', 'Related content (multiple)', '');PK\x07\x08:.\xfb8\xa6\x00\x00\x00\xa6\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x000\x00	\x0020181125122152.add_multiple_relationships.up.sqlUT\x05\x00\x01\x80Cm8CREATE TABLE `crm_content_links` (\n `content_id` bigint(20) unsigned NOT NULL,\n `column_name` varchar(255) NOT NULL,\n `rel_content_id` bigint(20) unsigned NOT NULL,\n PRIMARY KEY (`content_id`,`column_name`,`rel_content_id`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8;PK\x07\x08\xee\x12\x15	\x05\x01\x00\x00\x05\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00D\x00	\x0020181125132142.add_required_and_visible_to_module_form_fields.up.sqlUT\x05\x00\x01\x80Cm8ALTER TABLE `crm_module_form` ADD `is_required` TINYINT(1) NOT NULL AFTER `is_private`, ADD `is_visible` TINYINT(1) NOT NULL AFTER `is_required`;PK\x07\x08\xa5q c\x91\x00\x00\x00\x91\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x005\x00	\x0020181202163130.fix-crm-module-form-primary-key.up.sqlUT\x05\x00\x01\x80Cm8ALTER TABLE `crm_module_form` DROP PRIMARY KEY, ADD PRIMARY KEY(`module_id`, `place`);\nPK\x07\x08\xd9\xd4i\xe3W\x00\x00\x00W\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x000\x00	\x0020181204123650.add-crm-content-json-field.up.sqlUT\x05\x00\x01\x80Cm8ALTER TABLE `crm_content` ADD `json` json DEFAULT NULL COMMENT 'Content in JSON format.' AFTER `user_id`;\nPK\x07\x08\"\x96\xd6pj\x00\x00\x00j\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x004\x00	\x0020181204155326.add-crm-module-form-json-field.up.sqlUT\x05\x00\x01\x80Cm8ALTER TABLE `crm_module_form` ADD `json` JSON NOT NULL COMMENT 'Options in JSON format.' AFTER `kind`;PK\x07\x08\xb7\x93\xd4\xf6f\x00\x00\x00f\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00+\x00	\x0020181216214630.crm-content-to-record.up.sqlUT\x05\x00\x01\x80Cm8ALTER TABLE `crm_content` RENAME TO `crm_record`;\nALTER TABLE `crm_record` MODIFY COLUMN `json` json DEFAULT NULL COMMENT 'Records in JSON format.';\n\nALTER TABLE `crm_content_column` RENAME TO `crm_record_column`;\nALTER TABLE `crm_record_column` CHANGE COLUMN `content_id` `record_id` bigint(20);\n\nALTER TABLE `crm_content_links` RENAME TO `crm_record_links`;\nALTER TABLE `crm_record_links` CHANGE COLUMN `content_id` `record_id` bigint(20) unsigned;\nALTER TABLE `crm_record_links` CHANGE COLUMN `rel_content_id` `rel_record_id` bigint(20) unsigned;\nPK\x07\x08mA\xa8\x1e&\x02\x00\x00&\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00$\x00	\x0020181217100000.add-charts-tbl.up.sqlUT\x05\x00\x01\x80Cm8CREATE TABLE `crm_chart` (\n `id`         BIGINT(20)  UNSIGNED NOT NULL,\n `name`       VARCHAR(64)          NOT NULL COMMENT 'The name of the chart',\n `config`     JSON                 NOT NULL COMMENT 'Chart & reporting configuration',\n\n `created_at` DATETIME             NOT NULL DEFAULT CURRENT_TIMESTAMP,\n `updated_at` DATETIME                      DEFAULT NULL,\n `deleted_at` DATETIME                      DEFAULT NULL,\n\n PRIMARY KEY (`id`)\n\n) ENGINE=InnoDB DEFAULT CHARSET=utf8;\nPK\x07\x08\xcf\xc6g\xf6\xe4\x01\x00\x00\xe4\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00#\x00	\x0020181224122301.rem-crm_field.up.sqlUT\x05\x00\x01\x80Cm8DROP TABLE `crm_field`;\nPK\x07\x08\xae \xfd2\x18\x00\x00\x00\x18\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00&\x00	\x0020190108100000.add-triggers-tbl.up.sqlUT\x05\x00\x01\x80Cm8CREATE TABLE `crm_trigger` (\n `id`         BIGINT(20)  UNSIGNED NOT NULL,\n `name`       VARCHAR(64)          NOT NULL COMMENT 'The name of the trigger',\n `enabled`    BOOLEAN              NOT NULL COMMENT 'Trigger enabled?',\n `actions`    TEXT                 NOT NULL COMMENT 'All actions that trigger it',\n `source`     TEXT                 NOT NULL COMMENT 'Trigger source',\n `rel_module` BIGINT(20)  UNSIGNED     NULL COMMENT 'Primary module',\n\n `created_at` DATETIME             NOT NULL DEFAULT CURRENT_TIMESTAMP,\n `updated_at` DATETIME                      DEFAULT NULL,\n `deleted_at` DATETIME                      DEFAULT NULL,\n\n PRIMARY KEY (`id`)\n\n) ENGINE=InnoDB DEFAULT CHARSET=utf8;\nPK\x07\x08+\xad\xb7\xed\xb8\x02\x00\x00\xb8\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00/\x00	\x0020190110175924.rem-crm-record-json-field.up.sqlUT\x05\x00\x01\x80Cm8ALTER TABLE `crm_record` DROP COLUMN `json`;\nPK\x07\x08\x94#\xb9\x99-\x00\x00\x00-\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x008\x00	\x0020190114072000.cleanup-record-tables-and-multival.up.sqlUT\x05\x00\x01\x80Cm8-- No more links, we'll handle this through ref field on crm_record_value tbl\nDROP TABLE IF EXISTS `crm_record_links`;\n\n-- Not columns, values\nALTER TABLE `crm_record_column` RENAME TO `crm_record_value`;\n\n-- Simplify names\nALTER TABLE `crm_record_value` CHANGE COLUMN `column_name`  `name`  VARCHAR(64);\nALTER TABLE `crm_record_value` CHANGE COLUMN `column_value` `value` TEXT;\n\n-- Add reference\nALTER TABLE `crm_record_value` ADD  COLUMN `ref` BIGINT UNSIGNED DEFAULT 0 NOT NULL;\nALTER TABLE `crm_record_value` ADD  COLUMN `deleted_at` datetime DEFAULT NULL;\nALTER TABLE `crm_record_value` ADD  COLUMN `place` INT UNSIGNED DEFAULT 0 NOT NULL;\nALTER TABLE `crm_record_value` DROP PRIMARY KEY, ADD PRIMARY KEY(`record_id`, `name`, `place`);\nCREATE INDEX crm_record_value_ref ON crm_record_value (ref);\n\n\n-- We want this as a real field\nALTER TABLE `crm_module_form`  ADD  COLUMN `is_multi` TINYINT(1) NOT NULL;\n\n-- This will be handled through meta(json) fieldd\nALTER TABLE `crm_module_form`  DROP COLUMN `help_text`;\nALTER TABLE `crm_module_form`  DROP COLUMN `max_length`;\nALTER TABLE `crm_module_form`  DROP COLUMN `default_Value`;\nPK\x07\x08\x04]{\x1fo\x04\x00\x00o\x04\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00'\x00	\x0020190121132408.record-updated-by.up.sqlUT\x05\x00\x01\x80Cm8ALTER TABLE `crm_record` CHANGE COLUMN `user_id`  `owned_by` BIGINT UNSIGNED NOT NULL DEFAULT 0;\nALTER TABLE `crm_record` ADD COLUMN `created_by` BIGINT UNSIGNED NOT NULL DEFAULT 0;\nALTER TABLE `crm_record` ADD COLUMN `updated_by` BIGINT UNSIGNED NOT NULL DEFAULT 0;\nALTER TABLE `crm_record` ADD COLUMN `deleted_by` BIGINT UNSIGNED NOT NULL DEFAULT 0;\nUPDATE crm_record SET created_by = owned_by;\nUPDATE crm_record SET updated_by = owned_by WHERE updated_at IS NOT NULL;\nUPDATE crm_record SET deleted_by = owned_by WHERE deleted_at IS NOT NULL;\nPK\x07\x08h\xe2\xeb\n!\x02\x00\x00!\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00 \x00	\x0020190227090642.attachment.up.sqlUT\x05\x00\x01\x80Cm8CREATE TABLE crm_attachment (\n  id               BIGINT UNSIGNED NOT NULL,\n  rel_owner        BIGINT UNSIGNED NOT NULL,\n\n  kind             VARCHAR(32) NOT NULL,\n\n  url              VARCHAR(512),\n  preview_url      VARCHAR(512),\n\n  size             INT    UNSIGNED,\n  mimetype         VARCHAR(255),\n  name             TEXT,\n\n  meta             JSON,\n\n  created_at       DATETIME        NOT NULL DEFAULT NOW(),\n  updated_at       DATETIME            NULL,\n  deleted_at       DATETIME            NULL,\n\n  PRIMARY KEY (id)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8;\n\n-- page attachments will be referenced via page-block meta data\n-- module/record attachment will be referenced via crm_record_value\nPK\x07\x08\xce\xde?\x08\xb3\x02\x00\x00\xb3\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00'\x00	\x0020190427180922.change-tbl-prefix.up.sqlUT\x05\x00\x01\x80Cm8DROP TABLE IF EXISTS crm_field;\nDROP TABLE IF EXISTS crm_fields;\nDROP TABLE IF EXISTS crm_content;\nDROP TABLE IF EXISTS crm_content_links;\nDROP TABLE IF EXISTS crm_content_column;\nDROP TABLE IF EXISTS crm_module_content;\n\nALTER TABLE crm_attachment\n  RENAME TO compose_attachment;\n\nALTER TABLE crm_chart\n  RENAME TO compose_chart;\n\nALTER TABLE crm_module\n  RENAME TO compose_module;\n\nALTER TABLE crm_module_form\n  RENAME TO compose_module_form;\n\nALTER TABLE crm_page\n  RENAME TO compose_page;\n\nALTER TABLE crm_record\n  RENAME TO compose_record;\n\nALTER TABLE crm_record_value\n  RENAME TO compose_record_value;\n\nALTER TABLE crm_trigger\n  RENAME TO compose_trigger;\nPK\x07\x08\xf2\x1a)|\x97\x02\x00\x00\x97\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00#\x00	\x0020190427210922.namespace-tbl.up.sqlUT\x05\x00\x01\x80Cm8CREATE TABLE `compose_namespace` (\n `id`         BIGINT(20)  UNSIGNED NOT NULL,\n `name`       VARCHAR(64)          NOT NULL COMMENT 'Name',\n `slug`       VARCHAR(64)          NOT NULL COMMENT 'URL slug',\n `enabled`    BOOLEAN              NOT NULL COMMENT 'Is namespace enabled?',\n `meta`       JSON                 NOT NULL COMMENT 'Meta data',\n\n `created_at` DATETIME             NOT NULL DEFAULT CURRENT_TIMESTAMP,\n `updated_at` DATETIME                      DEFAULT NULL,\n `deleted_at` DATETIME                      DEFAULT NULL,\n\n PRIMARY KEY (`id`)\n\n) ENGINE=InnoDB DEFAULT CHARSET=utf8;\nPK\x07\x08m\xeb\xed~R\x02\x00\x00R\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00$\x00	\x0020190428080000.namespace-refs.up.sqlUT\x05\x00\x01\x80Cm8ALTER TABLE `compose_attachment`\n        ADD `rel_namespace` BIGINT UNSIGNED NOT NULL AFTER `id`,\n        ADD INDEX (`rel_namespace`);\n\nALTER TABLE `compose_chart`\n        ADD `rel_namespace` BIGINT UNSIGNED NOT NULL AFTER `id`,\n        ADD INDEX (`rel_namespace`);\n\nALTER TABLE `compose_module`\n        ADD `rel_namespace` BIGINT UNSIGNED NOT NULL AFTER `id`,\n        ADD INDEX (`rel_namespace`);\n\nALTER TABLE `compose_page`\n        ADD `rel_namespace` BIGINT UNSIGNED NOT NULL AFTER `id`,\n        ADD INDEX (`rel_namespace`);\n\nALTER TABLE `compose_record`\n        ADD `rel_namespace` BIGINT UNSIGNED NOT NULL AFTER `id`,\n        ADD INDEX (`rel_namespace`);\n\nALTER TABLE `compose_trigger`\n        ADD `rel_namespace` BIGINT UNSIGNED NOT NULL AFTER `id`,\n        ADD INDEX (`rel_namespace`);\n\nUPDATE `compose_attachment`   SET `rel_namespace` = 88714882739863655;\nUPDATE `compose_chart`        SET `rel_namespace` = 88714882739863655;\nUPDATE `compose_module`       SET `rel_namespace` = 88714882739863655;\nUPDATE `compose_page`         SET `rel_namespace` = 88714882739863655;\nUPDATE `compose_record`       SET `rel_namespace` = 88714882739863655;\nUPDATE `compose_trigger`      SET `rel_namespace` = 88714882739863655;\n\n\nALTER TABLE `compose_attachment`\n        ADD CONSTRAINT `compose_attachment_namespace`\n            FOREIGN KEY (`rel_namespace`)\n            REFERENCES `compose_namespace` (`id`);\n\nALTER TABLE `compose_chart`\n        ADD CONSTRAINT `compose_chart_namespace`\n            FOREIGN KEY (`rel_namespace`)\n            REFERENCES `compose_namespace` (`id`);\n\nALTER TABLE `compose_module`\n        ADD CONSTRAINT `compose_module_namespace`\n            FOREIGN KEY (`rel_namespace`)\n            REFERENCES `compose_namespace` (`id`);\n\nALTER TABLE `compose_page`\n        ADD CONSTRAINT `compose_page_namespace`\n            FOREIGN KEY (`rel_namespace`)\n            REFERENCES `compose_namespace` (`id`);\n\nALTER TABLE `compose_record`\n        ADD CONSTRAINT `compose_record_namespace`\n            FOREIGN KEY (`rel_namespace`)\n            REFERENCES `compose_namespace` (`id`);\n\nALTER TABLE `compose_trigger`\n        ADD CONSTRAINT `compose_trigger_namespace`\n            FOREIGN KEY (`rel_namespace`)\n            REFERENCES `compose_namespace` (`id`);\nPK\x07\x08+\xecO\xd2\xd7\x08\x00\x00\xd7\x08\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00%\x00	\x0020190428080000.page-timestamps.up.sqlUT\x05\x00\x01\x80Cm8ALTER TABLE `compose_page`\n    ADD COLUMN `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,\n    ADD COLUMN `updated_at` DATETIME DEFAULT NULL,\n    ADD COLUMN `deleted_at` DATETIME DEFAULT NULL;\n\nALTER TABLE `compose_page` CHANGE COLUMN `module_id` `rel_module` BIGINT UNSIGNED NOT NULL DEFAULT 0;\nPK\x07\x08\x82\x01Rn1\x01\x00\x001\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00#\x00	\x0020190514090000.module_fields.up.sqlUT\x05\x00\x01\x80Cm8ALTER TABLE compose_module_form\n    RENAME TO compose_module_field;\n\n-- Remove orphaned and invalid fields\nDELETE FROM `compose_module_field` WHERE `module_id` NOT IN (SELECT `id` FROM `compose_module`) OR `name` = '';\n\n-- Order and consistency.\nALTER TABLE `compose_module_field`\n    ADD COLUMN `id`         BIGINT UNSIGNED NOT NULL FIRST,\n    ADD COLUMN `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,\n    ADD COLUMN `updated_at` DATETIME DEFAULT NULL,\n    ADD COLUMN `deleted_at` DATETIME DEFAULT NULL,\n    RENAME COLUMN `module_id` TO `rel_module`,\n    RENAME COLUMN `json`      TO `options`;\n\n-- Generate IDs for the new field, use module, offset by one (just to start with a different ID)\n-- and use place (0 based, +1 for every field, expecting to be unique per module because of the existing pkey)\nUPDATE `compose_module_field` SET id = rel_module + 1 + place;\n\n-- Drop old primary key (module_id, place)\nALTER TABLE `compose_module_field` DROP PRIMARY KEY, ADD PRIMARY KEY(`id`);\n\n-- Foreign key\nALTER TABLE `compose_module_field`\n    ADD CONSTRAINT `compose_module`\n        FOREIGN KEY (`rel_module`)\n            REFERENCES `compose_module` (`id`);\n\n-- And unique indexes for module+place/name combos.\nCREATE UNIQUE INDEX uid_compose_module_field_place ON compose_module_field (`rel_module`, `place`);\nCREATE UNIQUE INDEX uid_compose_module_field_name  ON compose_module_field (`rel_module`, `name`);\nPK\x07\x08\xb1(\xbb\xf0\x8d\x05\x00\x00\x8d\x05\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00!\x00	\x0020190526090000.permissions.up.sqlUT\x05\x00\x01\x80Cm8CREATE TABLE IF NOT EXISTS compose_permission_rules (\n  rel_role   BIGINT UNSIGNED NOT NULL,\n  resource   VARCHAR(128)    NOT NULL,\n  operation  VARCHAR(128)    NOT NULL,\n  access     TINYINT(1)      NOT NULL,\n\n  PRIMARY KEY (rel_role, resource, operation)\n) ENGINE=InnoDB;\nPK\x07\x08\"\xd8\xe5H\x12\x01\x00\x00\x12\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00 \x00	\x0020190701090000.automation.up.sqlUT\x05\x00\x01\x80Cm8DROP TABLE IF EXISTS compose_automation_trigger;\nDROP TABLE IF EXISTS compose_automation_script;\n\nCREATE TABLE IF NOT EXISTS compose_automation_script (\n    `id`         BIGINT(20)  UNSIGNED NOT NULL,\n    `name`       VARCHAR(64)          NOT NULL DEFAULT 'unnamed' COMMENT 'The name of the script',\n    `source`     TEXT                 NOT NULL                   COMMENT 'Source code for the script',\n    `source_ref` VARCHAR(200)         NOT NULL                   COMMENT 'Where is the script located (if remote)',\n    `async`      BOOLEAN              NOT NULL DEFAULT FALSE     COMMENT 'Do we run this script asynchronously?',\n    `rel_runner` BIGINT(20)  UNSIGNED NOT NULL DEFAULT 0         COMMENT 'Who is running the script? 0 for invoker',\n    `run_in_ua`  BOOLEAN              NOT NULL DEFAULT FALSE     COMMENT 'Run this script inside user-agent environment',\n    `timeout`    INT         UNSIGNED NOT NULL DEFAULT 0         COMMENT 'Any explicit timeout set for this script (milliseconds)?',\n    `critical`   BOOLEAN              NOT NULL DEFAULT TRUE      COMMENT 'Is it critical that this script is executed successfully',\n    `enabled`    BOOLEAN              NOT NULL DEFAULT TRUE      COMMENT 'Is this script enabled?',\n\n    `created_by` BIGINT(20)  UNSIGNED NOT NULL DEFAULT 0,\n    `created_at` DATETIME             NOT NULL DEFAULT CURRENT_TIMESTAMP,\n    `updated_by` BIGINT(20)  UNSIGNED NOT NULL DEFAULT 0,\n    `updated_at` DATETIME                 NULL DEFAULT NULL,\n    `deleted_by` BIGINT(20)  UNSIGNED NOT NULL DEFAULT 0,\n    `deleted_at` DATETIME                 NULL DEFAULT NULL,\n\n    PRIMARY KEY (`id`)\n\n) ENGINE=InnoDB DEFAULT CHARSET=utf8;\n\nCREATE TABLE IF NOT EXISTS compose_automation_trigger (\n    `id`         BIGINT(20)  UNSIGNED NOT NULL,\n    `rel_script` BIGINT(20)  UNSIGNED NOT NULL              COMMENT 'Script that is triggered',\n\n    `resource`   VARCHAR(128)         NOT NULL              COMMENT 'Resource triggering the event',\n    `event`      VARCHAR(128)         NOT NULL              COMMENT 'Event triggered',\n    `event_condition`\n                 TEXT                 NOT NULL              COMMENT 'Trigger condition',\n    `enabled`    BOOLEAN              NOT NULL DEFAULT TRUE COMMENT 'Trigger enabled?',\n\n    `weight`     INT                  NOT NULL DEFAULT 0,\n\n    `created_by` BIGINT(20)  UNSIGNED NOT NULL DEFAULT 0,\n    `created_at` DATETIME             NOT NULL DEFAULT CURRENT_TIMESTAMP,\n    `updated_by` BIGINT(20)  UNSIGNED NOT NULL DEFAULT 0,\n    `updated_at` DATETIME                 NULL DEFAULT NULL,\n    `deleted_by` BIGINT(20)  UNSIGNED NOT NULL DEFAULT 0,\n    `deleted_at` DATETIME                 NULL DEFAULT NULL,\n\n    CONSTRAINT `fk_script` FOREIGN KEY (`rel_script`) REFERENCES `compose_automation_script` (`id`),\n\n    PRIMARY KEY (`id`)\n\n) ENGINE=InnoDB DEFAULT CHARSET=utf8;\n\n\n\n# Migrate old triggers into scripts\nINSERT INTO compose_automation_script (id, name, source, source_ref, run_in_ua, critical, enabled, created_at, updated_at, deleted_at)\nSELECT id, name, source, '', true, false, enabled, created_at, updated_at, deleted_at from compose_trigger;\n\n# Migrate old triggers into new triggers\nINSERT INTO compose_automation_trigger (id, event, resource, event_condition, rel_script, enabled, created_at, updated_at, deleted_at)\nSELECT id+seq, events.event, 'compose:record', rel_module, id, enabled, created_at, updated_at, deleted_at from compose_trigger AS t INNER JOIN\n              (      SELECT 0 as seq, ''             AS event\n               UNION SELECT 1 as seq, 'manual'       AS event\n               UNION SELECT 2 as seq, 'beforeCreate' AS event\n               UNION SELECT 3 as seq, 'afterCreate'  AS event\n               UNION SELECT 4 as seq, 'beforeUpdate' AS event\n               UNION SELECT 5 as seq, 'afterUpdate'  AS event\n               UNION SELECT 6 as seq, 'beforeDelete' AS event\n               UNION SELECT 7 as seq, 'afterDelete'  AS event) AS events ON ((event  = '' AND t.actions = '')\n                                                                          OR (event <> '' AND t.actions LIKE concat('%',event,'%') ));\n# Normalize and cleanup\nUPDATE compose_automation_trigger SET event = 'manual' WHERE event = '';\nDELETE FROM compose_automation_trigger WHERE event_condition IN ('', '0') AND event <> 'manual';\n\nDROP TABLE IF EXISTS compose_trigger;\nPK\x07\x08c\xda\x17\xa4\x13\x11\x00\x00\x13\x11\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00*\x00	\x0020190825090000.automation-namespace.up.sqlUT\x05\x00\x01\x80Cm8ALTER TABLE `compose_automation_script`\n    ADD `rel_namespace` BIGINT UNSIGNED NOT NULL AFTER `id`,\n    ADD INDEX (`rel_namespace`);\n\nUPDATE `compose_automation_script` SET `rel_namespace` = (SELECT MIN(id) FROM compose_namespace);\n\nALTER TABLE `compose_automation_script`\n    ADD CONSTRAINT `compose_automation_script_namespace`\n    FOREIGN KEY (`rel_namespace`)\n    REFERENCES `compose_namespace` (`id`);\nPK\x07\x08;#~I\x98\x01\x00\x00\x98\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00#\x00	\x0020190912125228.field-default.up.sqlUT\x05\x00\x01\x80Cm8ALTER TABLE `compose_module_field`\n  ADD `default_value` JSON DEFAULT NULL COMMENT 'Default value as a record value set.'\n  AFTER `options`;\nPK\x07\x08&~D\xee\x8d\x00\x00\x00\x8d\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00!\x00	\x0020190917080000.add-handles.up.sqlUT\x05\x00\x01\x80Cm8ALTER TABLE `compose_module` ADD `handle` VARCHAR(200) NOT NULL AFTER `id`;\nALTER TABLE `compose_page`   ADD `handle` VARCHAR(200) NOT NULL AFTER `id`;\nALTER TABLE `compose_chart`  ADD `handle` VARCHAR(200) NOT NULL AFTER `id`;\nPK\x07\x08}h\xa5\xba\xe4\x00\x00\x00\xe4\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x1e\x00	\x0020191008152820.settings.up.sqlUT\x05\x00\x01\x80Cm8CREATE TABLE IF NOT EXISTS `compose_settings` (\n  rel_owner        BIGINT UNSIGNED NOT NULL DEFAULT 0     COMMENT 'Value owner, 0 for global settings',\n  name             VARCHAR(200)    NOT NULL               COMMENT 'Unique set of setting keys',\n  value            JSON                                   COMMENT 'Setting value',\n\n  updated_at       DATETIME        NOT NULL DEFAULT NOW() COMMENT 'When was the value updated',\n  updated_by       BIGINT UNSIGNED NOT NULL DEFAULT 0     COMMENT 'Who created/updated the value',\n\n  PRIMARY KEY (name, rel_owner)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8;\nPK\x07\x08WF\x8e\xd1V\x02\x00\x00V\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x15\x00	\x0020191009172213.up.sqlUT\x05\x00\x01\x80Cm8ALTER TABLE `compose_record_value` MODIFY `value` LONGTEXT;\nPK\x07\x08\xe0\x1e\x94\xc4<\x00\x00\x00<\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0e\x00	\x00migrations.sqlUT\x05\x00\x01\x80Cm8CREATE TABLE IF NOT EXISTS `migrations` (\n `project` varchar(16) NOT NULL COMMENT 'sam, crm, ...',\n `filename` varchar(255) NOT NULL COMMENT 'yyyymmddHHMMSS.sql',\n `statement_index` int(11) NOT NULL COMMENT 'Statement number from SQL file',\n `status` text NOT NULL COMMENT 'ok or full error message',\n PRIMARY KEY (`project`,`filename`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8;\n\nPK\x07\x089S\x05%x\x01\x00\x00x\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x06\x00	\x00new.shUT\x05\x00\x01\x80Cm8#!/bin/bash\ntouch $(date +%Y%m%d%H%M%S).up.sql\nPK\x07\x08\xc1h\xf1\xfb/\x00\x00\x00/\x00\x00\x00PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\xac\xe8\x19\x1d\x12\n\x00\x00\x12\n\x00\x00\x1a\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x00\x00\x00\x0020180704080000.base.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(f\x18\x1e\x84\xc5\x01\x00\x00\xc5\x01\x00\x00%\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81c\n\x00\x0020180704080001.crm_fields-data.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\xeb!\x81\xc2k\x00\x00\x00k\x00\x00\x00+\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x84\x0c\x00\x0020181109133134.crm_content-ownership.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(:.\xfb8\xa6\x00\x00\x00\xa6\x00\x00\x00.\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81Q\x0d\x00\x0020181109193047.crm_fields-related_types.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\xee\x12\x15	\x05\x01\x00\x00\x05\x01\x00\x000\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\\\x0e\x00\x0020181125122152.add_multiple_relationships.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\xa5q c\x91\x00\x00\x00\x91\x00\x00\x00D\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xc8\x0f\x00\x0020181125132142.add_required_and_visible_to_module_form_fields.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\xd9\xd4i\xe3W\x00\x00\x00W\x00\x00\x005\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xd4\x10\x00\x0020181202163130.fix-crm-module-form-primary-key.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\"\x96\xd6pj\x00\x00\x00j\x00\x00\x000\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x97\x11\x00\x0020181204123650.add-crm-content-json-field.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\xb7\x93\xd4\xf6f\x00\x00\x00f\x00\x00\x004\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81h\x12\x00\x0020181204155326.add-crm-module-form-json-field.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(mA\xa8\x1e&\x02\x00\x00&\x02\x00\x00+\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x819\x13\x00\x0020181216214630.crm-content-to-record.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\xcf\xc6g\xf6\xe4\x01\x00\x00\xe4\x01\x00\x00$\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xc1\x15\x00\x0020181217100000.add-charts-tbl.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\xae \xfd2\x18\x00\x00\x00\x18\x00\x00\x00#\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x00\x18\x00\x0020181224122301.rem-crm_field.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(+\xad\xb7\xed\xb8\x02\x00\x00\xb8\x02\x00\x00&\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81r\x18\x00\x0020190108100000.add-triggers-tbl.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\x94#\xb9\x99-\x00\x00\x00-\x00\x00\x00/\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x87\x1b\x00\x0020190110175924.rem-crm-record-json-field.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\x04]{\x1fo\x04\x00\x00o\x04\x00\x008\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x1a\x1c\x00\x0020190114072000.cleanup-record-tables-and-multival.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(h\xe2\xeb\n!\x02\x00\x00!\x02\x00\x00'\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xf8 \x00\x0020190121132408.record-updated-by.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\xce\xde?\x08\xb3\x02\x00\x00\xb3\x02\x00\x00 \x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81w#\x00\x0020190227090642.attachment.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\xf2\x1a)|\x97\x02\x00\x00\x97\x02\x00\x00'\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x81&\x00\x0020190427180922.change-tbl-prefix.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(m\xeb\xed~R\x02\x00\x00R\x02\x00\x00#\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81v)\x00\x0020190427210922.namespace-tbl.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(+\xecO\xd2\xd7\x08\x00\x00\xd7\x08\x00\x00$\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\",\x00\x0020190428080000.namespace-refs.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\x82\x01Rn1\x01\x00\x001\x01\x00\x00%\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81T5\x00\x0020190428080000.page-timestamps.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\xb1(\xbb\xf0\x8d\x05\x00\x00\x8d\x05\x00\x00#\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xe16\x00\x0020190514090000.module_fields.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\"\xd8\xe5H\x12\x01\x00\x00\x12\x01\x00\x00!\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xc8<\x00\x0020190526090000.permissions.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(c\xda\x17\xa4\x13\x11\x00\x00\x13\x11\x00\x00 \x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x812>\x00\x0020190701090000.automation.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(;#~I\x98\x01\x00\x00\x98\x01\x00\x00*\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x9cO\x00\x0020190825090000.automation-namespace.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(&~D\xee\x8d\x00\x00\x00\x8d\x00\x00\x00#\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x95Q\x00\x0020190912125228.field-default.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(}h\xa5\xba\xe4\x00\x00\x00\xe4\x00\x00\x00!\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81|R\x00\x0020190917080000.add-handles.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(WF\x8e\xd1V\x02\x00\x00V\x02\x00\x00\x1e\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xb8S\x00\x0020191008152820.settings.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\xe0\x1e\x94\xc4<\x00\x00\x00<\x00\x00\x00\x15\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81cV\x00\x0020191009172213.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(9S\x05%x\x01\x00\x00x\x01\x00\x00\x0e\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xebV\x00\x00migrations.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\xc1h\xf1\xfb/\x00\x00\x00/\x00\x00\x00\x06\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xed\x81\xa8X\x00\x00new.shUT\x05\x00\x01\x80Cm8PK\x05\x06\x00\x00\x00\x00\x1f\x00\x1f\x00@\x0b\x00\x00\x14Y\x00\x00\x00\x00"
+var	Asset = "PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x1a\x00	\x0020180704080000.base.up.sqlUT\x05\x00\x01\x80Cm8CREATE TABLE `crm_content` (\n `id` bigint(20) unsigned NOT NULL,\n `module_id` bigint(20) unsigned NOT NULL,\n `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,\n `updated_at` datetime DEFAULT NULL,\n `deleted_at` datetime DEFAULT NULL,\n PRIMARY KEY (`id`,`module_id`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8;\n\nCREATE TABLE `crm_content_column` (\n `content_id` bigint(20) NOT NULL,\n `column_name` varchar(255) NOT NULL,\n `column_value` text NOT NULL,\n PRIMARY KEY (`content_id`,`column_name`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8;\n\nCREATE TABLE `crm_field` (\n `field_type` varchar(16) NOT NULL COMMENT 'Short field type (string, boolean,...)',\n `field_name` varchar(255) NOT NULL COMMENT 'Description of field contents',\n `field_template` varchar(255) NOT NULL COMMENT 'HTML template file for field',\n PRIMARY KEY (`field_type`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8;\n\nCREATE TABLE `crm_module` (\n `id` bigint(20) unsigned NOT NULL,\n `name` varchar(64) NOT NULL COMMENT 'The name of the module',\n `json` json NOT NULL COMMENT 'List of field definitions for the module',\n `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,\n `updated_at` datetime DEFAULT NULL,\n `deleted_at` datetime DEFAULT NULL,\n PRIMARY KEY (`id`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8;\n\nCREATE TABLE `crm_module_form` (\n `module_id` bigint(20) unsigned NOT NULL,\n `place` tinyint(3) unsigned NOT NULL,\n `kind` varchar(64) NOT NULL COMMENT 'The type of the form input field',\n `name` varchar(64) NOT NULL COMMENT 'The name of the field in the form',\n `label` varchar(255) NOT NULL COMMENT 'The label of the form input',\n `help_text` text NOT NULL COMMENT 'Help text',\n `default_value` text NOT NULL COMMENT 'Default value',\n `max_length` int(10) unsigned NOT NULL COMMENT 'Maximum input length',\n `is_private` tinyint(1) NOT NULL COMMENT 'Contains personal/sensitive data?',\n PRIMARY KEY (`module_id`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8;\n\nCREATE TABLE `crm_page` (\n `id` bigint(20) unsigned NOT NULL COMMENT 'Page ID',\n `self_id` bigint(20) unsigned NOT NULL COMMENT 'Parent Page ID',\n `module_id` bigint(20) unsigned NOT NULL COMMENT 'Module ID (optional)',\n `title` varchar(255) NOT NULL COMMENT 'Title (required)',\n `description` text NOT NULL COMMENT 'Description',\n `blocks` json NOT NULL COMMENT 'JSON array of blocks for the page',\n `visible` tinyint(4) NOT NULL COMMENT 'Is page visible in navigation?',\n `weight` int(11) NOT NULL COMMENT 'Order for navigation',\n PRIMARY KEY (`id`) USING BTREE,\n KEY `module_id` (`module_id`),\n KEY `self_id` (`self_id`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8;\n\nPK\x07\x08\xac\xe8\x19\x1d\x12\n\x00\x00\x12\n\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00%\x00	\x0020180704080001.crm_fields-data.up.sqlUT\x05\x00\x01\x80Cm8INSERT INTO `crm_field` VALUES ('bool','Boolean value (yes / no)','');\nINSERT INTO `crm_field` VALUES ('email','E-mail input','');\nINSERT INTO `crm_field` VALUES ('enum','Single option picker','');\nINSERT INTO `crm_field` VALUES ('hidden','Hidden value','');\nINSERT INTO `crm_field` VALUES ('stamp','Date/time input','');\nINSERT INTO `crm_field` VALUES ('text','Text input','');\nINSERT INTO `crm_field` VALUES ('textarea','Text input (multi-line)','');\nPK\x07\x08f\x18\x1e\x84\xc5\x01\x00\x00\xc5\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00+\x00	\x0020181109133134.crm_content-ownership.up.sqlUT\x05\x00\x01\x80Cm8ALTER TABLE `crm_content` ADD `user_id` BIGINT UNSIGNED NOT NULL AFTER `module_id`, ADD INDEX (`user_id`);\nPK\x07\x08\xeb!\x81\xc2k\x00\x00\x00k\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00.\x00	\x0020181109193047.crm_fields-related_types.up.sqlUT\x05\x00\x01\x80Cm8INSERT INTO `crm_field` (`field_type`, `field_name`, `field_template`) VALUES ('related', 'Related content', ''), ('related_multi', 'Related content (multiple)', '');PK\x07\x08:.\xfb8\xa6\x00\x00\x00\xa6\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x000\x00	\x0020181125122152.add_multiple_relationships.up.sqlUT\x05\x00\x01\x80Cm8CREATE TABLE `crm_content_links` (\n `content_id` bigint(20) unsigned NOT NULL,\n `column_name` varchar(255) NOT NULL,\n `rel_content_id` bigint(20) unsigned NOT NULL,\n PRIMARY KEY (`content_id`,`column_name`,`rel_content_id`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8;PK\x07\x08\xee\x12\x15	\x05\x01\x00\x00\x05\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00D\x00	\x0020181125132142.add_required_and_visible_to_module_form_fields.up.sqlUT\x05\x00\x01\x80Cm8ALTER TABLE `crm_module_form` ADD `is_required` TINYINT(1) NOT NULL AFTER `is_private`, ADD `is_visible` TINYINT(1) NOT NULL AFTER `is_required`;PK\x07\x08\xa5q c\x91\x00\x00\x00\x91\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x005\x00	\x0020181202163130.fix-crm-module-form-primary-key.up.sqlUT\x05\x00\x01\x80Cm8ALTER TABLE `crm_module_form` DROP PRIMARY KEY, ADD PRIMARY KEY(`module_id`, `place`);\nPK\x07\x08\xd9\xd4i\xe3W\x00\x00\x00W\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x000\x00	\x0020181204123650.add-crm-content-json-field.up.sqlUT\x05\x00\x01\x80Cm8ALTER TABLE `crm_content` ADD `json` json DEFAULT NULL COMMENT 'Content in JSON format.' AFTER `user_id`;\nPK\x07\x08\"\x96\xd6pj\x00\x00\x00j\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x004\x00	\x0020181204155326.add-crm-module-form-json-field.up.sqlUT\x05\x00\x01\x80Cm8ALTER TABLE `crm_module_form` ADD `json` JSON NOT NULL COMMENT 'Options in JSON format.' AFTER `kind`;PK\x07\x08\xb7\x93\xd4\xf6f\x00\x00\x00f\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00+\x00	\x0020181216214630.crm-content-to-record.up.sqlUT\x05\x00\x01\x80Cm8ALTER TABLE `crm_content` RENAME TO `crm_record`;\nALTER TABLE `crm_record` MODIFY COLUMN `json` json DEFAULT NULL COMMENT 'Records in JSON format.';\n\nALTER TABLE `crm_content_column` RENAME TO `crm_record_column`;\nALTER TABLE `crm_record_column` CHANGE COLUMN `content_id` `record_id` bigint(20);\n\nALTER TABLE `crm_content_links` RENAME TO `crm_record_links`;\nALTER TABLE `crm_record_links` CHANGE COLUMN `content_id` `record_id` bigint(20) unsigned;\nALTER TABLE `crm_record_links` CHANGE COLUMN `rel_content_id` `rel_record_id` bigint(20) unsigned;\nPK\x07\x08mA\xa8\x1e&\x02\x00\x00&\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00$\x00	\x0020181217100000.add-charts-tbl.up.sqlUT\x05\x00\x01\x80Cm8CREATE TABLE `crm_chart` (\n `id`         BIGINT(20)  UNSIGNED NOT NULL,\n `name`       VARCHAR(64)          NOT NULL COMMENT 'The name of the chart',\n `config`     JSON                 NOT NULL COMMENT 'Chart & reporting configuration',\n\n `created_at` DATETIME             NOT NULL DEFAULT CURRENT_TIMESTAMP,\n `updated_at` DATETIME                      DEFAULT NULL,\n `deleted_at` DATETIME                      DEFAULT NULL,\n\n PRIMARY KEY (`id`)\n\n) ENGINE=InnoDB DEFAULT CHARSET=utf8;\nPK\x07\x08\xcf\xc6g\xf6\xe4\x01\x00\x00\xe4\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00#\x00	\x0020181224122301.rem-crm_field.up.sqlUT\x05\x00\x01\x80Cm8DROP TABLE `crm_field`;\nPK\x07\x08\xae \xfd2\x18\x00\x00\x00\x18\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00&\x00	\x0020190108100000.add-triggers-tbl.up.sqlUT\x05\x00\x01\x80Cm8CREATE TABLE `crm_trigger` (\n `id`         BIGINT(20)  UNSIGNED NOT NULL,\n `name`       VARCHAR(64)          NOT NULL COMMENT 'The name of the trigger',\n `enabled`    BOOLEAN              NOT NULL COMMENT 'Trigger enabled?',\n `actions`    TEXT                 NOT NULL COMMENT 'All actions that trigger it',\n `source`     TEXT                 NOT NULL COMMENT 'Trigger source',\n `rel_module` BIGINT(20)  UNSIGNED     NULL COMMENT 'Primary module',\n\n `created_at` DATETIME             NOT NULL DEFAULT CURRENT_TIMESTAMP,\n `updated_at` DATETIME                      DEFAULT NULL,\n `deleted_at` DATETIME                      DEFAULT NULL,\n\n PRIMARY KEY (`id`)\n\n) ENGINE=InnoDB DEFAULT CHARSET=utf8;\nPK\x07\x08+\xad\xb7\xed\xb8\x02\x00\x00\xb8\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00/\x00	\x0020190110175924.rem-crm-record-json-field.up.sqlUT\x05\x00\x01\x80Cm8ALTER TABLE `crm_record` DROP COLUMN `json`;\nPK\x07\x08\x94#\xb9\x99-\x00\x00\x00-\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x008\x00	\x0020190114072000.cleanup-record-tables-and-multival.up.sqlUT\x05\x00\x01\x80Cm8-- No more links, we'll handle this through ref field on crm_record_value tbl\nDROP TABLE IF EXISTS `crm_record_links`;\n\n-- Not columns, values\nALTER TABLE `crm_record_column` RENAME TO `crm_record_value`;\n\n-- Simplify names\nALTER TABLE `crm_record_value` CHANGE COLUMN `column_name`  `name`  VARCHAR(64);\nALTER TABLE `crm_record_value` CHANGE COLUMN `column_value` `value` TEXT;\n\n-- Add reference\nALTER TABLE `crm_record_value` ADD  COLUMN `ref` BIGINT UNSIGNED DEFAULT 0 NOT NULL;\nALTER TABLE `crm_record_value` ADD  COLUMN `deleted_at` datetime DEFAULT NULL;\nALTER TABLE `crm_record_value` ADD  COLUMN `place` INT UNSIGNED DEFAULT 0 NOT NULL;\nALTER TABLE `crm_record_value` DROP PRIMARY KEY, ADD PRIMARY KEY(`record_id`, `name`, `place`);\nCREATE INDEX crm_record_value_ref ON crm_record_value (ref);\n\n\n-- We want this as a real field\nALTER TABLE `crm_module_form`  ADD  COLUMN `is_multi` TINYINT(1) NOT NULL;\n\n-- This will be handled through meta(json) fieldd\nALTER TABLE `crm_module_form`  DROP COLUMN `help_text`;\nALTER TABLE `crm_module_form`  DROP COLUMN `max_length`;\nALTER TABLE `crm_module_form`  DROP COLUMN `default_Value`;\nPK\x07\x08\x04]{\x1fo\x04\x00\x00o\x04\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00'\x00	\x0020190121132408.record-updated-by.up.sqlUT\x05\x00\x01\x80Cm8ALTER TABLE `crm_record` CHANGE COLUMN `user_id`  `owned_by` BIGINT UNSIGNED NOT NULL DEFAULT 0;\nALTER TABLE `crm_record` ADD COLUMN `created_by` BIGINT UNSIGNED NOT NULL DEFAULT 0;\nALTER TABLE `crm_record` ADD COLUMN `updated_by` BIGINT UNSIGNED NOT NULL DEFAULT 0;\nALTER TABLE `crm_record` ADD COLUMN `deleted_by` BIGINT UNSIGNED NOT NULL DEFAULT 0;\nUPDATE crm_record SET created_by = owned_by;\nUPDATE crm_record SET updated_by = owned_by WHERE updated_at IS NOT NULL;\nUPDATE crm_record SET deleted_by = owned_by WHERE deleted_at IS NOT NULL;\nPK\x07\x08h\xe2\xeb\n!\x02\x00\x00!\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00 \x00	\x0020190227090642.attachment.up.sqlUT\x05\x00\x01\x80Cm8CREATE TABLE crm_attachment (\n  id               BIGINT UNSIGNED NOT NULL,\n  rel_owner        BIGINT UNSIGNED NOT NULL,\n\n  kind             VARCHAR(32) NOT NULL,\n\n  url              VARCHAR(512),\n  preview_url      VARCHAR(512),\n\n  size             INT    UNSIGNED,\n  mimetype         VARCHAR(255),\n  name             TEXT,\n\n  meta             JSON,\n\n  created_at       DATETIME        NOT NULL DEFAULT NOW(),\n  updated_at       DATETIME            NULL,\n  deleted_at       DATETIME            NULL,\n\n  PRIMARY KEY (id)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8;\n\n-- page attachments will be referenced via page-block meta data\n-- module/record attachment will be referenced via crm_record_value\nPK\x07\x08\xce\xde?\x08\xb3\x02\x00\x00\xb3\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00'\x00	\x0020190427180922.change-tbl-prefix.up.sqlUT\x05\x00\x01\x80Cm8DROP TABLE IF EXISTS crm_field;\nDROP TABLE IF EXISTS crm_fields;\nDROP TABLE IF EXISTS crm_content;\nDROP TABLE IF EXISTS crm_content_links;\nDROP TABLE IF EXISTS crm_content_column;\nDROP TABLE IF EXISTS crm_module_content;\n\nALTER TABLE crm_attachment\n  RENAME TO compose_attachment;\n\nALTER TABLE crm_chart\n  RENAME TO compose_chart;\n\nALTER TABLE crm_module\n  RENAME TO compose_module;\n\nALTER TABLE crm_module_form\n  RENAME TO compose_module_form;\n\nALTER TABLE crm_page\n  RENAME TO compose_page;\n\nALTER TABLE crm_record\n  RENAME TO compose_record;\n\nALTER TABLE crm_record_value\n  RENAME TO compose_record_value;\n\nALTER TABLE crm_trigger\n  RENAME TO compose_trigger;\nPK\x07\x08\xf2\x1a)|\x97\x02\x00\x00\x97\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00#\x00	\x0020190427210922.namespace-tbl.up.sqlUT\x05\x00\x01\x80Cm8CREATE TABLE `compose_namespace` (\n `id`         BIGINT(20)  UNSIGNED NOT NULL,\n `name`       VARCHAR(64)          NOT NULL COMMENT 'Name',\n `slug`       VARCHAR(64)          NOT NULL COMMENT 'URL slug',\n `enabled`    BOOLEAN              NOT NULL COMMENT 'Is namespace enabled?',\n `meta`       JSON                 NOT NULL COMMENT 'Meta data',\n\n `created_at` DATETIME             NOT NULL DEFAULT CURRENT_TIMESTAMP,\n `updated_at` DATETIME                      DEFAULT NULL,\n `deleted_at` DATETIME                      DEFAULT NULL,\n\n PRIMARY KEY (`id`)\n\n) ENGINE=InnoDB DEFAULT CHARSET=utf8;\nPK\x07\x08m\xeb\xed~R\x02\x00\x00R\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00$\x00	\x0020190428080000.namespace-refs.up.sqlUT\x05\x00\x01\x80Cm8ALTER TABLE `compose_attachment`\n        ADD `rel_namespace` BIGINT UNSIGNED NOT NULL AFTER `id`,\n        ADD INDEX (`rel_namespace`);\n\nALTER TABLE `compose_chart`\n        ADD `rel_namespace` BIGINT UNSIGNED NOT NULL AFTER `id`,\n        ADD INDEX (`rel_namespace`);\n\nALTER TABLE `compose_module`\n        ADD `rel_namespace` BIGINT UNSIGNED NOT NULL AFTER `id`,\n        ADD INDEX (`rel_namespace`);\n\nALTER TABLE `compose_page`\n        ADD `rel_namespace` BIGINT UNSIGNED NOT NULL AFTER `id`,\n        ADD INDEX (`rel_namespace`);\n\nALTER TABLE `compose_record`\n        ADD `rel_namespace` BIGINT UNSIGNED NOT NULL AFTER `id`,\n        ADD INDEX (`rel_namespace`);\n\nALTER TABLE `compose_trigger`\n        ADD `rel_namespace` BIGINT UNSIGNED NOT NULL AFTER `id`,\n        ADD INDEX (`rel_namespace`);\n\nUPDATE `compose_attachment`   SET `rel_namespace` = 88714882739863655;\nUPDATE `compose_chart`        SET `rel_namespace` = 88714882739863655;\nUPDATE `compose_module`       SET `rel_namespace` = 88714882739863655;\nUPDATE `compose_page`         SET `rel_namespace` = 88714882739863655;\nUPDATE `compose_record`       SET `rel_namespace` = 88714882739863655;\nUPDATE `compose_trigger`      SET `rel_namespace` = 88714882739863655;\n\n\nALTER TABLE `compose_attachment`\n        ADD CONSTRAINT `compose_attachment_namespace`\n            FOREIGN KEY (`rel_namespace`)\n            REFERENCES `compose_namespace` (`id`);\n\nALTER TABLE `compose_chart`\n        ADD CONSTRAINT `compose_chart_namespace`\n            FOREIGN KEY (`rel_namespace`)\n            REFERENCES `compose_namespace` (`id`);\n\nALTER TABLE `compose_module`\n        ADD CONSTRAINT `compose_module_namespace`\n            FOREIGN KEY (`rel_namespace`)\n            REFERENCES `compose_namespace` (`id`);\n\nALTER TABLE `compose_page`\n        ADD CONSTRAINT `compose_page_namespace`\n            FOREIGN KEY (`rel_namespace`)\n            REFERENCES `compose_namespace` (`id`);\n\nALTER TABLE `compose_record`\n        ADD CONSTRAINT `compose_record_namespace`\n            FOREIGN KEY (`rel_namespace`)\n            REFERENCES `compose_namespace` (`id`);\n\nALTER TABLE `compose_trigger`\n        ADD CONSTRAINT `compose_trigger_namespace`\n            FOREIGN KEY (`rel_namespace`)\n            REFERENCES `compose_namespace` (`id`);\nPK\x07\x08+\xecO\xd2\xd7\x08\x00\x00\xd7\x08\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00%\x00	\x0020190428080000.page-timestamps.up.sqlUT\x05\x00\x01\x80Cm8ALTER TABLE `compose_page`\n    ADD COLUMN `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,\n    ADD COLUMN `updated_at` DATETIME DEFAULT NULL,\n    ADD COLUMN `deleted_at` DATETIME DEFAULT NULL;\n\nALTER TABLE `compose_page` CHANGE COLUMN `module_id` `rel_module` BIGINT UNSIGNED NOT NULL DEFAULT 0;\nPK\x07\x08\x82\x01Rn1\x01\x00\x001\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00#\x00	\x0020190514090000.module_fields.up.sqlUT\x05\x00\x01\x80Cm8ALTER TABLE compose_module_form\n    RENAME TO compose_module_field;\n\n-- Remove orphaned and invalid fields\nDELETE FROM `compose_module_field` WHERE `module_id` NOT IN (SELECT `id` FROM `compose_module`) OR `name` = '';\n\n-- Order and consistency.\nALTER TABLE `compose_module_field`\n    ADD COLUMN `id`         BIGINT UNSIGNED NOT NULL FIRST,\n    ADD COLUMN `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,\n    ADD COLUMN `updated_at` DATETIME DEFAULT NULL,\n    ADD COLUMN `deleted_at` DATETIME DEFAULT NULL,\n    RENAME COLUMN `module_id` TO `rel_module`,\n    RENAME COLUMN `json`      TO `options`;\n\n-- Generate IDs for the new field, use module, offset by one (just to start with a different ID)\n-- and use place (0 based, +1 for every field, expecting to be unique per module because of the existing pkey)\nUPDATE `compose_module_field` SET id = rel_module + 1 + place;\n\n-- Drop old primary key (module_id, place)\nALTER TABLE `compose_module_field` DROP PRIMARY KEY, ADD PRIMARY KEY(`id`);\n\n-- Foreign key\nALTER TABLE `compose_module_field`\n    ADD CONSTRAINT `compose_module`\n        FOREIGN KEY (`rel_module`)\n            REFERENCES `compose_module` (`id`);\n\n-- And unique indexes for module+place/name combos.\nCREATE UNIQUE INDEX uid_compose_module_field_place ON compose_module_field (`rel_module`, `place`);\nCREATE UNIQUE INDEX uid_compose_module_field_name  ON compose_module_field (`rel_module`, `name`);\nPK\x07\x08\xb1(\xbb\xf0\x8d\x05\x00\x00\x8d\x05\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00!\x00	\x0020190526090000.permissions.up.sqlUT\x05\x00\x01\x80Cm8CREATE TABLE IF NOT EXISTS compose_permission_rules (\n  rel_role   BIGINT UNSIGNED NOT NULL,\n  resource   VARCHAR(128)    NOT NULL,\n  operation  VARCHAR(128)    NOT NULL,\n  access     TINYINT(1)      NOT NULL,\n\n  PRIMARY KEY (rel_role, resource, operation)\n) ENGINE=InnoDB;\nPK\x07\x08\"\xd8\xe5H\x12\x01\x00\x00\x12\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00 \x00	\x0020190701090000.automation.up.sqlUT\x05\x00\x01\x80Cm8DROP TABLE IF EXISTS compose_automation_trigger;\nDROP TABLE IF EXISTS compose_automation_script;\n\nCREATE TABLE IF NOT EXISTS compose_automation_script (\n    `id`         BIGINT(20)  UNSIGNED NOT NULL,\n    `name`       VARCHAR(64)          NOT NULL DEFAULT 'unnamed' COMMENT 'The name of the script',\n    `source`     TEXT                 NOT NULL                   COMMENT 'Source code for the script',\n    `source_ref` VARCHAR(200)         NOT NULL                   COMMENT 'Where is the script located (if remote)',\n    `async`      BOOLEAN              NOT NULL DEFAULT FALSE     COMMENT 'Do we run this script asynchronously?',\n    `rel_runner` BIGINT(20)  UNSIGNED NOT NULL DEFAULT 0         COMMENT 'Who is running the script? 0 for invoker',\n    `run_in_ua`  BOOLEAN              NOT NULL DEFAULT FALSE     COMMENT 'Run this script inside user-agent environment',\n    `timeout`    INT         UNSIGNED NOT NULL DEFAULT 0         COMMENT 'Any explicit timeout set for this script (milliseconds)?',\n    `critical`   BOOLEAN              NOT NULL DEFAULT TRUE      COMMENT 'Is it critical that this script is executed successfully',\n    `enabled`    BOOLEAN              NOT NULL DEFAULT TRUE      COMMENT 'Is this script enabled?',\n\n    `created_by` BIGINT(20)  UNSIGNED NOT NULL DEFAULT 0,\n    `created_at` DATETIME             NOT NULL DEFAULT CURRENT_TIMESTAMP,\n    `updated_by` BIGINT(20)  UNSIGNED NOT NULL DEFAULT 0,\n    `updated_at` DATETIME                 NULL DEFAULT NULL,\n    `deleted_by` BIGINT(20)  UNSIGNED NOT NULL DEFAULT 0,\n    `deleted_at` DATETIME                 NULL DEFAULT NULL,\n\n    PRIMARY KEY (`id`)\n\n) ENGINE=InnoDB DEFAULT CHARSET=utf8;\n\nCREATE TABLE IF NOT EXISTS compose_automation_trigger (\n    `id`         BIGINT(20)  UNSIGNED NOT NULL,\n    `rel_script` BIGINT(20)  UNSIGNED NOT NULL              COMMENT 'Script that is triggered',\n\n    `resource`   VARCHAR(128)         NOT NULL              COMMENT 'Resource triggering the event',\n    `event`      VARCHAR(128)         NOT NULL              COMMENT 'Event triggered',\n    `event_condition`\n                 TEXT                 NOT NULL              COMMENT 'Trigger condition',\n    `enabled`    BOOLEAN              NOT NULL DEFAULT TRUE COMMENT 'Trigger enabled?',\n\n    `weight`     INT                  NOT NULL DEFAULT 0,\n\n    `created_by` BIGINT(20)  UNSIGNED NOT NULL DEFAULT 0,\n    `created_at` DATETIME             NOT NULL DEFAULT CURRENT_TIMESTAMP,\n    `updated_by` BIGINT(20)  UNSIGNED NOT NULL DEFAULT 0,\n    `updated_at` DATETIME                 NULL DEFAULT NULL,\n    `deleted_by` BIGINT(20)  UNSIGNED NOT NULL DEFAULT 0,\n    `deleted_at` DATETIME                 NULL DEFAULT NULL,\n\n    CONSTRAINT `fk_script` FOREIGN KEY (`rel_script`) REFERENCES `compose_automation_script` (`id`),\n\n    PRIMARY KEY (`id`)\n\n) ENGINE=InnoDB DEFAULT CHARSET=utf8;\n\n\n\n# Migrate old triggers into scripts\nINSERT INTO compose_automation_script (id, name, source, source_ref, run_in_ua, critical, enabled, created_at, updated_at, deleted_at)\nSELECT id, name, source, '', true, false, enabled, created_at, updated_at, deleted_at from compose_trigger;\n\n# Migrate old triggers into new triggers\nINSERT INTO compose_automation_trigger (id, event, resource, event_condition, rel_script, enabled, created_at, updated_at, deleted_at)\nSELECT id+seq, events.event, 'compose:record', rel_module, id, enabled, created_at, updated_at, deleted_at from compose_trigger AS t INNER JOIN\n              (      SELECT 0 as seq, ''             AS event\n               UNION SELECT 1 as seq, 'manual'       AS event\n               UNION SELECT 2 as seq, 'beforeCreate' AS event\n               UNION SELECT 3 as seq, 'afterCreate'  AS event\n               UNION SELECT 4 as seq, 'beforeUpdate' AS event\n               UNION SELECT 5 as seq, 'afterUpdate'  AS event\n               UNION SELECT 6 as seq, 'beforeDelete' AS event\n               UNION SELECT 7 as seq, 'afterDelete'  AS event) AS events ON ((event  = '' AND t.actions = '')\n                                                                          OR (event <> '' AND t.actions LIKE concat('%',event,'%') ));\n# Normalize and cleanup\nUPDATE compose_automation_trigger SET event = 'manual' WHERE event = '';\nDELETE FROM compose_automation_trigger WHERE event_condition IN ('', '0') AND event <> 'manual';\n\nDROP TABLE IF EXISTS compose_trigger;\nPK\x07\x08c\xda\x17\xa4\x13\x11\x00\x00\x13\x11\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00*\x00	\x0020190825090000.automation-namespace.up.sqlUT\x05\x00\x01\x80Cm8ALTER TABLE `compose_automation_script`\n    ADD `rel_namespace` BIGINT UNSIGNED NOT NULL AFTER `id`,\n    ADD INDEX (`rel_namespace`);\n\nUPDATE `compose_automation_script` SET `rel_namespace` = (SELECT MIN(id) FROM compose_namespace);\n\nALTER TABLE `compose_automation_script`\n    ADD CONSTRAINT `compose_automation_script_namespace`\n    FOREIGN KEY (`rel_namespace`)\n    REFERENCES `compose_namespace` (`id`);\nPK\x07\x08;#~I\x98\x01\x00\x00\x98\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00#\x00	\x0020190912125228.field-default.up.sqlUT\x05\x00\x01\x80Cm8ALTER TABLE `compose_module_field`\n  ADD `default_value` JSON DEFAULT NULL COMMENT 'Default value as a record value set.'\n  AFTER `options`;\nPK\x07\x08&~D\xee\x8d\x00\x00\x00\x8d\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00!\x00	\x0020190917080000.add-handles.up.sqlUT\x05\x00\x01\x80Cm8ALTER TABLE `compose_module` ADD `handle` VARCHAR(200) NOT NULL AFTER `id`;\nALTER TABLE `compose_page`   ADD `handle` VARCHAR(200) NOT NULL AFTER `id`;\nALTER TABLE `compose_chart`  ADD `handle` VARCHAR(200) NOT NULL AFTER `id`;\nPK\x07\x08}h\xa5\xba\xe4\x00\x00\x00\xe4\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x1e\x00	\x0020191008152820.settings.up.sqlUT\x05\x00\x01\x80Cm8CREATE TABLE IF NOT EXISTS `compose_settings` (\n  rel_owner        BIGINT UNSIGNED NOT NULL DEFAULT 0     COMMENT 'Value owner, 0 for global settings',\n  name             VARCHAR(200)    NOT NULL               COMMENT 'Unique set of setting keys',\n  value            JSON                                   COMMENT 'Setting value',\n\n  updated_at       DATETIME        NOT NULL DEFAULT NOW() COMMENT 'When was the value updated',\n  updated_by       BIGINT UNSIGNED NOT NULL DEFAULT 0     COMMENT 'Who created/updated the value',\n\n  PRIMARY KEY (name, rel_owner)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8;\nPK\x07\x08WF\x8e\xd1V\x02\x00\x00V\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x15\x00	\x0020191009172213.up.sqlUT\x05\x00\x01\x80Cm8ALTER TABLE `compose_record_value` MODIFY `value` LONGTEXT;\nPK\x07\x08\xe0\x1e\x94\xc4<\x00\x00\x00<\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00&\x00	\x0020200610090000.record-revisions.up.sqlUT\x05\x00\x01\x80Cm8CREATE TABLE IF NOT EXISTS compose_record_revision (\n  id               BIGINT          UNSIGNED NOT NULL,\n  rel_namespace    BIGINT          UNSIGNED NOT NULL              COMMENT 'Namespace',\n  rel_module       BIGINT          UNSIGNED NOT NULL              COMMENT 'Module',\n  rel_record       BIGINT          UNSIGNED NOT NULL              COMMENT 'Revised record',\n  operation        VARCHAR(16)              NOT NULL              COMMENT 'Operation that created the revision (create, update, delete, restore)',\n  changes          JSON                     NOT NULL              COMMENT 'List of changed fields with old and new values',\n  snapshot         JSON                     NOT NULL              COMMENT 'Record values after the operation',\n\n  created_at       DATETIME                 NOT NULL DEFAULT NOW(),\n  created_by       BIGINT          UNSIGNED NOT NULL DEFAULT 0    COMMENT 'Who made the change',\n\n  PRIMARY KEY (id)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8;\n\nCREATE INDEX record_revision ON compose_record_revision (rel_record, created_at);\nPK\x07\x08\x91v:\xb5#\x04\x00\x00#\x04\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00'\x00	\x0020200611090000.module-validators.up.sqlUT\x05\x00\x01\x80Cm8ALTER TABLE `compose_module`\n  ADD `validators` JSON DEFAULT NULL COMMENT 'Record validation rules (expressions with error messages)' AFTER `json`;\nPK\x07\x08\x00l&\xde\x94\x00\x00\x00\x94\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00,\x00	\x0020200612090000.record-import-sessions.up.sqlUT\x05\x00\x01\x80Cm8CREATE TABLE IF NOT EXISTS compose_record_import_session (\n  id               BIGINT          UNSIGNED NOT NULL,\n  rel_namespace    BIGINT          UNSIGNED NOT NULL              COMMENT 'Namespace',\n  rel_module       BIGINT          UNSIGNED NOT NULL              COMMENT 'Module records are imported into',\n  rel_user         BIGINT          UNSIGNED NOT NULL              COMMENT 'Owner of the session',\n  source           VARCHAR(512)             NOT NULL              COMMENT 'Location of the uploaded source in the store',\n  fields           JSON                     NOT NULL              COMMENT 'Source columns to module fields mapping',\n  on_error         VARCHAR(16)              NOT NULL DEFAULT ''   COMMENT 'What happens when record fails to import (SKIP, FAIL)',\n  mode             VARCHAR(16)              NOT NULL DEFAULT ''   COMMENT 'Import mode (CREATE, UPSERT)',\n  match_field      VARCHAR(64)              NOT NULL DEFAULT ''   COMMENT 'Field existing records are matched on in UPSERT mode',\n  dry_run          BOOLEAN                  NOT NULL DEFAULT FALSE,\n  progress         JSON                     NOT NULL              COMMENT 'Import progress, updated with each imported record',\n  report           JSON                         NULL DEFAULT NULL COMMENT 'Errors of the failed records',\n\n  created_at       DATETIME                 NOT NULL DEFAULT NOW(),\n  updated_at       DATETIME                 NOT NULL DEFAULT NOW(),\n  heartbeat_at     DATETIME                     NULL DEFAULT NULL COMMENT 'Last progress report of the running import',\n\n  PRIMARY KEY (id)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8;\n\nCREATE INDEX record_import_session_heartbeat ON compose_record_import_session (heartbeat_at);\nPK\x07\x08\x06\xb0\x81\xbb\xbe\x06\x00\x00\xbe\x06\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x000\x00	\x0020200613090000.record-import-spreadsheets.up.sqlUT\x05\x00\x01\x80Cm8ALTER TABLE `compose_record_import_session`\n  ADD `sheet`      VARCHAR(255) NOT NULL DEFAULT '' COMMENT 'Sheet of the spreadsheet (xlsx, ods) source' AFTER `source`,\n  ADD `header_row` INT UNSIGNED NOT NULL DEFAULT 0  COMMENT 'Header row of the spreadsheet source' AFTER `sheet`;\nPK\x07\x08Q\xd7/\xe1\x18\x01\x00\x00\x18\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00+\x00	\x0020200615090000.record-value-fulltext.up.sqlUT\x05\x00\x01\x80Cm8ALTER TABLE `compose_record_value` ADD FULLTEXT INDEX `ft_compose_record_value` (`value`);\nPK\x07\x08\xb9Q\xe1\x9a[\x00\x00\x00[\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\"\x00	\x0020200616090000.record-views.up.sqlUT\x05\x00\x01\x80Cm8CREATE TABLE IF NOT EXISTS compose_record_view (\n  id               BIGINT          UNSIGNED NOT NULL,\n  handle           VARCHAR(200)             NOT NULL,\n  rel_namespace    BIGINT          UNSIGNED NOT NULL              COMMENT 'Namespace',\n  rel_module       BIGINT          UNSIGNED NOT NULL              COMMENT 'Module of the listed records',\n  name             VARCHAR(64)              NOT NULL,\n  filter           TEXT                     NOT NULL              COMMENT 'Record query (ql) filter',\n  sort             TEXT                     NOT NULL              COMMENT 'Record query (ql) sort',\n  columns          JSON                     NOT NULL              COMMENT 'Names of the visible fields',\n\n  created_at       DATETIME                 NOT NULL DEFAULT NOW(),\n  updated_at       DATETIME                     NULL DEFAULT NULL,\n  deleted_at       DATETIME                     NULL DEFAULT NULL,\n\n  PRIMARY KEY (id)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8;\n\nCREATE INDEX compose_record_view_namespace ON compose_record_view (rel_namespace);\nCREATE INDEX compose_record_view_module    ON compose_record_view (rel_module);\nPK\x07\x08{R)\xddp\x04\x00\x00p\x04\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00(\x00	\x0020200617090000.attachment-content.up.sqlUT\x05\x00\x01\x80Cm8CREATE TABLE IF NOT EXISTS compose_attachment_content (\n  rel_attachment   BIGINT          UNSIGNED NOT NULL              COMMENT 'Attachment',\n  content          LONGTEXT                 NOT NULL              COMMENT 'Text extracted from the attachment',\n\n  PRIMARY KEY (rel_attachment)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8;\n\nALTER TABLE `compose_attachment_content` ADD FULLTEXT INDEX `ft_compose_attachment_content` (`content`);\nPK\x07\x08:\xa4\x93y\xb0\x01\x00\x00\xb0\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00%\x00	\x0020200618090000.attachment-size.up.sqlUT\x05\x00\x01\x80Cm8-- Size of the original file is used for storage accounting (quotas);\n-- it was kept only in the attachment meta until now\nUPDATE `compose_attachment` SET `size` = JSON_EXTRACT(`meta`, '$.original.size') WHERE `size` IS NULL;\nPK\x07\x08\x07q\xfd.\xe2\x00\x00\x00\xe2\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00%\x00	\x0020200619090000.attachment-page.up.sqlUT\x05\x00\x01\x80Cm8-- Page the attachment was uploaded to, used to check page permissions;\n-- attachments uploaded before are not linked to any page\nALTER TABLE `compose_attachment`\n  ADD `rel_page` BIGINT UNSIGNED NOT NULL DEFAULT 0 COMMENT 'Page (for page attachments)' AFTER `rel_owner`;\nPK\x07\x08\x92(\x0f\xb3\x10\x01\x00\x00\x10\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00)\x00	\x0020200620090000.attachment-unlinked.up.sqlUT\x05\x00\x01\x80Cm8-- Time when record values referencing the attachment were last removed,\n-- orphaned attachments are kept for the retention period since then\nALTER TABLE `compose_attachment`\n  ADD `unlinked_at` DATETIME NULL COMMENT 'Last removal of record values referencing the attachment' AFTER `deleted_at`;\nPK\x07\x08\xd0\xa3G\xa5(\x01\x00\x00(\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00+\x00	\x0020200621090000.attachment-usage-lock.up.sqlUT\x05\x00\x01\x80Cm8-- Rows locked while usage of the compose attachment quota is checked and the new attachment is stored,\n-- serializing concurrent uploads across all server instances\nCREATE TABLE IF NOT EXISTS compose_attachment_usage_lock (\n  lock_key         VARCHAR(64)              NOT NULL              COMMENT 'Locked scope (namespace or user)',\n  locks            BIGINT          UNSIGNED NOT NULL DEFAULT 0    COMMENT 'Number of times lock was acquired',\n\n  PRIMARY KEY (lock_key)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8;\nPK\x07\x08\x1f\x04\xb2\xf5\xfe\x01\x00\x00\xfe\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0e\x00	\x00migrations.sqlUT\x05\x00\x01\x80Cm8CREATE TABLE IF NOT EXISTS `migrations` (\n `project` varchar(16) NOT NULL COMMENT 'sam, crm, ...',\n `filename` varchar(255) NOT NULL COMMENT 'yyyymmddHHMMSS.sql',\n `statement_index` int(11) NOT NULL COMMENT 'Statement number from SQL file',\n `status` text NOT NULL COMMENT 'ok or full error message',\n PRIMARY KEY (`project`,`filename`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8;\n\nPK\x07\x089S\x05%x\x01\x00\x00x\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x00\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x06\x00	\x00new.shUT\x05\x00\x01\x80Cm8#!/bin/bash\ntouch $(date +%Y%m%d%H%M%S).up.sql\nPK\x07\x08\xc1h\xf1\xfb/\x00\x00\x00/\x00\x00\x00PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\xac\xe8\x19\x1d\x12\n\x00\x00\x12\n\x00\x00\x1a\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x00\x00\x00\x0020180704080000.base.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(f\x18\x1e\x84\xc5\x01\x00\x00\xc5\x01\x00\x00%\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81c\n\x00\x0020180704080001.crm_fields-data.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\xeb!\x81\xc2k\x00\x00\x00k\x00\x00\x00+\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x84\x0c\x00\x0020181109133134.crm_content-ownership.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(:.\xfb8\xa6\x00\x00\x00\xa6\x00\x00\x00.\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81Q\x0d\x00\x0020181109193047.crm_fields-related_types.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\xee\x12\x15	\x05\x01\x00\x00\x05\x01\x00\x000\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\\\x0e\x00\x0020181125122152.add_multiple_relationships.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\xa5q c\x91\x00\x00\x00\x91\x00\x00\x00D\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xc8\x0f\x00\x0020181125132142.add_required_and_visible_to_module_form_fields.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\xd9\xd4i\xe3W\x00\x00\x00W\x00\x00\x005\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xd4\x10\x00\x0020181202163130.fix-crm-module-form-primary-key.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\"\x96\xd6pj\x00\x00\x00j\x00\x00\x000\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x97\x11\x00\x0020181204123650.add-crm-content-json-field.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\xb7\x93\xd4\xf6f\x00\x00\x00f\x00\x00\x004\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81h\x12\x00\x0020181204155326.add-crm-module-form-json-field.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(mA\xa8\x1e&\x02\x00\x00&\x02\x00\x00+\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x819\x13\x00\x0020181216214630.crm-content-to-record.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\xcf\xc6g\xf6\xe4\x01\x00\x00\xe4\x01\x00\x00$\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xc1\x15\x00\x0020181217100000.add-charts-tbl.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\xae \xfd2\x18\x00\x00\x00\x18\x00\x00\x00#\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x00\x18\x00\x0020181224122301.rem-crm_field.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(+\xad\xb7\xed\xb8\x02\x00\x00\xb8\x02\x00\x00&\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81r\x18\x00\x0020190108100000.add-triggers-tbl.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\x94#\xb9\x99-\x00\x00\x00-\x00\x00\x00/\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x87\x1b\x00\x0020190110175924.rem-crm-record-json-field.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\x04]{\x1fo\x04\x00\x00o\x04\x00\x008\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x1a\x1c\x00\x0020190114072000.cleanup-record-tables-and-multival.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(h\xe2\xeb\n!\x02\x00\x00!\x02\x00\x00'\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xf8 \x00\x0020190121132408.record-updated-by.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\xce\xde?\x08\xb3\x02\x00\x00\xb3\x02\x00\x00 \x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81w#\x00\x0020190227090642.attachment.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\xf2\x1a)|\x97\x02\x00\x00\x97\x02\x00\x00'\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x81&\x00\x0020190427180922.change-tbl-prefix.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(m\xeb\xed~R\x02\x00\x00R\x02\x00\x00#\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81v)\x00\x0020190427210922.namespace-tbl.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(+\xecO\xd2\xd7\x08\x00\x00\xd7\x08\x00\x00$\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\",\x00\x0020190428080000.namespace-refs.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\x82\x01Rn1\x01\x00\x001\x01\x00\x00%\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81T5\x00\x0020190428080000.page-timestamps.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\xb1(\xbb\xf0\x8d\x05\x00\x00\x8d\x05\x00\x00#\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xe16\x00\x0020190514090000.module_fields.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\"\xd8\xe5H\x12\x01\x00\x00\x12\x01\x00\x00!\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xc8<\x00\x0020190526090000.permissions.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(c\xda\x17\xa4\x13\x11\x00\x00\x13\x11\x00\x00 \x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x812>\x00\x0020190701090000.automation.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(;#~I\x98\x01\x00\x00\x98\x01\x00\x00*\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x9cO\x00\x0020190825090000.automation-namespace.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(&~D\xee\x8d\x00\x00\x00\x8d\x00\x00\x00#\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x95Q\x00\x0020190912125228.field-default.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(}h\xa5\xba\xe4\x00\x00\x00\xe4\x00\x00\x00!\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81|R\x00\x0020190917080000.add-handles.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(WF\x8e\xd1V\x02\x00\x00V\x02\x00\x00\x1e\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xb8S\x00\x0020191008152820.settings.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\xe0\x1e\x94\xc4<\x00\x00\x00<\x00\x00\x00\x15\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81cV\x00\x0020191009172213.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\x91v:\xb5#\x04\x00\x00#\x04\x00\x00&\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xebV\x00\x0020200610090000.record-revisions.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\x00l&\xde\x94\x00\x00\x00\x94\x00\x00\x00'\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81k[\x00\x0020200611090000.module-validators.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\x06\xb0\x81\xbb\xbe\x06\x00\x00\xbe\x06\x00\x00,\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81]\\\x00\x0020200612090000.record-import-sessions.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(Q\xd7/\xe1\x18\x01\x00\x00\x18\x01\x00\x000\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81~c\x00\x0020200613090000.record-import-spreadsheets.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\xb9Q\xe1\x9a[\x00\x00\x00[\x00\x00\x00+\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xfdd\x00\x0020200615090000.record-value-fulltext.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!({R)\xddp\x04\x00\x00p\x04\x00\x00\"\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xbae\x00\x0020200616090000.record-views.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(:\xa4\x93y\xb0\x01\x00\x00\xb0\x01\x00\x00(\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x83j\x00\x0020200617090000.attachment-content.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\x07q\xfd.\xe2\x00\x00\x00\xe2\x00\x00\x00%\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x92l\x00\x0020200618090000.attachment-size.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\x92(\x0f\xb3\x10\x01\x00\x00\x10\x01\x00\x00%\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xd0m\x00\x0020200619090000.attachment-page.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\xd0\xa3G\xa5(\x01\x00\x00(\x01\x00\x00)\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81<o\x00\x0020200620090000.attachment-unlinked.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\x1f\x04\xb2\xf5\xfe\x01\x00\x00\xfe\x01\x00\x00+\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xc4p\x00\x0020200621090000.attachment-usage-lock.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(9S\x05%x\x01\x00\x00x\x01\x00\x00\x0e\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81$s\x00\x00migrations.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x00\x00\x00\x00!(\xc1h\xf1\xfb/\x00\x00\x00/\x00\x00\x00\x06\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xed\x81\xe1t\x00\x00new.shUT\x05\x00\x01\x80Cm8PK\x05\x06\x00\x00\x00\x00*\x00*\x00Y\x0f\x00\x00Mu\x00\x00\x00\x00"
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS compose_record_revision (
+  id               BIGINT          UNSIGNED NOT NULL,
+  rel_namespace    BIGINT          UNSIGNED NOT NULL              COMMENT 'Namespace',
+  rel_module       BIGINT          UNSIGNED NOT NULL              COMMENT 'Module',
+  rel_record       BIGINT          UNSIGNED NOT NULL              COMMENT 'Revised record',
+  operation        VARCHAR(16)              NOT NULL              COMMENT 'Operation that created the revision (create, update, delete, restore)',
+  changes          JSON                     NOT NULL              COMMENT 'List of changed fields with old and new values',
+  snapshot         JSON                     NOT NULL              COMMENT 'Record values after the operation',
+
+  created_at       DATETIME                 NOT NULL DEFAULT NOW(),
+  created_by       BIGINT          UNSIGNED NOT NULL DEFAULT 0    COMMENT 'Who made the change',
+
+  PRIMARY KEY (id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+CREATE INDEX record_revision ON compose_record_revision (rel_record, created_at);
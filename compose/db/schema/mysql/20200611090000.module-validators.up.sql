@@ -0,0 +1,2 @@
+ALTER TABLE `compose_module`
+  ADD `validators` JSON DEFAULT NULL COMMENT 'Record validation rules (expressions with error messages)' AFTER `json`;
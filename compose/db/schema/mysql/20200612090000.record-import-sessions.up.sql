@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS compose_record_import_session (
+  id               BIGINT          UNSIGNED NOT NULL,
+  rel_namespace    BIGINT          UNSIGNED NOT NULL              COMMENT 'Namespace',
+  rel_module       BIGINT          UNSIGNED NOT NULL              COMMENT 'Module records are imported into',
+  rel_user         BIGINT          UNSIGNED NOT NULL              COMMENT 'Owner of the session',
+  source           VARCHAR(512)             NOT NULL              COMMENT 'Location of the uploaded source in the store',
+  fields           JSON                     NOT NULL              COMMENT 'Source columns to module fields mapping',
+  on_error         VARCHAR(16)              NOT NULL DEFAULT ''   COMMENT 'What happens when record fails to import (SKIP, FAIL)',
+  mode             VARCHAR(16)              NOT NULL DEFAULT ''   COMMENT 'Import mode (CREATE, UPSERT)',
+  match_field      VARCHAR(64)              NOT NULL DEFAULT ''   COMMENT 'Field existing records are matched on in UPSERT mode',
+  dry_run          BOOLEAN                  NOT NULL DEFAULT FALSE,
+  progress         JSON                     NOT NULL              COMMENT 'Import progress, updated with each imported record',
+  report           JSON                         NULL DEFAULT NULL COMMENT 'Errors of the failed records',
+
+  created_at       DATETIME                 NOT NULL DEFAULT NOW(),
+  updated_at       DATETIME                 NOT NULL DEFAULT NOW(),
+  heartbeat_at     DATETIME                     NULL DEFAULT NULL COMMENT 'Last progress report of the running import',
+
+  PRIMARY KEY (id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+CREATE INDEX record_import_session_heartbeat ON compose_record_import_session (heartbeat_at);
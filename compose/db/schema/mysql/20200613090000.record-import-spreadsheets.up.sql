@@ -0,0 +1,3 @@
+ALTER TABLE `compose_record_import_session`
+  ADD `sheet`      VARCHAR(255) NOT NULL DEFAULT '' COMMENT 'Sheet of the spreadsheet (xlsx, ods) source' AFTER `source`,
+  ADD `header_row` INT UNSIGNED NOT NULL DEFAULT 0  COMMENT 'Header row of the spreadsheet source' AFTER `sheet`;
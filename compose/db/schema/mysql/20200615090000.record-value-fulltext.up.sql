@@ -0,0 +1 @@
+ALTER TABLE `compose_record_value` ADD FULLTEXT INDEX `ft_compose_record_value` (`value`);
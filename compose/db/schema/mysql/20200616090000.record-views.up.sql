@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS compose_record_view (
+  id               BIGINT          UNSIGNED NOT NULL,
+  handle           VARCHAR(200)             NOT NULL,
+  rel_namespace    BIGINT          UNSIGNED NOT NULL              COMMENT 'Namespace',
+  rel_module       BIGINT          UNSIGNED NOT NULL              COMMENT 'Module of the listed records',
+  name             VARCHAR(64)              NOT NULL,
+  filter           TEXT                     NOT NULL              COMMENT 'Record query (ql) filter',
+  sort             TEXT                     NOT NULL              COMMENT 'Record query (ql) sort',
+  columns          JSON                     NOT NULL              COMMENT 'Names of the visible fields',
+
+  created_at       DATETIME                 NOT NULL DEFAULT NOW(),
+  updated_at       DATETIME                     NULL DEFAULT NULL,
+  deleted_at       DATETIME                     NULL DEFAULT NULL,
+
+  PRIMARY KEY (id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+CREATE INDEX compose_record_view_namespace ON compose_record_view (rel_namespace);
+CREATE INDEX compose_record_view_module    ON compose_record_view (rel_module);
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS compose_attachment_content (
+  rel_attachment   BIGINT          UNSIGNED NOT NULL              COMMENT 'Attachment',
+  content          LONGTEXT                 NOT NULL              COMMENT 'Text extracted from the attachment',
+
+  PRIMARY KEY (rel_attachment)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+ALTER TABLE `compose_attachment_content` ADD FULLTEXT INDEX `ft_compose_attachment_content` (`content`);
@@ -0,0 +1,4 @@
+-- Page the attachment was uploaded to, used to check page permissions;
+-- attachments uploaded before are not linked to any page
+ALTER TABLE `compose_attachment`
+  ADD `rel_page` BIGINT UNSIGNED NOT NULL DEFAULT 0 COMMENT 'Page (for page attachments)' AFTER `rel_owner`;
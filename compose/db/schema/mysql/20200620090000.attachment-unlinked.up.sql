@@ -0,0 +1,4 @@
+-- Time when record values referencing the attachment were last removed,
+-- orphaned attachments are kept for the retention period since then
+ALTER TABLE `compose_attachment`
+  ADD `unlinked_at` DATETIME NULL COMMENT 'Last removal of record values referencing the attachment' AFTER `deleted_at`;
@@ -0,0 +1,8 @@
+-- Rows locked while usage of the compose attachment quota is checked and the new attachment is stored,
+-- serializing concurrent uploads across all server instances
+CREATE TABLE IF NOT EXISTS compose_attachment_usage_lock (
+  lock_key         VARCHAR(64)              NOT NULL              COMMENT 'Locked scope (namespace or user)',
+  locks            BIGINT          UNSIGNED NOT NULL DEFAULT 0    COMMENT 'Number of times lock was acquired',
+
+  PRIMARY KEY (lock_key)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
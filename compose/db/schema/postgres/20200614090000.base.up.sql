@@ -0,0 +1,261 @@
+-- PostgreSQL schema, equivalent to the state of the MySQL schema after 20200613090000 migration
+
+CREATE TABLE IF NOT EXISTS compose_namespace (
+  id               BIGINT                   NOT NULL,
+  name             VARCHAR(64)              NOT NULL,
+  slug             VARCHAR(64)              NOT NULL, -- URL slug
+  enabled          BOOLEAN                  NOT NULL,
+  meta             JSON                     NOT NULL,
+
+  created_at       TIMESTAMPTZ              NOT NULL DEFAULT NOW(),
+  updated_at       TIMESTAMPTZ                  NULL,
+  deleted_at       TIMESTAMPTZ                  NULL,
+
+  PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS compose_attachment (
+  id               BIGINT                   NOT NULL,
+  rel_namespace    BIGINT                   NOT NULL REFERENCES compose_namespace (id),
+  rel_owner        BIGINT                   NOT NULL,
+
+  kind             VARCHAR(32)              NOT NULL,
+
+  url              VARCHAR(512),
+  preview_url      VARCHAR(512),
+
+  size             INTEGER,
+  mimetype         VARCHAR(255),
+  name             TEXT,
+
+  meta             JSON,
+
+  created_at       TIMESTAMPTZ              NOT NULL DEFAULT NOW(),
+  updated_at       TIMESTAMPTZ                  NULL,
+  deleted_at       TIMESTAMPTZ                  NULL,
+
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX compose_attachment_namespace ON compose_attachment (rel_namespace);
+
+CREATE TABLE IF NOT EXISTS compose_chart (
+  id               BIGINT                   NOT NULL,
+  handle           VARCHAR(200)             NOT NULL,
+  rel_namespace    BIGINT                   NOT NULL REFERENCES compose_namespace (id),
+  name             VARCHAR(64)              NOT NULL,
+  config           JSON                     NOT NULL, -- chart & reporting configuration
+
+  created_at       TIMESTAMPTZ              NOT NULL DEFAULT NOW(),
+  updated_at       TIMESTAMPTZ                  NULL,
+  deleted_at       TIMESTAMPTZ                  NULL,
+
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX compose_chart_namespace ON compose_chart (rel_namespace);
+
+CREATE TABLE IF NOT EXISTS compose_module (
+  id               BIGINT                   NOT NULL,
+  handle           VARCHAR(200)             NOT NULL,
+  rel_namespace    BIGINT                   NOT NULL REFERENCES compose_namespace (id),
+  name             VARCHAR(64)              NOT NULL,
+  json             JSON                     NOT NULL,
+  validators       JSON                         NULL, -- record validation rules (expressions with error messages)
+
+  created_at       TIMESTAMPTZ              NOT NULL DEFAULT NOW(),
+  updated_at       TIMESTAMPTZ                  NULL,
+  deleted_at       TIMESTAMPTZ                  NULL,
+
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX compose_module_namespace ON compose_module (rel_namespace);
+
+CREATE TABLE IF NOT EXISTS compose_module_field (
+  id               BIGINT                   NOT NULL,
+  rel_module       BIGINT                   NOT NULL REFERENCES compose_module (id),
+  place            SMALLINT                 NOT NULL,
+  kind             VARCHAR(64)              NOT NULL,
+  options          JSON                     NOT NULL,
+  default_value    JSON                         NULL, -- default value as a record value set
+  name             VARCHAR(64)              NOT NULL,
+  label            VARCHAR(255)             NOT NULL,
+  is_private       BOOLEAN                  NOT NULL,
+  is_required      BOOLEAN                  NOT NULL,
+  is_visible       BOOLEAN                  NOT NULL,
+  is_multi         BOOLEAN                  NOT NULL,
+
+  created_at       TIMESTAMPTZ              NOT NULL DEFAULT NOW(),
+  updated_at       TIMESTAMPTZ                  NULL,
+  deleted_at       TIMESTAMPTZ                  NULL,
+
+  PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX uid_compose_module_field_place ON compose_module_field (rel_module, place);
+CREATE UNIQUE INDEX uid_compose_module_field_name  ON compose_module_field (rel_module, name);
+
+CREATE TABLE IF NOT EXISTS compose_page (
+  id               BIGINT                   NOT NULL,
+  handle           VARCHAR(200)             NOT NULL,
+  rel_namespace    BIGINT                   NOT NULL REFERENCES compose_namespace (id),
+  self_id          BIGINT                   NOT NULL, -- parent page
+  rel_module       BIGINT                   NOT NULL DEFAULT 0,
+  title            VARCHAR(255)             NOT NULL,
+  description      TEXT                     NOT NULL,
+  blocks           JSON                     NOT NULL,
+  visible          BOOLEAN                  NOT NULL, -- is page visible in navigation?
+  weight           INTEGER                  NOT NULL, -- order for navigation
+
+  created_at       TIMESTAMPTZ              NOT NULL DEFAULT NOW(),
+  updated_at       TIMESTAMPTZ                  NULL,
+  deleted_at       TIMESTAMPTZ                  NULL,
+
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX compose_page_namespace ON compose_page (rel_namespace);
+CREATE INDEX compose_page_module    ON compose_page (rel_module);
+CREATE INDEX compose_page_self      ON compose_page (self_id);
+
+CREATE TABLE IF NOT EXISTS compose_record (
+  id               BIGINT                   NOT NULL,
+  rel_namespace    BIGINT                   NOT NULL REFERENCES compose_namespace (id),
+  module_id        BIGINT                   NOT NULL,
+
+  owned_by         BIGINT                   NOT NULL DEFAULT 0,
+  created_at       TIMESTAMPTZ              NOT NULL DEFAULT NOW(),
+  created_by       BIGINT                   NOT NULL DEFAULT 0,
+  updated_at       TIMESTAMPTZ                  NULL,
+  updated_by       BIGINT                   NOT NULL DEFAULT 0,
+  deleted_at       TIMESTAMPTZ                  NULL,
+  deleted_by       BIGINT                   NOT NULL DEFAULT 0,
+
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX compose_record_namespace ON compose_record (rel_namespace);
+CREATE INDEX compose_record_module    ON compose_record (module_id);
+CREATE INDEX compose_record_owner     ON compose_record (owned_by);
+
+CREATE TABLE IF NOT EXISTS compose_record_value (
+  record_id        BIGINT                   NOT NULL,
+  name             VARCHAR(64)              NOT NULL,
+  value            TEXT,
+  ref              BIGINT                   NOT NULL DEFAULT 0,
+  place            INTEGER                  NOT NULL DEFAULT 0,
+  deleted_at       TIMESTAMPTZ                  NULL,
+
+  PRIMARY KEY (record_id, name, place)
+);
+
+CREATE INDEX compose_record_value_ref ON compose_record_value (ref);
+
+CREATE TABLE IF NOT EXISTS compose_record_revision (
+  id               BIGINT                   NOT NULL,
+  rel_namespace    BIGINT                   NOT NULL,
+  rel_module       BIGINT                   NOT NULL,
+  rel_record       BIGINT                   NOT NULL, -- revised record
+  operation        VARCHAR(16)              NOT NULL, -- operation that created the revision (create, update, delete, restore)
+  changes          JSON                     NOT NULL, -- list of changed fields with old and new values
+  snapshot         JSON                     NOT NULL, -- record values after the operation
+
+  created_at       TIMESTAMPTZ              NOT NULL DEFAULT NOW(),
+  created_by       BIGINT                   NOT NULL DEFAULT 0,
+
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX record_revision ON compose_record_revision (rel_record, created_at);
+
+CREATE TABLE IF NOT EXISTS compose_record_import_session (
+  id               BIGINT                   NOT NULL,
+  rel_namespace    BIGINT                   NOT NULL,
+  rel_module       BIGINT                   NOT NULL, -- module records are imported into
+  rel_user         BIGINT                   NOT NULL, -- owner of the session
+  source           VARCHAR(512)             NOT NULL, -- location of the uploaded source in the store
+  sheet            VARCHAR(255)             NOT NULL DEFAULT '', -- sheet of the spreadsheet (xlsx, ods) source
+  header_row       INTEGER                  NOT NULL DEFAULT 0,  -- header row of the spreadsheet source
+  fields           JSON                     NOT NULL, -- source columns to module fields mapping
+  on_error         VARCHAR(16)              NOT NULL DEFAULT '',
+  mode             VARCHAR(16)              NOT NULL DEFAULT '',
+  match_field      VARCHAR(64)              NOT NULL DEFAULT '',
+  dry_run          BOOLEAN                  NOT NULL DEFAULT FALSE,
+  progress         JSON                     NOT NULL,
+  report           JSON                         NULL,
+
+  created_at       TIMESTAMPTZ              NOT NULL DEFAULT NOW(),
+  updated_at       TIMESTAMPTZ              NOT NULL DEFAULT NOW(),
+  heartbeat_at     TIMESTAMPTZ                  NULL, -- last progress report of the running import
+
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX record_import_session_heartbeat ON compose_record_import_session (heartbeat_at);
+
+CREATE TABLE IF NOT EXISTS compose_permission_rules (
+  rel_role         BIGINT                   NOT NULL,
+  resource         VARCHAR(128)             NOT NULL,
+  operation        VARCHAR(128)             NOT NULL,
+  access           SMALLINT                 NOT NULL,
+
+  PRIMARY KEY (rel_role, resource, operation)
+);
+
+CREATE TABLE IF NOT EXISTS compose_settings (
+  rel_owner        BIGINT                   NOT NULL DEFAULT 0, -- value owner, 0 for global settings
+  name             VARCHAR(200)             NOT NULL,
+  value            JSON,
+
+  updated_at       TIMESTAMPTZ              NOT NULL DEFAULT NOW(),
+  updated_by       BIGINT                   NOT NULL DEFAULT 0,
+
+  PRIMARY KEY (name, rel_owner)
+);
+
+CREATE TABLE IF NOT EXISTS compose_automation_script (
+  id               BIGINT                   NOT NULL,
+  rel_namespace    BIGINT                   NOT NULL REFERENCES compose_namespace (id),
+  name             VARCHAR(64)              NOT NULL DEFAULT 'unnamed',
+  source           TEXT                     NOT NULL,
+  source_ref       VARCHAR(200)             NOT NULL, -- where is the script located (if remote)
+  async            BOOLEAN                  NOT NULL DEFAULT FALSE,
+  rel_runner       BIGINT                   NOT NULL DEFAULT 0, -- who is running the script? 0 for invoker
+  run_in_ua        BOOLEAN                  NOT NULL DEFAULT FALSE,
+  timeout          INTEGER                  NOT NULL DEFAULT 0,
+  critical         BOOLEAN                  NOT NULL DEFAULT TRUE,
+  enabled          BOOLEAN                  NOT NULL DEFAULT TRUE,
+
+  created_by       BIGINT                   NOT NULL DEFAULT 0,
+  created_at       TIMESTAMPTZ              NOT NULL DEFAULT NOW(),
+  updated_by       BIGINT                   NOT NULL DEFAULT 0,
+  updated_at       TIMESTAMPTZ                  NULL,
+  deleted_by       BIGINT                   NOT NULL DEFAULT 0,
+  deleted_at       TIMESTAMPTZ                  NULL,
+
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX compose_automation_script_namespace ON compose_automation_script (rel_namespace);
+
+CREATE TABLE IF NOT EXISTS compose_automation_trigger (
+  id               BIGINT                   NOT NULL,
+  rel_script       BIGINT                   NOT NULL REFERENCES compose_automation_script (id),
+
+  resource         VARCHAR(128)             NOT NULL,
+  event            VARCHAR(128)             NOT NULL,
+  event_condition  TEXT                     NOT NULL,
+  enabled          BOOLEAN                  NOT NULL DEFAULT TRUE,
+
+  weight           INTEGER                  NOT NULL DEFAULT 0,
+
+  created_by       BIGINT                   NOT NULL DEFAULT 0,
+  created_at       TIMESTAMPTZ              NOT NULL DEFAULT NOW(),
+  updated_by       BIGINT                   NOT NULL DEFAULT 0,
+  updated_at       TIMESTAMPTZ                  NULL,
+  deleted_by       BIGINT                   NOT NULL DEFAULT 0,
+  deleted_at       TIMESTAMPTZ                  NULL,
+
+  PRIMARY KEY (id)
+);
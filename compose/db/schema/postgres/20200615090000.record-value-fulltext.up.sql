@@ -0,0 +1,2 @@
+-- Expression must match the one used by the full-text search conditions
+CREATE INDEX compose_record_value_fulltext ON compose_record_value USING GIN (to_tsvector('simple', COALESCE(value, '')));
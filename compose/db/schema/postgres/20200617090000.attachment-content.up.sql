@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS compose_attachment_content (
+  rel_attachment   BIGINT                   NOT NULL REFERENCES compose_attachment (id),
+  content          TEXT                     NOT NULL, -- text extracted from the attachment
+
+  PRIMARY KEY (rel_attachment)
+);
+
+-- Expression must match the one used by the full-text search conditions
+CREATE INDEX compose_attachment_content_fulltext ON compose_attachment_content USING GIN (to_tsvector('simple', COALESCE(content, '')));
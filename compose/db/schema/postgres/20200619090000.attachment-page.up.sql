@@ -0,0 +1,3 @@
+-- Page the attachment was uploaded to, used to check page permissions;
+-- attachments uploaded before are not linked to any page
+ALTER TABLE compose_attachment ADD COLUMN rel_page BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,10 @@
+-- External content full-text index of record values, kept in sync by triggers
+CREATE VIRTUAL TABLE IF NOT EXISTS compose_record_value_fts USING fts4(content="compose_record_value", value, tokenize=unicode61);
+
+CREATE TRIGGER compose_record_value_fts_bu BEFORE UPDATE ON compose_record_value BEGIN DELETE FROM compose_record_value_fts WHERE docid = old.rowid; END;
+CREATE TRIGGER compose_record_value_fts_bd BEFORE DELETE ON compose_record_value BEGIN DELETE FROM compose_record_value_fts WHERE docid = old.rowid; END;
+CREATE TRIGGER compose_record_value_fts_au AFTER UPDATE ON compose_record_value BEGIN INSERT INTO compose_record_value_fts (docid, value) VALUES (new.rowid, new.value); END;
+CREATE TRIGGER compose_record_value_fts_ai AFTER INSERT ON compose_record_value BEGIN INSERT INTO compose_record_value_fts (docid, value) VALUES (new.rowid, new.value); END;
+
+-- Index existing values
+INSERT INTO compose_record_value_fts (compose_record_value_fts) VALUES ('rebuild');
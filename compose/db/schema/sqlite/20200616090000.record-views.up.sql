@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS compose_record_view (
+  id               BIGINT                   NOT NULL,
+  handle           VARCHAR(200)             NOT NULL,
+  rel_namespace    BIGINT                   NOT NULL REFERENCES compose_namespace (id),
+  rel_module       BIGINT                   NOT NULL REFERENCES compose_module (id),
+  name             VARCHAR(64)              NOT NULL,
+  filter           TEXT                     NOT NULL, -- record query (ql) filter
+  sort             TEXT                     NOT NULL, -- record query (ql) sort
+  columns          TEXT                     NOT NULL, -- names of the visible fields
+
+  created_at       DATETIME                 NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at       DATETIME                     NULL,
+  deleted_at       DATETIME                     NULL,
+
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX compose_record_view_namespace ON compose_record_view (rel_namespace);
+CREATE INDEX compose_record_view_module    ON compose_record_view (rel_module);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS compose_attachment_content (
+  rel_attachment   BIGINT                   NOT NULL REFERENCES compose_attachment (id),
+  content          TEXT                     NOT NULL, -- text extracted from the attachment
+
+  PRIMARY KEY (rel_attachment)
+);
+
+-- External content full-text index of attachment contents, kept in sync by triggers
+CREATE VIRTUAL TABLE IF NOT EXISTS compose_attachment_content_fts USING fts4(content="compose_attachment_content", content, tokenize=unicode61);
+
+CREATE TRIGGER compose_attachment_content_fts_bu BEFORE UPDATE ON compose_attachment_content BEGIN DELETE FROM compose_attachment_content_fts WHERE docid = old.rowid; END;
+CREATE TRIGGER compose_attachment_content_fts_bd BEFORE DELETE ON compose_attachment_content BEGIN DELETE FROM compose_attachment_content_fts WHERE docid = old.rowid; END;
+CREATE TRIGGER compose_attachment_content_fts_au AFTER UPDATE ON compose_attachment_content BEGIN INSERT INTO compose_attachment_content_fts (docid, content) VALUES (new.rowid, new.content); END;
+CREATE TRIGGER compose_attachment_content_fts_ai AFTER INSERT ON compose_attachment_content BEGIN INSERT INTO compose_attachment_content_fts (docid, content) VALUES (new.rowid, new.content); END;
@@ -0,0 +1,3 @@
+-- Time when record values referencing the attachment were last removed,
+-- orphaned attachments are kept for the retention period since then
+ALTER TABLE compose_attachment ADD COLUMN unlinked_at DATETIME NULL;
@@ -0,0 +1,2 @@
+#!/bin/bash
+touch $(date +%Y%m%d%H%M%S).up.sql
@@ -0,0 +1,227 @@
+package decoder
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	odsContent struct {
+		Tables []struct {
+			Name string   `xml:"name,attr"`
+			Rows []odsRow `xml:"table-row"`
+		} `xml:"body>spreadsheet>table"`
+	}
+
+	odsRow struct {
+		Repeated int       `xml:"number-rows-repeated,attr"`
+		Cells    []odsCell `xml:",any"`
+	}
+
+	odsCell struct {
+		XMLName   xml.Name
+		Repeated  int     `xml:"number-columns-repeated,attr"`
+		ValueType string  `xml:"value-type,attr"`
+		Value     string  `xml:"value,attr"`
+		DateValue string  `xml:"date-value,attr"`
+		TimeValue string  `xml:"time-value,attr"`
+		BoolValue string  `xml:"boolean-value,attr"`
+		Text      odsText `xml:"p"`
+	}
+
+	// odsText collects text of all paragraphs in the cell
+	odsText string
+)
+
+var (
+	odsDuration = regexp.MustCompile(`^PT(\d+)H(\d+)M(\d+(?:\.\d+)?)S$`)
+)
+
+// NewOdsDecoder reads sheet (table) of the ODS (OpenDocument) spreadsheet
+func NewOdsDecoder(f io.ReadSeeker, opt SpreadsheetOptions) (*spreadsheetDecoder, error) {
+	z, err := openZip(f)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := readZipFile(z, "content.xml")
+	if err != nil {
+		return nil, err
+	} else if raw == nil {
+		return nil, fmt.Errorf("spreadsheet content not found")
+	}
+
+	var (
+		doc  = odsContent{}
+		rows []spreadsheetRow
+	)
+
+	if err = xml.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+
+	for _, t := range doc.Tables {
+		if opt.Sheet != "" && t.Name != opt.Sheet {
+			continue
+		}
+
+		rows = make([]spreadsheetRow, 0, len(t.Rows))
+
+		// Empty rows are added only when followed by a non-empty
+		// one; trailing rows are usually repeated to the end of the sheet
+		empty := 0
+		for _, r := range t.Rows {
+			row := r.cells()
+
+			if row.empty() {
+				empty += r.repeated()
+				continue
+			}
+
+			for ; empty > 0; empty-- {
+				rows = append(rows, spreadsheetRow{})
+			}
+
+			for i := 0; i < r.repeated(); i++ {
+				rows = append(rows, row)
+			}
+		}
+
+		return newSpreadsheetDecoder(rows, opt)
+	}
+
+	return nil, fmt.Errorf("sheet %q not found", opt.Sheet)
+}
+
+func (r odsRow) repeated() int {
+	if r.Repeated > 1 {
+		return r.Repeated
+	}
+
+	return 1
+}
+
+// cells converts row cells; repeated empty cells are added
+// only when followed by a non-empty cell
+func (r odsRow) cells() spreadsheetRow {
+	var (
+		row   = spreadsheetRow{}
+		empty = 0
+	)
+
+	for _, c := range r.Cells {
+		if c.XMLName.Local != "table-cell" && c.XMLName.Local != "covered-table-cell" {
+			continue
+		}
+
+		n := c.Repeated
+		if n < 1 {
+			n = 1
+		}
+
+		cell := c.cell()
+		if cell.value == "" {
+			empty += n
+			continue
+		}
+
+		for ; empty > 0; empty-- {
+			row = append(row, spreadsheetCell{})
+		}
+
+		for i := 0; i < n; i++ {
+			row = append(row, cell)
+		}
+	}
+
+	return row
+}
+
+func (c odsCell) cell() spreadsheetCell {
+	switch c.ValueType {
+	case "float", "percentage", "currency":
+		return spreadsheetCell{value: c.Value}
+
+	case "boolean":
+		if c.BoolValue == "true" {
+			return spreadsheetCell{value: "1"}
+		}
+
+		return spreadsheetCell{value: "0"}
+
+	case "date":
+		// Dates without time do not have the time part (2006-01-02)
+		for _, layout := range []string{"2006-01-02T15:04:05", "2006-01-02"} {
+			if t, err := time.Parse(layout, c.DateValue); err == nil {
+				return spreadsheetCell{value: t.Format(time.RFC3339), time: &t}
+			}
+		}
+
+	case "time":
+		// Durations (PT12H30M00S) are converted into time on the spreadsheet epoch
+		if m := odsDuration.FindStringSubmatch(c.TimeValue); m != nil {
+			h, _ := strconv.Atoi(m[1])
+			i, _ := strconv.Atoi(m[2])
+			s, _ := strconv.ParseFloat(m[3], 64)
+
+			t := time.Date(1899, 12, 30, h, i, int(s), 0, time.UTC)
+			return spreadsheetCell{value: t.Format(time.RFC3339), time: &t}
+		}
+	}
+
+	return spreadsheetCell{value: string(c.Text)}
+}
+
+// UnmarshalXML concatenates text of the paragraph and all its spans,
+// expanding space (text:s), tab and line-break elements
+func (t *odsText) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var sb strings.Builder
+
+	if *t != "" {
+		// Paragraphs are separated by new line
+		sb.WriteString(string(*t))
+		sb.WriteString("\n")
+	}
+
+	for depth := 1; depth > 0; {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch tok := tok.(type) {
+		case xml.CharData:
+			sb.Write(tok)
+
+		case xml.StartElement:
+			depth++
+
+			switch tok.Name.Local {
+			case "s":
+				n := 1
+				for _, a := range tok.Attr {
+					if a.Name.Local == "c" {
+						n, _ = strconv.Atoi(a.Value)
+					}
+				}
+
+				sb.WriteString(strings.Repeat(" ", n))
+			case "tab":
+				sb.WriteString("\t")
+			case "line-break":
+				sb.WriteString("\n")
+			}
+
+		case xml.EndElement:
+			depth--
+		}
+	}
+
+	*t = odsText(sb.String())
+	return nil
+}
@@ -0,0 +1,151 @@
+package decoder
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"time"
+)
+
+type (
+	// SpreadsheetOptions control how spreadsheet (xlsx, ods) sources are read
+	SpreadsheetOptions struct {
+		// Name of the sheet to import; first sheet is used when empty
+		Sheet string
+
+		// Row (1-based) holding the header; rows above it are ignored.
+		// First row is used when 0
+		HeaderRow uint
+
+		// DatetimeFormat returns time layout for date cells imported into the given field
+		//
+		// RFC3339 is used when nil or when empty layout is returned
+		DatetimeFormat func(field string) string
+	}
+
+	// spreadsheetCell holds textual value of the cell and
+	// parsed time for cells that are typed as date or time
+	spreadsheetCell struct {
+		value string
+		time  *time.Time
+	}
+
+	spreadsheetRow []spreadsheetCell
+
+	spreadsheetDecoder struct {
+		header []string
+		rows   []spreadsheetRow
+		opt    SpreadsheetOptions
+	}
+)
+
+func newSpreadsheetDecoder(rows []spreadsheetRow, opt SpreadsheetOptions) (*spreadsheetDecoder, error) {
+	var (
+		dec = &spreadsheetDecoder{opt: opt}
+		hr  = int(opt.HeaderRow)
+	)
+
+	if hr == 0 {
+		hr = 1
+	}
+
+	if len(rows) < hr {
+		return nil, fmt.Errorf("header row %d not found", hr)
+	}
+
+	for _, c := range rows[hr-1] {
+		dec.header = append(dec.header, c.value)
+	}
+
+	// Empty rows are skipped
+	for _, r := range rows[hr:] {
+		if !r.empty() {
+			dec.rows = append(dec.rows, r)
+		}
+	}
+
+	return dec, nil
+}
+
+func (dec *spreadsheetDecoder) Header() []string {
+	return dec.header
+}
+
+func (dec *spreadsheetDecoder) EntryCount() (uint64, error) {
+	return uint64(len(dec.rows)), nil
+}
+
+// mapify maps row cells to header columns
+func (dec *spreadsheetDecoder) mapify(row spreadsheetRow) map[string]spreadsheetCell {
+	rtr := make(map[string]spreadsheetCell)
+	for i, h := range dec.header {
+		if i < len(row) {
+			rtr[h] = row[i]
+		}
+	}
+
+	return rtr
+}
+
+// format converts cell into string value for the given field
+func (dec *spreadsheetDecoder) format(c spreadsheetCell, field string) string {
+	if c.time == nil {
+		return c.value
+	}
+
+	layout := ""
+	if dec.opt.DatetimeFormat != nil {
+		layout = dec.opt.DatetimeFormat(field)
+	}
+
+	if layout == "" {
+		layout = time.RFC3339
+	}
+
+	return c.time.Format(layout)
+}
+
+func (r spreadsheetRow) empty() bool {
+	for _, c := range r {
+		if c.value != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// openZip reads (ods) archive from the source
+func openZip(f io.ReadSeeker) (*zip.Reader, error) {
+	if _, err := f.Seek(0, 0); err != nil {
+		return nil, err
+	}
+
+	buf, err := ioutil.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return zip.NewReader(bytes.NewReader(buf), int64(len(buf)))
+}
+
+// readZipFile reads file from the archive; nil is returned when file does not exist
+func readZipFile(z *zip.Reader, name string) ([]byte, error) {
+	for _, f := range z.File {
+		if f.Name != name {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+
+		defer rc.Close()
+		return ioutil.ReadAll(rc)
+	}
+
+	return nil, nil
+}
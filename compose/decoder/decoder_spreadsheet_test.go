@@ -0,0 +1,177 @@
+package decoder
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/360EntSecGroup-Skylar/excelize/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortezaproject/corteza-server/compose/types"
+)
+
+const (
+	testODSContent = `<?xml version="1.0" encoding="UTF-8"?>
+<office:document-content
+  xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0"
+  xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0"
+  xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0">
+  <office:body><office:spreadsheet>
+    <table:table table:name="Other"><table:table-row><table:table-cell office:value-type="string"><text:p>nope</text:p></table:table-cell></table:table-row></table:table>
+    <table:table table:name="Data">
+      <table:table-row><table:table-cell office:value-type="string"><text:p>Exported at 2020-06-01</text:p></table:table-cell></table:table-row>
+      <table:table-row>
+        <table:table-cell office:value-type="string"><text:p>name</text:p></table:table-cell>
+        <table:table-cell office:value-type="string"><text:p>born</text:p></table:table-cell>
+        <table:table-cell office:value-type="string"><text:p>qty</text:p></table:table-cell>
+      </table:table-row>
+      <table:table-row>
+        <table:table-cell office:value-type="string"><text:p>John<text:s text:c="2"/><text:span>Doe</text:span></text:p></table:table-cell>
+        <table:table-cell office:value-type="date" office:date-value="1980-05-04"><text:p>04.05.80</text:p></table:table-cell>
+        <table:table-cell office:value-type="float" office:value="2.5"><text:p>2,5</text:p></table:table-cell>
+      </table:table-row>
+      <table:table-row table:number-rows-repeated="1048572"><table:table-cell table:number-columns-repeated="1024"/></table:table-row>
+    </table:table>
+  </office:spreadsheet></office:body>
+</office:document-content>`
+)
+
+func makeODS(t *testing.T, content string) *bytes.Reader {
+	buf := &bytes.Buffer{}
+	z := zip.NewWriter(buf)
+
+	w, err := z.Create("content.xml")
+	require.NoError(t, err)
+	_, err = w.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, z.Close())
+
+	return bytes.NewReader(buf.Bytes())
+}
+
+func makeXLSX(t *testing.T) *bytes.Reader {
+	f := excelize.NewFile()
+	f.NewSheet("Data")
+
+	require.NoError(t, f.SetCellValue("Data", "A1", "Exported at 2020-06-01"))
+	require.NoError(t, f.SetCellValue("Data", "A2", "name"))
+	require.NoError(t, f.SetCellValue("Data", "B2", "born"))
+	require.NoError(t, f.SetCellValue("Data", "C2", "qty"))
+	require.NoError(t, f.SetCellValue("Data", "A3", "John Doe"))
+	require.NoError(t, f.SetCellValue("Data", "B3", time.Date(1980, 5, 4, 0, 0, 0, 0, time.UTC)))
+	require.NoError(t, f.SetCellValue("Data", "C3", 2.5))
+
+	buf, err := f.WriteToBuffer()
+	require.NoError(t, err)
+
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestSpreadsheetDecoder(t *testing.T) {
+	var (
+		opt = SpreadsheetOptions{
+			Sheet:     "Data",
+			HeaderRow: 2,
+			DatetimeFormat: func(field string) string {
+				if field == "birthday" {
+					return "2006-01-02"
+				}
+
+				return ""
+			},
+		}
+
+		fields = map[string]string{"name": "fullName", "born": "birthday", "qty": "quantity"}
+	)
+
+	tests := []struct {
+		name string
+		dec  func() (*spreadsheetDecoder, error)
+		full string
+	}{
+		{
+			name: "xlsx",
+			dec:  func() (*spreadsheetDecoder, error) { return NewXlsxDecoder(makeXLSX(t), opt) },
+			full: "John Doe",
+		},
+		{
+			name: "ods",
+			dec:  func() (*spreadsheetDecoder, error) { return NewOdsDecoder(makeODS(t, testODSContent), opt) },
+			full: "John  Doe",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				req = require.New(t)
+				rr  []*types.Record
+			)
+
+			dec, err := tt.dec()
+			req.NoError(err)
+			req.Equal([]string{"name", "born", "qty"}, dec.Header())
+
+			c, err := dec.EntryCount()
+			req.NoError(err)
+			req.Equal(uint64(1), c)
+
+			req.NoError(dec.Records(fields, func(r *types.Record) error {
+				rr = append(rr, r)
+				return nil
+			}))
+
+			req.Len(rr, 1)
+			req.Equal(tt.full, rr[0].Values.Get("fullName", 0).Value)
+			req.Equal("1980-05-04", rr[0].Values.Get("birthday", 0).Value)
+			req.Equal("2.5", rr[0].Values.Get("quantity", 0).Value)
+		})
+	}
+}
+
+func TestSpreadsheetDecoder_sheetNotFound(t *testing.T) {
+	_, err := NewXlsxDecoder(makeXLSX(t), SpreadsheetOptions{Sheet: "missing"})
+	require.Error(t, err)
+
+	_, err = NewOdsDecoder(makeODS(t, testODSContent), SpreadsheetOptions{Sheet: "missing"})
+	require.Error(t, err)
+}
+
+func TestXlsxTime(t *testing.T) {
+	req := require.New(t)
+	req.Equal(time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC), xlsxTime(43983.5, false))
+	req.Equal(time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC), xlsxTime(1, false))
+	req.Equal(time.Date(1904, 1, 2, 0, 0, 0, 0, time.UTC), xlsxTime(1, true))
+}
+
+func TestXlsxDecoder_numberFormats(t *testing.T) {
+	var (
+		req = require.New(t)
+		f   = excelize.NewFile()
+	)
+
+	decimal, err := f.NewStyle(`{"number_format": 2}`)
+	req.NoError(err)
+	date, err := f.NewStyle(`{"custom_number_format": "dd.mm.yyyy"}`)
+	req.NoError(err)
+
+	req.NoError(f.SetCellValue("Sheet1", "A1", "qty"))
+	req.NoError(f.SetCellValue("Sheet1", "B1", "day"))
+	req.NoError(f.SetCellValue("Sheet1", "A2", 2.567))
+	req.NoError(f.SetCellStyle("Sheet1", "A2", "A2", decimal))
+	req.NoError(f.SetCellValue("Sheet1", "B2", 43983))
+	req.NoError(f.SetCellStyle("Sheet1", "B2", "B2", date))
+
+	buf, err := f.WriteToBuffer()
+	req.NoError(err)
+
+	dec, err := NewXlsxDecoder(bytes.NewReader(buf.Bytes()), SpreadsheetOptions{})
+	req.NoError(err)
+	req.Len(dec.rows, 1)
+	req.Equal("2.567", dec.rows[0][0].value)
+	req.Nil(dec.rows[0][0].time)
+	req.Equal("2020-06-01T00:00:00Z", dec.rows[0][1].value)
+	req.NotNil(dec.rows[0][1].time)
+}
@@ -0,0 +1,157 @@
+package decoder
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/360EntSecGroup-Skylar/excelize/v2"
+)
+
+var (
+	// Quoted strings, escaped characters and sections in brackets ([Red], [$-409])
+	// of the number format code that should not be considered when looking for date tokens
+	xlsxFormatLiterals = regexp.MustCompile(`"[^"]*"|\\.|\[[^\]]*\]`)
+)
+
+// NewXlsxDecoder reads sheet of the XLSX (Office Open XML) spreadsheet
+func NewXlsxDecoder(f io.ReadSeeker, opt SpreadsheetOptions) (*spreadsheetDecoder, error) {
+	if _, err := f.Seek(0, 0); err != nil {
+		return nil, err
+	}
+
+	x, err := excelize.OpenReader(f)
+	if err != nil {
+		return nil, err
+	}
+
+	sheet := opt.Sheet
+	if sheet == "" {
+		sheet = x.GetSheetName(1)
+	}
+
+	if sheet == "" || x.GetSheetIndex(sheet) == 0 {
+		return nil, fmt.Errorf("sheet %q not found", opt.Sheet)
+	}
+
+	dates := xlsxDateStyles(x)
+
+	rr, err := x.GetRows(sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		date1904 = x.WorkBook != nil && x.WorkBook.WorkbookPr != nil && x.WorkBook.WorkbookPr.Date1904
+		rows     = make([]spreadsheetRow, len(rr))
+	)
+
+	for r := range rr {
+		rows[r] = make(spreadsheetRow, len(rr[r]))
+
+		for c, v := range rr[r] {
+			cell := &rows[r][c]
+			cell.value = v
+
+			if v == "" || len(dates) == 0 {
+				continue
+			}
+
+			name, err := excelize.CoordinatesToCellName(c+1, r+1)
+			if err != nil {
+				return nil, err
+			}
+
+			style, err := x.GetCellStyle(sheet, name)
+			if err != nil {
+				return nil, err
+			}
+
+			if !dates[style] {
+				continue
+			}
+
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				t := xlsxTime(f, date1904)
+				cell.time = &t
+				cell.value = t.Format(time.RFC3339)
+			} else if t, err := time.Parse(time.RFC3339, v); err == nil {
+				// ISO 8601 date cells (t="d")
+				cell.time = &t
+			}
+		}
+	}
+
+	return newSpreadsheetDecoder(rows, opt)
+}
+
+// xlsxDateStyles returns indexes of cell styles with date & time number formats
+//
+// Number formats of all styles are reset so that cell values
+// are read as stored (excelize formats dates with a lossy layout)
+func xlsxDateStyles(x *excelize.File) map[int]bool {
+	var (
+		dates  = make(map[int]bool)
+		custom = make(map[int]string)
+	)
+
+	if x.Styles == nil || x.Styles.CellXfs == nil {
+		return dates
+	}
+
+	if x.Styles.NumFmts != nil {
+		for _, f := range x.Styles.NumFmts.NumFmt {
+			custom[f.NumFmtID] = f.FormatCode
+		}
+	}
+
+	for i := range x.Styles.CellXfs.Xf {
+		if xlsxIsDate(x.Styles.CellXfs.Xf[i].NumFmtID, custom) {
+			dates[i] = true
+		}
+
+		x.Styles.CellXfs.Xf[i].NumFmtID = 0
+	}
+
+	return dates
+}
+
+// xlsxIsDate checks if the number format is one of the built-in date & time formats
+// or if custom format code contains any of the date & time tokens
+func xlsxIsDate(numFmtID int, custom map[int]string) bool {
+	switch {
+	case numFmtID >= 14 && numFmtID <= 22,
+		numFmtID >= 27 && numFmtID <= 36,
+		numFmtID >= 45 && numFmtID <= 47,
+		numFmtID >= 50 && numFmtID <= 58:
+		return true
+	}
+
+	if code, ok := custom[numFmtID]; ok {
+		code = strings.ToLower(xlsxFormatLiterals.ReplaceAllString(code, ""))
+		return code != "general" && strings.ContainsAny(code, "ymdhs")
+	}
+
+	return false
+}
+
+// xlsxTime converts serial date (number of days since epoch) into time
+func xlsxTime(serial float64, date1904 bool) time.Time {
+	var epoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+	if date1904 {
+		epoch = time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC)
+	} else if serial < 60 {
+		// Lotus 1-2-3 bug: 1900 is treated as a leap year
+		epoch = epoch.AddDate(0, 0, 1)
+	}
+
+	days := math.Floor(serial)
+	secs := math.Round((serial - days) * 86400)
+
+	return epoch.AddDate(0, 0, int(days)).Add(time.Duration(secs) * time.Second)
+}
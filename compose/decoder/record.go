@@ -133,3 +133,34 @@ func (dec structuredDecoder) Records(fields map[string]string, Create RecordCrea
 
 	return err
 }
+
+func (dec spreadsheetDecoder) Records(fields map[string]string, Create RecordCreator) error {
+	for _, row := range dec.rows {
+		mapped := dec.mapify(row)
+		r := types.Record{}
+		rvs := types.RecordValueSet{}
+
+		for imp, rec := range fields {
+			if rec == "" {
+				return errors.New("Can not import record: Record field not defined")
+			}
+
+			val := dec.format(mapped[imp], rec)
+			if system, err := setSystemField(&r, rec, val); err != nil {
+				return err
+			} else if !system {
+				rvs = append(rvs, &types.RecordValue{
+					Name:  rec,
+					Value: val,
+				})
+			}
+		}
+
+		r.Values = rvs
+		if err := Create(&r); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
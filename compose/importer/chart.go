@@ -18,6 +18,7 @@ type (
 		set       types.ChartSet
 		dirty     map[uint64]bool
 		modRefs   []chartModuleRef
+		joinRefs  []chartJoinModuleRef
 	}
 
 	chartModuleRef struct {
@@ -27,6 +28,14 @@ type (
 		mh string
 	}
 
+	chartJoinModuleRef struct {
+		// chart handle, report index, join index, module handle
+		ch string
+		ri int
+		ji int
+		mh string
+	}
+
 	// @todo remove finder strategy, directly provide set of items
 	chartFinder interface {
 		Find(filter types.ChartFilter) (set types.ChartSet, f types.ChartFilter, err error)
@@ -155,6 +164,9 @@ func (cImp *Chart) castConfigReports(chart *types.Chart, def interface{}) ([]*ty
 					return fmt.Errorf("unknown module %q referenced from chart %q report config", module, chart.Handle)
 				}
 				cImp.modRefs = append(cImp.modRefs, chartModuleRef{chart.Handle, len(rr), module})
+			case "joins":
+				r.Joins, err = cImp.castConfigReportJoins(chart, len(rr), val)
+				return
 			case "metrics":
 				r.Metrics = deinterfacer.ToSliceOfStringToInterfaceMap(val)
 			case "dimensions":
@@ -183,6 +195,39 @@ func (cImp *Chart) castConfigReports(chart *types.Chart, def interface{}) ([]*ty
 	})
 }
 
+func (cImp *Chart) castConfigReportJoins(chart *types.Chart, ri int, def interface{}) ([]*types.ChartConfigReportJoin, error) {
+	var jj = make([]*types.ChartConfigReportJoin, 0)
+
+	return jj, deinterfacer.Each(def, func(_ int, _ string, join interface{}) (err error) {
+		var j = &types.ChartConfigReportJoin{}
+		err = deinterfacer.Each(join, func(_ int, key string, val interface{}) (err error) {
+			switch key {
+			case "alias":
+				j.Alias = deinterfacer.ToString(val)
+			case "field":
+				j.Field = deinterfacer.ToString(val)
+			case "module":
+				module := deinterfacer.ToString(val)
+				if m, err := cImp.getModule(module); err != nil || m == nil {
+					return fmt.Errorf("unknown module %q joined in chart %q report config", module, chart.Handle)
+				}
+				cImp.joinRefs = append(cImp.joinRefs, chartJoinModuleRef{chart.Handle, ri, len(jj), module})
+			default:
+				return fmt.Errorf("unexpected key %q for chart %q report join config", key, chart.Handle)
+			}
+
+			return
+		})
+
+		if err != nil {
+			return
+		}
+
+		jj = append(jj, j)
+		return
+	})
+}
+
 // Get existing charts
 func (cImp *Chart) Get(handle string) (*types.Chart, error) {
 	handle = importer.NormalizeHandle(handle)
@@ -241,5 +286,24 @@ func (cImp *Chart) resolveRefs() error {
 		}
 	}
 
+	for _, ref := range cImp.joinRefs {
+		chart := cImp.set.FindByHandle(ref.ch)
+		if chart == nil {
+			return errors.Errorf("invalid reference, unknown chart (%v)", ref)
+		}
+
+		if ref.ri >= len(chart.Config.Reports) || ref.ji >= len(chart.Config.Reports[ref.ri].Joins) {
+			return errors.Errorf("invalid reference, report join index out of range (%v)", ref)
+		}
+
+		if module, err := cImp.getModule(ref.mh); err != nil {
+			return errors.Errorf("invalid reference, module loading error: %v", err)
+		} else if module == nil {
+			return errors.Errorf("invalid reference, unknown module (%v)", ref)
+		} else {
+			chart.Config.Reports[ref.ri].Joins[ref.ji].ModuleID = module.ID
+		}
+	}
+
 	return nil
 }
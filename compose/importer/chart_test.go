@@ -21,6 +21,10 @@ func TestChartImport_CastSet(t *testing.T) {
 		"chart_with_unknown_module",
 		errors.New(`unknown module "un_kno_wn" referenced from chart "chart1" report config`))
 
+	impFixTester(t,
+		"chart_with_unknown_join_module",
+		errors.New(`unknown module "un_kno_wn" joined in chart "chart1" report config`))
+
 	// Pre fill with module that imported chart is referring to
 	imp.namespaces.Setup(ns)
 	imp.GetModuleImporter(ns.Slug).set = types.ModuleSet{{NamespaceID: ns.ID, Handle: "foo"}}
@@ -39,6 +43,9 @@ func TestChartImport_CastSet(t *testing.T) {
 			{
 				Filter:   "a=b",
 				ModuleID: 0,
+				Joins: []*types.ChartConfigReportJoin{
+					{Alias: "parent", Field: "parent"},
+				},
 				Metrics: []map[string]interface{}{
 					{
 						"backgroundColor": "#e5a83b",
@@ -21,6 +21,7 @@ func Import(ctx context.Context, ns *types.Namespace, ff ...io.Reader) (err erro
 			service.DefaultNamespace.With(ctx),
 			service.DefaultModule.With(ctx),
 			service.DefaultChart.With(ctx),
+			service.DefaultRecordView.With(ctx),
 			service.DefaultPage.With(ctx),
 
 			permissions.NewImporter(service.DefaultAccessControl.Whitelist()),
@@ -72,6 +73,7 @@ func Import(ctx context.Context, ns *types.Namespace, ff ...io.Reader) (err erro
 		service.DefaultNamespace.With(ctx),
 		service.DefaultModule.With(ctx),
 		service.DefaultChart.With(ctx),
+		service.DefaultRecordView.With(ctx),
 		service.DefaultPage.With(ctx),
 		recordService,
 		service.DefaultAccessControl,
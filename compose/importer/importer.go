@@ -17,10 +17,11 @@ type (
 	Importer struct {
 		namespaces *Namespace
 
-		namespaceFinder namespaceFinder
-		moduleFinder    moduleFinder
-		chartFinder     chartFinder
-		pageFinder      pageFinder
+		namespaceFinder  namespaceFinder
+		moduleFinder     moduleFinder
+		chartFinder      chartFinder
+		recordViewFinder recordViewFinder
+		pageFinder       pageFinder
 
 		permissions importer.PermissionImporter
 		settings    importer.SettingImporter
@@ -36,6 +37,11 @@ type (
 		Create(*types.Chart) (*types.Chart, error)
 	}
 
+	recordViewKeeper interface {
+		Update(*types.RecordView) (*types.RecordView, error)
+		Create(*types.RecordView) (*types.RecordView, error)
+	}
+
 	pageKeeper interface {
 		Update(*types.Page) (*types.Page, error)
 		Create(*types.Page) (*types.Page, error)
@@ -47,12 +53,13 @@ type (
 	}
 )
 
-func NewImporter(nsf namespaceFinder, mf moduleFinder, cf chartFinder, pf pageFinder, p importer.PermissionImporter, s importer.SettingImporter) *Importer {
+func NewImporter(nsf namespaceFinder, mf moduleFinder, cf chartFinder, vf recordViewFinder, pf pageFinder, p importer.PermissionImporter, s importer.SettingImporter) *Importer {
 	imp := &Importer{
-		namespaceFinder: nsf,
-		moduleFinder:    mf,
-		chartFinder:     cf,
-		pageFinder:      pf,
+		namespaceFinder:  nsf,
+		moduleFinder:     mf,
+		chartFinder:      cf,
+		recordViewFinder: vf,
+		pageFinder:       pf,
 
 		permissions: p,
 		settings:    s,
@@ -84,6 +91,10 @@ func (imp *Importer) GetChartImporter(handle string) *Chart {
 	return imp.namespaces.charts[handle]
 }
 
+func (imp *Importer) GetRecordViewImporter(handle string) *RecordView {
+	return imp.namespaces.recordViews[handle]
+}
+
 func (imp *Importer) Cast(def interface{}) (err error) {
 	var nsHandle string
 	// Solving a special case where namespace is defined as string
@@ -133,6 +144,7 @@ func (imp *Importer) Store(
 	nsStore namespaceKeeper,
 	mStore moduleKeeper,
 	cStore chartKeeper,
+	vStore recordViewKeeper,
 	pStore pageKeeper,
 	rStore recordKeeper,
 	pk permissions.ImportKeeper,
@@ -140,7 +152,7 @@ func (imp *Importer) Store(
 	roles sysTypes.RoleSet,
 ) (err error) {
 	if imp.namespaces != nil {
-		err = imp.namespaces.Store(ctx, nsStore, mStore, cStore, pStore, rStore)
+		err = imp.namespaces.Store(ctx, nsStore, mStore, cStore, vStore, pStore, rStore)
 		if err != nil {
 			return errors.Wrap(err, "could not import namespaces")
 		}
@@ -39,7 +39,7 @@ func resetMocks() {
 	pi = permissions.NewImporter(service.AccessControl(nil).Whitelist())
 	st = settings.NewImporter()
 
-	imp = NewImporter(nil, nil, nil, nil, pi, st)
+	imp = NewImporter(nil, nil, nil, nil, nil, pi, st)
 
 	// namespaces does not get initialized in the standard flow
 	// if namespace finder is not present
@@ -78,6 +78,8 @@ func impFixTester(t *testing.T, name string, tester interface{}) {
 			tester(t, imp.GetModuleImporter(ns.Slug))
 		case func(*testing.T, *Chart):
 			tester(t, imp.GetChartImporter(ns.Slug))
+		case func(*testing.T, *RecordView):
+			tester(t, imp.GetRecordViewImporter(ns.Slug))
 		case func(*testing.T, *Page):
 			tester(t, imp.GetPageImporter(ns.Slug))
 		case func(*testing.T, *Record):
@@ -132,6 +132,17 @@ func (mImp *Module) Cast(handle string, def interface{}) (err error) {
 			// from breaking up
 			sort.Sort(module.Fields)
 
+		case "validators":
+			module.Validators = types.ModuleValidatorSet{}
+			return deinterfacer.Each(val, func(_ int, _ string, val interface{}) (err error) {
+				mv := &types.ModuleValidator{}
+				deinterfacer.KVsetString(&mv.Expression, "expression", val)
+				deinterfacer.KVsetString(&mv.Message, "message", val)
+				deinterfacer.KVsetString(&mv.Field, "field", val)
+				module.Validators = append(module.Validators, mv)
+				return
+			})
+
 		// case "records":
 		// 	return c.resolveRecords(val)
 
@@ -23,6 +23,9 @@ type (
 		// charts per namespace
 		charts map[string]*Chart
 
+		// record views per namespace
+		recordViews map[string]*RecordView
+
 		// pages per namespace
 		pages map[string]*Page
 
@@ -48,10 +51,11 @@ func NewNamespaceImporter(imp *Importer) *Namespace {
 		set:   types.NamespaceSet{},
 		dirty: make(map[uint64]bool),
 
-		modules: map[string]*Module{},
-		charts:  map[string]*Chart{},
-		pages:   map[string]*Page{},
-		records: map[string]*Record{},
+		modules:     map[string]*Module{},
+		charts:      map[string]*Chart{},
+		recordViews: map[string]*RecordView{},
+		pages:       map[string]*Page{},
+		records:     map[string]*Record{},
 	}
 
 	if imp.namespaceFinder != nil {
@@ -130,6 +134,9 @@ func (nsImp *Namespace) Cast(handle string, def interface{}) (err error) {
 		case "charts":
 			return nsImp.castCharts(handle, val)
 
+		case "recordViews":
+			return nsImp.castRecordViews(handle, val)
+
 		case "pages":
 			return nsImp.castPages(handle, val)
 
@@ -184,6 +191,15 @@ func (nsImp *Namespace) castCharts(handle string, def interface{}) error {
 	return nsImp.charts[handle].CastSet(def)
 }
 
+func (nsImp *Namespace) castRecordViews(handle string, def interface{}) error {
+	if nsImp.recordViews[handle] == nil {
+		return fmt.Errorf("unknown namespace %q", handle)
+
+	}
+
+	return nsImp.recordViews[handle].CastSet(def)
+}
+
 func (nsImp *Namespace) castPages(handle string, def interface{}) error {
 	if nsImp.pages[handle] == nil {
 		return fmt.Errorf("unknown namespace %q", handle)
@@ -222,11 +238,12 @@ func (nsImp *Namespace) Setup(namespace *types.Namespace) {
 		nsImp.modules[namespace.Slug] = NewModuleImporter(nsImp.imp, namespace)
 		nsImp.pages[namespace.Slug] = NewPageImporter(nsImp.imp, namespace)
 		nsImp.charts[namespace.Slug] = NewChartImporter(nsImp.imp, namespace)
+		nsImp.recordViews[namespace.Slug] = NewRecordViewImporter(nsImp.imp, namespace)
 		nsImp.records[namespace.Slug] = NewRecordImporter(nsImp.imp, namespace)
 	}
 }
 
-func (nsImp *Namespace) Store(ctx context.Context, nsk namespaceKeeper, mk moduleKeeper, ck chartKeeper, pk pageKeeper, rk recordKeeper) error {
+func (nsImp *Namespace) Store(ctx context.Context, nsk namespaceKeeper, mk moduleKeeper, ck chartKeeper, vk recordViewKeeper, pk pageKeeper, rk recordKeeper) error {
 	return nsImp.set.Walk(func(namespace *types.Namespace) (err error) {
 		var handle = namespace.Slug
 
@@ -254,6 +271,11 @@ func (nsImp *Namespace) Store(ctx context.Context, nsk namespaceKeeper, mk modul
 				return errors.Wrap(err, "could not import charts")
 			}
 
+			nsImp.recordViews[handle].namespace = namespace
+			if err = nsImp.recordViews[handle].Store(ctx, vk); err != nil {
+				return errors.Wrap(err, "could not import record views")
+			}
+
 			nsImp.pages[handle].namespace = namespace
 			if err = nsImp.pages[handle].Store(ctx, pk); err != nil {
 				return errors.Wrap(err, "could not import pages")
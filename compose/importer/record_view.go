@@ -0,0 +1,200 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/deinterfacer"
+	"github.com/cortezaproject/corteza-server/pkg/importer"
+)
+
+type (
+	RecordView struct {
+		imp       *Importer
+		namespace *types.Namespace
+		set       types.RecordViewSet
+		dirty     map[uint64]bool
+
+		// record view handle => module handle
+		modRefs map[string]string
+	}
+
+	// @todo remove finder strategy, directly provide set of items
+	recordViewFinder interface {
+		Find(filter types.RecordViewFilter) (set types.RecordViewSet, f types.RecordViewFilter, err error)
+	}
+)
+
+func NewRecordViewImporter(imp *Importer, ns *types.Namespace) *RecordView {
+	out := &RecordView{
+		imp:       imp,
+		namespace: ns,
+		set:       types.RecordViewSet{},
+		dirty:     make(map[uint64]bool),
+		modRefs:   make(map[string]string),
+	}
+
+	if imp.recordViewFinder != nil && ns.ID > 0 {
+		out.set, _, _ = imp.recordViewFinder.Find(types.RecordViewFilter{NamespaceID: ns.ID})
+	}
+
+	return out
+}
+
+func (vImp *RecordView) getModule(handle string) (*types.Module, error) {
+	if g, ok := vImp.imp.namespaces.modules[vImp.namespace.Slug]; !ok {
+		return nil, errors.Errorf("could not get modules %q from non existing namespace %q", handle, vImp.namespace.Slug)
+	} else {
+		return g.Get(handle)
+	}
+}
+
+// CastSet resolves record views:
+// { <view-handle>: { view } } or [ { view }, ... ]
+func (vImp *RecordView) CastSet(set interface{}) error {
+	return deinterfacer.Each(set, func(index int, handle string, def interface{}) error {
+		if index > -1 {
+			// Record views defined as collection
+			deinterfacer.KVsetString(&handle, "handle", def)
+		}
+
+		return vImp.Cast(handle, def)
+	})
+}
+
+// Cast resolves record view:
+// { <view-handle>: { view } } or [ { view }, ... ]
+func (vImp *RecordView) Cast(handle string, def interface{}) (err error) {
+	if !deinterfacer.IsMap(def) {
+		return errors.New("expecting map of values for record view")
+	}
+
+	var view *types.RecordView
+
+	if !importer.IsValidHandle(handle) {
+		return errors.New("invalid record view handle")
+	}
+
+	handle = importer.NormalizeHandle(handle)
+	if view, err = vImp.Get(handle); err != nil {
+		return err
+	} else if view == nil {
+		view = &types.RecordView{
+			Handle: handle,
+			Name:   handle,
+		}
+
+		vImp.set = append(vImp.set, view)
+	} else if view.ID == 0 {
+		return errors.Errorf("record view handle %q already defined in this import session", view.Handle)
+	} else {
+		vImp.dirty[view.ID] = true
+	}
+
+	err = deinterfacer.Each(def, func(_ int, key string, val interface{}) (err error) {
+		switch key {
+		case "handle":
+			// handle value sanity check
+			if deinterfacer.ToString(val, handle) != handle {
+				return fmt.Errorf("explicitly set handle on record view %q shadows inherited handle", handle)
+			}
+
+		case "name", "title", "label":
+			view.Name = deinterfacer.ToString(val)
+
+		case "module":
+			module := deinterfacer.ToString(val)
+			if m, err := vImp.getModule(module); err != nil || m == nil {
+				return fmt.Errorf("unknown module %q referenced from record view %q", module, handle)
+			}
+
+			vImp.modRefs[handle] = module
+
+		case "filter", "query":
+			view.Filter = deinterfacer.ToString(val)
+
+		case "sort":
+			view.Sort = deinterfacer.ToString(val)
+
+		case "columns", "fields":
+			view.Columns = deinterfacer.ToStrings(val)
+
+		case "allow", "deny":
+			return vImp.imp.permissions.CastSet(types.RecordViewPermissionResource.String()+handle, key, val)
+
+		default:
+			return fmt.Errorf("unexpected key %q for record view %q", key, handle)
+		}
+
+		return
+	})
+
+	if err != nil {
+		return
+	}
+
+	if _, has := vImp.modRefs[handle]; !has && view.ModuleID == 0 {
+		return fmt.Errorf("module not set for record view %q", handle)
+	}
+
+	return nil
+}
+
+// Get existing record views
+func (vImp *RecordView) Get(handle string) (*types.RecordView, error) {
+	handle = importer.NormalizeHandle(handle)
+	if !importer.IsValidHandle(handle) {
+		return nil, errors.New("invalid record view handle")
+	}
+
+	return vImp.set.FindByHandle(handle), nil
+}
+
+func (vImp *RecordView) Store(ctx context.Context, k recordViewKeeper) (err error) {
+	if err = vImp.resolveRefs(); err != nil {
+		return
+	}
+
+	return vImp.set.Walk(func(view *types.RecordView) (err error) {
+		var handle = view.Handle
+
+		if view.ID == 0 {
+			view.NamespaceID = vImp.namespace.ID
+			view, err = k.Create(view)
+		} else if vImp.dirty[view.ID] {
+			view, err = k.Update(view)
+		}
+
+		if err != nil {
+			return
+		}
+
+		vImp.dirty[view.ID] = false
+		vImp.imp.permissions.UpdateResources(types.RecordViewPermissionResource.String(), handle, view.ID)
+
+		return
+	})
+}
+
+// Resolve module references of the record views
+func (vImp *RecordView) resolveRefs() error {
+	for vh, mh := range vImp.modRefs {
+		view := vImp.set.FindByHandle(vh)
+		if view == nil {
+			return errors.Errorf("invalid reference, unknown record view (%s)", vh)
+		}
+
+		if module, err := vImp.getModule(mh); err != nil {
+			return errors.Errorf("invalid reference, module loading error: %v", err)
+		} else if module == nil {
+			return errors.Errorf("invalid reference, unknown module (%s)", mh)
+		} else {
+			view.ModuleID = module.ID
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package importer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortezaproject/corteza-server/compose/types"
+)
+
+func TestRecordViewImport_CastSet(t *testing.T) {
+	impFixTester(t,
+		"record_view_with_unknown_module",
+		errors.New(`unknown module "un_kno_wn" referenced from record view "view1"`))
+
+	impFixTester(t,
+		"record_view_without_module",
+		errors.New(`module not set for record view "view1"`))
+
+	// Pre fill with module that imported record views are referring to
+	imp.namespaces.Setup(ns)
+	imp.GetModuleImporter(ns.Slug).set = types.ModuleSet{{ID: 42, NamespaceID: ns.ID, Handle: "foo"}}
+
+	impFixTester(t, "record_view_full", func(t *testing.T, view *RecordView) {
+		req := require.New(t)
+
+		req.Len(view.set, 2)
+
+		v := view.set.FindByHandle("open_leads")
+		req.NotNil(v)
+		req.Equal("Open leads", v.Name)
+		req.Equal("status = 'open'", v.Filter)
+		req.Equal("createdAt DESC", v.Sort)
+		req.Equal(types.RecordViewColumns{"name", "status", "createdAt"}, v.Columns)
+
+		req.NoError(view.resolveRefs())
+		req.Equal(uint64(42), v.ModuleID)
+		req.Equal(uint64(42), view.set.FindByHandle("all_leads").ModuleID)
+	})
+}
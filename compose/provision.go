@@ -62,7 +62,7 @@ func partialImportSettings(ctx context.Context, ss settings.Service, ff ...io.Re
 
 		// importer w/o permissions & roles
 		// we need only settings
-		imp = importer.NewImporter(nil, nil, nil, nil, nil, si)
+		imp = importer.NewImporter(nil, nil, nil, nil, nil, nil, si)
 
 		// current value
 		current settings.ValueSet
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/Masterminds/squirrel"
@@ -9,6 +10,7 @@ import (
 	"github.com/titpetric/factory"
 
 	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/db/dialect"
 	"github.com/cortezaproject/corteza-server/pkg/rh"
 )
 
@@ -17,9 +19,18 @@ type (
 		With(ctx context.Context, db *factory.DB) AttachmentRepository
 
 		Find(filter types.AttachmentFilter) (types.AttachmentSet, types.AttachmentFilter, error)
+		Search(filter types.AttachmentFilter) (types.AttachmentSet, types.AttachmentFilter, error)
 		FindByID(namespaceID, attachmentID uint64) (*types.Attachment, error)
 		Create(mod *types.Attachment) (*types.Attachment, error)
+		UpdatePreview(mod *types.Attachment) error
 		DeleteByID(namespaceID, attachmentID uint64) error
+
+		IndexContent(attachmentID uint64, content string) error
+
+		Usage(namespaceID, ownerID uint64) (int64, error)
+		LockUsage(keys ...string) error
+		FindOrphaned(before time.Time) (types.AttachmentSet, error)
+		Purge(attachmentID uint64) error
 	}
 
 	attachment struct {
@@ -45,11 +56,20 @@ func (r attachment) table() string {
 	return "compose_attachment"
 }
 
+func (r attachment) tableUsageLock() string {
+	return "compose_attachment_usage_lock"
+}
+
+func (r attachment) contentTable() string {
+	return "compose_attachment_content"
+}
+
 func (r attachment) columns() []string {
 	return []string{
 		"a.id",
 		"a.rel_namespace",
 		"a.rel_owner",
+		"a.rel_page",
 		"a.kind",
 		"a.url",
 		"a.preview_url",
@@ -156,6 +176,100 @@ func (r attachment) Find(filter types.AttachmentFilter) (set types.AttachmentSet
 	return set, f, rh.FetchPaged(r.db(), query, f.PageFilter, &set)
 }
 
+// Search finds attachments with the indexed content matching the free-text query
+//
+// Results are ordered by relevance
+func (r attachment) Search(filter types.AttachmentFilter) (set types.AttachmentSet, f types.AttachmentFilter, err error) {
+	f = filter
+
+	var (
+		d = dialect.Current()
+
+		match, matchArgs = d.FullTextMatch(r.contentTable(), "ac", "content", f.Query)
+		rank, rankArgs   = d.FullTextRank(r.contentTable(), "ac", "content", f.Query)
+
+		query = r.query().
+			Join(r.contentTable()+" AS ac ON (ac.rel_attachment = a.id)").
+			Where(match, matchArgs...)
+	)
+
+	if join, joinArgs := d.FullTextJoin(r.contentTable(), "ac", "content", f.Query); join != "" {
+		query = query.Join(join, joinArgs...)
+	}
+
+	if f.NamespaceID > 0 {
+		query = query.Where("a.rel_namespace = ?", f.NamespaceID)
+	}
+
+	if f.Kind != "" {
+		query = query.Where(squirrel.Eq{"a.kind": f.Kind})
+	}
+
+	if f.IsReadable != nil {
+		query = query.Where(f.IsReadable)
+	}
+
+	if f.IsPageReadable != nil {
+		// Page attachments must belong to a readable page
+		readable := squirrel.
+			Select("1").
+			From("compose_page AS p").
+			Where("p.id = a.rel_page").
+			Where("p.deleted_at IS NULL").
+			Where(f.IsPageReadable)
+
+		sql, args, err := readable.ToSql()
+		if err != nil {
+			return set, f, err
+		}
+
+		query = query.Where(squirrel.Or{
+			squirrel.NotEq{"a.kind": types.PageAttachment},
+			squirrel.Expr("EXISTS ("+sql+")", args...),
+		})
+	}
+
+	if f.IsRecordReadable != nil {
+		// Record attachments must be referenced by at least one readable record
+		readable := squirrel.
+			Select("1").
+			From("compose_record_value AS v").
+			Join("compose_record AS r ON (r.id = v.record_id)").
+			Where("v.ref = a.id").
+			Where("v.deleted_at IS NULL").
+			Where("r.deleted_at IS NULL").
+			Where(f.IsRecordReadable)
+
+		if f.IsRecordValueReadable != nil {
+			// ...through the field with readable values
+			readable = readable.
+				Join("compose_module_field AS f ON (f.rel_module = r.module_id AND f.name = v.name)").
+				Where("f.deleted_at IS NULL").
+				Where(f.IsRecordValueReadable)
+		}
+
+		sql, args, err := readable.ToSql()
+		if err != nil {
+			return set, f, err
+		}
+
+		query = query.Where(squirrel.Or{
+			squirrel.NotEq{"a.kind": types.RecordAttachment},
+			squirrel.Expr("EXISTS ("+sql+")", args...),
+		})
+	}
+
+	if f.Count, err = rh.Count(r.db(), query); err != nil || f.Count == 0 {
+		return
+	}
+
+	query = query.
+		OrderByClause(rank+" DESC", rankArgs...).
+		OrderBy("a.id DESC")
+
+	return set, f, rh.FetchPaged(r.db(), query, f.PageFilter, &set)
+}
+
 func (r attachment) Create(mod *types.Attachment) (*types.Attachment, error) {
 	if mod.ID == 0 {
 		mod.ID = factory.Sonyflake.NextID()
@@ -166,6 +280,18 @@ func (r attachment) Create(mod *types.Attachment) (*types.Attachment, error) {
 	return mod, r.db().Insert(r.table(), mod)
 }
 
+// UpdatePreview stores preview (url & meta) of the attachment
+func (r attachment) UpdatePreview(mod *types.Attachment) error {
+	now := time.Now()
+	mod.UpdatedAt = &now
+
+	return rh.UpdateColumns(r.db(), r.table(), rh.Set{
+		"preview_url": mod.PreviewUrl,
+		"meta":        mod.Meta,
+		"updated_at":  mod.UpdatedAt,
+	}, squirrel.Eq{"id": mod.ID})
+}
+
 func (r attachment) DeleteByID(namespaceID, attachmentID uint64) error {
 	_, err := r.db().Exec(
 		"UPDATE "+r.table()+" SET deleted_at = NOW() WHERE rel_namespace = ? AND id = ?",
@@ -175,3 +301,121 @@ func (r attachment) DeleteByID(namespaceID, attachmentID uint64) error {
 
 	return err
 }
+
+// IndexContent stores (or replaces) text content of the attachment
+func (r attachment) IndexContent(attachmentID uint64, content string) error {
+	_, err := r.db().Exec("DELETE FROM "+r.contentTable()+" WHERE rel_attachment = ?", attachmentID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db().Exec(
+		"INSERT INTO "+r.contentTable()+" (rel_attachment, content) VALUES (?, ?)",
+		attachmentID,
+		content,
+	)
+
+	return err
+}
+
+// LockUsage locks attachment usage of the given scopes until the end of the transaction
+//
+// Lock rows are created on the first use and updated to acquire the row lock
+// (on SQLite, the database write lock); concurrent uploads, also the ones handled
+// by other server instances, wait until the transaction holding the lock ends
+func (r attachment) LockUsage(keys ...string) error {
+	const update = "UPDATE %s SET locks = locks + 1 WHERE lock_key = ?"
+
+	for _, key := range keys {
+		res, err := r.db().Exec(fmt.Sprintf(update, r.tableUsageLock()), key)
+		if err != nil {
+			return err
+		}
+
+		if n, err := res.RowsAffected(); err != nil || n > 0 {
+			return err
+		}
+
+		if _, err = r.db().Exec(dialect.Current().InsertIgnore(r.tableUsageLock(), "lock_key"), key); err != nil {
+			return err
+		}
+
+		if _, err = r.db().Exec(fmt.Sprintf(update, r.tableUsageLock()), key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Usage returns number of bytes stored by (non-deleted) attachments
+// in the namespace and/or by the owner
+func (r attachment) Usage(namespaceID, ownerID uint64) (size int64, err error) {
+	query := squirrel.
+		Select("COALESCE(SUM(a.size), 0)").
+		From(r.table() + " AS a").
+		Where("a.deleted_at IS NULL")
+
+	if namespaceID > 0 {
+		query = query.Where("a.rel_namespace = ?", namespaceID)
+	}
+
+	if ownerID > 0 {
+		query = query.Where("a.rel_owner = ?", ownerID)
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	return size, r.db().Get(&size, sql, args...)
+}
+
+// FindOrphaned finds attachments that are no longer used since before the given time
+//
+// These are deleted attachments and record attachments without any
+// (non-deleted) record referencing them; record attachments are orphaned
+// since they were last unlinked from the record values (see record.unlinkRefs)
+func (r attachment) FindOrphaned(before time.Time) (set types.AttachmentSet, err error) {
+	// Record values that (still) reference the attachment
+	referenced := squirrel.
+		Select("1").
+		From("compose_record_value AS v").
+		Join("compose_record AS r ON (r.id = v.record_id)").
+		Where("v.ref = a.id").
+		Where(squirrel.Or{
+			squirrel.Expr("v.deleted_at IS NULL AND r.deleted_at IS NULL"),
+			squirrel.Gt{"v.deleted_at": before},
+			squirrel.Gt{"r.deleted_at": before},
+		})
+
+	sql, args, err := referenced.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	query := squirrel.
+		Select(r.columns()...).
+		From(r.table() + " AS a").
+		Where(squirrel.Or{
+			squirrel.Lt{"a.deleted_at": before},
+			squirrel.And{
+				squirrel.Eq{"a.kind": types.RecordAttachment},
+				squirrel.Expr("COALESCE(a.unlinked_at, a.created_at) < ?", before),
+				squirrel.Expr("NOT EXISTS ("+sql+")", args...),
+			},
+		}).
+		OrderBy("a.id")
+
+	return set, rh.FetchAll(r.db(), query, &set)
+}
+
+// Purge removes attachment and its indexed content from the database
+func (r attachment) Purge(attachmentID uint64) error {
+	if err := rh.Delete(r.db(), r.contentTable(), squirrel.Eq{"rel_attachment": attachmentID}); err != nil {
+		return err
+	}
+
+	return rh.Delete(r.db(), r.table(), squirrel.Eq{"id": attachmentID})
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/titpetric/factory"
 
 	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/db/dialect"
 	"github.com/cortezaproject/corteza-server/pkg/rh"
 )
 
@@ -23,6 +24,7 @@ type (
 		FindByHandle(namespaceID uint64, handle string) (*types.Module, error)
 		Find(filter types.ModuleFilter) (set types.ModuleSet, f types.ModuleFilter, err error)
 		FindFields(moduleIDs ...uint64) (ff types.ModuleFieldSet, err error)
+		FindFieldsByKind(namespaceID uint64, kind string) (ff types.ModuleFieldSet, err error)
 		Create(mod *types.Module) (*types.Module, error)
 		Update(mod *types.Module) (*types.Module, error)
 		UpdateFields(moduleID uint64, ff types.ModuleFieldSet, hasRecords bool) (err error)
@@ -65,6 +67,7 @@ func (r module) columns() []string {
 		"handle",
 		"name",
 		"json",
+		"validators",
 		"created_at",
 		"updated_at",
 		"deleted_at",
@@ -220,7 +223,7 @@ func (r module) UpdateFields(moduleID uint64, ff types.ModuleFieldSet, hasRecord
 			f.Place = idx
 			f.DeletedAt = nil
 
-			if err := r.db().Replace(r.tableFields(), f); err != nil {
+			if err := dialect.Replace(r.db(), r.tableFields(), f, "id"); err != nil {
 				return errors.Wrap(err, "Error updating module fields")
 			}
 
@@ -272,3 +275,21 @@ func (r module) FindFields(moduleIDs ...uint64) (ff types.ModuleFieldSet, err er
 		return ff, r.db().Select(&ff, sql, args...)
 	}
 }
+
+// FindFieldsByKind returns fields of a specific kind from all modules in the namespace
+func (r module) FindFieldsByKind(namespaceID uint64, kind string) (ff types.ModuleFieldSet, err error) {
+	query := `SELECT f.id, f.rel_module, f.place,
+                     f.kind, f.name, f.label, f.options,
+                     f.is_private, f.is_required, f.is_visible, f.is_multi, f.default_value,
+                     f.created_at, f.updated_at, f.deleted_at
+                FROM %s AS f INNER JOIN %s AS m ON (m.id = f.rel_module)
+               WHERE m.rel_namespace = ?
+                 AND f.kind = ?
+                 AND f.deleted_at IS NULL
+                 AND m.deleted_at IS NULL
+               ORDER BY f.rel_module, f.place`
+
+	query = fmt.Sprintf(query, r.tableFields(), r.table())
+
+	return ff, r.db().Select(&ff, query, namespaceID, kind)
+}
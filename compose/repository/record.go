@@ -2,15 +2,23 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/jmoiron/sqlx"
+	"github.com/lann/builder"
 	"github.com/pkg/errors"
 	"github.com/titpetric/factory"
 
 	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/db/dialect"
 	"github.com/cortezaproject/corteza-server/pkg/ql"
 	"github.com/cortezaproject/corteza-server/pkg/rh"
 )
@@ -20,29 +28,88 @@ type (
 		With(ctx context.Context, db *factory.DB) RecordRepository
 
 		FindByID(namespaceID, recordID uint64) (*types.Record, error)
+		FindByIDWithDeleted(namespaceID, recordID uint64) (*types.Record, error)
 
-		Report(module *types.Module, metrics, dimensions, filter string) (results interface{}, err error)
+		Report(module *types.Module, joins []*RecordReportJoin, metrics, dimensions, filter string) (results interface{}, err error)
 		Find(module *types.Module, filter types.RecordFilter) (set types.RecordSet, f types.RecordFilter, err error)
-		Export(module *types.Module, filter types.RecordFilter) (set types.RecordSet, err error)
+		Export(module *types.Module, filter types.RecordFilter) (set types.RecordSet, f types.RecordFilter, err error)
+		Search(namespaceID uint64, filter types.RecordFilter) (set types.RecordSet, f types.RecordFilter, err error)
 
 		Create(record *types.Record) (*types.Record, error)
 		Update(record *types.Record) (*types.Record, error)
 		Delete(record *types.Record) error
 
 		RefValueLookup(moduleID uint64, field string, ref uint64) (recordID uint64, err error)
+		RefValueRecords(moduleID uint64, field string, ref uint64) (recordIDs []uint64, err error)
+		ValueLookup(moduleID uint64, field string, value string) (recordID uint64, err error)
+		Rollup(field *types.ModuleField, recordIDs ...uint64) (values map[uint64]string, err error)
 		LoadValues(fieldNames []string, IDs []uint64) (rvs types.RecordValueSet, err error)
 		DeleteValues(record *types.Record) error
 		UpdateValues(recordID uint64, rvs types.RecordValueSet) (err error)
 		PartialUpdateValues(rvs ...*types.RecordValue) (err error)
+
+		WithRefResolver(RecordRefResolver) RecordRepository
+	}
+
+	// RecordRefResolver returns field of the module referenced by the record field
+	//
+	// Used for dot-path identifiers (account.industry) in record filters, sorting and reports.
+	// Returned error is returned as an error of the query
+	RecordRefResolver func(ref *types.ModuleField, name string) (*types.ModuleField, error)
+
+	// RecordReportJoin is a module joined into the report (see types.ChartConfigReportJoin)
+	//
+	// Modules are joined through the record field of the report's module
+	// or, when inverse, through the record field of the joined module
+	// (report's records are then repeated for each of the joined records)
+	RecordReportJoin struct {
+		Alias   string
+		Module  *types.Module
+		Field   string
+		Inverse bool
 	}
 
 	record struct {
 		*repository
+
+		refs RecordRefResolver
+	}
+
+	// recordValueJoiner joins values of the (referenced) record fields
+	// used in the query
+	recordValueJoiner struct {
+		module *types.Module
+		refs   RecordRefResolver
+		joined map[string]bool
+
+		// modules joined into the report, by alias
+		joins map[string]*RecordReportJoin
+
+		// adds join to the query
+		join func(join string, args ...interface{})
+	}
+
+	// recordSortKey is a resolved sort column
+	//
+	// Sort keys are used to build page cursor conditions
+	recordSortKey struct {
+		sql  string
+		args []interface{}
+		desc bool
+	}
+
+	// recordCursor holds sort key values of the last record on the page
+	//
+	// Sort expression is kept so we can detect when cursor is used with a different sort
+	recordCursor struct {
+		Sort   string        `json:"s"`
+		Values []interface{} `json:"v"`
 	}
 )
 
 const (
-	ErrRecordNotFound = repositoryError("RecordNotFound")
+	ErrRecordNotFound          = repositoryError("RecordNotFound")
+	ErrRecordInvalidPageCursor = repositoryError("RecordInvalidPageCursor")
 )
 
 func Record(ctx context.Context, db *factory.DB) RecordRepository {
@@ -52,9 +119,16 @@ func Record(ctx context.Context, db *factory.DB) RecordRepository {
 func (r record) With(ctx context.Context, db *factory.DB) RecordRepository {
 	return &record{
 		repository: r.repository.With(ctx, db),
+		refs:       r.refs,
 	}
 }
 
+// WithRefResolver returns repository that resolves dot-path identifiers with the given resolver
+func (r record) WithRefResolver(refs RecordRefResolver) RecordRepository {
+	r.refs = refs
+	return &r
+}
+
 func (r record) table() string {
 	return "compose_record"
 }
@@ -83,18 +157,18 @@ func (r record) query() squirrel.SelectBuilder {
 // @todo: update to accepted DeletedAt column semantics from Messaging
 
 func (r record) FindByID(namespaceID, recordID uint64) (*types.Record, error) {
-	return r.findOneBy(namespaceID, "id", recordID)
+	return r.findOneBy(r.query().Where("r.deleted_at IS NULL"), namespaceID, "id", recordID)
 }
 
-func (r record) findOneBy(namespaceID uint64, field string, value interface{}) (*types.Record, error) {
+// FindByIDWithDeleted returns record even when it is deleted
+func (r record) FindByIDWithDeleted(namespaceID, recordID uint64) (*types.Record, error) {
+	return r.findOneBy(r.query(), namespaceID, "id", recordID)
+}
+
+func (r record) findOneBy(q squirrel.SelectBuilder, namespaceID uint64, field string, value interface{}) (*types.Record, error) {
 	var (
 		rec = &types.Record{}
-
-		q = r.query().
-			Where("r.deleted_at IS NULL").
-			Where(squirrel.Eq{field: value, "rel_namespace": namespaceID})
-
-		err = rh.FetchOne(r.db(), q, rec)
+		err = rh.FetchOne(r.db(), q.Where(squirrel.Eq{field: value, "rel_namespace": namespaceID}), rec)
 	)
 
 	if err != nil {
@@ -106,8 +180,10 @@ func (r record) findOneBy(namespaceID uint64, field string, value interface{}) (
 	return rec, nil
 }
 
-func (r record) Report(module *types.Module, metrics, dimensions, filter string) (results interface{}, err error) {
+func (r record) Report(module *types.Module, joins []*RecordReportJoin, metrics, dimensions, filter string) (results interface{}, err error) {
 	crb := NewRecordReportBuilder(module)
+	crb.refs = r.refs
+	crb.joins = joins
 
 	var result = make([]map[string]interface{}, 0)
 
@@ -125,15 +201,26 @@ func (r record) Report(module *types.Module, metrics, dimensions, filter string)
 			return nil, err
 		}
 
+		if err = crb.CheckPercentiles(result); err != nil {
+			return nil, err
+		}
+
+		crb.Accumulate(result)
+
 		return result, nil
 	}
 }
 
 func (r record) Find(module *types.Module, filter types.RecordFilter) (set types.RecordSet, f types.RecordFilter, err error) {
-	var query squirrel.SelectBuilder
+	var (
+		query squirrel.SelectBuilder
+		keys  []recordSortKey
+	)
+
 	f = filter
+	f.NextPage = ""
 
-	query, err = r.buildQuery(module, filter)
+	query, keys, err = r.buildSortedQuery(module, filter)
 	if err != nil {
 		return
 	}
@@ -142,37 +229,220 @@ func (r record) Find(module *types.Module, filter types.RecordFilter) (set types
 		return
 	}
 
-	return set, f, rh.FetchPaged(r.db(), query, f.PageFilter, &set)
+	set, f.NextPage, err = r.fetchPage(query, keys, f)
+	return
 }
 
-// Export ignores paging and does not return filter
+// Export does not count records
+//
+// Records are fetched in pages (when limit is set) and
+// page cursor for the next page is returned with the filter
 //
 // @todo optimize and include value loading
-func (r record) Export(module *types.Module, filter types.RecordFilter) (set types.RecordSet, err error) {
-	filter.PageFilter = rh.PageFilter{}
+func (r record) Export(module *types.Module, filter types.RecordFilter) (set types.RecordSet, f types.RecordFilter, err error) {
+	var (
+		query squirrel.SelectBuilder
+		keys  []recordSortKey
+	)
+
+	f = filter
+	f.NextPage = ""
 
-	query, err := r.buildQuery(module, filter)
+	query, keys, err = r.buildSortedQuery(module, filter)
 	if err != nil {
 		return
 	}
 
-	return set, rh.FetchAll(r.db(), query, &set)
+	set, f.NextPage, err = r.fetchPage(query, keys, f)
+	return
 }
 
-func (r record) buildQuery(module *types.Module, f types.RecordFilter) (query squirrel.SelectBuilder, err error) {
+// Search finds records of all modules in the namespace by free-text search (filter.Q)
+//
+// Records are sorted by relevance, query and sort of the filter are ignored
+func (r record) Search(namespaceID uint64, filter types.RecordFilter) (set types.RecordSet, f types.RecordFilter, err error) {
 	var (
-		joinedFields  = []string{}
-		alreadyJoined = func(f string) bool {
-			for _, a := range joinedFields {
-				if a == f {
-					return true
-				}
+		query squirrel.SelectBuilder
+		keys  []recordSortKey
+	)
+
+	f = filter
+	f.NextPage = ""
+	f.Sort = ""
+
+	query, keys, err = r.buildSearchQuery(namespaceID, f)
+	if err != nil {
+		return
+	}
+
+	if f.Count, err = rh.Count(r.db(), query); err != nil || f.Count == 0 {
+		return
+	}
+
+	set, f.NextPage, err = r.fetchPage(query, keys, f)
+	return
+}
+
+// fetchPage fetches one page of records
+//
+// When page cursor is set, it is used instead of offset. If there are
+// more records after the fetched page, cursor for the next page is returned
+func (r record) fetchPage(query squirrel.SelectBuilder, keys []recordSortKey, f types.RecordFilter) (set types.RecordSet, next string, err error) {
+	var (
+		limit  = f.Limit
+		offset = f.Offset
+	)
+
+	if limit+offset == 0 && f.PerPage > 0 {
+		limit = f.PerPage
+		if f.Page > 1 {
+			offset = (f.Page - 1) * f.PerPage
+		}
+	}
+
+	if f.PageCursor != "" {
+		if query, err = r.applyCursor(query, keys, f); err != nil {
+			return
+		}
+
+		offset = 0
+	}
+
+	if limit == 0 {
+		return set, "", rh.FetchPaged(r.db(), query, rh.Limit(0, offset), &set)
+	}
+
+	// Fetch one more record than requested so we know
+	// if there is a next page or not
+	if err = rh.FetchPaged(r.db(), query, rh.Limit(limit+1, offset), &set); err != nil {
+		return
+	}
+
+	if uint(len(set)) <= limit {
+		return
+	}
+
+	set = set[:limit]
+	next, err = r.makeCursor(query, keys, f.Sort, set[limit-1].ID)
+	return
+}
+
+// makeCursor loads values of all sort keys for the given record and encodes them into a page cursor
+func (r record) makeCursor(query squirrel.SelectBuilder, keys []recordSortKey, sort string, recordID uint64) (string, error) {
+	var (
+		cols = make([]string, len(keys))
+		args = make([]interface{}, 0)
+		cur  = recordCursor{Sort: sort}
+	)
+
+	for i, k := range keys {
+		cols[i] = k.sql
+		args = append(args, k.args...)
+	}
+
+	query = builder.Delete(query, "OrderByParts").(squirrel.SelectBuilder)
+	query = builder.Delete(query, "Columns").(squirrel.SelectBuilder).
+		Column(squirrel.Expr(strings.Join(cols, ", "), args...)).
+		Where("r.id = ?", recordID).
+		RemoveLimit()
+
+	if sql, args, err := query.ToSql(); err != nil {
+		return "", err
+	} else if cur.Values, err = r.db().QueryRowx(sql, args...).SliceScan(); err != nil {
+		return "", errors.Wrap(err, "could not load page cursor values")
+	}
+
+	for i, v := range cur.Values {
+		if b, ok := v.([]byte); ok {
+			cur.Values[i] = string(b)
+		}
+	}
+
+	if enc, err := json.Marshal(cur); err != nil {
+		return "", err
+	} else {
+		return base64.RawURLEncoding.EncodeToString(enc), nil
+	}
+}
+
+// applyCursor decodes page cursor and adds conditions that skip all records before and on the cursor
+//
+// Conditions follow sort direction of each key. NULL values are sorted first in ascending
+// and last in descending order on all dialects; see orderByKeys
+func (r record) applyCursor(query squirrel.SelectBuilder, keys []recordSortKey, f types.RecordFilter) (squirrel.SelectBuilder, error) {
+	var (
+		cur = recordCursor{}
+		or  = squirrel.Or{}
+		eq  = squirrel.And{}
+	)
+
+	if raw, err := base64.RawURLEncoding.DecodeString(f.PageCursor); err != nil {
+		return query, ErrRecordInvalidPageCursor
+	} else {
+		dec := json.NewDecoder(strings.NewReader(string(raw)))
+		dec.UseNumber()
+		if err = dec.Decode(&cur); err != nil {
+			return query, ErrRecordInvalidPageCursor
+		}
+	}
+
+	if cur.Sort != f.Sort || len(cur.Values) != len(keys) {
+		return query, ErrRecordInvalidPageCursor
+	}
+
+	for i, k := range keys {
+		var (
+			v     = cur.Values[i]
+			after squirrel.Sqlizer
+		)
+
+		switch {
+		case v == nil && !k.desc:
+			after = squirrel.Expr(k.sql+" IS NOT NULL", k.args...)
+		case v == nil && k.desc:
+			// nothing comes after NULL in descending order
+		case !k.desc:
+			after = squirrel.Expr(k.sql+" > ?", append(k.args, v)...)
+		default:
+			after = squirrel.Or{
+				squirrel.Expr(k.sql+" < ?", append(k.args, v)...),
+				squirrel.Expr(k.sql+" IS NULL", k.args...),
 			}
+		}
 
-			joinedFields = append(joinedFields, f)
-			return false
+		if after != nil {
+			or = append(or, append(append(squirrel.And{}, eq...), after))
 		}
 
+		if v == nil {
+			eq = append(eq, squirrel.Expr(k.sql+" IS NULL", k.args...))
+		} else {
+			eq = append(eq, squirrel.Expr(k.sql+" = ?", append(k.args, v)...))
+		}
+	}
+
+	if len(or) == 0 {
+		return query.Where("FALSE"), nil
+	}
+
+	return query.Where(or), nil
+}
+
+func (r record) buildQuery(module *types.Module, f types.RecordFilter) (query squirrel.SelectBuilder, err error) {
+	query, _, err = r.buildSortedQuery(module, f)
+	return
+}
+
+// buildSortedQuery builds record query and returns resolved sort keys
+//
+// Record ID is always used as the last sort key so that order of records
+// is stable and can be used for cursor paging
+func (r record) buildSortedQuery(module *types.Module, f types.RecordFilter) (query squirrel.SelectBuilder, keys []recordSortKey, err error) {
+	var (
+		joiner = r.valueJoiner(module, func(join string, args ...interface{}) {
+			query = query.LeftJoin(join, args...)
+		})
+
 		identResolver = func(i ql.Ident) (ql.Ident, error) {
 			var is bool
 			if i.Value, is = isRealRecordCol(i.Value); is {
@@ -180,30 +450,34 @@ func (r record) buildQuery(module *types.Module, f types.RecordFilter) (query sq
 				return i, nil
 			}
 
-			if !module.Fields.HasName(i.Value) {
-				return i, errors.Errorf("unknown field %q", i.Value)
+			alias, field, err := joiner.resolve(i.Value)
+			if err != nil {
+				return i, err
 			}
 
-			if !alreadyJoined(i.Value) {
-				query = query.LeftJoin(fmt.Sprintf(
-					"compose_record_value AS rv_%s ON (rv_%s.record_id = r.id AND rv_%s.name = ? AND rv_%s.deleted_at IS NULL)",
-					i.Value, i.Value, i.Value, i.Value,
-				), i.Value)
+			var precision = valuePrecision(field)
+
+			if field.IsFormula() {
+				// Computed values are stored and cast as kind of the formula result
+				field = &types.ModuleField{Kind: field.Options.ResultKind()}
 			}
 
-			field := module.Fields.FindByName(i.Value)
+			if field.IsRollup() {
+				// Aggregated values are always numeric
+				field = &types.ModuleField{Kind: "Number"}
+			}
 
 			switch true {
 			case field.IsBoolean():
-				i.Value = fmt.Sprintf("(rv_%s.value NOT IN ('', '0', 'false', 'f',  'FALSE', 'F', false))", i.Value)
+				i.Value = dialect.Current().Truthy(alias + ".value")
 			case field.IsNumeric():
-				i.Value = fmt.Sprintf("CAST(rv_%s.value AS SIGNED)", i.Value)
+				i.Value = dialect.Current().DecimalCast(alias+".value", precision)
 			case field.IsDateTime():
-				i.Value = fmt.Sprintf("CAST(rv_%s.value AS DATETIME)", i.Value)
+				i.Value = dialect.Current().DatetimeCast(alias + ".value")
 			case field.IsRef():
-				i.Value = fmt.Sprintf("rv_%s.ref ", i.Value)
+				i.Value = fmt.Sprintf("%s.ref ", alias)
 			default:
-				i.Value = fmt.Sprintf("rv_%s.value ", i.Value)
+				i.Value = fmt.Sprintf("%s.value ", alias)
 			}
 
 			return i, nil
@@ -235,7 +509,7 @@ func (r record) buildQuery(module *types.Module, f types.RecordFilter) (query sq
 		if fn, err = fp.ParseExpression(f.Query); err != nil {
 			return
 		} else if filterSql, filterArgs, err := fn.ToSql(); err != nil {
-			return query, err
+			return query, nil, err
 		} else {
 			query = query.Where("("+filterSql+")", filterArgs...)
 		}
@@ -258,12 +532,261 @@ func (r record) buildQuery(module *types.Module, f types.RecordFilter) (query sq
 			return
 		}
 
-		query = query.OrderBy(sc.Strings()...)
+		if keys, err = sortKeys(sc); err != nil {
+			return
+		}
+	}
+
+	if q := strings.TrimSpace(f.Q); q != "" {
+		var ranked bool
+		if query, ranked, err = r.fullText(query, q, f.QFields); err != nil {
+			return
+		}
+
+		if ranked && f.Sort == "" {
+			// Most relevant records first
+			keys = append(keys, recordSortKey{sql: "fts.relevance", desc: true})
+		}
+	}
+
+	if !hasRecordIDKey(keys) {
+		keys = append(keys, recordSortKey{sql: "r.id"})
+	}
+
+	query = orderByKeys(query, keys)
+	return
+}
+
+// buildSearchQuery builds query for free-text search across modules of the namespace
+func (r record) buildSearchQuery(namespaceID uint64, f types.RecordFilter) (query squirrel.SelectBuilder, keys []recordSortKey, err error) {
+	query = r.query().
+		Where("r.rel_namespace = ?", namespaceID)
+
+	query = rh.FilterNullByState(query, "r.deleted_at", f.Deleted)
+
+	var ranked bool
+	if query, ranked, err = r.fullText(query, strings.TrimSpace(f.Q), f.QFields); err != nil {
+		return
+	}
+
+	if ranked {
+		keys = append(keys, recordSortKey{sql: "fts.relevance", desc: true})
+	}
+
+	keys = append(keys, recordSortKey{sql: "r.id"})
+	query = orderByKeys(query, keys)
+	return
+}
+
+// fullText joins relevance (sum of ranks of all matching values) of records
+// with values that match free-text search terms
+//
+// Only values of the given fields (by module ID) are matched, records
+// without any matching value are excluded. When there is nothing to search in,
+// no relevance is joined and query does not match any records
+func (r record) fullText(query squirrel.SelectBuilder, terms string, fields map[uint64][]string) (squirrel.SelectBuilder, bool, error) {
+	var (
+		d = dialect.Current()
+
+		moduleIDs = make([]uint64, 0, len(fields))
+		cond      = squirrel.Or{}
+	)
+
+	for moduleID, names := range fields {
+		if len(names) > 0 {
+			moduleIDs = append(moduleIDs, moduleID)
+		}
+	}
+
+	if terms == "" || len(moduleIDs) == 0 {
+		return query.Where("FALSE"), false, nil
+	}
+
+	// Keep order of conditions (and arguments) stable
+	sort.Slice(moduleIDs, func(i, j int) bool { return moduleIDs[i] < moduleIDs[j] })
+	for _, moduleID := range moduleIDs {
+		cond = append(cond, squirrel.And{
+			squirrel.Eq{"fr.module_id": moduleID},
+			squirrel.Eq{"rv.name": fields[moduleID]},
+		})
+	}
+
+	var (
+		match, matchArgs = d.FullTextMatch("compose_record_value", "rv", "value", terms)
+		rank, rankArgs   = d.FullTextRank("compose_record_value", "rv", "value", terms)
+
+		sub = squirrel.
+			Select("rv.record_id").
+			Column(squirrel.Alias(squirrel.Expr("SUM("+rank+")", rankArgs...), "relevance")).
+			From("compose_record_value AS rv").
+			Join("compose_record AS fr ON (fr.id = rv.record_id)").
+			Where("rv.deleted_at IS NULL").
+			Where(cond).
+			Where(match, matchArgs...).
+			GroupBy("rv.record_id")
+	)
+
+	if join, joinArgs := d.FullTextJoin("compose_record_value", "rv", "value", terms); join != "" {
+		sub = sub.Join(join, joinArgs...)
+	}
+
+	if sql, args, err := sub.ToSql(); err != nil {
+		return query, false, err
+	} else {
+		return query.Join("("+sql+") AS fts ON (fts.record_id = r.id)", args...), true, nil
+	}
+}
+
+// orderByKeys adds ORDER BY clauses for all sort keys
+//
+// Placement of NULL values is set by the dialect so that it matches page cursor conditions
+func orderByKeys(query squirrel.SelectBuilder, keys []recordSortKey) squirrel.SelectBuilder {
+	for _, k := range keys {
+		query = query.OrderByClause(dialect.Current().OrderBy(k.sql, k.desc), k.args...)
+	}
+
+	return query
+}
+
+func (r record) valueJoiner(module *types.Module, join func(string, ...interface{})) *recordValueJoiner {
+	return &recordValueJoiner{
+		module: module,
+		refs:   r.refs,
+		joined: map[string]bool{},
+		join:   join,
+	}
+}
+
+// resolve joins values of all fields on the (dot-separated) path
+// and returns table alias and field of the last one
+//
+// Each field on the path, except the last one, must be a single-value record field;
+// fields of the referenced modules are resolved with the ref. resolver
+func (j *recordValueJoiner) resolve(ident string) (alias string, field *types.ModuleField, err error) {
+	var (
+		path = strings.Split(ident, ".")
+
+		// column holding ID of the record that values are joined on
+		on = "r.id"
+	)
+
+	if jn := j.joins[path[0]]; jn != nil && len(path) == 2 {
+		return j.resolveJoined(jn, path[1])
+	}
+
+	for i, name := range path {
+		if i == 0 {
+			field = j.module.Fields.FindByName(name)
+		} else if field.Kind != "Record" || field.Multi {
+			return "", nil, errors.Errorf("can not resolve %q, field %q is not a single-value record field", ident, path[i-1])
+		} else if j.refs == nil {
+			return "", nil, errors.Errorf("can not resolve %q, references are not supported", ident)
+		} else if field, err = j.refs(field, name); err != nil {
+			return "", nil, err
+		}
+
+		if field == nil {
+			return "", nil, errors.Errorf("unknown field %q", ident)
+		}
+
+		// Aliases of the referenced record values are
+		// prefixed with the path (rv_account__industry)
+		alias = "rv_" + strings.Join(path[:i+1], "__")
+
+		if !j.joined[alias] {
+			j.joined[alias] = true
+			j.join(fmt.Sprintf(
+				"compose_record_value AS %s ON (%s.record_id = %s AND %s.name = ? AND %s.deleted_at IS NULL)",
+				alias, alias, on, alias, alias,
+			), name)
+		}
+
+		on = alias + ".ref"
+	}
+
+	return
+}
+
+// resolveJoined joins values of the field of the module joined into the report
+//
+// Fields are resolved with the ref. resolver, the same way as
+// fields of the modules referenced through a record field
+func (j *recordValueJoiner) resolveJoined(jn *RecordReportJoin, name string) (alias string, field *types.ModuleField, err error) {
+	if j.refs == nil {
+		field = jn.Module.Fields.FindByName(name)
+	} else {
+		ref := &types.ModuleField{
+			Name:    jn.Alias,
+			Kind:    "Record",
+			Options: types.ModuleFieldOptions{"moduleID": strconv.FormatUint(jn.Module.ID, 10)},
+		}
+
+		if field, err = j.refs(ref, name); err != nil {
+			return "", nil, err
+		}
+	}
+
+	if field == nil {
+		return "", nil, errors.Errorf("unknown field %q", jn.Alias+"."+name)
+	}
+
+	alias = "rv_" + jn.Alias + "__" + name
+
+	if !j.joined[alias] {
+		j.joined[alias] = true
+		j.join(fmt.Sprintf(
+			"compose_record_value AS %s ON (%s.record_id = %s.id AND %s.name = ? AND %s.deleted_at IS NULL)",
+			alias, alias, reportJoinTable(jn), alias, alias,
+		), name)
+	}
+
+	return
+}
+
+// sortKeys converts parsed sort columns into sort keys
+func sortKeys(sc ql.Columns) (keys []recordSortKey, err error) {
+	keys = make([]recordSortKey, 0, len(sc))
+	for _, c := range sc {
+		var (
+			k    = recordSortKey{}
+			expr = ql.ASTNodes{}
+		)
+
+		for _, n := range c.Expr {
+			if kw, ok := n.(ql.Keyword); ok {
+				switch strings.ToUpper(kw.Keyword) {
+				case "DESC":
+					k.desc = true
+					continue
+				case "ASC":
+					continue
+				}
+			}
+
+			expr = append(expr, n)
+		}
+
+		if k.sql, k.args, err = expr.ToSql(); err != nil {
+			return
+		}
+
+		k.sql = strings.TrimSpace(k.sql)
+		keys = append(keys, k)
 	}
 
 	return
 }
 
+func hasRecordIDKey(keys []recordSortKey) bool {
+	for _, k := range keys {
+		if k.sql == "r.id" {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (r record) Create(record *types.Record) (*types.Record, error) {
 	record.ID = factory.Sonyflake.NextID()
 
@@ -304,6 +827,10 @@ func (r record) DeleteValues(record *types.Record) error {
 }
 
 func (r record) UpdateValues(recordID uint64, rvs types.RecordValueSet) (err error) {
+	if err = r.unlinkRefs(squirrel.Eq{"record_id": recordID}); err != nil {
+		return err
+	}
+
 	// Remove all records and prepare to be updated
 	// @todo be more selective and delete only removed and update/insert changed/new values
 	if _, err = r.db().Exec("DELETE FROM compose_record_value WHERE record_id = ?", recordID); err != nil {
@@ -325,12 +852,43 @@ func (r record) UpdateValues(recordID uint64, rvs types.RecordValueSet) (err err
 
 func (r record) PartialUpdateValues(rvs ...*types.RecordValue) (err error) {
 	err = types.RecordValueSet(rvs).Walk(func(value *types.RecordValue) error {
-		return r.db().Replace("compose_record_value", value)
+		err = r.unlinkRefs(squirrel.Eq{"record_id": value.RecordID, "name": value.Name, "place": value.Place})
+		if err != nil {
+			return err
+		}
+
+		return dialect.Replace(r.db(), "compose_record_value", value, "record_id", "name", "place")
 	})
 
 	return errors.Wrap(err, "could not replace record values")
 }
 
+// Marks attachments referenced by the values that are about to be removed or replaced as unlinked
+//
+// Values are removed without keeping the deletion time so orphaned attachments
+// would otherwise be aged from their creation (see attachment.FindOrphaned).
+// Attachments that remain referenced are marked as well; they are not orphaned
+// until they are unlinked again
+func (r record) unlinkRefs(cnd squirrel.Sqlizer) error {
+	refs, args, err := squirrel.
+		Select("ref").
+		From("compose_record_value").
+		Where(cnd).
+		Where("ref > 0").
+		ToSql()
+
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db().Exec(
+		"UPDATE compose_attachment SET unlinked_at = ? WHERE id IN ("+refs+")",
+		append([]interface{}{time.Now()}, args...)...,
+	)
+
+	return errors.Wrap(err, "could not unlink referenced attachments")
+}
+
 func (r record) RefValueLookup(moduleID uint64, field string, ref uint64) (recordID uint64, err error) {
 	var sql = "SELECT record_id" +
 		"  FROM compose_record AS r INNER JOIN compose_record_value AS v " +
@@ -344,6 +902,105 @@ func (r record) RefValueLookup(moduleID uint64, field string, ref uint64) (recor
 	return recordID, r.db().Get(&recordID, sql, moduleID, field, ref)
 }
 
+// RefValueRecords returns IDs of all records in the module referencing given record through a field
+func (r record) RefValueRecords(moduleID uint64, field string, ref uint64) (recordIDs []uint64, err error) {
+	var sql = "SELECT DISTINCT record_id" +
+		"  FROM compose_record AS r INNER JOIN compose_record_value AS v ON (v.record_id = r.id) " +
+		" WHERE r.module_id = ? " +
+		"   AND v.name = ? " +
+		"   AND v.ref = ? " +
+		"   AND r.deleted_at IS NULL " +
+		"   AND v.deleted_at IS NULL " +
+		" ORDER BY record_id"
+
+	return recordIDs, r.db().Select(&recordIDs, sql, moduleID, field, ref)
+}
+
+// ValueLookup returns ID of the first record in the module with the given field value
+//
+// Returns 0 when there is no such record
+func (r record) ValueLookup(moduleID uint64, field string, value string) (recordID uint64, err error) {
+	var q = "SELECT record_id" +
+		"  FROM compose_record AS r INNER JOIN compose_record_value AS v ON (v.record_id = r.id) " +
+		" WHERE r.module_id = ? " +
+		"   AND v.name = ? " +
+		"   AND v.value = ? " +
+		"   AND r.deleted_at IS NULL " +
+		"   AND v.deleted_at IS NULL " +
+		" ORDER BY record_id " +
+		"       LIMIT 1"
+
+	if err = r.db().Get(&recordID, q, moduleID, field, value); err == sql.ErrNoRows {
+		return 0, nil
+	}
+
+	return recordID, err
+}
+
+// Rollup aggregates values of the records referencing given records through a rollup field
+//
+// Returns aggregated value for each of the existing records; aggregates
+// over an empty set are returned as empty strings (0 for SUM and COUNT)
+func (r record) Rollup(field *types.ModuleField, recordIDs ...uint64) (values map[uint64]string, err error) {
+	var (
+		aggr string
+		rows []struct {
+			RecordID uint64         `db:"record_id"`
+			Value    sql.NullString `db:"value"`
+		}
+
+		q = "SELECT p.id AS record_id, %s AS value" +
+			"  FROM compose_record AS p " +
+			"       LEFT JOIN compose_record_value AS ref ON (ref.ref = p.id AND ref.name = ? AND ref.deleted_at IS NULL) " +
+			"       LEFT JOIN compose_record AS c ON (c.id = ref.record_id AND c.module_id = ? AND c.deleted_at IS NULL) " +
+			"       LEFT JOIN compose_record_value AS val ON (val.record_id = c.id AND val.name = ? AND val.deleted_at IS NULL) " +
+			" WHERE p.module_id = ? " +
+			"   AND p.id IN (?) " +
+			"   AND p.deleted_at IS NULL " +
+			" GROUP BY p.id"
+	)
+
+	switch a := field.Options.Aggregate(); a {
+	case "COUNT":
+		// Value join repeats child records with multi-value fields
+		aggr = "COUNT(DISTINCT c.id)"
+	case "SUM":
+		aggr = "COALESCE(SUM(" + dialect.Current().DecimalCast("val.value", types.ModuleFieldPrecisionMax) + "), 0)"
+	case "MIN", "MAX", "AVG":
+		aggr = a + "(" + dialect.Current().DecimalCast("val.value", types.ModuleFieldPrecisionMax) + ")"
+	default:
+		return nil, errors.Errorf("unsupported rollup aggregate %q", a)
+	}
+
+	values = make(map[uint64]string)
+	if len(recordIDs) == 0 {
+		return
+	}
+
+	query, args, err := sqlx.In(
+		fmt.Sprintf(q, aggr),
+		field.Options.RefField(),
+		field.Options.ModuleID(),
+		field.Options.ValueField(),
+		field.ModuleID,
+		recordIDs,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = r.db().Select(&rows, query, args...); err != nil {
+		return nil, errors.Wrap(err, "could not aggregate rollup values")
+	}
+
+	for _, row := range rows {
+		values[row.RecordID] = trimDecimal(row.Value.String)
+	}
+
+	return
+}
+
 func (r record) LoadValues(fieldNames []string, IDs []uint64) (rvs types.RecordValueSet, err error) {
 	if len(fieldNames) == 0 || len(IDs) == 0 {
 		return
@@ -363,6 +1020,32 @@ func (r record) LoadValues(fieldNames []string, IDs []uint64) (rvs types.RecordV
 	}
 }
 
+// valuePrecision returns number of decimal places of the stored numeric values
+//
+// Values of number fields are sanitized to the field precision (0 when not set);
+// computed (formula, rollup) values are kept with all decimals unless precision is set
+func valuePrecision(f *types.ModuleField) uint {
+	if f.IsFormula() || f.IsRollup() {
+		return f.Options.Precision(types.ModuleFieldPrecisionMax)
+	}
+
+	return f.Options.Precision(0)
+}
+
+// trimDecimal removes trailing zeros from the fractional part of the decimal string
+func trimDecimal(d string) string {
+	if !strings.Contains(d, ".") {
+		return d
+	}
+
+	d = strings.TrimRight(strings.TrimRight(d, "0"), ".")
+	if d == "-0" {
+		return "0"
+	}
+
+	return d
+}
+
 // Checks if field name is "real column", reformats it and returns
 func isRealRecordCol(name string) (string, bool) {
 	switch name {
@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+	"github.com/titpetric/factory"
+
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/rh"
+)
+
+type (
+	RecordImportSessionRepository interface {
+		With(ctx context.Context, db *factory.DB) RecordImportSessionRepository
+
+		FindByID(sessionID uint64) (*types.RecordImportSession, error)
+		FindStale(heartbeat time.Time) (types.RecordImportSessionSet, error)
+		FindExpired(updated time.Time) (types.RecordImportSessionSet, error)
+		Create(ses *types.RecordImportSession) (*types.RecordImportSession, error)
+		Update(ses *types.RecordImportSession) (*types.RecordImportSession, error)
+		UpdateProgress(ses *types.RecordImportSession) error
+		Heartbeat(sessionID uint64) error
+		Claim(sessionID uint64, heartbeat time.Time) (bool, error)
+		DeleteByID(sessionID uint64) error
+	}
+
+	recordImportSession struct {
+		*repository
+	}
+)
+
+const (
+	ErrRecordImportSessionNotFound = repositoryError("RecordImportSessionNotFound")
+)
+
+func RecordImportSession(ctx context.Context, db *factory.DB) RecordImportSessionRepository {
+	return (&recordImportSession{}).With(ctx, db)
+}
+
+func (r recordImportSession) With(ctx context.Context, db *factory.DB) RecordImportSessionRepository {
+	return &recordImportSession{
+		repository: r.repository.With(ctx, db),
+	}
+}
+
+func (r recordImportSession) table() string {
+	return "compose_record_import_session"
+}
+
+func (r recordImportSession) columns() []string {
+	return []string{
+		"ris.id",
+		"ris.rel_namespace",
+		"ris.rel_module",
+		"ris.rel_user",
+		"ris.source",
+		"ris.sheet",
+		"ris.header_row",
+		"ris.fields",
+		"ris.on_error",
+		"ris.mode",
+		"ris.match_field",
+		"ris.dry_run",
+		"ris.progress",
+		"ris.report",
+		"ris.created_at",
+		"ris.updated_at",
+		"ris.heartbeat_at",
+	}
+}
+
+func (r recordImportSession) query() squirrel.SelectBuilder {
+	return squirrel.
+		Select(r.columns()...).
+		From(r.table() + " AS ris")
+}
+
+func (r recordImportSession) FindByID(sessionID uint64) (*types.RecordImportSession, error) {
+	var (
+		ses = &types.RecordImportSession{}
+
+		q = r.query().
+			Where(squirrel.Eq{"ris.id": sessionID})
+
+		err = rh.FetchOne(r.db(), q, ses)
+	)
+
+	if err != nil {
+		return nil, err
+	} else if ses.SessionID == 0 {
+		return nil, ErrRecordImportSessionNotFound
+	}
+
+	return ses, nil
+}
+
+// FindStale returns sessions with running imports that did not report progress since the given time
+func (r recordImportSession) FindStale(heartbeat time.Time) (set types.RecordImportSessionSet, err error) {
+	return set, rh.FetchAll(r.db(), r.query().Where(squirrel.Lt{"ris.heartbeat_at": heartbeat}), &set)
+}
+
+// FindExpired returns sessions that were not updated since the given time
+func (r recordImportSession) FindExpired(updated time.Time) (set types.RecordImportSessionSet, err error) {
+	return set, rh.FetchAll(r.db(), r.query().Where(squirrel.Lt{"ris.updated_at": updated}), &set)
+}
+
+func (r recordImportSession) Create(ses *types.RecordImportSession) (*types.RecordImportSession, error) {
+	if ses.SessionID == 0 {
+		ses.SessionID = factory.Sonyflake.NextID()
+	}
+
+	ses.CreatedAt = time.Now()
+	ses.UpdatedAt = ses.CreatedAt
+
+	if err := r.db().Insert(r.table(), ses); err != nil {
+		return nil, errors.Wrap(err, "could not create record import session")
+	}
+
+	return ses, nil
+}
+
+func (r recordImportSession) Update(ses *types.RecordImportSession) (*types.RecordImportSession, error) {
+	ses.UpdatedAt = time.Now()
+
+	if err := r.db().Update(r.table(), ses, "id"); err != nil {
+		return nil, errors.Wrap(err, "could not update record import session")
+	}
+
+	return ses, nil
+}
+
+// UpdateProgress stores progress and heartbeat of the running import
+func (r recordImportSession) UpdateProgress(ses *types.RecordImportSession) error {
+	ses.UpdatedAt = time.Now()
+
+	return r.db().UpdatePartial(r.table(), ses, []string{"id", "progress", "updated_at", "heartbeat_at"}, "id")
+}
+
+// Heartbeat updates heartbeat of the running import
+//
+// Finished imports (without heartbeat) are not changed
+func (r recordImportSession) Heartbeat(sessionID uint64) error {
+	_, err := r.db().Exec(
+		"UPDATE "+r.table()+" SET heartbeat_at = ? WHERE id = ? AND heartbeat_at IS NOT NULL",
+		time.Now(),
+		sessionID,
+	)
+
+	return err
+}
+
+// Claim sets heartbeat of the stale session
+//
+// Returns false when session was already claimed (heartbeat was updated) in the meantime
+func (r recordImportSession) Claim(sessionID uint64, heartbeat time.Time) (bool, error) {
+	res, err := r.db().Exec(
+		"UPDATE "+r.table()+" SET heartbeat_at = ? WHERE id = ? AND heartbeat_at < ?",
+		time.Now(),
+		sessionID,
+		heartbeat,
+	)
+
+	if err != nil {
+		return false, err
+	}
+
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+func (r recordImportSession) DeleteByID(sessionID uint64) error {
+	_, err := r.db().Exec("DELETE FROM "+r.table()+" WHERE id = ?", sessionID)
+	return err
+}
@@ -2,6 +2,8 @@ package repository
 
 import (
 	"fmt"
+	"math/big"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -10,30 +12,117 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/db/dialect"
 	"github.com/cortezaproject/corteza-server/pkg/ql"
-	"github.com/cortezaproject/corteza-server/pkg/rh"
 )
 
 type (
 	recordReportBuilder struct {
 		module *types.Module
 
+		// Resolves fields of the referenced modules (dot-path identifiers)
+		refs RecordRefResolver
+
+		// Modules joined into the report
+		joins []*RecordReportJoin
+
+		// Rewritten identifiers of the report module's fields and columns;
+		// used to detect aggregates that are skewed by inverse joins
+		baseIdents map[string]bool
+
 		// This is set by metric/column building to assist Cast()
 		numerics []string
 
+		// Aliases of metrics that are accumulated over the first dimension
+		// and aliases of all dimensions (used to separate accumulated series)
+		cumulatives []string
+		dimensions  []string
+
+		// Set when any of the metrics is (or uses) percentile
+		percentiles bool
+
+		// Set when number of rows is selected separately from the count
+		// for the percentile check (see CheckPercentiles)
+		percentileRows bool
+
 		report squirrel.SelectBuilder
 		parser *ql.Parser
 	}
 )
 
+var (
+	// Aliases of the joined modules are used in table aliases
+	reportJoinAlias = regexp.MustCompile(`^[A-Za-z][0-9A-Za-z_]*$`)
+)
+
 // Identifiers should be names of the fields (physical table columns OR json fields, defined in module)
+//
+// Besides standard aggregate functions, these are supported:
+//   - COUNTD(x) shorthand for COUNT(DISTINCT x)
+//   - MEDIAN(x) shorthand for PERCENTILE(x, 0.5)
+//   - PERCENTILE(x, fraction) continuous percentile (interpolated between values)
+//   - CUMULATIVE(<aggregate>) running total of the aggregate over the first dimension
 func stdAggregationHandler(f ql.Function) (ql.Function, error) {
 	switch strings.ToUpper(f.Name) {
-	// case "COUNTD":
-	// 	return SqlConcatExpr("COUNT(DISTINCT ", aggrFuncArgs, ")")
-	//
-	case "COUNT", "SUM", "MAX", "MIN", "AVG", "STD":
+	case "COUNT", "SUM", "AVG":
+		return f, nil
+
+	case "MAX", "MIN", "STD":
+		if f.Distinct {
+			return f, fmt.Errorf("DISTINCT is not supported in aggregate function %q", f.Name)
+		}
+
 		return f, nil
+
+	case "COUNTD":
+		if len(f.Arguments) != 1 {
+			return f, fmt.Errorf("aggregate function %q expects one argument", f.Name)
+		}
+
+		return ql.Function{Name: "COUNT", Arguments: f.Arguments, Distinct: true}, nil
+
+	case "MEDIAN":
+		if len(f.Arguments) != 1 || f.Distinct {
+			return f, fmt.Errorf("aggregate function %q expects one argument", f.Name)
+		}
+
+		return stdAggregationHandler(ql.Function{
+			Name:      "PERCENTILE",
+			Arguments: ql.ASTSet{f.Arguments[0], ql.LNumber{Value: "0.5"}},
+		})
+
+	case "PERCENTILE":
+		if len(f.Arguments) != 2 || f.Distinct {
+			return f, fmt.Errorf("aggregate function %q expects value and fraction arguments", f.Name)
+		}
+
+		if n, ok := f.Arguments[1].(ql.LNumber); !ok {
+			return f, fmt.Errorf("fraction for aggregate function %q must be a number", f.Name)
+		} else if fr, err := strconv.ParseFloat(n.Value, 64); err != nil || fr < 0 || fr > 1 {
+			return f, fmt.Errorf("fraction for aggregate function %q must be between 0 and 1", f.Name)
+		}
+
+		// Value expression is repeated in some dialects (see dialect.Function)
+		// so it must not contain any placeholders
+		if _, args, err := f.Arguments[0].ToSql(); err != nil {
+			return f, err
+		} else if len(args) > 0 {
+			return f, fmt.Errorf("string literals are not supported in aggregate function %q", f.Name)
+		}
+
+		return f, nil
+
+	case "CUMULATIVE":
+		if len(f.Arguments) != 1 || f.Distinct {
+			return f, fmt.Errorf("function %q expects one aggregate function as argument", f.Name)
+		}
+
+		if _, ok := f.Arguments[0].(ql.Function); !ok {
+			return f, fmt.Errorf("function %q expects one aggregate function as argument", f.Name)
+		}
+
+		return f, nil
+
 	default:
 		return f, fmt.Errorf("unsupported aggregate function %q", f.Name)
 	}
@@ -41,6 +130,10 @@ func stdAggregationHandler(f ql.Function) (ql.Function, error) {
 
 // Identifiers should be names of the fields (physical table columns OR json fields, defined in module)
 func stdFilterFuncHandler(f ql.Function) (ql.Function, error) {
+	if f.Distinct {
+		return f, fmt.Errorf("DISTINCT is not supported in function %q", f.Name)
+	}
+
 	switch strings.ToUpper(f.Name) {
 	case "CONCAT", "QUARTER", "YEAR", "DATE", "NOW", "DATE_ADD", "DATE_SUB", "DATE_FORMAT":
 		return f, nil
@@ -53,56 +146,107 @@ func stdFilterFuncHandler(f ql.Function) (ql.Function, error) {
 func NewRecordReportBuilder(module *types.Module) *recordReportBuilder {
 	var report = squirrel.
 		Select().
-		Column(squirrel.Alias(squirrel.Expr("COUNT(*)"), "count")).
 		From("compose_record AS r").
 		Where("r.deleted_at IS NULL").
 		Where("r.module_id = ?", module.ID)
 
 	return &recordReportBuilder{
-		parser: ql.NewParser(),
-		module: module,
-		report: report,
+		parser:     ql.NewParser(),
+		module:     module,
+		report:     report,
+		baseIdents: map[string]bool{},
 	}
 }
 
 func (b *recordReportBuilder) Build(metrics, dimensions, filters string) (sql string, args []interface{}, err error) {
-	var joinedFields = []string{}
-	var alreadyJoined = func(f string) bool {
-		for _, a := range joinedFields {
-			if a == f {
-				return true
-			}
+	var joiner = &recordValueJoiner{
+		module: b.module,
+		refs:   b.refs,
+		joined: map[string]bool{},
+		joins:  map[string]*RecordReportJoin{},
+		join: func(join string, args ...interface{}) {
+			b.report = b.report.LeftJoin(join, args...)
+		},
+	}
+
+	var inverse bool
+
+	// Records of the joined modules are joined before any of their values
+	for _, jn := range b.joins {
+		if !reportJoinAlias.MatchString(jn.Alias) || joiner.joins[jn.Alias] != nil || b.module.Fields.FindByName(jn.Alias) != nil {
+			return "", nil, fmt.Errorf("invalid or duplicate report join alias %q", jn.Alias)
 		}
 
-		joinedFields = append(joinedFields, f)
-		return false
+		joiner.joins[jn.Alias] = jn
+
+		var sel, on = "ref", "record_id"
+		if jn.Inverse {
+			sel, on = on, sel
+			inverse = true
+		}
+
+		b.report = b.report.LeftJoin(fmt.Sprintf(
+			"compose_record AS %s ON (%s.module_id = ? AND %s.deleted_at IS NULL AND %s.id IN "+
+				"(SELECT %s FROM compose_record_value WHERE %s = r.id AND name = ? AND deleted_at IS NULL))",
+			reportJoinTable(jn), reportJoinTable(jn), reportJoinTable(jn), reportJoinTable(jn), sel, on,
+		), jn.Module.ID, jn.Field)
 	}
 
-	b.parser.OnIdent = func(i ql.Ident) (ql.Ident, error) {
+	if inverse {
+		// Inverse join repeats the record for each of the joined records;
+		// count each record only once
+		b.report = b.report.Column(squirrel.Alias(squirrel.Expr("COUNT(DISTINCT r.id)"), "count"))
+	} else {
+		b.report = b.report.Column(squirrel.Alias(squirrel.Expr("COUNT(*)"), "count"))
+	}
+
+	resolveIdent := func(i ql.Ident) (ql.Ident, error) {
 		var is bool
 		if i.Value, is = isRealRecordCol(i.Value); is {
 			return i, nil
 		}
 
-		if !b.module.Fields.HasName(i.Value) {
-			return i, errors.Errorf("unknown field %q", i.Value)
+		// Record columns of the joined modules (alias.createdAt)
+		if p := strings.SplitN(i.Value, ".", 2); len(p) == 2 && joiner.joins[p[0]] != nil {
+			if col, is := isRealRecordCol(p[1]); is {
+				i.Value = reportJoinTable(joiner.joins[p[0]]) + strings.TrimPrefix(col, "r")
+				return i, nil
+			}
 		}
 
-		if !alreadyJoined(i.Value) {
-			b.report = b.report.LeftJoin(fmt.Sprintf(
-				"compose_record_value AS rv_%s ON (rv_%s.record_id = r.id AND rv_%s.name = ? AND rv_%s.deleted_at IS NULL)",
-				i.Value, i.Value, i.Value, i.Value,
-			), i.Value)
+		alias, field, err := joiner.resolve(i.Value)
+		if err != nil {
+			return i, err
+		}
+
+		if field.IsNumeric() || field.IsRollup() || (field.IsFormula() && field.Options.ResultKind() == "Number") {
+			// Numbers are aggregated as exact decimals
+			i.Value = dialect.Current().DecimalCast(alias+".value", valuePrecision(field))
+			return i, nil
 		}
 
 		// @todo switch value for ref when doing Record/Owner lookup
-		i.Value = fmt.Sprintf("rv_%s.value", i.Value)
+		i.Value = fmt.Sprintf("%s.value", alias)
 
 		return i, nil
 	}
 
+	b.parser.OnIdent = func(i ql.Ident) (ql.Ident, error) {
+		var p = strings.SplitN(i.Value, ".", 2)
+		if i, err := resolveIdent(i); err != nil || (len(p) == 2 && joiner.joins[p[0]] != nil) {
+			return i, err
+		} else {
+			b.baseIdents[i.Value] = true
+			return i, nil
+		}
+	}
+
 	var columns ql.Columns
 	b.parser.OnFunction = stdAggregationHandler
+	if inverse {
+		b.parser.OnFunction = b.inverseJoinAggregationHandler
+	}
+
 	if columns, err = b.parser.ParseColumns(metrics); err != nil {
 		err = errors.Wrapf(err, "could not parse metrics %q", metrics)
 		return
@@ -115,13 +259,36 @@ func (b *recordReportBuilder) Build(metrics, dimensions, filters string) (sql st
 			m.Alias = fmt.Sprintf("metric_%d", i)
 		}
 
-		// Wrap to cast func to ensure numeric output
-		col := squirrel.Alias(rh.SquirrelConcatExpr("CAST(", m.Expr, " AS DECIMAL(14,2))"), m.Alias)
+		// Cumulative metric is calculated as a regular aggregate
+		// and accumulated when results are fetched
+		if f, is := cumulativeMetric(m.Expr); is {
+			m.Expr = ql.ASTNodes{f.Arguments[0]}
+			b.cumulatives = append(b.cumulatives, m.Alias)
+		}
+
+		if hasFunction(m.Expr, "CUMULATIVE") {
+			return "", nil, fmt.Errorf("function CUMULATIVE can only be used on the whole metric")
+		}
+
+		b.percentiles = b.percentiles || hasFunction(m.Expr, "PERCENTILE")
+
+		expr, args, err := m.Expr.ToSql()
+		if err != nil {
+			return "", nil, err
+		}
+
+		// Wrap to cast func to ensure exact numeric output
+		col := squirrel.Alias(squirrel.Expr(dialect.Current().NumericCast(expr, types.ModuleFieldPrecisionMax), args...), m.Alias)
 		b.report = b.report.Column(col)
 
 		b.numerics = append(b.numerics, m.Alias)
 	}
 
+	if inverse && b.percentiles && dialect.Is(dialect.MySQL) {
+		b.report = b.report.Column(squirrel.Alias(squirrel.Expr("COUNT(*)"), "percentile_rows"))
+		b.percentileRows = true
+	}
+
 	b.parser.OnFunction = stdFilterFuncHandler
 	if columns, err = b.parser.ParseColumns(dimensions); err != nil {
 		err = errors.Wrapf(err, "could not parse dimensions %q", dimensions)
@@ -134,12 +301,18 @@ func (b *recordReportBuilder) Build(metrics, dimensions, filters string) (sql st
 			d.Alias = fmt.Sprintf("dimension_%d", i)
 		}
 
+		b.dimensions = append(b.dimensions, d.Alias)
+
 		b.report = b.report.
 			Column(d).
 			GroupBy(d.Alias).
 			OrderBy(d.Alias)
 	}
 
+	if len(b.cumulatives) > 0 && len(b.dimensions) == 0 {
+		return "", nil, fmt.Errorf("cumulative metrics require at least one dimension")
+	}
+
 	// Use a different handler for filter functions for this
 	b.parser.OnFunction = stdFilterFuncHandler
 
@@ -171,13 +344,189 @@ func (b recordReportBuilder) Cast(row sqlx.ColScanner) map[string]interface{} {
 		}
 	}
 
-	// Cast all metrics to float64
+	// Metrics are returned as exact decimal strings
+	// without insignificant trailing zeros
 	for _, fname := range b.numerics {
 		switch num := out[fname].(type) {
 		case string:
-			out[fname], _ = strconv.ParseFloat(num, 64)
+			out[fname] = trimDecimal(num)
+		case float64:
+			// SQLite has no exact decimal type
+			out[fname] = strconv.FormatFloat(num, 'f', -1, 64)
+		case int64:
+			out[fname] = strconv.FormatInt(num, 10)
 		}
 	}
 
 	return out
 }
+
+// CheckPercentiles returns an error when percentile was calculated from too many values
+//
+// Checked only on MySQL where values are concatenated (see dialect.MySQLPercentileMaxValues);
+// number of rows in the group is an upper bound for the number of values
+func (b recordReportBuilder) CheckPercentiles(rows []map[string]interface{}) error {
+	if !b.percentiles || !dialect.Is(dialect.MySQL) {
+		return nil
+	}
+
+	for _, row := range rows {
+		var (
+			count int64
+			col   = "count"
+		)
+
+		if b.percentileRows {
+			// Count of records with inverse joins is not the number of rows
+			col = "percentile_rows"
+		}
+
+		switch c := row[col].(type) {
+		case int64:
+			count = c
+		case string:
+			count, _ = strconv.ParseInt(c, 10, 64)
+		}
+
+		if count > dialect.MySQLPercentileMaxValues {
+			return fmt.Errorf("percentile can not be calculated from more than %d records per group", dialect.MySQLPercentileMaxValues)
+		}
+
+		if b.percentileRows {
+			delete(row, col)
+		}
+	}
+
+	return nil
+}
+
+// Accumulate replaces values of cumulative metrics with running totals
+//
+// Results are ordered by dimensions; metrics are accumulated over the first dimension,
+// separately for each combination of values of the other dimensions
+func (b recordReportBuilder) Accumulate(rows []map[string]interface{}) {
+	if len(b.cumulatives) == 0 {
+		return
+	}
+
+	var totals = map[string]map[string]*big.Rat{}
+
+	for _, row := range rows {
+		var series strings.Builder
+		for _, d := range b.dimensions[1:] {
+			series.WriteString(fmt.Sprintf("%v\x00", row[d]))
+		}
+
+		if totals[series.String()] == nil {
+			totals[series.String()] = map[string]*big.Rat{}
+		}
+
+		for _, m := range b.cumulatives {
+			total := totals[series.String()][m]
+			if total == nil {
+				total = new(big.Rat)
+				totals[series.String()][m] = total
+			}
+
+			if v, ok := row[m].(string); ok {
+				if r, ok := new(big.Rat).SetString(v); ok {
+					total.Add(total, r)
+				}
+			}
+
+			row[m] = trimDecimal(total.FloatString(int(types.ModuleFieldPrecisionMax)))
+		}
+	}
+}
+
+// Rejects aggregates over the report module's fields that are skewed by inverse joins
+//
+// Records are repeated for each of the inversely joined records so only
+// aggregates that are not affected by duplicates (COUNTD, MIN, MAX) are allowed
+func (b recordReportBuilder) inverseJoinAggregationHandler(f ql.Function) (ql.Function, error) {
+	f, err := stdAggregationHandler(f)
+	if err != nil {
+		return f, err
+	}
+
+	switch name := strings.ToUpper(f.Name); {
+	case name == "MIN", name == "MAX", name == "CUMULATIVE", name == "COUNT" && f.Distinct:
+		return f, nil
+
+	case b.hasBaseIdent(f.Arguments), name == "COUNT" && isAsterisk(f.Arguments):
+		return f, fmt.Errorf("aggregate function %q can not be used on fields of the report module with inverse joins, use COUNTD, MIN or MAX", name)
+	}
+
+	return f, nil
+}
+
+// Checks if any of the (nested) identifiers is a field or column of the report module
+func (b recordReportBuilder) hasBaseIdent(node ql.ASTNode) bool {
+	switch n := node.(type) {
+	case ql.ASTNodes:
+		for _, c := range n {
+			if b.hasBaseIdent(c) {
+				return true
+			}
+		}
+	case ql.ASTSet:
+		for _, c := range n {
+			if b.hasBaseIdent(c) {
+				return true
+			}
+		}
+	case ql.Function:
+		return b.hasBaseIdent(n.Arguments)
+	case ql.Ident:
+		return b.baseIdents[n.Value]
+	}
+
+	return false
+}
+
+// Checks for the COUNT(*) argument
+func isAsterisk(args ql.ASTSet) bool {
+	if len(args) != 1 {
+		return false
+	}
+
+	o, is := args[0].(ql.Operator)
+	return is && o.Kind == "*"
+}
+
+// Table alias of the joined module's records
+func reportJoinTable(jn *RecordReportJoin) string {
+	return "j_" + jn.Alias
+}
+
+// Returns the function if it is the only node of the expression and is CUMULATIVE()
+func cumulativeMetric(expr ql.ASTNodes) (ql.Function, bool) {
+	if len(expr) != 1 {
+		return ql.Function{}, false
+	}
+
+	f, is := expr[0].(ql.Function)
+	return f, is && strings.ToUpper(f.Name) == "CUMULATIVE"
+}
+
+// Checks if function is used anywhere in the (nested) expression
+func hasFunction(node ql.ASTNode, name string) bool {
+	switch n := node.(type) {
+	case ql.ASTNodes:
+		for _, c := range n {
+			if hasFunction(c, name) {
+				return true
+			}
+		}
+	case ql.ASTSet:
+		for _, c := range n {
+			if hasFunction(c, name) {
+				return true
+			}
+		}
+	case ql.Function:
+		return strings.ToUpper(n.Name) == name || hasFunction(n.Arguments, name)
+	}
+
+	return false
+}
@@ -1,11 +1,13 @@
 package repository
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/db/dialect"
 )
 
 func TestRecordReportBuilder2(t *testing.T) {
@@ -19,7 +21,7 @@ func TestRecordReportBuilder2(t *testing.T) {
 		}},
 	)
 
-	expected := "SELECT (COUNT(*)) AS count, (CAST(max(rv_single1.value) AS DECIMAL(14,2))) AS metric_0, " +
+	expected := "SELECT (COUNT(*)) AS count, (CAST(max(rv_single1.value) AS DECIMAL(65,6))) AS metric_0, " +
 		"(QUARTER(rv_ref1.value)) AS dimension_0 " +
 		"FROM compose_record AS r " +
 		"LEFT JOIN compose_record_value AS rv_single1 ON (rv_single1.record_id = r.id AND rv_single1.name = ? AND rv_single1.deleted_at IS NULL) " +
@@ -32,3 +34,264 @@ func TestRecordReportBuilder2(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, expected, sql)
 }
+
+func TestRecordReportBuilder_refs(t *testing.T) {
+	builder := NewRecordReportBuilder(&types.Module{
+		ID: 1000,
+		Fields: types.ModuleFieldSet{
+			&types.ModuleField{Name: "amount"},
+			&types.ModuleField{Name: "account", Kind: "Record"},
+		}},
+	)
+
+	builder.refs = func(ref *types.ModuleField, name string) (*types.ModuleField, error) {
+		return &types.ModuleField{Name: name}, nil
+	}
+
+	expected := "SELECT (COUNT(*)) AS count, (CAST(sum(rv_amount.value) AS DECIMAL(65,6))) AS metric_0, " +
+		"(rv_account__industry.value) AS dimension_0 " +
+		"FROM compose_record AS r " +
+		"LEFT JOIN compose_record_value AS rv_amount ON (rv_amount.record_id = r.id AND rv_amount.name = ? AND rv_amount.deleted_at IS NULL) " +
+		"LEFT JOIN compose_record_value AS rv_account ON (rv_account.record_id = r.id AND rv_account.name = ? AND rv_account.deleted_at IS NULL) " +
+		"LEFT JOIN compose_record_value AS rv_account__industry ON (rv_account__industry.record_id = rv_account.ref AND rv_account__industry.name = ? AND rv_account__industry.deleted_at IS NULL) " +
+		"WHERE r.deleted_at IS NULL AND r.module_id = ? " +
+		"GROUP BY dimension_0 " +
+		"ORDER BY dimension_0"
+
+	sql, args, err := builder.Build("sum(amount)", "account.industry", "")
+	require.NoError(t, err)
+	require.Equal(t, expected, sql)
+	require.Equal(t, []interface{}{"amount", "account", "industry", uint64(1000)}, args)
+}
+
+func TestRecordReportBuilder_joins(t *testing.T) {
+	var (
+		req     = require.New(t)
+		account = &types.Module{
+			ID: 2000,
+			Fields: types.ModuleFieldSet{
+				&types.ModuleField{Name: "region"},
+			}}
+
+		invoice = &types.Module{
+			ID: 3000,
+			Fields: types.ModuleFieldSet{
+				&types.ModuleField{Name: "amount"},
+				&types.ModuleField{Name: "account", Kind: "Record"},
+			}}
+	)
+
+	builder := NewRecordReportBuilder(invoice)
+	builder.joins = []*RecordReportJoin{{Alias: "acc", Module: account, Field: "account"}}
+
+	sql, args, err := builder.Build("sum(amount)", "acc.region, YEAR(acc.createdAt)", "")
+	req.NoError(err)
+	req.Equal("SELECT (COUNT(*)) AS count, (CAST(sum(rv_amount.value) AS DECIMAL(65,6))) AS metric_0, "+
+		"(rv_acc__region.value) AS dimension_0, (YEAR(j_acc.created_at)) AS dimension_1 "+
+		"FROM compose_record AS r "+
+		"LEFT JOIN compose_record AS j_acc ON (j_acc.module_id = ? AND j_acc.deleted_at IS NULL AND j_acc.id IN "+
+		"(SELECT ref FROM compose_record_value WHERE record_id = r.id AND name = ? AND deleted_at IS NULL)) "+
+		"LEFT JOIN compose_record_value AS rv_amount ON (rv_amount.record_id = r.id AND rv_amount.name = ? AND rv_amount.deleted_at IS NULL) "+
+		"LEFT JOIN compose_record_value AS rv_acc__region ON (rv_acc__region.record_id = j_acc.id AND rv_acc__region.name = ? AND rv_acc__region.deleted_at IS NULL) "+
+		"WHERE r.deleted_at IS NULL AND r.module_id = ? "+
+		"GROUP BY dimension_0, dimension_1 "+
+		"ORDER BY dimension_0, dimension_1", sql)
+	req.Equal([]interface{}{uint64(2000), "account", "amount", "region", uint64(3000)}, args)
+
+	builder = NewRecordReportBuilder(account)
+	builder.joins = []*RecordReportJoin{{Alias: "inv", Module: invoice, Field: "account", Inverse: true}}
+
+	sql, _, err = builder.Build("sum(inv.amount)", "region", "")
+	req.NoError(err)
+	req.Contains(sql, "LEFT JOIN compose_record AS j_inv ON (j_inv.module_id = ? AND j_inv.deleted_at IS NULL AND j_inv.id IN "+
+		"(SELECT record_id FROM compose_record_value WHERE ref = r.id AND name = ? AND deleted_at IS NULL))")
+	req.Contains(sql, "(CAST(sum(rv_inv__amount.value) AS DECIMAL(65,6))) AS metric_0")
+
+	_, _, err = builder.Build("sum(inv.unknown)", "region", "")
+	req.Error(err)
+
+	// Accounts are repeated for each of their invoices
+	account.Fields = append(account.Fields, &types.ModuleField{Name: "employees", Kind: "Number"})
+	builder = NewRecordReportBuilder(account)
+	builder.joins = []*RecordReportJoin{{Alias: "inv", Module: invoice, Field: "account", Inverse: true}}
+	sql, _, err = builder.Build("max(employees), countd(id), sum(inv.amount)", "region", "")
+	req.NoError(err)
+	req.Contains(sql, "SELECT (COUNT(DISTINCT r.id)) AS count")
+
+	for _, metrics := range []string{"sum(employees)", "avg(employees + inv.amount)", "count(employees)", "count(*)", "median(employees)", "cumulative(sum(employees))"} {
+		builder = NewRecordReportBuilder(account)
+		builder.joins = []*RecordReportJoin{{Alias: "inv", Module: invoice, Field: "account", Inverse: true}}
+		_, _, err = builder.Build(metrics, "region", "")
+		req.Error(err, metrics)
+	}
+
+	for _, alias := range []string{"region", "in-v", ""} {
+		builder = NewRecordReportBuilder(account)
+		builder.joins = []*RecordReportJoin{{Alias: alias, Module: invoice, Field: "account", Inverse: true}}
+		_, _, err = builder.Build("count(*)", "region", "")
+		req.Error(err, alias)
+	}
+}
+
+func TestRecordReportBuilder_numbers(t *testing.T) {
+	builder := NewRecordReportBuilder(&types.Module{
+		ID: 1000,
+		Fields: types.ModuleFieldSet{
+			&types.ModuleField{Name: "amount", Kind: "Number", Options: types.ModuleFieldOptions{"precision": 2}},
+			&types.ModuleField{Name: "category"},
+		}},
+	)
+
+	sql, _, err := builder.Build("sum(amount), avg(amount)", "category", "amount > 10.5")
+	require.NoError(t, err)
+	require.Contains(t, sql, "(CAST(sum(CAST(rv_amount.value AS DECIMAL(65,2))) AS DECIMAL(65,6))) AS metric_0")
+	require.Contains(t, sql, "(CAST(avg(CAST(rv_amount.value AS DECIMAL(65,2))) AS DECIMAL(65,6))) AS metric_1")
+	require.Contains(t, sql, "(rv_category.value) AS dimension_0")
+	require.Contains(t, sql, "(CAST(rv_amount.value AS DECIMAL(65,2)) > 10.5)")
+}
+
+func TestRecordReportBuilder_functions(t *testing.T) {
+	var (
+		module = &types.Module{
+			ID: 1000,
+			Fields: types.ModuleFieldSet{
+				&types.ModuleField{Name: "amount", Kind: "Number"},
+				&types.ModuleField{Name: "customer", Kind: "Record"},
+				&types.ModuleField{Name: "category"},
+			}}
+
+		tests = []struct {
+			metrics string
+			column  string
+			err     string
+		}{
+			{
+				metrics: "count(DISTINCT customer)",
+				column:  "(CAST(count(DISTINCT rv_customer.value) AS DECIMAL(65,6))) AS metric_0",
+			},
+			{
+				metrics: "COUNTD(customer) AS uniq",
+				column:  "(CAST(COUNT(DISTINCT rv_customer.value) AS DECIMAL(65,6))) AS uniq",
+			},
+			{
+				metrics: "MEDIAN(amount)",
+				column:  "SUBSTRING_INDEX(SUBSTRING_INDEX(GROUP_CONCAT(CAST(rv_amount.value AS DECIMAL(65,0)) ORDER BY CAST(rv_amount.value AS DECIMAL(65,0)) ASC SEPARATOR ','), ',', FLOOR(1 + 0.5 * (COUNT(CAST(rv_amount.value AS DECIMAL(65,0))) - 1))), ',', -1)",
+			},
+			{
+				metrics: "CUMULATIVE(SUM(amount))",
+				column:  "(CAST(SUM(CAST(rv_amount.value AS DECIMAL(65,0))) AS DECIMAL(65,6))) AS metric_0",
+			},
+			{metrics: "max(DISTINCT amount)", err: `DISTINCT is not supported in aggregate function "max"`},
+			{metrics: "PERCENTILE(amount, 2)", err: `fraction for aggregate function "PERCENTILE" must be between 0 and 1`},
+			{metrics: "PERCENTILE(amount, category)", err: `fraction for aggregate function "PERCENTILE" must be a number`},
+			{metrics: "CUMULATIVE(amount)", err: `function "CUMULATIVE" expects one aggregate function as argument`},
+			{metrics: "SUM(CUMULATIVE(SUM(amount)))", err: `function CUMULATIVE can only be used on the whole metric`},
+		}
+	)
+
+	for _, tt := range tests {
+		t.Run(tt.metrics, func(t *testing.T) {
+			sql, _, err := NewRecordReportBuilder(module).Build(tt.metrics, "category", "")
+			if tt.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Contains(t, sql, tt.column)
+		})
+	}
+}
+
+func TestRecordReportBuilder_Accumulate(t *testing.T) {
+	var (
+		req = require.New(t)
+		b   = NewRecordReportBuilder(&types.Module{
+			ID: 1000,
+			Fields: types.ModuleFieldSet{
+				&types.ModuleField{Name: "amount", Kind: "Number", Options: types.ModuleFieldOptions{"precision": 2}},
+				&types.ModuleField{Name: "closed", Kind: "DateTime"},
+				&types.ModuleField{Name: "category"},
+			}},
+		)
+	)
+
+	_, _, err := b.Build("CUMULATIVE(SUM(amount)) AS running, SUM(amount) AS total", "DATE(closed), category", "")
+	req.NoError(err)
+
+	rows := []map[string]interface{}{
+		{"dimension_0": "2020-01-01", "dimension_1": "a", "running": "10.5", "total": "10.5"},
+		{"dimension_0": "2020-01-01", "dimension_1": "b", "running": "1", "total": "1"},
+		{"dimension_0": "2020-01-02", "dimension_1": "a", "running": "0.25", "total": "0.25"},
+		{"dimension_0": "2020-01-03", "dimension_1": "a", "running": nil, "total": nil},
+		{"dimension_0": "2020-01-03", "dimension_1": "b", "running": "2", "total": "2"},
+	}
+
+	b.Accumulate(rows)
+
+	req.Equal("10.5", rows[0]["running"])
+	req.Equal("1", rows[1]["running"])
+	req.Equal("10.75", rows[2]["running"])
+	req.Equal("10.75", rows[3]["running"])
+	req.Equal("3", rows[4]["running"])
+	req.Equal("2", rows[4]["total"])
+
+	_, _, err = NewRecordReportBuilder(&types.Module{}).Build("CUMULATIVE(COUNT(*))", "", "")
+	req.Error(err)
+}
+
+func TestRecordReportBuilder_CheckPercentiles(t *testing.T) {
+	var (
+		req = require.New(t)
+
+		build = func(metrics string) *recordReportBuilder {
+			b := NewRecordReportBuilder(&types.Module{
+				ID:     1000,
+				Fields: types.ModuleFieldSet{&types.ModuleField{Name: "amount", Kind: "Number"}},
+			})
+
+			_, _, err := b.Build(metrics, "", "")
+			req.NoError(err)
+			return b
+		}
+
+		rows = []map[string]interface{}{
+			{"count": int64(10)},
+			{"count": fmt.Sprintf("%d", dialect.MySQLPercentileMaxValues+1)},
+		}
+	)
+
+	req.NoError(build("SUM(amount)").CheckPercentiles(rows))
+	req.Error(build("MEDIAN(amount)").CheckPercentiles(rows))
+	req.NoError(build("MEDIAN(amount)").CheckPercentiles(rows[:1]))
+
+	// With inverse joins, records are counted once but repeated in the rows
+	b := NewRecordReportBuilder(&types.Module{ID: 2000})
+	b.joins = []*RecordReportJoin{{Alias: "inv", Module: &types.Module{
+		ID:     1000,
+		Fields: types.ModuleFieldSet{&types.ModuleField{Name: "amount", Kind: "Number"}},
+	}, Field: "account", Inverse: true}}
+	sql, _, err := b.Build("MEDIAN(inv.amount)", "", "")
+	req.NoError(err)
+	req.Contains(sql, "(COUNT(*)) AS percentile_rows")
+
+	joined := []map[string]interface{}{{"count": int64(1), "percentile_rows": rows[1]["count"]}}
+	req.Error(b.CheckPercentiles(joined))
+	joined[0]["percentile_rows"] = int64(10)
+	req.NoError(b.CheckPercentiles(joined))
+	req.NotContains(joined[0], "percentile_rows")
+
+	req.NoError(dialect.Set(dialect.Postgres))
+	defer dialect.Set(dialect.MySQL)
+	req.NoError(build("MEDIAN(amount)").CheckPercentiles(rows))
+}
+
+func TestTrimDecimal(t *testing.T) {
+	req := require.New(t)
+	req.Equal("30.5", trimDecimal("30.500000"))
+	req.Equal("3", trimDecimal("3.000000"))
+	req.Equal("120", trimDecimal("120"))
+	req.Equal("0.000001", trimDecimal("0.000001"))
+	req.Equal("0", trimDecimal("-0.000000"))
+}
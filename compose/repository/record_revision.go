@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+	"github.com/titpetric/factory"
+
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/rh"
+)
+
+type (
+	RecordRevisionRepository interface {
+		With(ctx context.Context, db *factory.DB) RecordRevisionRepository
+
+		FindByID(namespaceID, revisionID uint64) (*types.RecordRevision, error)
+		Find(filter types.RecordRevisionFilter) (set types.RecordRevisionSet, f types.RecordRevisionFilter, err error)
+		Create(rev *types.RecordRevision) (*types.RecordRevision, error)
+	}
+
+	recordRevision struct {
+		*repository
+	}
+)
+
+const (
+	ErrRecordRevisionNotFound = repositoryError("RecordRevisionNotFound")
+)
+
+func RecordRevision(ctx context.Context, db *factory.DB) RecordRevisionRepository {
+	return (&recordRevision{}).With(ctx, db)
+}
+
+func (r recordRevision) With(ctx context.Context, db *factory.DB) RecordRevisionRepository {
+	return &recordRevision{
+		repository: r.repository.With(ctx, db),
+	}
+}
+
+func (r recordRevision) table() string {
+	return "compose_record_revision"
+}
+
+func (r recordRevision) columns() []string {
+	return []string{
+		"rr.id",
+		"rr.rel_namespace",
+		"rr.rel_module",
+		"rr.rel_record",
+		"rr.operation",
+		"rr.changes",
+		"rr.snapshot",
+		"rr.created_at",
+		"rr.created_by",
+	}
+}
+
+func (r recordRevision) query() squirrel.SelectBuilder {
+	return squirrel.
+		Select(r.columns()...).
+		From(r.table() + " AS rr")
+}
+
+func (r recordRevision) FindByID(namespaceID, revisionID uint64) (*types.RecordRevision, error) {
+	var (
+		rev = &types.RecordRevision{}
+
+		q = r.query().
+			Where(squirrel.Eq{"rr.id": revisionID, "rr.rel_namespace": namespaceID})
+
+		err = rh.FetchOne(r.db(), q, rev)
+	)
+
+	if err != nil {
+		return nil, err
+	} else if rev.ID == 0 {
+		return nil, ErrRecordRevisionNotFound
+	}
+
+	return rev, nil
+}
+
+// Find returns revisions of a record, newest first
+func (r recordRevision) Find(filter types.RecordRevisionFilter) (set types.RecordRevisionSet, f types.RecordRevisionFilter, err error) {
+	f = filter
+
+	query := r.query().
+		Where(squirrel.Eq{"rr.rel_record": f.RecordID})
+
+	if f.NamespaceID > 0 {
+		query = query.Where("rr.rel_namespace = ?", f.NamespaceID)
+	}
+
+	if f.ModuleID > 0 {
+		query = query.Where("rr.rel_module = ?", f.ModuleID)
+	}
+
+	if f.Count, err = rh.Count(r.db(), query); err != nil || f.Count == 0 {
+		return
+	}
+
+	query = query.OrderBy("rr.created_at DESC", "rr.id DESC")
+
+	return set, f, rh.FetchPaged(r.db(), query, f.PageFilter, &set)
+}
+
+func (r recordRevision) Create(rev *types.RecordRevision) (*types.RecordRevision, error) {
+	rev.ID = factory.Sonyflake.NextID()
+
+	if rev.CreatedAt.IsZero() {
+		rev.CreatedAt = time.Now()
+	}
+
+	if err := r.db().Insert(r.table(), rev); err != nil {
+		return nil, errors.Wrap(err, "could not create record revision")
+	}
+
+	return rev, nil
+}
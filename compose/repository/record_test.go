@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"github.com/cortezaproject/corteza-server/pkg/rh"
 	"strings"
@@ -9,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/db/dialect"
 )
 
 func TestRecordFinder(t *testing.T) {
@@ -20,6 +23,10 @@ func TestRecordFinder(t *testing.T) {
 			&types.ModuleField{Name: "foo"},
 			&types.ModuleField{Name: "bar"},
 			&types.ModuleField{Name: "booly", Kind: "Bool"},
+			&types.ModuleField{Name: "total", Kind: "Formula", Options: types.ModuleFieldOptions{"resultKind": "Number"}},
+			&types.ModuleField{Name: "rollup", Kind: "Rollup"},
+			&types.ModuleField{Name: "qty", Kind: "Number"},
+			&types.ModuleField{Name: "amount", Kind: "Number", Options: types.ModuleFieldOptions{"precision": 2}},
 		},
 	}
 
@@ -78,6 +85,59 @@ func TestRecordFinder(t *testing.T) {
 			match: []string{"(rv_booly.value NOT IN ("},
 			args:  []interface{}{"booly"},
 		},
+		{
+			name:  "formula",
+			f:     types.RecordFilter{Query: "total > 5"},
+			match: []string{"CAST(rv_total.value AS DECIMAL(65,6)) > 5"},
+			args:  []interface{}{"total"},
+		},
+		{
+			name:  "rollup",
+			f:     types.RecordFilter{Query: "rollup > 5"},
+			match: []string{"CAST(rv_rollup.value AS DECIMAL(65,6)) > 5"},
+			args:  []interface{}{"rollup"},
+		},
+		{
+			name:  "number",
+			f:     types.RecordFilter{Query: "qty > 5"},
+			match: []string{"CAST(rv_qty.value AS DECIMAL(65,0)) > 5"},
+			args:  []interface{}{"qty"},
+		},
+		{
+			name: "number with precision",
+			f:    types.RecordFilter{Query: "amount > 10.5", Sort: "amount DESC"},
+			match: []string{
+				"CAST(rv_amount.value AS DECIMAL(65,2)) > 10.5",
+				"ORDER BY CAST(rv_amount.value AS DECIMAL(65,2)) DESC",
+			},
+			args: []interface{}{"amount"},
+		},
+		{
+			name: "free-text search",
+			f:    types.RecordFilter{Q: " foo bar ", QFields: map[uint64][]string{123: {"foo"}}},
+			match: []string{
+				"JOIN (SELECT rv.record_id, (SUM(MATCH(rv.value) AGAINST (? IN NATURAL LANGUAGE MODE))) AS relevance " +
+					"FROM compose_record_value AS rv JOIN compose_record AS fr ON (fr.id = rv.record_id) " +
+					"WHERE rv.deleted_at IS NULL AND ((fr.module_id = ? AND rv.name IN (?))) " +
+					"AND MATCH(rv.value) AGAINST (? IN NATURAL LANGUAGE MODE) " +
+					"GROUP BY rv.record_id) AS fts ON (fts.record_id = r.id)",
+				"ORDER BY fts.relevance DESC, r.id ASC",
+			},
+			args: []interface{}{"foo bar", uint64(123), "foo", "foo bar"},
+		},
+		{
+			name:    "free-text search with sort",
+			f:       types.RecordFilter{Q: "foo", QFields: map[uint64][]string{123: {"foo"}}, Sort: "bar"},
+			match:   []string{"ORDER BY rv_bar.value ASC, r.id ASC"},
+			noMatch: []string{"fts.relevance DESC"},
+			args:    []interface{}{"bar", "foo", uint64(123), "foo", "foo"},
+		},
+		{
+			name:    "free-text search without searchable fields",
+			f:       types.RecordFilter{Q: "foo", QFields: map[uint64][]string{123: {}}},
+			match:   []string{"AND FALSE"},
+			noMatch: []string{"fts"},
+		},
 	}
 
 	for _, tc := range ttc {
@@ -111,3 +171,223 @@ func TestRecordFinder(t *testing.T) {
 		})
 	}
 }
+
+func TestRecordFinder_refs(t *testing.T) {
+	var (
+		req = require.New(t)
+
+		account = &types.Module{
+			ID: 100,
+			Fields: types.ModuleFieldSet{
+				&types.ModuleField{Name: "industry"},
+				&types.ModuleField{Name: "employees", Kind: "Number"},
+				&types.ModuleField{Name: "owner", Kind: "Record", Options: types.ModuleFieldOptions{"moduleID": "200"}},
+			},
+		}
+
+		owner = &types.Module{
+			ID: 200,
+			Fields: types.ModuleFieldSet{
+				&types.ModuleField{Name: "name"},
+			},
+		}
+
+		m = &types.Module{
+			ID:          123,
+			NamespaceID: 456,
+			Fields: types.ModuleFieldSet{
+				&types.ModuleField{Name: "account", Kind: "Record", Options: types.ModuleFieldOptions{"moduleID": "100"}},
+				&types.ModuleField{Name: "tags", Kind: "Record", Multi: true},
+				&types.ModuleField{Name: "foo"},
+			},
+		}
+
+		r = record{refs: func(ref *types.ModuleField, name string) (*types.ModuleField, error) {
+			for _, rm := range []*types.Module{account, owner} {
+				if rm.ID == ref.Options.ModuleID() {
+					return rm.Fields.FindByName(name), nil
+				}
+			}
+
+			return nil, nil
+		}}
+	)
+
+	sb, err := r.buildQuery(m, types.RecordFilter{
+		Query: "account.industry = 'Retail' AND account.employees > 10",
+		Sort:  "account.owner.name DESC",
+	})
+	req.NoError(err)
+
+	sql, args, err := sb.ToSql()
+	req.NoError(err)
+
+	req.Contains(sql, "LEFT JOIN compose_record_value AS rv_account ON (rv_account.record_id = r.id AND rv_account.name = ?")
+	req.Contains(sql, "LEFT JOIN compose_record_value AS rv_account__industry ON (rv_account__industry.record_id = rv_account.ref AND rv_account__industry.name = ?")
+	req.Contains(sql, "LEFT JOIN compose_record_value AS rv_account__owner__name ON (rv_account__owner__name.record_id = rv_account__owner.ref")
+	req.Contains(sql, "rv_account__industry.value  = ?")
+	req.Contains(sql, "CAST(rv_account__employees.value AS DECIMAL(65,0)) > 10")
+	req.Contains(sql, "ORDER BY rv_account__owner__name.value DESC")
+	req.Equal([]interface{}{"account", "industry", "employees", "owner", "name", m.ID, m.NamespaceID, "Retail"}, args)
+
+	_, err = r.buildQuery(m, types.RecordFilter{Query: "account.missing = 1"})
+	req.EqualError(err, `unknown field "account.missing"`)
+
+	_, err = r.buildQuery(m, types.RecordFilter{Query: "foo.bar = 1"})
+	req.Error(err)
+
+	_, err = r.buildQuery(m, types.RecordFilter{Query: "tags.name = 1"})
+	req.Error(err)
+
+	_, err = record{}.buildQuery(m, types.RecordFilter{Query: "account.industry = 1"})
+	req.Error(err)
+}
+
+func TestRecordCursor(t *testing.T) {
+	var (
+		r = record{}
+		m = &types.Module{
+			ID:          123,
+			NamespaceID: 456,
+			Fields: types.ModuleFieldSet{
+				&types.ModuleField{Name: "foo"},
+				&types.ModuleField{Name: "bar"},
+			},
+		}
+
+		cursor = func(sort string, vv ...interface{}) string {
+			enc, _ := json.Marshal(recordCursor{Sort: sort, Values: vv})
+			return base64.RawURLEncoding.EncodeToString(enc)
+		}
+	)
+
+	ttc := []struct {
+		name  string
+		f     types.RecordFilter
+		match []string
+		args  []interface{}
+		err   error
+	}{
+		{
+			name:  "default sort",
+			f:     types.RecordFilter{PageCursor: cursor("", "42")},
+			match: []string{"(r.id > ?)", "ORDER BY r.id ASC"},
+			args:  []interface{}{m.ID, m.NamespaceID, "42"},
+		},
+		{
+			name: "sort by field",
+			f:    types.RecordFilter{Sort: "bar DESC", PageCursor: cursor("bar DESC", "b", "42")},
+			match: []string{
+				"((rv_bar.value < ? OR rv_bar.value IS NULL)) OR (rv_bar.value = ? AND r.id > ?)",
+				"ORDER BY rv_bar.value DESC, r.id ASC",
+			},
+			args: []interface{}{"bar", m.ID, m.NamespaceID, "b", "b", "42"},
+		},
+		{
+			name: "null values",
+			f:    types.RecordFilter{Sort: "foo", PageCursor: cursor("foo", nil, "42")},
+			match: []string{
+				"((rv_foo.value IS NOT NULL) OR (rv_foo.value IS NULL AND r.id > ?))",
+			},
+			args: []interface{}{"foo", m.ID, m.NamespaceID, "42"},
+		},
+		{
+			name: "sort mismatch",
+			f:    types.RecordFilter{Sort: "foo", PageCursor: cursor("bar", "b", "42")},
+			err:  ErrRecordInvalidPageCursor,
+		},
+		{
+			name: "malformed cursor",
+			f:    types.RecordFilter{PageCursor: "not a cursor"},
+			err:  ErrRecordInvalidPageCursor,
+		},
+	}
+
+	for _, tc := range ttc {
+		t.Run(tc.name, func(t *testing.T) {
+			sb, keys, err := r.buildSortedQuery(m, tc.f)
+			require.NoError(t, err)
+
+			sb, err = r.applyCursor(sb, keys, tc.f)
+			if tc.err != nil {
+				require.Equal(t, tc.err, err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			sql, args, err := sb.ToSql()
+			require.NoError(t, err)
+
+			for _, m := range tc.match {
+				require.True(t, strings.Contains(sql, m),
+					"assertion failed; query %q \n  "+
+						"             did not contain  %q", sql, m)
+			}
+
+			require.Equal(t, fmt.Sprintf("%+v", tc.args), fmt.Sprintf("%+v", args))
+		})
+	}
+}
+
+// Cursor conditions on PostgreSQL; NULL values are placed explicitly
+// since they are sorted as higher than any other value by default
+func TestRecordCursor_postgres(t *testing.T) {
+	var (
+		req = require.New(t)
+		r   = record{}
+		m   = &types.Module{
+			ID:          123,
+			NamespaceID: 456,
+			Fields:      types.ModuleFieldSet{&types.ModuleField{Name: "foo"}},
+		}
+
+		enc, _ = json.Marshal(recordCursor{Sort: "foo DESC", Values: []interface{}{nil, "42"}})
+		f      = types.RecordFilter{Sort: "foo DESC", PageCursor: base64.RawURLEncoding.EncodeToString(enc)}
+	)
+
+	req.NoError(dialect.Set(dialect.Postgres))
+	defer dialect.Set(dialect.MySQL)
+
+	sb, keys, err := r.buildSortedQuery(m, f)
+	req.NoError(err)
+
+	sb, err = r.applyCursor(sb, keys, f)
+	req.NoError(err)
+
+	sql, args, err := sb.ToSql()
+	req.NoError(err)
+
+	req.Contains(sql, "((rv_foo.value IS NULL AND r.id > ?))")
+	req.Contains(sql, "ORDER BY rv_foo.value DESC NULLS LAST, r.id ASC NULLS FIRST")
+	req.Equal([]interface{}{"foo", m.ID, m.NamespaceID, "42"}, args)
+}
+
+func TestRecordSearch(t *testing.T) {
+	var (
+		req = require.New(t)
+		r   = record{}
+	)
+
+	sb, keys, err := r.buildSearchQuery(456, types.RecordFilter{
+		Q:       "foo",
+		QFields: map[uint64][]string{200: {"name"}, 100: {"title", "email"}, 300: {}},
+	})
+	req.NoError(err)
+	req.Len(keys, 2)
+
+	sql, args, err := sb.ToSql()
+	req.NoError(err)
+
+	req.Contains(sql, "((fr.module_id = ? AND rv.name IN (?,?)) OR (fr.module_id = ? AND rv.name IN (?)))")
+	req.Contains(sql, "WHERE r.rel_namespace = ? AND r.deleted_at IS NULL ORDER BY fts.relevance DESC, r.id ASC")
+	req.Equal([]interface{}{"foo", uint64(100), "title", "email", uint64(200), "name", "foo", uint64(456)}, args)
+
+	sb, keys, err = r.buildSearchQuery(456, types.RecordFilter{Q: "foo"})
+	req.NoError(err)
+	req.Len(keys, 1)
+
+	sql, _, err = sb.ToSql()
+	req.NoError(err)
+	req.NotContains(sql, "fts")
+}
@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/titpetric/factory"
+
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/rh"
+)
+
+type (
+	RecordViewRepository interface {
+		With(ctx context.Context, db *factory.DB) RecordViewRepository
+
+		FindByID(namespaceID, viewID uint64) (*types.RecordView, error)
+		FindByHandle(namespaceID uint64, handle string) (c *types.RecordView, err error)
+		Find(filter types.RecordViewFilter) (set types.RecordViewSet, f types.RecordViewFilter, err error)
+		Create(v *types.RecordView) (*types.RecordView, error)
+		Update(v *types.RecordView) (*types.RecordView, error)
+		DeleteByID(namespaceID, viewID uint64) error
+	}
+
+	recordView struct {
+		*repository
+	}
+)
+
+const (
+	ErrRecordViewNotFound        = repositoryError("RecordViewNotFound")
+	ErrRecordViewHandleNotUnique = repositoryError("RecordViewHandleNotUnique")
+)
+
+func RecordView(ctx context.Context, db *factory.DB) RecordViewRepository {
+	return (&recordView{}).With(ctx, db)
+}
+
+func (r recordView) With(ctx context.Context, db *factory.DB) RecordViewRepository {
+	return &recordView{
+		repository: r.repository.With(ctx, db),
+	}
+}
+
+func (r recordView) table() string {
+	return "compose_record_view"
+}
+
+func (r recordView) columns() []string {
+	return []string{
+		"id",
+		"rel_namespace",
+		"rel_module",
+		"handle",
+		"name",
+		"filter",
+		"sort",
+		"columns",
+		"created_at",
+		"updated_at",
+		"deleted_at",
+	}
+}
+
+func (r recordView) query() squirrel.SelectBuilder {
+	return squirrel.
+		Select(r.columns()...).
+		From(r.table()).
+		Where("deleted_at IS NULL")
+}
+
+func (r recordView) FindByID(namespaceID, viewID uint64) (*types.RecordView, error) {
+	return r.findOneBy(namespaceID, "id", viewID)
+}
+
+func (r recordView) FindByHandle(namespaceID uint64, handle string) (*types.RecordView, error) {
+	return r.findOneBy(namespaceID, "LOWER(handle)", strings.ToLower(strings.TrimSpace(handle)))
+}
+
+func (r recordView) findOneBy(namespaceID uint64, field string, value interface{}) (*types.RecordView, error) {
+	var (
+		c = &types.RecordView{}
+
+		q = r.query().
+			Where(squirrel.Eq{field: value, "rel_namespace": namespaceID})
+
+		err = rh.FetchOne(r.db(), q, c)
+	)
+
+	if err != nil {
+		return nil, err
+	} else if c.ID == 0 {
+		return nil, ErrRecordViewNotFound
+	}
+
+	return c, nil
+}
+
+func (r recordView) Find(filter types.RecordViewFilter) (set types.RecordViewSet, f types.RecordViewFilter, err error) {
+	f = filter
+
+	if f.Sort == "" {
+		f.Sort = "id ASC"
+	}
+
+	query := r.query()
+
+	if filter.NamespaceID > 0 {
+		query = query.Where(squirrel.Eq{"rel_namespace": filter.NamespaceID})
+	}
+
+	if filter.ModuleID > 0 {
+		query = query.Where(squirrel.Eq{"rel_module": filter.ModuleID})
+	}
+
+	if f.Query != "" {
+		q := "%" + strings.ToLower(f.Query) + "%"
+		query = query.Where(squirrel.Or{
+			squirrel.Like{"LOWER(name)": q},
+		})
+	}
+
+	if f.Handle != "" {
+		query = query.Where("LOWER(handle) = LOWER(?)", f.Handle)
+	}
+
+	if f.IsReadable != nil {
+		query = query.Where(f.IsReadable)
+	}
+
+	var orderBy []string
+	if orderBy, err = rh.ParseOrder(f.Sort, r.columns()...); err != nil {
+		return
+	} else {
+		query = query.OrderBy(orderBy...)
+	}
+
+	if f.Count, err = rh.Count(r.db(), query); err != nil || f.Count == 0 {
+		return
+	}
+
+	return set, f, rh.FetchPaged(r.db(), query, f.PageFilter, &set)
+}
+
+func (r recordView) Create(v *types.RecordView) (*types.RecordView, error) {
+	v.ID = factory.Sonyflake.NextID()
+	rh.SetCurrentTimeRounded(&v.CreatedAt)
+	v.UpdatedAt = nil
+
+	return v, r.db().Insert(r.table(), v)
+}
+
+func (r recordView) Update(v *types.RecordView) (*types.RecordView, error) {
+	rh.SetCurrentTimeRounded(&v.UpdatedAt)
+
+	return v, r.db().Update(r.table(), v, "id")
+}
+
+func (r recordView) DeleteByID(namespaceID, viewID uint64) error {
+	_, err := r.db().Exec(
+		"UPDATE "+r.table()+" SET deleted_at = NOW() WHERE rel_namespace = ? AND id = ?",
+		namespaceID,
+		viewID,
+	)
+
+	return err
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/cortezaproject/corteza-server/compose/types"
 	"github.com/cortezaproject/corteza-server/pkg/auth"
 	"github.com/cortezaproject/corteza-server/pkg/rh"
+	"github.com/cortezaproject/corteza-server/pkg/store"
 
 	"github.com/pkg/errors"
 )
@@ -128,6 +129,15 @@ func (ctrl Attachment) serve(ctx context.Context, namespaceID, attachmentID uint
 			return
 		}
 
+		// Let client download the file directly from the store when possible
+		if u, err := ctrl.attachment.PresignedURL(att, preview, download); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		} else if u != "" {
+			http.Redirect(w, req, u, http.StatusFound)
+			return
+		}
+
 		var fh io.ReadSeeker
 
 		if preview {
@@ -141,15 +151,9 @@ func (ctrl Attachment) serve(ctx context.Context, namespaceID, attachmentID uint
 			return
 		}
 
-		name := url.QueryEscape(att.Name)
-
-		if download {
-			w.Header().Add("Content-Disposition", "attachment; filename="+name)
-		} else {
-			w.Header().Add("Content-Disposition", "inline; filename="+name)
-		}
+		w.Header().Add("Content-Disposition", store.ContentDisposition(att.Name, download))
 
-		http.ServeContent(w, req, name, att.CreatedAt, fh)
+		http.ServeContent(w, req, url.QueryEscape(att.Name), att.CreatedAt, fh)
 	}, nil
 }
 
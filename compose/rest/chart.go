@@ -2,6 +2,8 @@ package rest
 
 import (
 	"context"
+	"net/http"
+	"strconv"
 
 	"github.com/titpetric/factory/resputil"
 
@@ -88,6 +90,25 @@ func (ctrl Chart) Read(ctx context.Context, r *request.ChartRead) (interface{},
 
 }
 
+// Render writes chart image (png or svg)
+func (ctrl Chart) Render(ctx context.Context, r *request.ChartRender) (interface{}, error) {
+	img, err := ctrl.chart.With(ctx).Render(r.NamespaceID, r.ChartID, r.Ext, int(r.Width), int(r.Height))
+	if err != nil {
+		return nil, err
+	}
+
+	return func(w http.ResponseWriter, req *http.Request) {
+		if r.Ext == "svg" {
+			w.Header().Set("Content-Type", "image/svg+xml")
+		} else {
+			w.Header().Set("Content-Type", "image/"+r.Ext)
+		}
+
+		w.Header().Set("Content-Length", strconv.Itoa(len(img)))
+		_, _ = w.Write(img)
+	}, nil
+}
+
 func (ctrl Chart) Update(ctx context.Context, r *request.ChartUpdate) (interface{}, error) {
 	var (
 		err error
@@ -32,6 +32,7 @@ type ChartAPI interface {
 	List(context.Context, *request.ChartList) (interface{}, error)
 	Create(context.Context, *request.ChartCreate) (interface{}, error)
 	Read(context.Context, *request.ChartRead) (interface{}, error)
+	Render(context.Context, *request.ChartRender) (interface{}, error)
 	Update(context.Context, *request.ChartUpdate) (interface{}, error)
 	Delete(context.Context, *request.ChartDelete) (interface{}, error)
 }
@@ -41,6 +42,7 @@ type Chart struct {
 	List   func(http.ResponseWriter, *http.Request)
 	Create func(http.ResponseWriter, *http.Request)
 	Read   func(http.ResponseWriter, *http.Request)
+	Render func(http.ResponseWriter, *http.Request)
 	Update func(http.ResponseWriter, *http.Request)
 	Delete func(http.ResponseWriter, *http.Request)
 }
@@ -107,6 +109,26 @@ func NewChart(h ChartAPI) *Chart {
 				resputil.JSON(w, value)
 			}
 		},
+		Render: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewChartRender()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("Chart.Render", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.Render(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("Chart.Render", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("Chart.Render", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
 		Update: func(w http.ResponseWriter, r *http.Request) {
 			defer r.Body.Close()
 			params := request.NewChartUpdate()
@@ -156,6 +178,7 @@ func (h Chart) MountRoutes(r chi.Router, middlewares ...func(http.Handler) http.
 		r.Get("/namespace/{namespaceID}/chart/", h.List)
 		r.Post("/namespace/{namespaceID}/chart/", h.Create)
 		r.Get("/namespace/{namespaceID}/chart/{chartID}", h.Read)
+		r.Get("/namespace/{namespaceID}/chart/{chartID}/render.{ext}", h.Render)
 		r.Post("/namespace/{namespaceID}/chart/{chartID}", h.Update)
 		r.Delete("/namespace/{namespaceID}/chart/{chartID}", h.Delete)
 	})
@@ -32,6 +32,7 @@ type NamespaceAPI interface {
 	List(context.Context, *request.NamespaceList) (interface{}, error)
 	Create(context.Context, *request.NamespaceCreate) (interface{}, error)
 	Read(context.Context, *request.NamespaceRead) (interface{}, error)
+	Search(context.Context, *request.NamespaceSearch) (interface{}, error)
 	Update(context.Context, *request.NamespaceUpdate) (interface{}, error)
 	Delete(context.Context, *request.NamespaceDelete) (interface{}, error)
 	TriggerScript(context.Context, *request.NamespaceTriggerScript) (interface{}, error)
@@ -42,6 +43,7 @@ type Namespace struct {
 	List          func(http.ResponseWriter, *http.Request)
 	Create        func(http.ResponseWriter, *http.Request)
 	Read          func(http.ResponseWriter, *http.Request)
+	Search        func(http.ResponseWriter, *http.Request)
 	Update        func(http.ResponseWriter, *http.Request)
 	Delete        func(http.ResponseWriter, *http.Request)
 	TriggerScript func(http.ResponseWriter, *http.Request)
@@ -109,6 +111,26 @@ func NewNamespace(h NamespaceAPI) *Namespace {
 				resputil.JSON(w, value)
 			}
 		},
+		Search: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewNamespaceSearch()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("Namespace.Search", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.Search(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("Namespace.Search", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("Namespace.Search", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
 		Update: func(w http.ResponseWriter, r *http.Request) {
 			defer r.Body.Close()
 			params := request.NewNamespaceUpdate()
@@ -178,6 +200,7 @@ func (h Namespace) MountRoutes(r chi.Router, middlewares ...func(http.Handler) h
 		r.Get("/namespace/", h.List)
 		r.Post("/namespace/", h.Create)
 		r.Get("/namespace/{namespaceID}", h.Read)
+		r.Get("/namespace/{namespaceID}/search", h.Search)
 		r.Post("/namespace/{namespaceID}", h.Update)
 		r.Delete("/namespace/{namespaceID}", h.Delete)
 		r.Post("/namespace/{namespaceID}/trigger", h.TriggerScript)
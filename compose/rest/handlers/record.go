@@ -34,6 +34,7 @@ type RecordAPI interface {
 	ImportInit(context.Context, *request.RecordImportInit) (interface{}, error)
 	ImportRun(context.Context, *request.RecordImportRun) (interface{}, error)
 	ImportProgress(context.Context, *request.RecordImportProgress) (interface{}, error)
+	ImportReport(context.Context, *request.RecordImportReport) (interface{}, error)
 	Export(context.Context, *request.RecordExport) (interface{}, error)
 	Exec(context.Context, *request.RecordExec) (interface{}, error)
 	Create(context.Context, *request.RecordCreate) (interface{}, error)
@@ -41,6 +42,9 @@ type RecordAPI interface {
 	Update(context.Context, *request.RecordUpdate) (interface{}, error)
 	BulkDelete(context.Context, *request.RecordBulkDelete) (interface{}, error)
 	Delete(context.Context, *request.RecordDelete) (interface{}, error)
+	Revisions(context.Context, *request.RecordRevisions) (interface{}, error)
+	RevisionsDiff(context.Context, *request.RecordRevisionsDiff) (interface{}, error)
+	RevisionRestore(context.Context, *request.RecordRevisionRestore) (interface{}, error)
 	Upload(context.Context, *request.RecordUpload) (interface{}, error)
 	TriggerScript(context.Context, *request.RecordTriggerScript) (interface{}, error)
 	TriggerScriptOnList(context.Context, *request.RecordTriggerScriptOnList) (interface{}, error)
@@ -53,6 +57,7 @@ type Record struct {
 	ImportInit          func(http.ResponseWriter, *http.Request)
 	ImportRun           func(http.ResponseWriter, *http.Request)
 	ImportProgress      func(http.ResponseWriter, *http.Request)
+	ImportReport        func(http.ResponseWriter, *http.Request)
 	Export              func(http.ResponseWriter, *http.Request)
 	Exec                func(http.ResponseWriter, *http.Request)
 	Create              func(http.ResponseWriter, *http.Request)
@@ -60,6 +65,9 @@ type Record struct {
 	Update              func(http.ResponseWriter, *http.Request)
 	BulkDelete          func(http.ResponseWriter, *http.Request)
 	Delete              func(http.ResponseWriter, *http.Request)
+	Revisions           func(http.ResponseWriter, *http.Request)
+	RevisionsDiff       func(http.ResponseWriter, *http.Request)
+	RevisionRestore     func(http.ResponseWriter, *http.Request)
 	Upload              func(http.ResponseWriter, *http.Request)
 	TriggerScript       func(http.ResponseWriter, *http.Request)
 	TriggerScriptOnList func(http.ResponseWriter, *http.Request)
@@ -167,6 +175,26 @@ func NewRecord(h RecordAPI) *Record {
 				resputil.JSON(w, value)
 			}
 		},
+		ImportReport: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewRecordImportReport()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("Record.ImportReport", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.ImportReport(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("Record.ImportReport", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("Record.ImportReport", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
 		Export: func(w http.ResponseWriter, r *http.Request) {
 			defer r.Body.Close()
 			params := request.NewRecordExport()
@@ -307,6 +335,66 @@ func NewRecord(h RecordAPI) *Record {
 				resputil.JSON(w, value)
 			}
 		},
+		Revisions: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewRecordRevisions()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("Record.Revisions", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.Revisions(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("Record.Revisions", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("Record.Revisions", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
+		RevisionsDiff: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewRecordRevisionsDiff()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("Record.RevisionsDiff", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.RevisionsDiff(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("Record.RevisionsDiff", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("Record.RevisionsDiff", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
+		RevisionRestore: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewRecordRevisionRestore()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("Record.RevisionRestore", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.RevisionRestore(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("Record.RevisionRestore", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("Record.RevisionRestore", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
 		Upload: func(w http.ResponseWriter, r *http.Request) {
 			defer r.Body.Close()
 			params := request.NewRecordUpload()
@@ -378,6 +466,7 @@ func (h Record) MountRoutes(r chi.Router, middlewares ...func(http.Handler) http
 		r.Post("/namespace/{namespaceID}/module/{moduleID}/record/import", h.ImportInit)
 		r.Patch("/namespace/{namespaceID}/module/{moduleID}/record/import/{sessionID}", h.ImportRun)
 		r.Get("/namespace/{namespaceID}/module/{moduleID}/record/import/{sessionID}", h.ImportProgress)
+		r.Get("/namespace/{namespaceID}/module/{moduleID}/record/import/{sessionID}/report", h.ImportReport)
 		r.Get("/namespace/{namespaceID}/module/{moduleID}/record/export{filename}.{ext}", h.Export)
 		r.Post("/namespace/{namespaceID}/module/{moduleID}/record/exec/{procedure}", h.Exec)
 		r.Post("/namespace/{namespaceID}/module/{moduleID}/record/", h.Create)
@@ -385,6 +474,9 @@ func (h Record) MountRoutes(r chi.Router, middlewares ...func(http.Handler) http
 		r.Post("/namespace/{namespaceID}/module/{moduleID}/record/{recordID}", h.Update)
 		r.Delete("/namespace/{namespaceID}/module/{moduleID}/record/", h.BulkDelete)
 		r.Delete("/namespace/{namespaceID}/module/{moduleID}/record/{recordID}", h.Delete)
+		r.Get("/namespace/{namespaceID}/module/{moduleID}/record/{recordID}/revisions", h.Revisions)
+		r.Get("/namespace/{namespaceID}/module/{moduleID}/record/{recordID}/revisions/diff", h.RevisionsDiff)
+		r.Post("/namespace/{namespaceID}/module/{moduleID}/record/{recordID}/revisions/{revisionID}/restore", h.RevisionRestore)
 		r.Post("/namespace/{namespaceID}/module/{moduleID}/record/attachment", h.Upload)
 		r.Post("/namespace/{namespaceID}/module/{moduleID}/record/{recordID}/trigger", h.TriggerScript)
 		r.Post("/namespace/{namespaceID}/module/{moduleID}/record/trigger", h.TriggerScriptOnList)
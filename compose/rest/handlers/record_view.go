@@ -0,0 +1,162 @@
+package handlers
+
+/*
+	Hello! This file is auto-generated from `docs/src/spec.json`.
+
+	For development:
+	In order to update the generated files, edit this file under the location,
+	add your struct fields, imports, API definitions and whatever you want, and:
+
+	1. run [spec](https://github.com/titpetric/spec) in the same folder,
+	2. run `./_gen.php` in this folder.
+
+	You may edit `record_view.go`, `record_view.util.go` or `record_view_test.go` to
+	implement your API calls, helper functions and tests. The file `record_view.go`
+	is only generated the first time, and will not be overwritten if it exists.
+*/
+
+import (
+	"context"
+
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/titpetric/factory/resputil"
+
+	"github.com/cortezaproject/corteza-server/compose/rest/request"
+	"github.com/cortezaproject/corteza-server/pkg/logger"
+)
+
+// Internal API interface
+type RecordViewAPI interface {
+	List(context.Context, *request.RecordViewList) (interface{}, error)
+	Create(context.Context, *request.RecordViewCreate) (interface{}, error)
+	Read(context.Context, *request.RecordViewRead) (interface{}, error)
+	Update(context.Context, *request.RecordViewUpdate) (interface{}, error)
+	Delete(context.Context, *request.RecordViewDelete) (interface{}, error)
+}
+
+// HTTP API interface
+type RecordView struct {
+	List   func(http.ResponseWriter, *http.Request)
+	Create func(http.ResponseWriter, *http.Request)
+	Read   func(http.ResponseWriter, *http.Request)
+	Update func(http.ResponseWriter, *http.Request)
+	Delete func(http.ResponseWriter, *http.Request)
+}
+
+func NewRecordView(h RecordViewAPI) *RecordView {
+	return &RecordView{
+		List: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewRecordViewList()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("RecordView.List", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.List(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("RecordView.List", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("RecordView.List", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
+		Create: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewRecordViewCreate()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("RecordView.Create", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.Create(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("RecordView.Create", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("RecordView.Create", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
+		Read: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewRecordViewRead()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("RecordView.Read", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.Read(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("RecordView.Read", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("RecordView.Read", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
+		Update: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewRecordViewUpdate()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("RecordView.Update", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.Update(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("RecordView.Update", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("RecordView.Update", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
+		Delete: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewRecordViewDelete()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("RecordView.Delete", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.Delete(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("RecordView.Delete", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("RecordView.Delete", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
+	}
+}
+
+func (h RecordView) MountRoutes(r chi.Router, middlewares ...func(http.Handler) http.Handler) {
+	r.Group(func(r chi.Router) {
+		r.Use(middlewares...)
+		r.Get("/namespace/{namespaceID}/record-view/", h.List)
+		r.Post("/namespace/{namespaceID}/record-view/", h.Create)
+		r.Get("/namespace/{namespaceID}/record-view/{viewID}", h.Read)
+		r.Post("/namespace/{namespaceID}/record-view/{viewID}", h.Update)
+		r.Delete("/namespace/{namespaceID}/record-view/{viewID}", h.Delete)
+	})
+}
@@ -0,0 +1,70 @@
+package handlers
+
+/*
+	Hello! This file is auto-generated from `docs/src/spec.json`.
+
+	For development:
+	In order to update the generated files, edit this file under the location,
+	add your struct fields, imports, API definitions and whatever you want, and:
+
+	1. run [spec](https://github.com/titpetric/spec) in the same folder,
+	2. run `./_gen.php` in this folder.
+
+	You may edit `search.go`, `search.util.go` or `search_test.go` to
+	implement your API calls, helper functions and tests. The file `search.go`
+	is only generated the first time, and will not be overwritten if it exists.
+*/
+
+import (
+	"context"
+
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/titpetric/factory/resputil"
+
+	"github.com/cortezaproject/corteza-server/compose/rest/request"
+	"github.com/cortezaproject/corteza-server/pkg/logger"
+)
+
+// Internal API interface
+type SearchAPI interface {
+	Attachments(context.Context, *request.SearchAttachments) (interface{}, error)
+}
+
+// HTTP API interface
+type Search struct {
+	Attachments func(http.ResponseWriter, *http.Request)
+}
+
+func NewSearch(h SearchAPI) *Search {
+	return &Search{
+		Attachments: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewSearchAttachments()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("Search.Attachments", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.Attachments(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("Search.Attachments", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("Search.Attachments", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
+	}
+}
+
+func (h Search) MountRoutes(r chi.Router, middlewares ...func(http.Handler) http.Handler) {
+	r.Group(func(r chi.Router) {
+		r.Use(middlewares...)
+		r.Get("/search/attachments", h.Attachments)
+	})
+}
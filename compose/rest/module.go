@@ -101,6 +101,7 @@ func (ctrl *Module) Create(ctx context.Context, r *request.ModuleCreate) (interf
 			Name:        r.Name,
 			Handle:      r.Handle,
 			Fields:      r.Fields,
+			Validators:  r.Validators,
 			Meta:        r.Meta,
 		}
 	)
@@ -118,6 +119,7 @@ func (ctrl *Module) Update(ctx context.Context, r *request.ModuleUpdate) (interf
 			Name:        r.Name,
 			Handle:      r.Handle,
 			Fields:      r.Fields,
+			Validators:  r.Validators,
 			Meta:        r.Meta,
 			UpdatedAt:   r.UpdatedAt,
 		}
@@ -33,6 +33,8 @@ type (
 
 	Namespace struct {
 		namespace service.NamespaceService
+		module    service.ModuleService
+		record    service.RecordService
 		ac        namespaceAccessController
 	}
 
@@ -46,12 +48,17 @@ type (
 		CanCreateModule(context.Context, *types.Namespace) bool
 		CanCreateChart(context.Context, *types.Namespace) bool
 		CanCreatePage(context.Context, *types.Namespace) bool
+
+		CanUpdateRecord(context.Context, *types.Module) bool
+		CanDeleteRecord(context.Context, *types.Module) bool
 	}
 )
 
 func (Namespace) New() *Namespace {
 	return &Namespace{
 		namespace: service.DefaultNamespace,
+		module:    service.DefaultModule,
+		record:    service.DefaultRecord,
 		ac:        service.DefaultAccessControl,
 	}
 }
@@ -93,6 +100,44 @@ func (ctrl Namespace) Read(ctx context.Context, r *request.NamespaceRead) (inter
 	return ctrl.makePayload(ctx, ns, err)
 }
 
+// Search finds records of all modules in the namespace by free-text search
+func (ctrl Namespace) Search(ctx context.Context, r *request.NamespaceSearch) (interface{}, error) {
+	var (
+		rf = types.RecordFilter{
+			NamespaceID: r.NamespaceID,
+			Q:           r.Q,
+
+			Deleted: rh.FilterState(r.Deleted),
+
+			PageFilter: rh.Paging(r),
+			PageCursor: r.PageCursor,
+		}
+	)
+
+	rr, filter, err := ctrl.record.With(ctx).Search(rf)
+	if err != nil {
+		return nil, err
+	}
+
+	// Modules are needed to resolve record permissions
+	mm, _, err := ctrl.module.With(ctx).Find(types.ModuleFilter{NamespaceID: r.NamespaceID})
+	if err != nil {
+		return nil, err
+	}
+
+	rsp := &recordSetPayload{Filter: &filter, Set: make([]*recordPayload, len(rr))}
+	for i := range rr {
+		m := mm.FindByID(rr[i].ModuleID)
+		rsp.Set[i] = &recordPayload{
+			Record:          rr[i],
+			CanUpdateRecord: m != nil && ctrl.ac.CanUpdateRecord(ctx, m),
+			CanDeleteRecord: m != nil && ctrl.ac.CanDeleteRecord(ctx, m),
+		}
+	}
+
+	return rsp, nil
+}
+
 func (ctrl Namespace) Update(ctx context.Context, r *request.NamespaceUpdate) (interface{}, error) {
 	var (
 		err error
@@ -54,6 +54,12 @@ func (ctrl *Notification) EmailSend(ctx context.Context, r *request.Notification
 		}
 	}
 
+	if len(r.ChartAttachments) > 2 {
+		if err := r.ChartAttachments.Unmarshal(&ntf.ChartAttachments); err != nil {
+			return false, fmt.Errorf("could not unmarshal chart attachments: %w", err)
+		}
+	}
+
 	if err := ctrl.svc.SendEmail(ctx, ntf); err != nil {
 		return false, err
 	} else {
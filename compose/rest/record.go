@@ -6,13 +6,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"path"
 	"strconv"
 	"strings"
 
 	"github.com/titpetric/factory/resputil"
 
-	"github.com/cortezaproject/corteza-server/compose/decoder"
 	"github.com/cortezaproject/corteza-server/compose/encoder"
 	"github.com/cortezaproject/corteza-server/compose/repository"
 	"github.com/cortezaproject/corteza-server/compose/rest/request"
@@ -21,7 +19,6 @@ import (
 	"github.com/cortezaproject/corteza-server/compose/service/values"
 	"github.com/cortezaproject/corteza-server/compose/types"
 	"github.com/cortezaproject/corteza-server/pkg/corredor"
-	"github.com/cortezaproject/corteza-server/pkg/mime"
 	"github.com/cortezaproject/corteza-server/pkg/payload"
 	"github.com/cortezaproject/corteza-server/pkg/rh"
 	systemService "github.com/cortezaproject/corteza-server/system/service"
@@ -43,9 +40,15 @@ type (
 		Set    []*recordPayload    `json:"set"`
 	}
 
+	recordRevisionSetPayload struct {
+		Filter *types.RecordRevisionFilter `json:"filter,omitempty"`
+		Set    types.RecordRevisionSet     `json:"set"`
+	}
+
 	Record struct {
 		importSession service.ImportSessionService
 		record        service.RecordService
+		recordView    service.RecordViewService
 		module        service.ModuleService
 		namespace     service.NamespaceService
 		attachment    service.AttachmentService
@@ -63,6 +66,7 @@ func (Record) New() *Record {
 	return &Record{
 		importSession: service.DefaultImportSession,
 		record:        service.DefaultRecord,
+		recordView:    service.DefaultRecordView,
 		module:        service.DefaultModule,
 		namespace:     service.DefaultNamespace,
 		attachment:    service.DefaultAttachment,
@@ -74,7 +78,15 @@ func (Record) New() *Record {
 }
 
 func (ctrl *Record) Report(ctx context.Context, r *request.RecordReport) (interface{}, error) {
-	return ctrl.record.With(ctx).Report(r.NamespaceID, r.ModuleID, r.Metrics, r.Dimensions, r.Filter)
+	var joins []*types.ChartConfigReportJoin
+
+	if len(r.Joins) > 2 {
+		if err := r.Joins.Unmarshal(&joins); err != nil {
+			return nil, err
+		}
+	}
+
+	return ctrl.record.With(ctx).Report(r.NamespaceID, r.ModuleID, joins, r.Metrics, r.Dimensions, r.Filter)
 }
 
 func (ctrl *Record) List(ctx context.Context, r *request.RecordList) (interface{}, error) {
@@ -86,10 +98,14 @@ func (ctrl *Record) List(ctx context.Context, r *request.RecordList) (interface{
 			NamespaceID: r.NamespaceID,
 			ModuleID:    r.ModuleID,
 			Sort:        r.Sort,
+			Q:           r.Q,
+			ViewID:      r.ViewID,
+			ViewHandle:  r.ViewHandle,
 
 			Deleted: rh.FilterState(r.Deleted),
 
 			PageFilter: rh.Paging(r),
+			PageCursor: r.PageCursor,
 		}
 	)
 
@@ -232,6 +248,44 @@ func (ctrl *Record) Delete(ctx context.Context, r *request.RecordDelete) (interf
 	return resputil.OK(), ctrl.record.With(ctx).DeleteByID(r.NamespaceID, r.ModuleID, r.RecordID)
 }
 
+func (ctrl *Record) Revisions(ctx context.Context, r *request.RecordRevisions) (interface{}, error) {
+	rr, filter, err := ctrl.record.With(ctx).FindRevisions(types.RecordRevisionFilter{
+		NamespaceID: r.NamespaceID,
+		ModuleID:    r.ModuleID,
+		RecordID:    r.RecordID,
+		PageFilter:  rh.Paging(r),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &recordRevisionSetPayload{Filter: &filter, Set: rr}, nil
+}
+
+func (ctrl *Record) RevisionsDiff(ctx context.Context, r *request.RecordRevisionsDiff) (interface{}, error) {
+	return ctrl.record.With(ctx).DiffRevisions(r.NamespaceID, r.ModuleID, r.RecordID, r.From, r.To)
+}
+
+func (ctrl *Record) RevisionRestore(ctx context.Context, r *request.RecordRevisionRestore) (interface{}, error) {
+	var (
+		m   *types.Module
+		err error
+	)
+
+	if m, err = ctrl.module.With(ctx).FindByID(r.NamespaceID, r.ModuleID); err != nil {
+		return nil, err
+	}
+
+	record, err := ctrl.record.With(ctx).RestoreRevision(r.NamespaceID, r.ModuleID, r.RecordID, r.RevisionID)
+
+	if rve := types.IsRecordValueErrorSet(err); rve != nil {
+		return ctrl.handleValidationError(rve), nil
+	}
+
+	return ctrl.makePayload(ctx, m, record, err)
+}
+
 func (ctrl *Record) BulkDelete(ctx context.Context, r *request.RecordBulkDelete) (interface{}, error) {
 	if r.Truncate {
 		return nil, fmt.Errorf("pending implementation")
@@ -267,9 +321,7 @@ func (ctrl *Record) Upload(ctx context.Context, r *request.RecordUpload) (interf
 
 func (ctrl *Record) ImportInit(ctx context.Context, r *request.RecordImportInit) (interface{}, error) {
 	var (
-		err           error
-		recordDecoder service.Decoder
-		entryCount    uint64
+		err error
 	)
 
 	// Access control.
@@ -283,55 +335,14 @@ func (ctrl *Record) ImportInit(ctx context.Context, r *request.RecordImportInit)
 	}
 	defer f.Close()
 
-	_, ext, err := mime.Type(f)
-	if err != nil {
-		return nil, err
-	}
-
-	if ext == "txt" {
-		if is, err := mime.JsonL(f); err != nil {
-			return nil, err
-		} else if is {
-			ext = "jsonl"
-		} else {
-			// As last resort, use extension of the upload filename
-			ext = strings.TrimLeft(path.Ext(r.Upload.Filename), ".")
-		}
-	}
-
-	// determine decoder
-	switch strings.ToLower(ext) {
-	case "json", "jsonl", "ldjson", "ndjson":
-		recordDecoder = decoder.NewStructuredDecoder(json.NewDecoder(f), f)
-
-	case "csv":
-		recordDecoder = decoder.NewFlatReader(csv.NewReader(f), f)
-
-	default:
-		// copied here from service/errors.go for backward compatibility
-		// @todo move this logic to service and use action/error pattern
-		return nil, fmt.Errorf("compose.service.RecordImportFormatNotSupported")
-	}
-
-	entryCount, err = recordDecoder.EntryCount()
-	if err != nil {
-		return nil, err
-	}
-
-	header := recordDecoder.Header()
-	hh := make(map[string]string)
-	for _, h := range header {
-		hh[h] = ""
+	ses := &types.RecordImportSession{
+		NamespaceID: r.NamespaceID,
+		ModuleID:    r.ModuleID,
+		Sheet:       r.Sheet,
+		HeaderRow:   r.HeaderRow,
 	}
 
-	return ctrl.importSession.SetByID(
-		ctx,
-		0,
-		r.NamespaceID,
-		r.ModuleID,
-		hh,
-		&service.RecordImportProgress{EntryCount: entryCount},
-		recordDecoder)
+	return ctrl.importSession.Create(ctx, ses, r.Upload.Filename, f)
 }
 
 func (ctrl *Record) ImportRun(ctx context.Context, r *request.RecordImportRun) (interface{}, error) {
@@ -350,16 +361,22 @@ func (ctrl *Record) ImportRun(ctx context.Context, r *request.RecordImportRun) (
 		return nil, err
 	}
 
-	ses.Fields = make(map[string]string)
+	ses.Fields = types.RecordImportFields{}
 	err = json.Unmarshal(r.Fields, &ses.Fields)
 	if err != nil {
 		return nil, err
 	}
 
 	ses.OnError = r.OnError
+	ses.Mode = r.Mode
+	ses.MatchField = r.MatchField
+	ses.DryRun = r.DryRun
 
-	// @todo routine
-	ctrl.record.With(ctx).Import(ses, ctrl.importSession)
+	// Import runs in background, failures of individual
+	// records are reported through import progress
+	if err = ctrl.record.With(ctx).Import(ses, ctrl.importSession); err != nil {
+		return nil, err
+	}
 
 	return ses, nil
 }
@@ -374,6 +391,33 @@ func (ctrl *Record) ImportProgress(ctx context.Context, r *request.RecordImportP
 	return ses, nil
 }
 
+// ImportReport writes errors of the failed import records as CSV
+func (ctrl *Record) ImportReport(ctx context.Context, r *request.RecordImportReport) (interface{}, error) {
+	// Access control.
+	if _, err := ctrl.module.With(ctx).FindByID(r.NamespaceID, r.ModuleID); err != nil {
+		return nil, err
+	}
+
+	ses, err := ctrl.importSession.FindByID(ctx, r.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Add("Content-Type", "text/csv")
+		w.Header().Add("Content-Disposition", fmt.Sprintf("attachment; filename=import-%d-report.csv", ses.SessionID))
+
+		cw := csv.NewWriter(w)
+		_ = cw.Write([]string{"row", "field", "kind", "message"})
+
+		for _, e := range ses.Report {
+			_ = cw.Write([]string{strconv.Itoa(e.Row), e.Field, e.Kind, e.Message})
+		}
+
+		cw.Flush()
+	}, nil
+}
+
 func (ctrl *Record) Export(ctx context.Context, r *request.RecordExport) (interface{}, error) {
 	type (
 		// ad-hoc interface for our encoder
@@ -395,6 +439,8 @@ func (ctrl *Record) Export(ctx context.Context, r *request.RecordExport) (interf
 			NamespaceID: r.NamespaceID,
 			ModuleID:    r.ModuleID,
 			Query:       r.Filter,
+			ViewID:      r.ViewID,
+			ViewHandle:  r.ViewHandle,
 		}
 
 		contentType string
@@ -408,6 +454,21 @@ func (ctrl *Record) Export(ctx context.Context, r *request.RecordExport) (interf
 		r.Fields = strings.Split(r.Fields[0], ",")
 	}
 
+	if len(r.Fields) == 0 && (r.ViewID > 0 || r.ViewHandle != "") {
+		var view interface{} = r.ViewHandle
+		if r.ViewID > 0 {
+			view = r.ViewID
+		}
+
+		// Export columns of the record view
+		v, err := ctrl.recordView.With(ctx).FindByAny(r.NamespaceID, view)
+		if err != nil {
+			return nil, err
+		}
+
+		r.Fields = v.Columns
+	}
+
 	return func(w http.ResponseWriter, req *http.Request) {
 		ff := encoder.MakeFields(r.Fields...)
 
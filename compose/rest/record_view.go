@@ -0,0 +1,131 @@
+package rest
+
+import (
+	"context"
+
+	"github.com/titpetric/factory/resputil"
+
+	"github.com/cortezaproject/corteza-server/compose/rest/request"
+	"github.com/cortezaproject/corteza-server/compose/service"
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/rh"
+)
+
+type (
+	recordViewPayload struct {
+		*types.RecordView
+
+		CanGrant            bool `json:"canGrant"`
+		CanUpdateRecordView bool `json:"canUpdateRecordView"`
+		CanDeleteRecordView bool `json:"canDeleteRecordView"`
+	}
+
+	recordViewSetPayload struct {
+		Filter types.RecordViewFilter `json:"filter"`
+		Set    []*recordViewPayload   `json:"set"`
+	}
+
+	RecordView struct {
+		view service.RecordViewService
+		ac   recordViewAccessController
+	}
+
+	recordViewAccessController interface {
+		CanGrant(context.Context) bool
+
+		CanUpdateRecordView(context.Context, *types.RecordView) bool
+		CanDeleteRecordView(context.Context, *types.RecordView) bool
+	}
+)
+
+func (RecordView) New() *RecordView {
+	return &RecordView{
+		view: service.DefaultRecordView,
+		ac:   service.DefaultAccessControl,
+	}
+}
+
+func (ctrl RecordView) List(ctx context.Context, r *request.RecordViewList) (interface{}, error) {
+	f := types.RecordViewFilter{
+		NamespaceID: r.NamespaceID,
+		ModuleID:    r.ModuleID,
+
+		Handle: r.Handle,
+		Query:  r.Query,
+
+		Sort: r.Sort,
+
+		PageFilter: rh.Paging(r),
+	}
+
+	set, filter, err := ctrl.view.With(ctx).Find(f)
+	return ctrl.makeFilterPayload(ctx, set, filter, err)
+}
+
+func (ctrl RecordView) Create(ctx context.Context, r *request.RecordViewCreate) (interface{}, error) {
+	v, err := ctrl.view.With(ctx).Create(&types.RecordView{
+		NamespaceID: r.NamespaceID,
+		ModuleID:    r.ModuleID,
+		Name:        r.Name,
+		Handle:      r.Handle,
+		Filter:      r.Filter,
+		Sort:        r.Sort,
+		Columns:     r.Columns,
+	})
+
+	return ctrl.makePayload(ctx, v, err)
+}
+
+func (ctrl RecordView) Read(ctx context.Context, r *request.RecordViewRead) (interface{}, error) {
+	v, err := ctrl.view.With(ctx).FindByID(r.NamespaceID, r.ViewID)
+	return ctrl.makePayload(ctx, v, err)
+}
+
+func (ctrl RecordView) Update(ctx context.Context, r *request.RecordViewUpdate) (interface{}, error) {
+	v, err := ctrl.view.With(ctx).Update(&types.RecordView{
+		ID:          r.ViewID,
+		NamespaceID: r.NamespaceID,
+		ModuleID:    r.ModuleID,
+		Name:        r.Name,
+		Handle:      r.Handle,
+		Filter:      r.Filter,
+		Sort:        r.Sort,
+		Columns:     r.Columns,
+		UpdatedAt:   r.UpdatedAt,
+	})
+
+	return ctrl.makePayload(ctx, v, err)
+}
+
+func (ctrl RecordView) Delete(ctx context.Context, r *request.RecordViewDelete) (interface{}, error) {
+	return resputil.OK(), ctrl.view.With(ctx).DeleteByID(r.NamespaceID, r.ViewID)
+}
+
+func (ctrl RecordView) makePayload(ctx context.Context, v *types.RecordView, err error) (*recordViewPayload, error) {
+	if err != nil || v == nil {
+		return nil, err
+	}
+
+	return &recordViewPayload{
+		RecordView: v,
+
+		CanGrant: ctrl.ac.CanGrant(ctx),
+
+		CanUpdateRecordView: ctrl.ac.CanUpdateRecordView(ctx, v),
+		CanDeleteRecordView: ctrl.ac.CanDeleteRecordView(ctx, v),
+	}, nil
+}
+
+func (ctrl RecordView) makeFilterPayload(ctx context.Context, vv types.RecordViewSet, f types.RecordViewFilter, err error) (*recordViewSetPayload, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	p := &recordViewSetPayload{Filter: f, Set: make([]*recordViewPayload, len(vv))}
+
+	for i := range vv {
+		p.Set[i], _ = ctrl.makePayload(ctx, vv[i], nil)
+	}
+
+	return p, nil
+}
@@ -318,6 +318,100 @@ func (r *ChartRead) Fill(req *http.Request) (err error) {
 
 var _ RequestFiller = NewChartRead()
 
+// ChartRender request parameters
+type ChartRender struct {
+	hasWidth bool
+	rawWidth string
+	Width    uint
+
+	hasHeight bool
+	rawHeight string
+	Height    uint
+
+	hasChartID bool
+	rawChartID string
+	ChartID    uint64 `json:",string"`
+
+	hasExt bool
+	rawExt string
+	Ext    string
+
+	hasNamespaceID bool
+	rawNamespaceID string
+	NamespaceID    uint64 `json:",string"`
+}
+
+// NewChartRender request
+func NewChartRender() *ChartRender {
+	return &ChartRender{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r ChartRender) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["width"] = r.Width
+	out["height"] = r.Height
+	out["chartID"] = r.ChartID
+	out["ext"] = r.Ext
+	out["namespaceID"] = r.NamespaceID
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *ChartRender) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	if val, ok := get["width"]; ok {
+		r.hasWidth = true
+		r.rawWidth = val
+		r.Width = parseUint(val)
+	}
+	if val, ok := get["height"]; ok {
+		r.hasHeight = true
+		r.rawHeight = val
+		r.Height = parseUint(val)
+	}
+	r.hasChartID = true
+	r.rawChartID = chi.URLParam(req, "chartID")
+	r.ChartID = parseUInt64(chi.URLParam(req, "chartID"))
+	r.hasExt = true
+	r.rawExt = chi.URLParam(req, "ext")
+	r.Ext = chi.URLParam(req, "ext")
+	r.hasNamespaceID = true
+	r.rawNamespaceID = chi.URLParam(req, "namespaceID")
+	r.NamespaceID = parseUInt64(chi.URLParam(req, "namespaceID"))
+
+	return err
+}
+
+var _ RequestFiller = NewChartRender()
+
 // ChartUpdate request parameters
 type ChartUpdate struct {
 	hasChartID bool
@@ -706,6 +800,81 @@ func (r *ChartRead) GetNamespaceID() uint64 {
 	return r.NamespaceID
 }
 
+// HasWidth returns true if width was set
+func (r *ChartRender) HasWidth() bool {
+	return r.hasWidth
+}
+
+// RawWidth returns raw value of width parameter
+func (r *ChartRender) RawWidth() string {
+	return r.rawWidth
+}
+
+// GetWidth returns casted value of  width parameter
+func (r *ChartRender) GetWidth() uint {
+	return r.Width
+}
+
+// HasHeight returns true if height was set
+func (r *ChartRender) HasHeight() bool {
+	return r.hasHeight
+}
+
+// RawHeight returns raw value of height parameter
+func (r *ChartRender) RawHeight() string {
+	return r.rawHeight
+}
+
+// GetHeight returns casted value of  height parameter
+func (r *ChartRender) GetHeight() uint {
+	return r.Height
+}
+
+// HasChartID returns true if chartID was set
+func (r *ChartRender) HasChartID() bool {
+	return r.hasChartID
+}
+
+// RawChartID returns raw value of chartID parameter
+func (r *ChartRender) RawChartID() string {
+	return r.rawChartID
+}
+
+// GetChartID returns casted value of  chartID parameter
+func (r *ChartRender) GetChartID() uint64 {
+	return r.ChartID
+}
+
+// HasExt returns true if ext was set
+func (r *ChartRender) HasExt() bool {
+	return r.hasExt
+}
+
+// RawExt returns raw value of ext parameter
+func (r *ChartRender) RawExt() string {
+	return r.rawExt
+}
+
+// GetExt returns casted value of  ext parameter
+func (r *ChartRender) GetExt() string {
+	return r.Ext
+}
+
+// HasNamespaceID returns true if namespaceID was set
+func (r *ChartRender) HasNamespaceID() bool {
+	return r.hasNamespaceID
+}
+
+// RawNamespaceID returns raw value of namespaceID parameter
+func (r *ChartRender) RawNamespaceID() string {
+	return r.rawNamespaceID
+}
+
+// GetNamespaceID returns casted value of  namespaceID parameter
+func (r *ChartRender) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
 // HasChartID returns true if chartID was set
 func (r *ChartUpdate) HasChartID() bool {
 	return r.hasChartID
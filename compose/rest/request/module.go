@@ -186,6 +186,10 @@ type ModuleCreate struct {
 	rawFields string
 	Fields    types.ModuleFieldSet
 
+	hasValidators bool
+	rawValidators string
+	Validators    types.ModuleValidatorSet
+
 	hasMeta bool
 	rawMeta string
 	Meta    sqlxTypes.JSONText
@@ -207,6 +211,7 @@ func (r ModuleCreate) Auditable() map[string]interface{} {
 	out["name"] = r.Name
 	out["handle"] = r.Handle
 	out["fields"] = r.Fields
+	out["validators"] = r.Validators
 	out["meta"] = r.Meta
 	out["namespaceID"] = r.NamespaceID
 
@@ -356,6 +361,10 @@ type ModuleUpdate struct {
 	rawFields string
 	Fields    types.ModuleFieldSet
 
+	hasValidators bool
+	rawValidators string
+	Validators    types.ModuleValidatorSet
+
 	hasMeta bool
 	rawMeta string
 	Meta    sqlxTypes.JSONText
@@ -379,6 +388,7 @@ func (r ModuleUpdate) Auditable() map[string]interface{} {
 	out["name"] = r.Name
 	out["handle"] = r.Handle
 	out["fields"] = r.Fields
+	out["validators"] = r.Validators
 	out["meta"] = r.Meta
 	out["updatedAt"] = r.UpdatedAt
 
@@ -773,6 +783,21 @@ func (r *ModuleCreate) GetFields() types.ModuleFieldSet {
 	return r.Fields
 }
 
+// HasValidators returns true if validators was set
+func (r *ModuleCreate) HasValidators() bool {
+	return r.hasValidators
+}
+
+// RawValidators returns raw value of validators parameter
+func (r *ModuleCreate) RawValidators() string {
+	return r.rawValidators
+}
+
+// GetValidators returns casted value of  validators parameter
+func (r *ModuleCreate) GetValidators() types.ModuleValidatorSet {
+	return r.Validators
+}
+
 // HasMeta returns true if meta was set
 func (r *ModuleCreate) HasMeta() bool {
 	return r.hasMeta
@@ -908,6 +933,21 @@ func (r *ModuleUpdate) GetFields() types.ModuleFieldSet {
 	return r.Fields
 }
 
+// HasValidators returns true if validators was set
+func (r *ModuleUpdate) HasValidators() bool {
+	return r.hasValidators
+}
+
+// RawValidators returns raw value of validators parameter
+func (r *ModuleUpdate) RawValidators() string {
+	return r.rawValidators
+}
+
+// GetValidators returns casted value of  validators parameter
+func (r *ModuleUpdate) GetValidators() types.ModuleValidatorSet {
+	return r.Validators
+}
+
 // HasMeta returns true if meta was set
 func (r *ModuleUpdate) HasMeta() bool {
 	return r.hasMeta
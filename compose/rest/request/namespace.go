@@ -304,6 +304,134 @@ func (r *NamespaceRead) Fill(req *http.Request) (err error) {
 
 var _ RequestFiller = NewNamespaceRead()
 
+// NamespaceSearch request parameters
+type NamespaceSearch struct {
+	hasQ bool
+	rawQ string
+	Q    string
+
+	hasDeleted bool
+	rawDeleted string
+	Deleted    uint
+
+	hasLimit bool
+	rawLimit string
+	Limit    uint
+
+	hasOffset bool
+	rawOffset string
+	Offset    uint
+
+	hasPage bool
+	rawPage string
+	Page    uint
+
+	hasPerPage bool
+	rawPerPage string
+	PerPage    uint
+
+	hasPageCursor bool
+	rawPageCursor string
+	PageCursor    string
+
+	hasNamespaceID bool
+	rawNamespaceID string
+	NamespaceID    uint64 `json:",string"`
+}
+
+// NewNamespaceSearch request
+func NewNamespaceSearch() *NamespaceSearch {
+	return &NamespaceSearch{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r NamespaceSearch) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["q"] = r.Q
+	out["deleted"] = r.Deleted
+	out["limit"] = r.Limit
+	out["offset"] = r.Offset
+	out["page"] = r.Page
+	out["perPage"] = r.PerPage
+	out["pageCursor"] = r.PageCursor
+	out["namespaceID"] = r.NamespaceID
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *NamespaceSearch) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	if val, ok := get["q"]; ok {
+		r.hasQ = true
+		r.rawQ = val
+		r.Q = val
+	}
+	if val, ok := get["deleted"]; ok {
+		r.hasDeleted = true
+		r.rawDeleted = val
+		r.Deleted = parseUint(val)
+	}
+	if val, ok := get["limit"]; ok {
+		r.hasLimit = true
+		r.rawLimit = val
+		r.Limit = parseUint(val)
+	}
+	if val, ok := get["offset"]; ok {
+		r.hasOffset = true
+		r.rawOffset = val
+		r.Offset = parseUint(val)
+	}
+	if val, ok := get["page"]; ok {
+		r.hasPage = true
+		r.rawPage = val
+		r.Page = parseUint(val)
+	}
+	if val, ok := get["perPage"]; ok {
+		r.hasPerPage = true
+		r.rawPerPage = val
+		r.PerPage = parseUint(val)
+	}
+	if val, ok := get["pageCursor"]; ok {
+		r.hasPageCursor = true
+		r.rawPageCursor = val
+		r.PageCursor = val
+	}
+	r.hasNamespaceID = true
+	r.rawNamespaceID = chi.URLParam(req, "namespaceID")
+	r.NamespaceID = parseUInt64(chi.URLParam(req, "namespaceID"))
+
+	return err
+}
+
+var _ RequestFiller = NewNamespaceSearch()
+
 // NamespaceUpdate request parameters
 type NamespaceUpdate struct {
 	hasNamespaceID bool
@@ -724,6 +852,126 @@ func (r *NamespaceRead) GetNamespaceID() uint64 {
 	return r.NamespaceID
 }
 
+// HasQ returns true if q was set
+func (r *NamespaceSearch) HasQ() bool {
+	return r.hasQ
+}
+
+// RawQ returns raw value of q parameter
+func (r *NamespaceSearch) RawQ() string {
+	return r.rawQ
+}
+
+// GetQ returns casted value of  q parameter
+func (r *NamespaceSearch) GetQ() string {
+	return r.Q
+}
+
+// HasDeleted returns true if deleted was set
+func (r *NamespaceSearch) HasDeleted() bool {
+	return r.hasDeleted
+}
+
+// RawDeleted returns raw value of deleted parameter
+func (r *NamespaceSearch) RawDeleted() string {
+	return r.rawDeleted
+}
+
+// GetDeleted returns casted value of  deleted parameter
+func (r *NamespaceSearch) GetDeleted() uint {
+	return r.Deleted
+}
+
+// HasLimit returns true if limit was set
+func (r *NamespaceSearch) HasLimit() bool {
+	return r.hasLimit
+}
+
+// RawLimit returns raw value of limit parameter
+func (r *NamespaceSearch) RawLimit() string {
+	return r.rawLimit
+}
+
+// GetLimit returns casted value of  limit parameter
+func (r *NamespaceSearch) GetLimit() uint {
+	return r.Limit
+}
+
+// HasOffset returns true if offset was set
+func (r *NamespaceSearch) HasOffset() bool {
+	return r.hasOffset
+}
+
+// RawOffset returns raw value of offset parameter
+func (r *NamespaceSearch) RawOffset() string {
+	return r.rawOffset
+}
+
+// GetOffset returns casted value of  offset parameter
+func (r *NamespaceSearch) GetOffset() uint {
+	return r.Offset
+}
+
+// HasPage returns true if page was set
+func (r *NamespaceSearch) HasPage() bool {
+	return r.hasPage
+}
+
+// RawPage returns raw value of page parameter
+func (r *NamespaceSearch) RawPage() string {
+	return r.rawPage
+}
+
+// GetPage returns casted value of  page parameter
+func (r *NamespaceSearch) GetPage() uint {
+	return r.Page
+}
+
+// HasPerPage returns true if perPage was set
+func (r *NamespaceSearch) HasPerPage() bool {
+	return r.hasPerPage
+}
+
+// RawPerPage returns raw value of perPage parameter
+func (r *NamespaceSearch) RawPerPage() string {
+	return r.rawPerPage
+}
+
+// GetPerPage returns casted value of  perPage parameter
+func (r *NamespaceSearch) GetPerPage() uint {
+	return r.PerPage
+}
+
+// HasPageCursor returns true if pageCursor was set
+func (r *NamespaceSearch) HasPageCursor() bool {
+	return r.hasPageCursor
+}
+
+// RawPageCursor returns raw value of pageCursor parameter
+func (r *NamespaceSearch) RawPageCursor() string {
+	return r.rawPageCursor
+}
+
+// GetPageCursor returns casted value of  pageCursor parameter
+func (r *NamespaceSearch) GetPageCursor() string {
+	return r.PageCursor
+}
+
+// HasNamespaceID returns true if namespaceID was set
+func (r *NamespaceSearch) HasNamespaceID() bool {
+	return r.hasNamespaceID
+}
+
+// RawNamespaceID returns raw value of namespaceID parameter
+func (r *NamespaceSearch) RawNamespaceID() string {
+	return r.rawNamespaceID
+}
+
+// GetNamespaceID returns casted value of  namespaceID parameter
+func (r *NamespaceSearch) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
 // HasNamespaceID returns true if namespaceID was set
 func (r *NamespaceUpdate) HasNamespaceID() bool {
 	return r.hasNamespaceID
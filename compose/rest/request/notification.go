@@ -57,6 +57,10 @@ type NotificationEmailSend struct {
 	hasRemoteAttachments bool
 	rawRemoteAttachments []string
 	RemoteAttachments    []string
+
+	hasChartAttachments bool
+	rawChartAttachments string
+	ChartAttachments    sqlxTypes.JSONText
 }
 
 // NewNotificationEmailSend request
@@ -74,6 +78,7 @@ func (r NotificationEmailSend) Auditable() map[string]interface{} {
 	out["subject"] = r.Subject
 	out["content"] = r.Content
 	out["remoteAttachments"] = r.RemoteAttachments
+	out["chartAttachments"] = r.ChartAttachments
 
 	return out
 }
@@ -143,6 +148,15 @@ func (r *NotificationEmailSend) Fill(req *http.Request) (err error) {
 		r.RemoteAttachments = parseStrings(val)
 	}
 
+	if val, ok := post["chartAttachments"]; ok {
+		r.hasChartAttachments = true
+		r.rawChartAttachments = val
+
+		if r.ChartAttachments, err = parseJSONTextWithErr(val); err != nil {
+			return err
+		}
+	}
+
 	return err
 }
 
@@ -237,3 +251,18 @@ func (r *NotificationEmailSend) RawRemoteAttachments() []string {
 func (r *NotificationEmailSend) GetRemoteAttachments() []string {
 	return r.RemoteAttachments
 }
+
+// HasChartAttachments returns true if chartAttachments was set
+func (r *NotificationEmailSend) HasChartAttachments() bool {
+	return r.hasChartAttachments
+}
+
+// RawChartAttachments returns raw value of chartAttachments parameter
+func (r *NotificationEmailSend) RawChartAttachments() string {
+	return r.rawChartAttachments
+}
+
+// GetChartAttachments returns casted value of  chartAttachments parameter
+func (r *NotificationEmailSend) GetChartAttachments() sqlxTypes.JSONText {
+	return r.ChartAttachments
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/cortezaproject/corteza-server/compose/types"
+	sqlxTypes "github.com/jmoiron/sqlx/types"
 )
 
 var _ = chi.URLParam
@@ -46,6 +47,10 @@ type RecordReport struct {
 	rawFilter string
 	Filter    string
 
+	hasJoins bool
+	rawJoins string
+	Joins    sqlxTypes.JSONText
+
 	hasNamespaceID bool
 	rawNamespaceID string
 	NamespaceID    uint64 `json:",string"`
@@ -67,6 +72,7 @@ func (r RecordReport) Auditable() map[string]interface{} {
 	out["metrics"] = r.Metrics
 	out["dimensions"] = r.Dimensions
 	out["filter"] = r.Filter
+	out["joins"] = r.Joins
 	out["namespaceID"] = r.NamespaceID
 	out["moduleID"] = r.ModuleID
 
@@ -116,6 +122,14 @@ func (r *RecordReport) Fill(req *http.Request) (err error) {
 		r.rawFilter = val
 		r.Filter = val
 	}
+	if val, ok := get["joins"]; ok {
+		r.hasJoins = true
+		r.rawJoins = val
+
+		if r.Joins, err = parseJSONTextWithErr(val); err != nil {
+			return err
+		}
+	}
 	r.hasNamespaceID = true
 	r.rawNamespaceID = chi.URLParam(req, "namespaceID")
 	r.NamespaceID = parseUInt64(chi.URLParam(req, "namespaceID"))
@@ -138,6 +152,18 @@ type RecordList struct {
 	rawFilter string
 	Filter    string
 
+	hasQ bool
+	rawQ string
+	Q    string
+
+	hasViewID bool
+	rawViewID string
+	ViewID    uint64 `json:",string"`
+
+	hasViewHandle bool
+	rawViewHandle string
+	ViewHandle    string
+
 	hasDeleted bool
 	rawDeleted string
 	Deleted    uint
@@ -162,6 +188,10 @@ type RecordList struct {
 	rawSort string
 	Sort    string
 
+	hasPageCursor bool
+	rawPageCursor string
+	PageCursor    string
+
 	hasNamespaceID bool
 	rawNamespaceID string
 	NamespaceID    uint64 `json:",string"`
@@ -182,12 +212,16 @@ func (r RecordList) Auditable() map[string]interface{} {
 
 	out["query"] = r.Query
 	out["filter"] = r.Filter
+	out["q"] = r.Q
+	out["viewID"] = r.ViewID
+	out["viewHandle"] = r.ViewHandle
 	out["deleted"] = r.Deleted
 	out["limit"] = r.Limit
 	out["offset"] = r.Offset
 	out["page"] = r.Page
 	out["perPage"] = r.PerPage
 	out["sort"] = r.Sort
+	out["pageCursor"] = r.PageCursor
 	out["namespaceID"] = r.NamespaceID
 	out["moduleID"] = r.ModuleID
 
@@ -232,6 +266,21 @@ func (r *RecordList) Fill(req *http.Request) (err error) {
 		r.rawFilter = val
 		r.Filter = val
 	}
+	if val, ok := get["q"]; ok {
+		r.hasQ = true
+		r.rawQ = val
+		r.Q = val
+	}
+	if val, ok := get["viewID"]; ok {
+		r.hasViewID = true
+		r.rawViewID = val
+		r.ViewID = parseUInt64(val)
+	}
+	if val, ok := get["viewHandle"]; ok {
+		r.hasViewHandle = true
+		r.rawViewHandle = val
+		r.ViewHandle = val
+	}
 	if val, ok := get["deleted"]; ok {
 		r.hasDeleted = true
 		r.rawDeleted = val
@@ -262,6 +311,11 @@ func (r *RecordList) Fill(req *http.Request) (err error) {
 		r.rawSort = val
 		r.Sort = val
 	}
+	if val, ok := get["pageCursor"]; ok {
+		r.hasPageCursor = true
+		r.rawPageCursor = val
+		r.PageCursor = val
+	}
 	r.hasNamespaceID = true
 	r.rawNamespaceID = chi.URLParam(req, "namespaceID")
 	r.NamespaceID = parseUInt64(chi.URLParam(req, "namespaceID"))
@@ -280,6 +334,14 @@ type RecordImportInit struct {
 	rawUpload string
 	Upload    *multipart.FileHeader
 
+	hasSheet bool
+	rawSheet string
+	Sheet    string
+
+	hasHeaderRow bool
+	rawHeaderRow string
+	HeaderRow    uint
+
 	hasNamespaceID bool
 	rawNamespaceID string
 	NamespaceID    uint64 `json:",string"`
@@ -301,6 +363,8 @@ func (r RecordImportInit) Auditable() map[string]interface{} {
 	out["upload.size"] = r.Upload.Size
 	out["upload.filename"] = r.Upload.Filename
 
+	out["sheet"] = r.Sheet
+	out["headerRow"] = r.HeaderRow
 	out["namespaceID"] = r.NamespaceID
 	out["moduleID"] = r.ModuleID
 
@@ -339,6 +403,16 @@ func (r *RecordImportInit) Fill(req *http.Request) (err error) {
 		return errors.Wrap(err, "error processing uploaded file")
 	}
 
+	if val, ok := post["sheet"]; ok {
+		r.hasSheet = true
+		r.rawSheet = val
+		r.Sheet = val
+	}
+	if val, ok := post["headerRow"]; ok {
+		r.hasHeaderRow = true
+		r.rawHeaderRow = val
+		r.HeaderRow = parseUint(val)
+	}
 	r.hasNamespaceID = true
 	r.rawNamespaceID = chi.URLParam(req, "namespaceID")
 	r.NamespaceID = parseUInt64(chi.URLParam(req, "namespaceID"))
@@ -372,6 +446,18 @@ type RecordImportRun struct {
 	hasOnError bool
 	rawOnError string
 	OnError    string
+
+	hasMode bool
+	rawMode string
+	Mode    string
+
+	hasMatchField bool
+	rawMatchField string
+	MatchField    string
+
+	hasDryRun bool
+	rawDryRun string
+	DryRun    bool
 }
 
 // NewRecordImportRun request
@@ -388,6 +474,9 @@ func (r RecordImportRun) Auditable() map[string]interface{} {
 	out["moduleID"] = r.ModuleID
 	out["fields"] = r.Fields
 	out["onError"] = r.OnError
+	out["mode"] = r.Mode
+	out["matchField"] = r.MatchField
+	out["dryRun"] = r.DryRun
 
 	return out
 }
@@ -439,6 +528,21 @@ func (r *RecordImportRun) Fill(req *http.Request) (err error) {
 		r.rawOnError = val
 		r.OnError = val
 	}
+	if val, ok := post["mode"]; ok {
+		r.hasMode = true
+		r.rawMode = val
+		r.Mode = val
+	}
+	if val, ok := post["matchField"]; ok {
+		r.hasMatchField = true
+		r.rawMatchField = val
+		r.MatchField = val
+	}
+	if val, ok := post["dryRun"]; ok {
+		r.hasDryRun = true
+		r.rawDryRun = val
+		r.DryRun = parseBool(val)
+	}
 
 	return err
 }
@@ -519,12 +623,94 @@ func (r *RecordImportProgress) Fill(req *http.Request) (err error) {
 
 var _ RequestFiller = NewRecordImportProgress()
 
+// RecordImportReport request parameters
+type RecordImportReport struct {
+	hasSessionID bool
+	rawSessionID string
+	SessionID    uint64 `json:",string"`
+
+	hasNamespaceID bool
+	rawNamespaceID string
+	NamespaceID    uint64 `json:",string"`
+
+	hasModuleID bool
+	rawModuleID string
+	ModuleID    uint64 `json:",string"`
+}
+
+// NewRecordImportReport request
+func NewRecordImportReport() *RecordImportReport {
+	return &RecordImportReport{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordImportReport) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["sessionID"] = r.SessionID
+	out["namespaceID"] = r.NamespaceID
+	out["moduleID"] = r.ModuleID
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *RecordImportReport) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	r.hasSessionID = true
+	r.rawSessionID = chi.URLParam(req, "sessionID")
+	r.SessionID = parseUInt64(chi.URLParam(req, "sessionID"))
+	r.hasNamespaceID = true
+	r.rawNamespaceID = chi.URLParam(req, "namespaceID")
+	r.NamespaceID = parseUInt64(chi.URLParam(req, "namespaceID"))
+	r.hasModuleID = true
+	r.rawModuleID = chi.URLParam(req, "moduleID")
+	r.ModuleID = parseUInt64(chi.URLParam(req, "moduleID"))
+
+	return err
+}
+
+var _ RequestFiller = NewRecordImportReport()
+
 // RecordExport request parameters
 type RecordExport struct {
 	hasFilter bool
 	rawFilter string
 	Filter    string
 
+	hasViewID bool
+	rawViewID string
+	ViewID    uint64 `json:",string"`
+
+	hasViewHandle bool
+	rawViewHandle string
+	ViewHandle    string
+
 	hasFields bool
 	rawFields []string
 	Fields    []string
@@ -560,6 +746,8 @@ func (r RecordExport) Auditable() map[string]interface{} {
 	var out = map[string]interface{}{}
 
 	out["filter"] = r.Filter
+	out["viewID"] = r.ViewID
+	out["viewHandle"] = r.ViewHandle
 	out["fields"] = r.Fields
 	out["timezone"] = r.Timezone
 	out["filename"] = r.Filename
@@ -603,6 +791,16 @@ func (r *RecordExport) Fill(req *http.Request) (err error) {
 		r.rawFilter = val
 		r.Filter = val
 	}
+	if val, ok := get["viewID"]; ok {
+		r.hasViewID = true
+		r.rawViewID = val
+		r.ViewID = parseUInt64(val)
+	}
+	if val, ok := get["viewHandle"]; ok {
+		r.hasViewHandle = true
+		r.rawViewHandle = val
+		r.ViewHandle = val
+	}
 
 	if val, ok := urlQuery["fields[]"]; ok {
 		r.hasFields = true
@@ -1111,20 +1309,28 @@ func (r *RecordDelete) Fill(req *http.Request) (err error) {
 
 var _ RequestFiller = NewRecordDelete()
 
-// RecordUpload request parameters
-type RecordUpload struct {
+// RecordRevisions request parameters
+type RecordRevisions struct {
+	hasLimit bool
+	rawLimit string
+	Limit    uint
+
+	hasOffset bool
+	rawOffset string
+	Offset    uint
+
+	hasPage bool
+	rawPage string
+	Page    uint
+
+	hasPerPage bool
+	rawPerPage string
+	PerPage    uint
+
 	hasRecordID bool
 	rawRecordID string
 	RecordID    uint64 `json:",string"`
 
-	hasFieldName bool
-	rawFieldName string
-	FieldName    string
-
-	hasUpload bool
-	rawUpload string
-	Upload    *multipart.FileHeader
-
 	hasNamespaceID bool
 	rawNamespaceID string
 	NamespaceID    uint64 `json:",string"`
@@ -1134,20 +1340,20 @@ type RecordUpload struct {
 	ModuleID    uint64 `json:",string"`
 }
 
-// NewRecordUpload request
-func NewRecordUpload() *RecordUpload {
-	return &RecordUpload{}
+// NewRecordRevisions request
+func NewRecordRevisions() *RecordRevisions {
+	return &RecordRevisions{}
 }
 
 // Auditable returns all auditable/loggable parameters
-func (r RecordUpload) Auditable() map[string]interface{} {
+func (r RecordRevisions) Auditable() map[string]interface{} {
 	var out = map[string]interface{}{}
 
+	out["limit"] = r.Limit
+	out["offset"] = r.Offset
+	out["page"] = r.Page
+	out["perPage"] = r.PerPage
 	out["recordID"] = r.RecordID
-	out["fieldName"] = r.FieldName
-	out["upload.size"] = r.Upload.Size
-	out["upload.filename"] = r.Upload.Filename
-
 	out["namespaceID"] = r.NamespaceID
 	out["moduleID"] = r.ModuleID
 
@@ -1155,7 +1361,7 @@ func (r RecordUpload) Auditable() map[string]interface{} {
 }
 
 // Fill processes request and fills internal variables
-func (r *RecordUpload) Fill(req *http.Request) (err error) {
+func (r *RecordRevisions) Fill(req *http.Request) (err error) {
 	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
 		err = json.NewDecoder(req.Body).Decode(r)
 
@@ -1167,7 +1373,7 @@ func (r *RecordUpload) Fill(req *http.Request) (err error) {
 		}
 	}
 
-	if err = req.ParseMultipartForm(32 << 20); err != nil {
+	if err = req.ParseForm(); err != nil {
 		return err
 	}
 
@@ -1182,20 +1388,29 @@ func (r *RecordUpload) Fill(req *http.Request) (err error) {
 		post[name] = string(param[0])
 	}
 
-	if val, ok := post["recordID"]; ok {
-		r.hasRecordID = true
-		r.rawRecordID = val
-		r.RecordID = parseUInt64(val)
+	if val, ok := get["limit"]; ok {
+		r.hasLimit = true
+		r.rawLimit = val
+		r.Limit = parseUint(val)
 	}
-	if val, ok := post["fieldName"]; ok {
-		r.hasFieldName = true
-		r.rawFieldName = val
-		r.FieldName = val
+	if val, ok := get["offset"]; ok {
+		r.hasOffset = true
+		r.rawOffset = val
+		r.Offset = parseUint(val)
 	}
-	if _, r.Upload, err = req.FormFile("upload"); err != nil {
-		return errors.Wrap(err, "error processing uploaded file")
+	if val, ok := get["page"]; ok {
+		r.hasPage = true
+		r.rawPage = val
+		r.Page = parseUint(val)
 	}
-
+	if val, ok := get["perPage"]; ok {
+		r.hasPerPage = true
+		r.rawPerPage = val
+		r.PerPage = parseUint(val)
+	}
+	r.hasRecordID = true
+	r.rawRecordID = chi.URLParam(req, "recordID")
+	r.RecordID = parseUInt64(chi.URLParam(req, "recordID"))
 	r.hasNamespaceID = true
 	r.rawNamespaceID = chi.URLParam(req, "namespaceID")
 	r.NamespaceID = parseUInt64(chi.URLParam(req, "namespaceID"))
@@ -1206,10 +1421,18 @@ func (r *RecordUpload) Fill(req *http.Request) (err error) {
 	return err
 }
 
-var _ RequestFiller = NewRecordUpload()
+var _ RequestFiller = NewRecordRevisions()
+
+// RecordRevisionsDiff request parameters
+type RecordRevisionsDiff struct {
+	hasFrom bool
+	rawFrom string
+	From    uint64 `json:",string"`
+
+	hasTo bool
+	rawTo string
+	To    uint64 `json:",string"`
 
-// RecordTriggerScript request parameters
-type RecordTriggerScript struct {
 	hasRecordID bool
 	rawRecordID string
 	RecordID    uint64 `json:",string"`
@@ -1221,36 +1444,28 @@ type RecordTriggerScript struct {
 	hasModuleID bool
 	rawModuleID string
 	ModuleID    uint64 `json:",string"`
-
-	hasScript bool
-	rawScript string
-	Script    string
-
-	hasValues bool
-	rawValues string
-	Values    types.RecordValueSet
 }
 
-// NewRecordTriggerScript request
-func NewRecordTriggerScript() *RecordTriggerScript {
-	return &RecordTriggerScript{}
+// NewRecordRevisionsDiff request
+func NewRecordRevisionsDiff() *RecordRevisionsDiff {
+	return &RecordRevisionsDiff{}
 }
 
 // Auditable returns all auditable/loggable parameters
-func (r RecordTriggerScript) Auditable() map[string]interface{} {
+func (r RecordRevisionsDiff) Auditable() map[string]interface{} {
 	var out = map[string]interface{}{}
 
+	out["from"] = r.From
+	out["to"] = r.To
 	out["recordID"] = r.RecordID
 	out["namespaceID"] = r.NamespaceID
 	out["moduleID"] = r.ModuleID
-	out["script"] = r.Script
-	out["values"] = r.Values
 
 	return out
 }
 
 // Fill processes request and fills internal variables
-func (r *RecordTriggerScript) Fill(req *http.Request) (err error) {
+func (r *RecordRevisionsDiff) Fill(req *http.Request) (err error) {
 	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
 		err = json.NewDecoder(req.Body).Decode(r)
 
@@ -1277,6 +1492,16 @@ func (r *RecordTriggerScript) Fill(req *http.Request) (err error) {
 		post[name] = string(param[0])
 	}
 
+	if val, ok := get["from"]; ok {
+		r.hasFrom = true
+		r.rawFrom = val
+		r.From = parseUInt64(val)
+	}
+	if val, ok := get["to"]; ok {
+		r.hasTo = true
+		r.rawTo = val
+		r.To = parseUInt64(val)
+	}
 	r.hasRecordID = true
 	r.rawRecordID = chi.URLParam(req, "recordID")
 	r.RecordID = parseUInt64(chi.URLParam(req, "recordID"))
@@ -1286,22 +1511,21 @@ func (r *RecordTriggerScript) Fill(req *http.Request) (err error) {
 	r.hasModuleID = true
 	r.rawModuleID = chi.URLParam(req, "moduleID")
 	r.ModuleID = parseUInt64(chi.URLParam(req, "moduleID"))
-	if val, ok := post["script"]; ok {
-		r.hasScript = true
-		r.rawScript = val
-		r.Script = val
-	}
 
 	return err
 }
 
-var _ RequestFiller = NewRecordTriggerScript()
+var _ RequestFiller = NewRecordRevisionsDiff()
 
-// RecordTriggerScriptOnList request parameters
-type RecordTriggerScriptOnList struct {
-	hasScript bool
-	rawScript string
-	Script    string
+// RecordRevisionRestore request parameters
+type RecordRevisionRestore struct {
+	hasRecordID bool
+	rawRecordID string
+	RecordID    uint64 `json:",string"`
+
+	hasRevisionID bool
+	rawRevisionID string
+	RevisionID    uint64 `json:",string"`
 
 	hasNamespaceID bool
 	rawNamespaceID string
@@ -1312,16 +1536,17 @@ type RecordTriggerScriptOnList struct {
 	ModuleID    uint64 `json:",string"`
 }
 
-// NewRecordTriggerScriptOnList request
-func NewRecordTriggerScriptOnList() *RecordTriggerScriptOnList {
-	return &RecordTriggerScriptOnList{}
+// NewRecordRevisionRestore request
+func NewRecordRevisionRestore() *RecordRevisionRestore {
+	return &RecordRevisionRestore{}
 }
 
 // Auditable returns all auditable/loggable parameters
-func (r RecordTriggerScriptOnList) Auditable() map[string]interface{} {
+func (r RecordRevisionRestore) Auditable() map[string]interface{} {
 	var out = map[string]interface{}{}
 
-	out["script"] = r.Script
+	out["recordID"] = r.RecordID
+	out["revisionID"] = r.RevisionID
 	out["namespaceID"] = r.NamespaceID
 	out["moduleID"] = r.ModuleID
 
@@ -1329,7 +1554,7 @@ func (r RecordTriggerScriptOnList) Auditable() map[string]interface{} {
 }
 
 // Fill processes request and fills internal variables
-func (r *RecordTriggerScriptOnList) Fill(req *http.Request) (err error) {
+func (r *RecordRevisionRestore) Fill(req *http.Request) (err error) {
 	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
 		err = json.NewDecoder(req.Body).Decode(r)
 
@@ -1356,11 +1581,12 @@ func (r *RecordTriggerScriptOnList) Fill(req *http.Request) (err error) {
 		post[name] = string(param[0])
 	}
 
-	if val, ok := post["script"]; ok {
-		r.hasScript = true
-		r.rawScript = val
-		r.Script = val
-	}
+	r.hasRecordID = true
+	r.rawRecordID = chi.URLParam(req, "recordID")
+	r.RecordID = parseUInt64(chi.URLParam(req, "recordID"))
+	r.hasRevisionID = true
+	r.rawRevisionID = chi.URLParam(req, "revisionID")
+	r.RevisionID = parseUInt64(chi.URLParam(req, "revisionID"))
 	r.hasNamespaceID = true
 	r.rawNamespaceID = chi.URLParam(req, "namespaceID")
 	r.NamespaceID = parseUInt64(chi.URLParam(req, "namespaceID"))
@@ -1371,31 +1597,293 @@ func (r *RecordTriggerScriptOnList) Fill(req *http.Request) (err error) {
 	return err
 }
 
-var _ RequestFiller = NewRecordTriggerScriptOnList()
+var _ RequestFiller = NewRecordRevisionRestore()
 
-// HasMetrics returns true if metrics was set
-func (r *RecordReport) HasMetrics() bool {
-	return r.hasMetrics
-}
+// RecordUpload request parameters
+type RecordUpload struct {
+	hasRecordID bool
+	rawRecordID string
+	RecordID    uint64 `json:",string"`
 
-// RawMetrics returns raw value of metrics parameter
-func (r *RecordReport) RawMetrics() string {
-	return r.rawMetrics
-}
+	hasFieldName bool
+	rawFieldName string
+	FieldName    string
 
-// GetMetrics returns casted value of  metrics parameter
-func (r *RecordReport) GetMetrics() string {
-	return r.Metrics
-}
+	hasUpload bool
+	rawUpload string
+	Upload    *multipart.FileHeader
 
-// HasDimensions returns true if dimensions was set
-func (r *RecordReport) HasDimensions() bool {
-	return r.hasDimensions
+	hasNamespaceID bool
+	rawNamespaceID string
+	NamespaceID    uint64 `json:",string"`
+
+	hasModuleID bool
+	rawModuleID string
+	ModuleID    uint64 `json:",string"`
 }
 
-// RawDimensions returns raw value of dimensions parameter
-func (r *RecordReport) RawDimensions() string {
-	return r.rawDimensions
+// NewRecordUpload request
+func NewRecordUpload() *RecordUpload {
+	return &RecordUpload{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordUpload) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["recordID"] = r.RecordID
+	out["fieldName"] = r.FieldName
+	out["upload.size"] = r.Upload.Size
+	out["upload.filename"] = r.Upload.Filename
+
+	out["namespaceID"] = r.NamespaceID
+	out["moduleID"] = r.ModuleID
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *RecordUpload) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseMultipartForm(32 << 20); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	if val, ok := post["recordID"]; ok {
+		r.hasRecordID = true
+		r.rawRecordID = val
+		r.RecordID = parseUInt64(val)
+	}
+	if val, ok := post["fieldName"]; ok {
+		r.hasFieldName = true
+		r.rawFieldName = val
+		r.FieldName = val
+	}
+	if _, r.Upload, err = req.FormFile("upload"); err != nil {
+		return errors.Wrap(err, "error processing uploaded file")
+	}
+
+	r.hasNamespaceID = true
+	r.rawNamespaceID = chi.URLParam(req, "namespaceID")
+	r.NamespaceID = parseUInt64(chi.URLParam(req, "namespaceID"))
+	r.hasModuleID = true
+	r.rawModuleID = chi.URLParam(req, "moduleID")
+	r.ModuleID = parseUInt64(chi.URLParam(req, "moduleID"))
+
+	return err
+}
+
+var _ RequestFiller = NewRecordUpload()
+
+// RecordTriggerScript request parameters
+type RecordTriggerScript struct {
+	hasRecordID bool
+	rawRecordID string
+	RecordID    uint64 `json:",string"`
+
+	hasNamespaceID bool
+	rawNamespaceID string
+	NamespaceID    uint64 `json:",string"`
+
+	hasModuleID bool
+	rawModuleID string
+	ModuleID    uint64 `json:",string"`
+
+	hasScript bool
+	rawScript string
+	Script    string
+
+	hasValues bool
+	rawValues string
+	Values    types.RecordValueSet
+}
+
+// NewRecordTriggerScript request
+func NewRecordTriggerScript() *RecordTriggerScript {
+	return &RecordTriggerScript{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordTriggerScript) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["recordID"] = r.RecordID
+	out["namespaceID"] = r.NamespaceID
+	out["moduleID"] = r.ModuleID
+	out["script"] = r.Script
+	out["values"] = r.Values
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *RecordTriggerScript) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	r.hasRecordID = true
+	r.rawRecordID = chi.URLParam(req, "recordID")
+	r.RecordID = parseUInt64(chi.URLParam(req, "recordID"))
+	r.hasNamespaceID = true
+	r.rawNamespaceID = chi.URLParam(req, "namespaceID")
+	r.NamespaceID = parseUInt64(chi.URLParam(req, "namespaceID"))
+	r.hasModuleID = true
+	r.rawModuleID = chi.URLParam(req, "moduleID")
+	r.ModuleID = parseUInt64(chi.URLParam(req, "moduleID"))
+	if val, ok := post["script"]; ok {
+		r.hasScript = true
+		r.rawScript = val
+		r.Script = val
+	}
+
+	return err
+}
+
+var _ RequestFiller = NewRecordTriggerScript()
+
+// RecordTriggerScriptOnList request parameters
+type RecordTriggerScriptOnList struct {
+	hasScript bool
+	rawScript string
+	Script    string
+
+	hasNamespaceID bool
+	rawNamespaceID string
+	NamespaceID    uint64 `json:",string"`
+
+	hasModuleID bool
+	rawModuleID string
+	ModuleID    uint64 `json:",string"`
+}
+
+// NewRecordTriggerScriptOnList request
+func NewRecordTriggerScriptOnList() *RecordTriggerScriptOnList {
+	return &RecordTriggerScriptOnList{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordTriggerScriptOnList) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["script"] = r.Script
+	out["namespaceID"] = r.NamespaceID
+	out["moduleID"] = r.ModuleID
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *RecordTriggerScriptOnList) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	if val, ok := post["script"]; ok {
+		r.hasScript = true
+		r.rawScript = val
+		r.Script = val
+	}
+	r.hasNamespaceID = true
+	r.rawNamespaceID = chi.URLParam(req, "namespaceID")
+	r.NamespaceID = parseUInt64(chi.URLParam(req, "namespaceID"))
+	r.hasModuleID = true
+	r.rawModuleID = chi.URLParam(req, "moduleID")
+	r.ModuleID = parseUInt64(chi.URLParam(req, "moduleID"))
+
+	return err
+}
+
+var _ RequestFiller = NewRecordTriggerScriptOnList()
+
+// HasMetrics returns true if metrics was set
+func (r *RecordReport) HasMetrics() bool {
+	return r.hasMetrics
+}
+
+// RawMetrics returns raw value of metrics parameter
+func (r *RecordReport) RawMetrics() string {
+	return r.rawMetrics
+}
+
+// GetMetrics returns casted value of  metrics parameter
+func (r *RecordReport) GetMetrics() string {
+	return r.Metrics
+}
+
+// HasDimensions returns true if dimensions was set
+func (r *RecordReport) HasDimensions() bool {
+	return r.hasDimensions
+}
+
+// RawDimensions returns raw value of dimensions parameter
+func (r *RecordReport) RawDimensions() string {
+	return r.rawDimensions
 }
 
 // GetDimensions returns casted value of  dimensions parameter
@@ -1418,6 +1906,21 @@ func (r *RecordReport) GetFilter() string {
 	return r.Filter
 }
 
+// HasJoins returns true if joins was set
+func (r *RecordReport) HasJoins() bool {
+	return r.hasJoins
+}
+
+// RawJoins returns raw value of joins parameter
+func (r *RecordReport) RawJoins() string {
+	return r.rawJoins
+}
+
+// GetJoins returns casted value of  joins parameter
+func (r *RecordReport) GetJoins() sqlxTypes.JSONText {
+	return r.Joins
+}
+
 // HasNamespaceID returns true if namespaceID was set
 func (r *RecordReport) HasNamespaceID() bool {
 	return r.hasNamespaceID
@@ -1478,6 +1981,51 @@ func (r *RecordList) GetFilter() string {
 	return r.Filter
 }
 
+// HasQ returns true if q was set
+func (r *RecordList) HasQ() bool {
+	return r.hasQ
+}
+
+// RawQ returns raw value of q parameter
+func (r *RecordList) RawQ() string {
+	return r.rawQ
+}
+
+// GetQ returns casted value of  q parameter
+func (r *RecordList) GetQ() string {
+	return r.Q
+}
+
+// HasViewID returns true if viewID was set
+func (r *RecordList) HasViewID() bool {
+	return r.hasViewID
+}
+
+// RawViewID returns raw value of viewID parameter
+func (r *RecordList) RawViewID() string {
+	return r.rawViewID
+}
+
+// GetViewID returns casted value of  viewID parameter
+func (r *RecordList) GetViewID() uint64 {
+	return r.ViewID
+}
+
+// HasViewHandle returns true if viewHandle was set
+func (r *RecordList) HasViewHandle() bool {
+	return r.hasViewHandle
+}
+
+// RawViewHandle returns raw value of viewHandle parameter
+func (r *RecordList) RawViewHandle() string {
+	return r.rawViewHandle
+}
+
+// GetViewHandle returns casted value of  viewHandle parameter
+func (r *RecordList) GetViewHandle() string {
+	return r.ViewHandle
+}
+
 // HasDeleted returns true if deleted was set
 func (r *RecordList) HasDeleted() bool {
 	return r.hasDeleted
@@ -1563,9 +2111,24 @@ func (r *RecordList) RawSort() string {
 	return r.rawSort
 }
 
-// GetSort returns casted value of  sort parameter
-func (r *RecordList) GetSort() string {
-	return r.Sort
+// GetSort returns casted value of  sort parameter
+func (r *RecordList) GetSort() string {
+	return r.Sort
+}
+
+// HasPageCursor returns true if pageCursor was set
+func (r *RecordList) HasPageCursor() bool {
+	return r.hasPageCursor
+}
+
+// RawPageCursor returns raw value of pageCursor parameter
+func (r *RecordList) RawPageCursor() string {
+	return r.rawPageCursor
+}
+
+// GetPageCursor returns casted value of  pageCursor parameter
+func (r *RecordList) GetPageCursor() string {
+	return r.PageCursor
 }
 
 // HasNamespaceID returns true if namespaceID was set
@@ -1613,6 +2176,36 @@ func (r *RecordImportInit) GetUpload() *multipart.FileHeader {
 	return r.Upload
 }
 
+// HasSheet returns true if sheet was set
+func (r *RecordImportInit) HasSheet() bool {
+	return r.hasSheet
+}
+
+// RawSheet returns raw value of sheet parameter
+func (r *RecordImportInit) RawSheet() string {
+	return r.rawSheet
+}
+
+// GetSheet returns casted value of  sheet parameter
+func (r *RecordImportInit) GetSheet() string {
+	return r.Sheet
+}
+
+// HasHeaderRow returns true if headerRow was set
+func (r *RecordImportInit) HasHeaderRow() bool {
+	return r.hasHeaderRow
+}
+
+// RawHeaderRow returns raw value of headerRow parameter
+func (r *RecordImportInit) RawHeaderRow() string {
+	return r.rawHeaderRow
+}
+
+// GetHeaderRow returns casted value of  headerRow parameter
+func (r *RecordImportInit) GetHeaderRow() uint {
+	return r.HeaderRow
+}
+
 // HasNamespaceID returns true if namespaceID was set
 func (r *RecordImportInit) HasNamespaceID() bool {
 	return r.hasNamespaceID
@@ -1718,6 +2311,51 @@ func (r *RecordImportRun) GetOnError() string {
 	return r.OnError
 }
 
+// HasMode returns true if mode was set
+func (r *RecordImportRun) HasMode() bool {
+	return r.hasMode
+}
+
+// RawMode returns raw value of mode parameter
+func (r *RecordImportRun) RawMode() string {
+	return r.rawMode
+}
+
+// GetMode returns casted value of  mode parameter
+func (r *RecordImportRun) GetMode() string {
+	return r.Mode
+}
+
+// HasMatchField returns true if matchField was set
+func (r *RecordImportRun) HasMatchField() bool {
+	return r.hasMatchField
+}
+
+// RawMatchField returns raw value of matchField parameter
+func (r *RecordImportRun) RawMatchField() string {
+	return r.rawMatchField
+}
+
+// GetMatchField returns casted value of  matchField parameter
+func (r *RecordImportRun) GetMatchField() string {
+	return r.MatchField
+}
+
+// HasDryRun returns true if dryRun was set
+func (r *RecordImportRun) HasDryRun() bool {
+	return r.hasDryRun
+}
+
+// RawDryRun returns raw value of dryRun parameter
+func (r *RecordImportRun) RawDryRun() string {
+	return r.rawDryRun
+}
+
+// GetDryRun returns casted value of  dryRun parameter
+func (r *RecordImportRun) GetDryRun() bool {
+	return r.DryRun
+}
+
 // HasSessionID returns true if sessionID was set
 func (r *RecordImportProgress) HasSessionID() bool {
 	return r.hasSessionID
@@ -1763,6 +2401,51 @@ func (r *RecordImportProgress) GetModuleID() uint64 {
 	return r.ModuleID
 }
 
+// HasSessionID returns true if sessionID was set
+func (r *RecordImportReport) HasSessionID() bool {
+	return r.hasSessionID
+}
+
+// RawSessionID returns raw value of sessionID parameter
+func (r *RecordImportReport) RawSessionID() string {
+	return r.rawSessionID
+}
+
+// GetSessionID returns casted value of  sessionID parameter
+func (r *RecordImportReport) GetSessionID() uint64 {
+	return r.SessionID
+}
+
+// HasNamespaceID returns true if namespaceID was set
+func (r *RecordImportReport) HasNamespaceID() bool {
+	return r.hasNamespaceID
+}
+
+// RawNamespaceID returns raw value of namespaceID parameter
+func (r *RecordImportReport) RawNamespaceID() string {
+	return r.rawNamespaceID
+}
+
+// GetNamespaceID returns casted value of  namespaceID parameter
+func (r *RecordImportReport) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// HasModuleID returns true if moduleID was set
+func (r *RecordImportReport) HasModuleID() bool {
+	return r.hasModuleID
+}
+
+// RawModuleID returns raw value of moduleID parameter
+func (r *RecordImportReport) RawModuleID() string {
+	return r.rawModuleID
+}
+
+// GetModuleID returns casted value of  moduleID parameter
+func (r *RecordImportReport) GetModuleID() uint64 {
+	return r.ModuleID
+}
+
 // HasFilter returns true if filter was set
 func (r *RecordExport) HasFilter() bool {
 	return r.hasFilter
@@ -1778,6 +2461,36 @@ func (r *RecordExport) GetFilter() string {
 	return r.Filter
 }
 
+// HasViewID returns true if viewID was set
+func (r *RecordExport) HasViewID() bool {
+	return r.hasViewID
+}
+
+// RawViewID returns raw value of viewID parameter
+func (r *RecordExport) RawViewID() string {
+	return r.rawViewID
+}
+
+// GetViewID returns casted value of  viewID parameter
+func (r *RecordExport) GetViewID() uint64 {
+	return r.ViewID
+}
+
+// HasViewHandle returns true if viewHandle was set
+func (r *RecordExport) HasViewHandle() bool {
+	return r.hasViewHandle
+}
+
+// RawViewHandle returns raw value of viewHandle parameter
+func (r *RecordExport) RawViewHandle() string {
+	return r.rawViewHandle
+}
+
+// GetViewHandle returns casted value of  viewHandle parameter
+func (r *RecordExport) GetViewHandle() string {
+	return r.ViewHandle
+}
+
 // HasFields returns true if fields was set
 func (r *RecordExport) HasFields() bool {
 	return r.hasFields
@@ -2213,6 +2926,246 @@ func (r *RecordDelete) GetModuleID() uint64 {
 	return r.ModuleID
 }
 
+// HasLimit returns true if limit was set
+func (r *RecordRevisions) HasLimit() bool {
+	return r.hasLimit
+}
+
+// RawLimit returns raw value of limit parameter
+func (r *RecordRevisions) RawLimit() string {
+	return r.rawLimit
+}
+
+// GetLimit returns casted value of  limit parameter
+func (r *RecordRevisions) GetLimit() uint {
+	return r.Limit
+}
+
+// HasOffset returns true if offset was set
+func (r *RecordRevisions) HasOffset() bool {
+	return r.hasOffset
+}
+
+// RawOffset returns raw value of offset parameter
+func (r *RecordRevisions) RawOffset() string {
+	return r.rawOffset
+}
+
+// GetOffset returns casted value of  offset parameter
+func (r *RecordRevisions) GetOffset() uint {
+	return r.Offset
+}
+
+// HasPage returns true if page was set
+func (r *RecordRevisions) HasPage() bool {
+	return r.hasPage
+}
+
+// RawPage returns raw value of page parameter
+func (r *RecordRevisions) RawPage() string {
+	return r.rawPage
+}
+
+// GetPage returns casted value of  page parameter
+func (r *RecordRevisions) GetPage() uint {
+	return r.Page
+}
+
+// HasPerPage returns true if perPage was set
+func (r *RecordRevisions) HasPerPage() bool {
+	return r.hasPerPage
+}
+
+// RawPerPage returns raw value of perPage parameter
+func (r *RecordRevisions) RawPerPage() string {
+	return r.rawPerPage
+}
+
+// GetPerPage returns casted value of  perPage parameter
+func (r *RecordRevisions) GetPerPage() uint {
+	return r.PerPage
+}
+
+// HasRecordID returns true if recordID was set
+func (r *RecordRevisions) HasRecordID() bool {
+	return r.hasRecordID
+}
+
+// RawRecordID returns raw value of recordID parameter
+func (r *RecordRevisions) RawRecordID() string {
+	return r.rawRecordID
+}
+
+// GetRecordID returns casted value of  recordID parameter
+func (r *RecordRevisions) GetRecordID() uint64 {
+	return r.RecordID
+}
+
+// HasNamespaceID returns true if namespaceID was set
+func (r *RecordRevisions) HasNamespaceID() bool {
+	return r.hasNamespaceID
+}
+
+// RawNamespaceID returns raw value of namespaceID parameter
+func (r *RecordRevisions) RawNamespaceID() string {
+	return r.rawNamespaceID
+}
+
+// GetNamespaceID returns casted value of  namespaceID parameter
+func (r *RecordRevisions) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// HasModuleID returns true if moduleID was set
+func (r *RecordRevisions) HasModuleID() bool {
+	return r.hasModuleID
+}
+
+// RawModuleID returns raw value of moduleID parameter
+func (r *RecordRevisions) RawModuleID() string {
+	return r.rawModuleID
+}
+
+// GetModuleID returns casted value of  moduleID parameter
+func (r *RecordRevisions) GetModuleID() uint64 {
+	return r.ModuleID
+}
+
+// HasFrom returns true if from was set
+func (r *RecordRevisionsDiff) HasFrom() bool {
+	return r.hasFrom
+}
+
+// RawFrom returns raw value of from parameter
+func (r *RecordRevisionsDiff) RawFrom() string {
+	return r.rawFrom
+}
+
+// GetFrom returns casted value of  from parameter
+func (r *RecordRevisionsDiff) GetFrom() uint64 {
+	return r.From
+}
+
+// HasTo returns true if to was set
+func (r *RecordRevisionsDiff) HasTo() bool {
+	return r.hasTo
+}
+
+// RawTo returns raw value of to parameter
+func (r *RecordRevisionsDiff) RawTo() string {
+	return r.rawTo
+}
+
+// GetTo returns casted value of  to parameter
+func (r *RecordRevisionsDiff) GetTo() uint64 {
+	return r.To
+}
+
+// HasRecordID returns true if recordID was set
+func (r *RecordRevisionsDiff) HasRecordID() bool {
+	return r.hasRecordID
+}
+
+// RawRecordID returns raw value of recordID parameter
+func (r *RecordRevisionsDiff) RawRecordID() string {
+	return r.rawRecordID
+}
+
+// GetRecordID returns casted value of  recordID parameter
+func (r *RecordRevisionsDiff) GetRecordID() uint64 {
+	return r.RecordID
+}
+
+// HasNamespaceID returns true if namespaceID was set
+func (r *RecordRevisionsDiff) HasNamespaceID() bool {
+	return r.hasNamespaceID
+}
+
+// RawNamespaceID returns raw value of namespaceID parameter
+func (r *RecordRevisionsDiff) RawNamespaceID() string {
+	return r.rawNamespaceID
+}
+
+// GetNamespaceID returns casted value of  namespaceID parameter
+func (r *RecordRevisionsDiff) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// HasModuleID returns true if moduleID was set
+func (r *RecordRevisionsDiff) HasModuleID() bool {
+	return r.hasModuleID
+}
+
+// RawModuleID returns raw value of moduleID parameter
+func (r *RecordRevisionsDiff) RawModuleID() string {
+	return r.rawModuleID
+}
+
+// GetModuleID returns casted value of  moduleID parameter
+func (r *RecordRevisionsDiff) GetModuleID() uint64 {
+	return r.ModuleID
+}
+
+// HasRecordID returns true if recordID was set
+func (r *RecordRevisionRestore) HasRecordID() bool {
+	return r.hasRecordID
+}
+
+// RawRecordID returns raw value of recordID parameter
+func (r *RecordRevisionRestore) RawRecordID() string {
+	return r.rawRecordID
+}
+
+// GetRecordID returns casted value of  recordID parameter
+func (r *RecordRevisionRestore) GetRecordID() uint64 {
+	return r.RecordID
+}
+
+// HasRevisionID returns true if revisionID was set
+func (r *RecordRevisionRestore) HasRevisionID() bool {
+	return r.hasRevisionID
+}
+
+// RawRevisionID returns raw value of revisionID parameter
+func (r *RecordRevisionRestore) RawRevisionID() string {
+	return r.rawRevisionID
+}
+
+// GetRevisionID returns casted value of  revisionID parameter
+func (r *RecordRevisionRestore) GetRevisionID() uint64 {
+	return r.RevisionID
+}
+
+// HasNamespaceID returns true if namespaceID was set
+func (r *RecordRevisionRestore) HasNamespaceID() bool {
+	return r.hasNamespaceID
+}
+
+// RawNamespaceID returns raw value of namespaceID parameter
+func (r *RecordRevisionRestore) RawNamespaceID() string {
+	return r.rawNamespaceID
+}
+
+// GetNamespaceID returns casted value of  namespaceID parameter
+func (r *RecordRevisionRestore) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// HasModuleID returns true if moduleID was set
+func (r *RecordRevisionRestore) HasModuleID() bool {
+	return r.hasModuleID
+}
+
+// RawModuleID returns raw value of moduleID parameter
+func (r *RecordRevisionRestore) RawModuleID() string {
+	return r.rawModuleID
+}
+
+// GetModuleID returns casted value of  moduleID parameter
+func (r *RecordRevisionRestore) GetModuleID() uint64 {
+	return r.ModuleID
+}
+
 // HasRecordID returns true if recordID was set
 func (r *RecordUpload) HasRecordID() bool {
 	return r.hasRecordID
@@ -0,0 +1,999 @@
+package request
+
+/*
+	Hello! This file is auto-generated from `docs/src/spec.json`.
+
+	For development:
+	In order to update the generated files, edit this file under the location,
+	add your struct fields, imports, API definitions and whatever you want, and:
+
+	1. run [spec](https://github.com/titpetric/spec) in the same folder,
+	2. run `./_gen.php` in this folder.
+
+	You may edit `record_view.go`, `record_view.util.go` or `record_view_test.go` to
+	implement your API calls, helper functions and tests. The file `record_view.go`
+	is only generated the first time, and will not be overwritten if it exists.
+*/
+
+import (
+	"io"
+	"strings"
+
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/pkg/errors"
+
+	"time"
+)
+
+var _ = chi.URLParam
+var _ = multipart.FileHeader{}
+
+// RecordViewList request parameters
+type RecordViewList struct {
+	hasQuery bool
+	rawQuery string
+	Query    string
+
+	hasHandle bool
+	rawHandle string
+	Handle    string
+
+	hasModuleID bool
+	rawModuleID string
+	ModuleID    uint64 `json:",string"`
+
+	hasLimit bool
+	rawLimit string
+	Limit    uint
+
+	hasOffset bool
+	rawOffset string
+	Offset    uint
+
+	hasPage bool
+	rawPage string
+	Page    uint
+
+	hasPerPage bool
+	rawPerPage string
+	PerPage    uint
+
+	hasSort bool
+	rawSort string
+	Sort    string
+
+	hasNamespaceID bool
+	rawNamespaceID string
+	NamespaceID    uint64 `json:",string"`
+}
+
+// NewRecordViewList request
+func NewRecordViewList() *RecordViewList {
+	return &RecordViewList{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordViewList) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["query"] = r.Query
+	out["handle"] = r.Handle
+	out["moduleID"] = r.ModuleID
+	out["limit"] = r.Limit
+	out["offset"] = r.Offset
+	out["page"] = r.Page
+	out["perPage"] = r.PerPage
+	out["sort"] = r.Sort
+	out["namespaceID"] = r.NamespaceID
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *RecordViewList) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	if val, ok := get["query"]; ok {
+		r.hasQuery = true
+		r.rawQuery = val
+		r.Query = val
+	}
+	if val, ok := get["handle"]; ok {
+		r.hasHandle = true
+		r.rawHandle = val
+		r.Handle = val
+	}
+	if val, ok := get["moduleID"]; ok {
+		r.hasModuleID = true
+		r.rawModuleID = val
+		r.ModuleID = parseUInt64(val)
+	}
+	if val, ok := get["limit"]; ok {
+		r.hasLimit = true
+		r.rawLimit = val
+		r.Limit = parseUint(val)
+	}
+	if val, ok := get["offset"]; ok {
+		r.hasOffset = true
+		r.rawOffset = val
+		r.Offset = parseUint(val)
+	}
+	if val, ok := get["page"]; ok {
+		r.hasPage = true
+		r.rawPage = val
+		r.Page = parseUint(val)
+	}
+	if val, ok := get["perPage"]; ok {
+		r.hasPerPage = true
+		r.rawPerPage = val
+		r.PerPage = parseUint(val)
+	}
+	if val, ok := get["sort"]; ok {
+		r.hasSort = true
+		r.rawSort = val
+		r.Sort = val
+	}
+	r.hasNamespaceID = true
+	r.rawNamespaceID = chi.URLParam(req, "namespaceID")
+	r.NamespaceID = parseUInt64(chi.URLParam(req, "namespaceID"))
+
+	return err
+}
+
+var _ RequestFiller = NewRecordViewList()
+
+// RecordViewCreate request parameters
+type RecordViewCreate struct {
+	hasName bool
+	rawName string
+	Name    string
+
+	hasHandle bool
+	rawHandle string
+	Handle    string
+
+	hasModuleID bool
+	rawModuleID string
+	ModuleID    uint64 `json:",string"`
+
+	hasFilter bool
+	rawFilter string
+	Filter    string
+
+	hasSort bool
+	rawSort string
+	Sort    string
+
+	hasColumns bool
+	rawColumns []string
+	Columns    []string
+
+	hasNamespaceID bool
+	rawNamespaceID string
+	NamespaceID    uint64 `json:",string"`
+}
+
+// NewRecordViewCreate request
+func NewRecordViewCreate() *RecordViewCreate {
+	return &RecordViewCreate{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordViewCreate) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["name"] = r.Name
+	out["handle"] = r.Handle
+	out["moduleID"] = r.ModuleID
+	out["filter"] = r.Filter
+	out["sort"] = r.Sort
+	out["columns"] = r.Columns
+	out["namespaceID"] = r.NamespaceID
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *RecordViewCreate) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	if val, ok := post["name"]; ok {
+		r.hasName = true
+		r.rawName = val
+		r.Name = val
+	}
+	if val, ok := post["handle"]; ok {
+		r.hasHandle = true
+		r.rawHandle = val
+		r.Handle = val
+	}
+	if val, ok := post["moduleID"]; ok {
+		r.hasModuleID = true
+		r.rawModuleID = val
+		r.ModuleID = parseUInt64(val)
+	}
+	if val, ok := post["filter"]; ok {
+		r.hasFilter = true
+		r.rawFilter = val
+		r.Filter = val
+	}
+	if val, ok := post["sort"]; ok {
+		r.hasSort = true
+		r.rawSort = val
+		r.Sort = val
+	}
+
+	if val, ok := req.Form["columns"]; ok {
+		r.hasColumns = true
+		r.rawColumns = val
+		r.Columns = parseStrings(val)
+	}
+
+	r.hasNamespaceID = true
+	r.rawNamespaceID = chi.URLParam(req, "namespaceID")
+	r.NamespaceID = parseUInt64(chi.URLParam(req, "namespaceID"))
+
+	return err
+}
+
+var _ RequestFiller = NewRecordViewCreate()
+
+// RecordViewRead request parameters
+type RecordViewRead struct {
+	hasViewID bool
+	rawViewID string
+	ViewID    uint64 `json:",string"`
+
+	hasNamespaceID bool
+	rawNamespaceID string
+	NamespaceID    uint64 `json:",string"`
+}
+
+// NewRecordViewRead request
+func NewRecordViewRead() *RecordViewRead {
+	return &RecordViewRead{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordViewRead) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["viewID"] = r.ViewID
+	out["namespaceID"] = r.NamespaceID
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *RecordViewRead) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	r.hasViewID = true
+	r.rawViewID = chi.URLParam(req, "viewID")
+	r.ViewID = parseUInt64(chi.URLParam(req, "viewID"))
+	r.hasNamespaceID = true
+	r.rawNamespaceID = chi.URLParam(req, "namespaceID")
+	r.NamespaceID = parseUInt64(chi.URLParam(req, "namespaceID"))
+
+	return err
+}
+
+var _ RequestFiller = NewRecordViewRead()
+
+// RecordViewUpdate request parameters
+type RecordViewUpdate struct {
+	hasViewID bool
+	rawViewID string
+	ViewID    uint64 `json:",string"`
+
+	hasNamespaceID bool
+	rawNamespaceID string
+	NamespaceID    uint64 `json:",string"`
+
+	hasName bool
+	rawName string
+	Name    string
+
+	hasHandle bool
+	rawHandle string
+	Handle    string
+
+	hasModuleID bool
+	rawModuleID string
+	ModuleID    uint64 `json:",string"`
+
+	hasFilter bool
+	rawFilter string
+	Filter    string
+
+	hasSort bool
+	rawSort string
+	Sort    string
+
+	hasColumns bool
+	rawColumns []string
+	Columns    []string
+
+	hasUpdatedAt bool
+	rawUpdatedAt string
+	UpdatedAt    *time.Time
+}
+
+// NewRecordViewUpdate request
+func NewRecordViewUpdate() *RecordViewUpdate {
+	return &RecordViewUpdate{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordViewUpdate) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["viewID"] = r.ViewID
+	out["namespaceID"] = r.NamespaceID
+	out["name"] = r.Name
+	out["handle"] = r.Handle
+	out["moduleID"] = r.ModuleID
+	out["filter"] = r.Filter
+	out["sort"] = r.Sort
+	out["columns"] = r.Columns
+	out["updatedAt"] = r.UpdatedAt
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *RecordViewUpdate) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	r.hasViewID = true
+	r.rawViewID = chi.URLParam(req, "viewID")
+	r.ViewID = parseUInt64(chi.URLParam(req, "viewID"))
+	r.hasNamespaceID = true
+	r.rawNamespaceID = chi.URLParam(req, "namespaceID")
+	r.NamespaceID = parseUInt64(chi.URLParam(req, "namespaceID"))
+	if val, ok := post["name"]; ok {
+		r.hasName = true
+		r.rawName = val
+		r.Name = val
+	}
+	if val, ok := post["handle"]; ok {
+		r.hasHandle = true
+		r.rawHandle = val
+		r.Handle = val
+	}
+	if val, ok := post["moduleID"]; ok {
+		r.hasModuleID = true
+		r.rawModuleID = val
+		r.ModuleID = parseUInt64(val)
+	}
+	if val, ok := post["filter"]; ok {
+		r.hasFilter = true
+		r.rawFilter = val
+		r.Filter = val
+	}
+	if val, ok := post["sort"]; ok {
+		r.hasSort = true
+		r.rawSort = val
+		r.Sort = val
+	}
+
+	if val, ok := req.Form["columns"]; ok {
+		r.hasColumns = true
+		r.rawColumns = val
+		r.Columns = parseStrings(val)
+	}
+
+	if val, ok := post["updatedAt"]; ok {
+		r.hasUpdatedAt = true
+		r.rawUpdatedAt = val
+
+		if r.UpdatedAt, err = parseISODatePtrWithErr(val); err != nil {
+			return err
+		}
+	}
+
+	return err
+}
+
+var _ RequestFiller = NewRecordViewUpdate()
+
+// RecordViewDelete request parameters
+type RecordViewDelete struct {
+	hasViewID bool
+	rawViewID string
+	ViewID    uint64 `json:",string"`
+
+	hasNamespaceID bool
+	rawNamespaceID string
+	NamespaceID    uint64 `json:",string"`
+}
+
+// NewRecordViewDelete request
+func NewRecordViewDelete() *RecordViewDelete {
+	return &RecordViewDelete{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordViewDelete) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["viewID"] = r.ViewID
+	out["namespaceID"] = r.NamespaceID
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *RecordViewDelete) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	r.hasViewID = true
+	r.rawViewID = chi.URLParam(req, "viewID")
+	r.ViewID = parseUInt64(chi.URLParam(req, "viewID"))
+	r.hasNamespaceID = true
+	r.rawNamespaceID = chi.URLParam(req, "namespaceID")
+	r.NamespaceID = parseUInt64(chi.URLParam(req, "namespaceID"))
+
+	return err
+}
+
+var _ RequestFiller = NewRecordViewDelete()
+
+// HasQuery returns true if query was set
+func (r *RecordViewList) HasQuery() bool {
+	return r.hasQuery
+}
+
+// RawQuery returns raw value of query parameter
+func (r *RecordViewList) RawQuery() string {
+	return r.rawQuery
+}
+
+// GetQuery returns casted value of  query parameter
+func (r *RecordViewList) GetQuery() string {
+	return r.Query
+}
+
+// HasHandle returns true if handle was set
+func (r *RecordViewList) HasHandle() bool {
+	return r.hasHandle
+}
+
+// RawHandle returns raw value of handle parameter
+func (r *RecordViewList) RawHandle() string {
+	return r.rawHandle
+}
+
+// GetHandle returns casted value of  handle parameter
+func (r *RecordViewList) GetHandle() string {
+	return r.Handle
+}
+
+// HasModuleID returns true if moduleID was set
+func (r *RecordViewList) HasModuleID() bool {
+	return r.hasModuleID
+}
+
+// RawModuleID returns raw value of moduleID parameter
+func (r *RecordViewList) RawModuleID() string {
+	return r.rawModuleID
+}
+
+// GetModuleID returns casted value of  moduleID parameter
+func (r *RecordViewList) GetModuleID() uint64 {
+	return r.ModuleID
+}
+
+// HasLimit returns true if limit was set
+func (r *RecordViewList) HasLimit() bool {
+	return r.hasLimit
+}
+
+// RawLimit returns raw value of limit parameter
+func (r *RecordViewList) RawLimit() string {
+	return r.rawLimit
+}
+
+// GetLimit returns casted value of  limit parameter
+func (r *RecordViewList) GetLimit() uint {
+	return r.Limit
+}
+
+// HasOffset returns true if offset was set
+func (r *RecordViewList) HasOffset() bool {
+	return r.hasOffset
+}
+
+// RawOffset returns raw value of offset parameter
+func (r *RecordViewList) RawOffset() string {
+	return r.rawOffset
+}
+
+// GetOffset returns casted value of  offset parameter
+func (r *RecordViewList) GetOffset() uint {
+	return r.Offset
+}
+
+// HasPage returns true if page was set
+func (r *RecordViewList) HasPage() bool {
+	return r.hasPage
+}
+
+// RawPage returns raw value of page parameter
+func (r *RecordViewList) RawPage() string {
+	return r.rawPage
+}
+
+// GetPage returns casted value of  page parameter
+func (r *RecordViewList) GetPage() uint {
+	return r.Page
+}
+
+// HasPerPage returns true if perPage was set
+func (r *RecordViewList) HasPerPage() bool {
+	return r.hasPerPage
+}
+
+// RawPerPage returns raw value of perPage parameter
+func (r *RecordViewList) RawPerPage() string {
+	return r.rawPerPage
+}
+
+// GetPerPage returns casted value of  perPage parameter
+func (r *RecordViewList) GetPerPage() uint {
+	return r.PerPage
+}
+
+// HasSort returns true if sort was set
+func (r *RecordViewList) HasSort() bool {
+	return r.hasSort
+}
+
+// RawSort returns raw value of sort parameter
+func (r *RecordViewList) RawSort() string {
+	return r.rawSort
+}
+
+// GetSort returns casted value of  sort parameter
+func (r *RecordViewList) GetSort() string {
+	return r.Sort
+}
+
+// HasNamespaceID returns true if namespaceID was set
+func (r *RecordViewList) HasNamespaceID() bool {
+	return r.hasNamespaceID
+}
+
+// RawNamespaceID returns raw value of namespaceID parameter
+func (r *RecordViewList) RawNamespaceID() string {
+	return r.rawNamespaceID
+}
+
+// GetNamespaceID returns casted value of  namespaceID parameter
+func (r *RecordViewList) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// HasName returns true if name was set
+func (r *RecordViewCreate) HasName() bool {
+	return r.hasName
+}
+
+// RawName returns raw value of name parameter
+func (r *RecordViewCreate) RawName() string {
+	return r.rawName
+}
+
+// GetName returns casted value of  name parameter
+func (r *RecordViewCreate) GetName() string {
+	return r.Name
+}
+
+// HasHandle returns true if handle was set
+func (r *RecordViewCreate) HasHandle() bool {
+	return r.hasHandle
+}
+
+// RawHandle returns raw value of handle parameter
+func (r *RecordViewCreate) RawHandle() string {
+	return r.rawHandle
+}
+
+// GetHandle returns casted value of  handle parameter
+func (r *RecordViewCreate) GetHandle() string {
+	return r.Handle
+}
+
+// HasModuleID returns true if moduleID was set
+func (r *RecordViewCreate) HasModuleID() bool {
+	return r.hasModuleID
+}
+
+// RawModuleID returns raw value of moduleID parameter
+func (r *RecordViewCreate) RawModuleID() string {
+	return r.rawModuleID
+}
+
+// GetModuleID returns casted value of  moduleID parameter
+func (r *RecordViewCreate) GetModuleID() uint64 {
+	return r.ModuleID
+}
+
+// HasFilter returns true if filter was set
+func (r *RecordViewCreate) HasFilter() bool {
+	return r.hasFilter
+}
+
+// RawFilter returns raw value of filter parameter
+func (r *RecordViewCreate) RawFilter() string {
+	return r.rawFilter
+}
+
+// GetFilter returns casted value of  filter parameter
+func (r *RecordViewCreate) GetFilter() string {
+	return r.Filter
+}
+
+// HasSort returns true if sort was set
+func (r *RecordViewCreate) HasSort() bool {
+	return r.hasSort
+}
+
+// RawSort returns raw value of sort parameter
+func (r *RecordViewCreate) RawSort() string {
+	return r.rawSort
+}
+
+// GetSort returns casted value of  sort parameter
+func (r *RecordViewCreate) GetSort() string {
+	return r.Sort
+}
+
+// HasColumns returns true if columns was set
+func (r *RecordViewCreate) HasColumns() bool {
+	return r.hasColumns
+}
+
+// RawColumns returns raw value of columns parameter
+func (r *RecordViewCreate) RawColumns() []string {
+	return r.rawColumns
+}
+
+// GetColumns returns casted value of  columns parameter
+func (r *RecordViewCreate) GetColumns() []string {
+	return r.Columns
+}
+
+// HasNamespaceID returns true if namespaceID was set
+func (r *RecordViewCreate) HasNamespaceID() bool {
+	return r.hasNamespaceID
+}
+
+// RawNamespaceID returns raw value of namespaceID parameter
+func (r *RecordViewCreate) RawNamespaceID() string {
+	return r.rawNamespaceID
+}
+
+// GetNamespaceID returns casted value of  namespaceID parameter
+func (r *RecordViewCreate) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// HasViewID returns true if viewID was set
+func (r *RecordViewRead) HasViewID() bool {
+	return r.hasViewID
+}
+
+// RawViewID returns raw value of viewID parameter
+func (r *RecordViewRead) RawViewID() string {
+	return r.rawViewID
+}
+
+// GetViewID returns casted value of  viewID parameter
+func (r *RecordViewRead) GetViewID() uint64 {
+	return r.ViewID
+}
+
+// HasNamespaceID returns true if namespaceID was set
+func (r *RecordViewRead) HasNamespaceID() bool {
+	return r.hasNamespaceID
+}
+
+// RawNamespaceID returns raw value of namespaceID parameter
+func (r *RecordViewRead) RawNamespaceID() string {
+	return r.rawNamespaceID
+}
+
+// GetNamespaceID returns casted value of  namespaceID parameter
+func (r *RecordViewRead) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// HasViewID returns true if viewID was set
+func (r *RecordViewUpdate) HasViewID() bool {
+	return r.hasViewID
+}
+
+// RawViewID returns raw value of viewID parameter
+func (r *RecordViewUpdate) RawViewID() string {
+	return r.rawViewID
+}
+
+// GetViewID returns casted value of  viewID parameter
+func (r *RecordViewUpdate) GetViewID() uint64 {
+	return r.ViewID
+}
+
+// HasNamespaceID returns true if namespaceID was set
+func (r *RecordViewUpdate) HasNamespaceID() bool {
+	return r.hasNamespaceID
+}
+
+// RawNamespaceID returns raw value of namespaceID parameter
+func (r *RecordViewUpdate) RawNamespaceID() string {
+	return r.rawNamespaceID
+}
+
+// GetNamespaceID returns casted value of  namespaceID parameter
+func (r *RecordViewUpdate) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// HasName returns true if name was set
+func (r *RecordViewUpdate) HasName() bool {
+	return r.hasName
+}
+
+// RawName returns raw value of name parameter
+func (r *RecordViewUpdate) RawName() string {
+	return r.rawName
+}
+
+// GetName returns casted value of  name parameter
+func (r *RecordViewUpdate) GetName() string {
+	return r.Name
+}
+
+// HasHandle returns true if handle was set
+func (r *RecordViewUpdate) HasHandle() bool {
+	return r.hasHandle
+}
+
+// RawHandle returns raw value of handle parameter
+func (r *RecordViewUpdate) RawHandle() string {
+	return r.rawHandle
+}
+
+// GetHandle returns casted value of  handle parameter
+func (r *RecordViewUpdate) GetHandle() string {
+	return r.Handle
+}
+
+// HasModuleID returns true if moduleID was set
+func (r *RecordViewUpdate) HasModuleID() bool {
+	return r.hasModuleID
+}
+
+// RawModuleID returns raw value of moduleID parameter
+func (r *RecordViewUpdate) RawModuleID() string {
+	return r.rawModuleID
+}
+
+// GetModuleID returns casted value of  moduleID parameter
+func (r *RecordViewUpdate) GetModuleID() uint64 {
+	return r.ModuleID
+}
+
+// HasFilter returns true if filter was set
+func (r *RecordViewUpdate) HasFilter() bool {
+	return r.hasFilter
+}
+
+// RawFilter returns raw value of filter parameter
+func (r *RecordViewUpdate) RawFilter() string {
+	return r.rawFilter
+}
+
+// GetFilter returns casted value of  filter parameter
+func (r *RecordViewUpdate) GetFilter() string {
+	return r.Filter
+}
+
+// HasSort returns true if sort was set
+func (r *RecordViewUpdate) HasSort() bool {
+	return r.hasSort
+}
+
+// RawSort returns raw value of sort parameter
+func (r *RecordViewUpdate) RawSort() string {
+	return r.rawSort
+}
+
+// GetSort returns casted value of  sort parameter
+func (r *RecordViewUpdate) GetSort() string {
+	return r.Sort
+}
+
+// HasColumns returns true if columns was set
+func (r *RecordViewUpdate) HasColumns() bool {
+	return r.hasColumns
+}
+
+// RawColumns returns raw value of columns parameter
+func (r *RecordViewUpdate) RawColumns() []string {
+	return r.rawColumns
+}
+
+// GetColumns returns casted value of  columns parameter
+func (r *RecordViewUpdate) GetColumns() []string {
+	return r.Columns
+}
+
+// HasUpdatedAt returns true if updatedAt was set
+func (r *RecordViewUpdate) HasUpdatedAt() bool {
+	return r.hasUpdatedAt
+}
+
+// RawUpdatedAt returns raw value of updatedAt parameter
+func (r *RecordViewUpdate) RawUpdatedAt() string {
+	return r.rawUpdatedAt
+}
+
+// GetUpdatedAt returns casted value of  updatedAt parameter
+func (r *RecordViewUpdate) GetUpdatedAt() *time.Time {
+	return r.UpdatedAt
+}
+
+// HasViewID returns true if viewID was set
+func (r *RecordViewDelete) HasViewID() bool {
+	return r.hasViewID
+}
+
+// RawViewID returns raw value of viewID parameter
+func (r *RecordViewDelete) RawViewID() string {
+	return r.rawViewID
+}
+
+// GetViewID returns casted value of  viewID parameter
+func (r *RecordViewDelete) GetViewID() uint64 {
+	return r.ViewID
+}
+
+// HasNamespaceID returns true if namespaceID was set
+func (r *RecordViewDelete) HasNamespaceID() bool {
+	return r.hasNamespaceID
+}
+
+// RawNamespaceID returns raw value of namespaceID parameter
+func (r *RecordViewDelete) RawNamespaceID() string {
+	return r.rawNamespaceID
+}
+
+// GetNamespaceID returns casted value of  namespaceID parameter
+func (r *RecordViewDelete) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
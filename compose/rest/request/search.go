@@ -0,0 +1,256 @@
+package request
+
+/*
+	Hello! This file is auto-generated from `docs/src/spec.json`.
+
+	For development:
+	In order to update the generated files, edit this file under the location,
+	add your struct fields, imports, API definitions and whatever you want, and:
+
+	1. run [spec](https://github.com/titpetric/spec) in the same folder,
+	2. run `./_gen.php` in this folder.
+
+	You may edit `search.go`, `search.util.go` or `search_test.go` to
+	implement your API calls, helper functions and tests. The file `search.go`
+	is only generated the first time, and will not be overwritten if it exists.
+*/
+
+import (
+	"io"
+	"strings"
+
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/pkg/errors"
+)
+
+var _ = chi.URLParam
+var _ = multipart.FileHeader{}
+
+// SearchAttachments request parameters
+type SearchAttachments struct {
+	hasNamespaceID bool
+	rawNamespaceID string
+	NamespaceID    uint64 `json:",string"`
+
+	hasKind bool
+	rawKind string
+	Kind    string
+
+	hasLimit bool
+	rawLimit string
+	Limit    uint
+
+	hasOffset bool
+	rawOffset string
+	Offset    uint
+
+	hasPage bool
+	rawPage string
+	Page    uint
+
+	hasPerPage bool
+	rawPerPage string
+	PerPage    uint
+
+	hasQuery bool
+	rawQuery string
+	Query    string
+}
+
+// NewSearchAttachments request
+func NewSearchAttachments() *SearchAttachments {
+	return &SearchAttachments{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r SearchAttachments) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["namespaceID"] = r.NamespaceID
+	out["kind"] = r.Kind
+	out["limit"] = r.Limit
+	out["offset"] = r.Offset
+	out["page"] = r.Page
+	out["perPage"] = r.PerPage
+	out["query"] = r.Query
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *SearchAttachments) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	if val, ok := get["namespaceID"]; ok {
+		r.hasNamespaceID = true
+		r.rawNamespaceID = val
+		r.NamespaceID = parseUInt64(val)
+	}
+	if val, ok := get["kind"]; ok {
+		r.hasKind = true
+		r.rawKind = val
+		r.Kind = val
+	}
+	if val, ok := get["limit"]; ok {
+		r.hasLimit = true
+		r.rawLimit = val
+		r.Limit = parseUint(val)
+	}
+	if val, ok := get["offset"]; ok {
+		r.hasOffset = true
+		r.rawOffset = val
+		r.Offset = parseUint(val)
+	}
+	if val, ok := get["page"]; ok {
+		r.hasPage = true
+		r.rawPage = val
+		r.Page = parseUint(val)
+	}
+	if val, ok := get["perPage"]; ok {
+		r.hasPerPage = true
+		r.rawPerPage = val
+		r.PerPage = parseUint(val)
+	}
+	if val, ok := get["query"]; ok {
+		r.hasQuery = true
+		r.rawQuery = val
+		r.Query = val
+	}
+
+	return err
+}
+
+var _ RequestFiller = NewSearchAttachments()
+
+// HasNamespaceID returns true if namespaceID was set
+func (r *SearchAttachments) HasNamespaceID() bool {
+	return r.hasNamespaceID
+}
+
+// RawNamespaceID returns raw value of namespaceID parameter
+func (r *SearchAttachments) RawNamespaceID() string {
+	return r.rawNamespaceID
+}
+
+// GetNamespaceID returns casted value of  namespaceID parameter
+func (r *SearchAttachments) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// HasKind returns true if kind was set
+func (r *SearchAttachments) HasKind() bool {
+	return r.hasKind
+}
+
+// RawKind returns raw value of kind parameter
+func (r *SearchAttachments) RawKind() string {
+	return r.rawKind
+}
+
+// GetKind returns casted value of  kind parameter
+func (r *SearchAttachments) GetKind() string {
+	return r.Kind
+}
+
+// HasLimit returns true if limit was set
+func (r *SearchAttachments) HasLimit() bool {
+	return r.hasLimit
+}
+
+// RawLimit returns raw value of limit parameter
+func (r *SearchAttachments) RawLimit() string {
+	return r.rawLimit
+}
+
+// GetLimit returns casted value of  limit parameter
+func (r *SearchAttachments) GetLimit() uint {
+	return r.Limit
+}
+
+// HasOffset returns true if offset was set
+func (r *SearchAttachments) HasOffset() bool {
+	return r.hasOffset
+}
+
+// RawOffset returns raw value of offset parameter
+func (r *SearchAttachments) RawOffset() string {
+	return r.rawOffset
+}
+
+// GetOffset returns casted value of  offset parameter
+func (r *SearchAttachments) GetOffset() uint {
+	return r.Offset
+}
+
+// HasPage returns true if page was set
+func (r *SearchAttachments) HasPage() bool {
+	return r.hasPage
+}
+
+// RawPage returns raw value of page parameter
+func (r *SearchAttachments) RawPage() string {
+	return r.rawPage
+}
+
+// GetPage returns casted value of  page parameter
+func (r *SearchAttachments) GetPage() uint {
+	return r.Page
+}
+
+// HasPerPage returns true if perPage was set
+func (r *SearchAttachments) HasPerPage() bool {
+	return r.hasPerPage
+}
+
+// RawPerPage returns raw value of perPage parameter
+func (r *SearchAttachments) RawPerPage() string {
+	return r.rawPerPage
+}
+
+// GetPerPage returns casted value of  perPage parameter
+func (r *SearchAttachments) GetPerPage() uint {
+	return r.PerPage
+}
+
+// HasQuery returns true if query was set
+func (r *SearchAttachments) HasQuery() bool {
+	return r.hasQuery
+}
+
+// RawQuery returns raw value of query parameter
+func (r *SearchAttachments) RawQuery() string {
+	return r.rawQuery
+}
+
+// GetQuery returns casted value of  query parameter
+func (r *SearchAttachments) GetQuery() string {
+	return r.Query
+}
@@ -14,6 +14,7 @@ func MountRoutes(r chi.Router) {
 		record       = Record{}.New()
 		page         = Page{}.New()
 		chart        = Chart{}.New()
+		recordView   = RecordView{}.New()
 		notification = Notification{}.New()
 		attachment   = Attachment{}.New()
 		automation   = Automation{}.New()
@@ -36,8 +37,10 @@ func MountRoutes(r chi.Router) {
 		handlers.NewModule(module).MountRoutes(r)
 		handlers.NewRecord(record).MountRoutes(r)
 		handlers.NewChart(chart).MountRoutes(r)
+		handlers.NewRecordView(recordView).MountRoutes(r)
 		handlers.NewNotification(notification).MountRoutes(r)
 		handlers.NewPermissions(Permissions{}.New()).MountRoutes(r)
 		handlers.NewSettings(Settings{}.New()).MountRoutes(r)
+		handlers.NewSearch(Search{}.New()).MountRoutes(r)
 	})
 }
@@ -0,0 +1,40 @@
+package rest
+
+import (
+	"context"
+
+	"github.com/cortezaproject/corteza-server/compose/rest/request"
+	"github.com/cortezaproject/corteza-server/compose/service"
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/rh"
+
+	"github.com/pkg/errors"
+)
+
+var _ = errors.Wrap
+
+type (
+	Search struct {
+		attachment service.AttachmentService
+	}
+)
+
+func (Search) New() *Search {
+	return &Search{
+		attachment: service.DefaultAttachment,
+	}
+}
+
+// Attachments searches content of attachments from all readable namespaces
+func (ctrl Search) Attachments(ctx context.Context, r *request.SearchAttachments) (interface{}, error) {
+	f := types.AttachmentFilter{
+		NamespaceID: r.NamespaceID,
+		Kind:        r.Kind,
+		Query:       r.Query,
+
+		PageFilter: rh.Paging(r),
+	}
+
+	set, filter, err := ctrl.attachment.With(ctx).Search(f)
+	return Attachment{}.makeFilterPayload(ctx, set, filter, err)
+}
@@ -110,6 +110,10 @@ func (svc accessControl) CanReadRecordValue(ctx context.Context, r *types.Module
 	return svc.can(ctx, r, "record.value.read", permissions.Allowed)
 }
 
+func (svc accessControl) FilterReadableRecordValues(ctx context.Context) *permissions.ResourceFilter {
+	return svc.permissions.ResourceFilter(ctx, types.ModuleFieldPermissionResource, "record.value.read", permissions.Allow)
+}
+
 func (svc accessControl) CanUpdateRecordValue(ctx context.Context, r *types.ModuleField) bool {
 	return svc.can(ctx, r, "record.value.update", permissions.Allowed)
 }
@@ -122,6 +126,10 @@ func (svc accessControl) CanReadRecord(ctx context.Context, r *types.Module) boo
 	return svc.can(ctx, r, "record.read")
 }
 
+func (svc accessControl) FilterReadableRecords(ctx context.Context) *permissions.ResourceFilter {
+	return svc.permissions.ResourceFilter(ctx, types.ModulePermissionResource, "record.read", permissions.Deny)
+}
+
 func (svc accessControl) CanUpdateRecord(ctx context.Context, r *types.Module) bool {
 	return svc.can(ctx, r, "record.update")
 }
@@ -154,6 +162,26 @@ func (svc accessControl) CanDeleteChart(ctx context.Context, r *types.Chart) boo
 	return svc.can(ctx, r, "delete")
 }
 
+func (svc accessControl) CanCreateRecordView(ctx context.Context, r *types.Namespace) bool {
+	return svc.can(ctx, r, "record-view.create")
+}
+
+func (svc accessControl) CanReadRecordView(ctx context.Context, r *types.RecordView) bool {
+	return svc.can(ctx, r, "read")
+}
+
+func (svc accessControl) FilterReadableRecordViews(ctx context.Context) *permissions.ResourceFilter {
+	return svc.permissions.ResourceFilter(ctx, types.RecordViewPermissionResource, "read", permissions.Deny)
+}
+
+func (svc accessControl) CanUpdateRecordView(ctx context.Context, r *types.RecordView) bool {
+	return svc.can(ctx, r, "update")
+}
+
+func (svc accessControl) CanDeleteRecordView(ctx context.Context, r *types.RecordView) bool {
+	return svc.can(ctx, r, "delete")
+}
+
 func (svc accessControl) CanCreatePage(ctx context.Context, r *types.Namespace) bool {
 	// @todo move to func args when namespaces are implemented
 	return svc.can(ctx, r, "page.create")
@@ -236,6 +264,7 @@ func (svc accessControl) Whitelist() permissions.Whitelist {
 		"module.create",
 		"chart.create",
 		"page.create",
+		"record-view.create",
 	)
 
 	wl.Set(
@@ -262,6 +291,13 @@ func (svc accessControl) Whitelist() permissions.Whitelist {
 		"delete",
 	)
 
+	wl.Set(
+		types.RecordViewPermissionResource,
+		"read",
+		"update",
+		"delete",
+	)
+
 	wl.Set(
 		types.PagePermissionResource,
 		"read",
@@ -3,10 +3,10 @@ package service
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"image"
 	"image/gif"
 	"io"
-	"net/http"
 	"path"
 	"strings"
 
@@ -14,12 +14,19 @@ import (
 	"github.com/edwvee/exiffix"
 	"github.com/pkg/errors"
 	"github.com/titpetric/factory"
+	"go.uber.org/zap"
 
 	"github.com/cortezaproject/corteza-server/compose/repository"
 	"github.com/cortezaproject/corteza-server/compose/types"
 	"github.com/cortezaproject/corteza-server/pkg/actionlog"
 	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/lock"
+	"github.com/cortezaproject/corteza-server/pkg/mime"
+	"github.com/cortezaproject/corteza-server/pkg/permissions"
+	"github.com/cortezaproject/corteza-server/pkg/preview"
+	"github.com/cortezaproject/corteza-server/pkg/scan"
 	"github.com/cortezaproject/corteza-server/pkg/store"
+	"github.com/cortezaproject/corteza-server/pkg/textract"
 )
 
 const (
@@ -34,7 +41,9 @@ type (
 
 		actionlog actionlog.Recorder
 
-		store store.Store
+		store   store.Store
+		scanner scan.Scanner
+		quota   *AttachmentQuota
 
 		ac attachmentAccessController
 
@@ -54,6 +63,11 @@ type (
 		CanReadRecord(context.Context, *types.Module) bool
 		CanUpdateRecord(context.Context, *types.Module) bool
 		CanCreateRecord(context.Context, *types.Module) bool
+
+		FilterReadableNamespaces(ctx context.Context) *permissions.ResourceFilter
+		FilterReadablePages(ctx context.Context) *permissions.ResourceFilter
+		FilterReadableRecords(ctx context.Context) *permissions.ResourceFilter
+		FilterReadableRecordValues(ctx context.Context) *permissions.ResourceFilter
 	}
 
 	AttachmentService interface {
@@ -61,18 +75,40 @@ type (
 
 		FindByID(namespaceID, attachmentID uint64) (*types.Attachment, error)
 		Find(filter types.AttachmentFilter) (types.AttachmentSet, types.AttachmentFilter, error)
+		Search(filter types.AttachmentFilter) (types.AttachmentSet, types.AttachmentFilter, error)
 		CreatePageAttachment(namespaceID uint64, name string, size int64, fh io.ReadSeeker, pageID uint64) (*types.Attachment, error)
 		CreateRecordAttachment(namespaceID uint64, name string, size int64, fh io.ReadSeeker, moduleID, recordID uint64, fieldName string) (*types.Attachment, error)
 		OpenOriginal(att *types.Attachment) (io.ReadSeeker, error)
 		OpenPreview(att *types.Attachment) (io.ReadSeeker, error)
+		PresignedURL(att *types.Attachment, preview, download bool) (string, error)
 		DeleteByID(namespaceID, attachmentID uint64) error
 	}
+
+	// AttachmentQuota limits number of bytes stored per namespace and per user
+	//
+	// User's usage only includes attachments stored in compose
+	// (see options.AttachmentOpt.ComposeUserQuota).
+	// Only sizes of the original files are counted, not of their previews
+	// (see options.AttachmentOpt).
+	// Limit is not enforced when set to 0
+	AttachmentQuota struct {
+		Namespace int64
+		User      int64
+	}
+)
+
+var (
+	// Serializes quota checks of uploads handled by this server instance
+	// before they wait for the usage lock in the database (see quotaKeys)
+	attachmentQuotaLocks = &lock.Keyed{}
 )
 
 func Attachment(store store.Store) AttachmentService {
 	return (&attachment{
-		store: store,
-		ac:    DefaultAccessControl,
+		store:   store,
+		scanner: DefaultUploadScanner,
+		quota:   DefaultAttachmentQuota,
+		ac:      DefaultAccessControl,
 	}).With(context.Background())
 }
 
@@ -84,8 +120,10 @@ func (svc attachment) With(ctx context.Context) AttachmentService {
 
 		actionlog: DefaultActionlog,
 
-		ac:    svc.ac,
-		store: svc.store,
+		ac:      svc.ac,
+		store:   svc.store,
+		scanner: svc.scanner,
+		quota:   svc.quota,
 
 		attachmentRepo: repository.Attachment(ctx, db),
 		recordRepo:     repository.Record(ctx, db),
@@ -175,6 +213,42 @@ func (svc attachment) Find(filter types.AttachmentFilter) (set types.AttachmentS
 	return set, f, svc.recordAction(svc.ctx, aProps, AttachmentActionSearch, err)
 }
 
+// Search finds attachments by their (indexed) content
+//
+// Only attachments from readable namespaces, pages and records
+// (referenced through the fields with readable values) are returned
+func (svc attachment) Search(filter types.AttachmentFilter) (set types.AttachmentSet, f types.AttachmentFilter, err error) {
+	var (
+		aProps = &attachmentActionProps{filter: &filter}
+	)
+
+	err = svc.db.Transaction(func() error {
+		filter.Query = strings.TrimSpace(filter.Query)
+		if filter.Query == "" {
+			return AttachmentErrInvalidQuery()
+		}
+
+		if filter.NamespaceID > 0 {
+			ns, err := svc.findNamespaceByID(filter.NamespaceID)
+			if err != nil {
+				return err
+			}
+
+			aProps.setNamespace(ns)
+		}
+
+		filter.IsReadable = svc.ac.FilterReadableNamespaces(svc.ctx).Build("a.rel_namespace")
+		filter.IsPageReadable = svc.ac.FilterReadablePages(svc.ctx).Build("p.id")
+		filter.IsRecordReadable = svc.ac.FilterReadableRecords(svc.ctx).Build("r.module_id")
+		filter.IsRecordValueReadable = svc.ac.FilterReadableRecordValues(svc.ctx).Build("f.id")
+
+		set, f, err = svc.attachmentRepo.Search(filter)
+		return err
+	})
+
+	return set, f, svc.recordAction(svc.ctx, aProps, AttachmentActionSearch, err)
+}
+
 func (svc attachment) findNamespaceByID(namespaceID uint64) (ns *types.Namespace, err error) {
 	if namespaceID == 0 {
 		return nil, AttachmentErrInvalidNamespaceID()
@@ -259,6 +333,22 @@ func (svc attachment) OpenPreview(att *types.Attachment) (io.ReadSeeker, error)
 	return svc.store.Open(att.PreviewUrl)
 }
 
+// PresignedURL returns short-lived URL for downloading the file directly from the store
+//
+// Empty string is returned when store does not support it and file needs to be streamed
+func (svc attachment) PresignedURL(att *types.Attachment, preview, download bool) (string, error) {
+	var filename = att.Url
+	if preview {
+		filename = att.PreviewUrl
+	}
+
+	if len(filename) == 0 {
+		return "", nil
+	}
+
+	return store.PresignedGet(svc.store, filename, store.ContentDisposition(att.Name, download))
+}
+
 func (svc attachment) CreatePageAttachment(namespaceID uint64, name string, size int64, fh io.ReadSeeker, pageID uint64) (att *types.Attachment, err error) {
 	var (
 		ns *types.Namespace
@@ -270,7 +360,7 @@ func (svc attachment) CreatePageAttachment(namespaceID uint64, name string, size
 		}
 	)
 
-	err = svc.db.Transaction(func() error {
+	err = func() error {
 		ns, err = svc.findNamespaceByID(namespaceID)
 		if err != nil {
 			return err
@@ -291,12 +381,17 @@ func (svc attachment) CreatePageAttachment(namespaceID uint64, name string, size
 
 		att = &types.Attachment{
 			NamespaceID: namespaceID,
+			PageID:      pageID,
 			Name:        strings.TrimSpace(name),
 			Kind:        types.PageAttachment,
 		}
 
 		return svc.create(name, size, fh, att)
-	})
+	}()
+
+	if err == nil {
+		svc.processDocument(att)
+	}
 
 	return att, svc.recordAction(svc.ctx, aProps, AttachmentActionCreate, err)
 
@@ -314,7 +409,7 @@ func (svc attachment) CreateRecordAttachment(namespaceID uint64, name string, si
 		}
 	)
 
-	err = svc.db.Transaction(func() (err error) {
+	err = func() (err error) {
 		ns, err = svc.findNamespaceByID(namespaceID)
 		if err != nil {
 			return err
@@ -362,7 +457,11 @@ func (svc attachment) CreateRecordAttachment(namespaceID uint64, name string, si
 		}
 
 		return svc.create(name, size, fh, att)
-	})
+	}()
+
+	if err == nil {
+		svc.processDocument(att)
+	}
 
 	return att, svc.recordAction(svc.ctx, aProps, AttachmentActionCreate, err)
 
@@ -390,11 +489,28 @@ func (svc attachment) create(name string, size int64, fh io.ReadSeeker, att *typ
 	// Extract extension but make sure path.Ext is not confused by any leading/trailing dots
 	att.Meta.Original.Extension = strings.Trim(path.Ext(strings.Trim(name, ".")), ".")
 
+	att.Size = size
 	att.Meta.Original.Size = size
-	if att.Meta.Original.Mimetype, err = svc.extractMimetype(fh); err != nil {
+
+	// Fail early, before the file is scanned and stored
+	if err = svc.checkQuota(att, aProps); err != nil {
+		return err
+	}
+
+	if att.Meta.Original.Mimetype, err = mime.Detect(fh); err != nil {
 		return AttachmentErrFailedToExtractMimeType(aProps).Wrap(err)
 	}
 
+	if svc.scanner != nil {
+		// Inspect the file before it is stored
+		err = svc.scanner.Scan(fh, att.Meta.Original.Mimetype, att.Meta.Original.Extension)
+		if scan.IsRejected(err) {
+			return AttachmentErrRejected(aProps).Wrap(err)
+		} else if err != nil {
+			return AttachmentErrFailedToScanFile(aProps).Wrap(err)
+		}
+	}
+
 	att.Url = svc.store.Original(att.ID, att.Meta.Original.Extension)
 	aProps.setUrl(att.Url)
 
@@ -408,34 +524,129 @@ func (svc attachment) create(name string, size int64, fh io.ReadSeeker, att *typ
 		return AttachmentErrFailedToProcessImage(aProps).Wrap(err)
 	}
 
-	if att, err = svc.attachmentRepo.Create(att); err != nil {
-		return
+	err = func() error {
+		var keys = svc.quotaKeys(att)
+
+		defer attachmentQuotaLocks.Lock(keys...)()
+
+		return svc.db.Transaction(func() (err error) {
+			// Quota is checked again, serialized with the other uploads,
+			// until the attachment is created
+			if err = svc.attachmentRepo.LockUsage(keys...); err != nil {
+				return err
+			}
+
+			if err = svc.checkQuota(att, aProps); err != nil {
+				return err
+			}
+
+			_, err = svc.attachmentRepo.Create(att)
+			return err
+		})
+	}()
+
+	if err != nil {
+		svc.removeStored(att)
+		return err
 	}
 
-	return nil
+	return svc.indexContent(fh, att)
 }
 
-func (svc attachment) extractMimetype(file io.ReadSeeker) (mimetype string, err error) {
-	if _, err = file.Seek(0, 0); err != nil {
-		return
+// removeStored removes files of the attachment that could not be created
+func (svc attachment) removeStored(att *types.Attachment) {
+	for _, filename := range []string{att.Url, att.PreviewUrl} {
+		if filename == "" {
+			continue
+		}
+
+		if err := svc.store.Remove(filename); err != nil {
+			DefaultLogger.Named("attachment").Warn(
+				"could not remove stored file",
+				zap.Uint64("attachmentID", att.ID),
+				zap.String("filename", filename),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// enabled checks if any of the limits is set
+func (q *AttachmentQuota) enabled() bool {
+	return q != nil && (q.Namespace > 0 || q.User > 0)
+}
+
+// quotaKeys returns keys of the attachment's namespace and its owner
+// (when quota is enforced on them) for locking their usage
+//
+// Namespace is always locked before the user
+func (svc attachment) quotaKeys(att *types.Attachment) (keys []string) {
+	if !svc.quota.enabled() {
+		return nil
 	}
 
-	// Make sure we rewind when we're done
-	defer file.Seek(0, 0)
+	if svc.quota.Namespace > 0 {
+		keys = append(keys, fmt.Sprintf("namespace:%d", att.NamespaceID))
+	}
 
-	// See http.DetectContentType about 512 bytes
-	var buf = make([]byte, 512)
-	if _, err = file.Read(buf); err != nil {
-		return
+	if svc.quota.User > 0 {
+		keys = append(keys, fmt.Sprintf("user:%d", att.OwnerID))
+	}
+
+	return keys
+}
+
+// checkQuota makes sure that storing the attachment
+// does not exceed namespace or user quota
+//
+// Authoritative check must be made under the usage lock (see quotaKeys),
+// in the same transaction the attachment is created
+func (svc attachment) checkQuota(att *types.Attachment, aProps *attachmentActionProps) error {
+	if !svc.quota.enabled() {
+		return nil
+	}
+
+	if svc.quota.Namespace > 0 {
+		used, err := svc.attachmentRepo.Usage(att.NamespaceID, 0)
+		if err != nil {
+			return err
+		}
+
+		if used+att.Size > svc.quota.Namespace {
+			return AttachmentErrNamespaceQuotaExceeded(aProps)
+		}
+	}
+
+	if svc.quota.User > 0 {
+		used, err := svc.attachmentRepo.Usage(0, att.OwnerID)
+		if err != nil {
+			return err
+		}
+
+		if used+att.Size > svc.quota.User {
+			return AttachmentErrUserQuotaExceeded(aProps)
+		}
+	}
+
+	return nil
+}
+
+// indexContent stores text extracted from the attachment for searching
+//
+// Failed extraction is not fatal, attachment is just not indexed
+func (svc attachment) indexContent(original io.ReadSeeker, att *types.Attachment) error {
+	text, err := textract.Extract(original, att.Meta.Original.Mimetype, att.Meta.Original.Extension)
+	if err != nil || text == "" {
+		return nil
 	}
 
-	return http.DetectContentType(buf), nil
+	return svc.attachmentRepo.IndexContent(att.ID, text)
 }
 
 func (svc attachment) processImage(original io.ReadSeeker, att *types.Attachment) (err error) {
 	if !strings.HasPrefix(att.Meta.Original.Mimetype, "image/") {
-		// Only supporting previews from images (for now)
-		return
+		// Previews of documents are made in the background (see processDocument)
+		return nil
 	}
 
 	var (
@@ -530,4 +741,41 @@ func (svc attachment) processImage(original io.ReadSeeker, att *types.Attachment
 	return svc.store.Save(att.PreviewUrl, buf)
 }
 
+// processDocument makes preview of PDFs (first page), plain-text & office documents (text snippet)
+//
+// Previews of documents are made in the background, after attachment is stored (see preview.Queue)
+func (svc attachment) processDocument(att *types.Attachment) {
+	// Attachment is returned to the caller, preview is set on a copy
+	var doc = *att
+
+	preview.Queue(preview.Document{
+		ID:        doc.ID,
+		Mimetype:  doc.Meta.Original.Mimetype,
+		Extension: doc.Meta.Original.Extension,
+		MaxWidth:  attachmentPreviewMaxWidth,
+		MaxHeight: attachmentPreviewMaxHeight,
+
+		Open: func() (io.ReadSeeker, error) {
+			return svc.store.Open(doc.Url)
+		},
+
+		Save: func(buf *bytes.Buffer, width, height int) error {
+			meta := doc.SetPreviewImageMeta(width, height, false)
+			meta.Size = int64(buf.Len())
+			meta.Mimetype = "image/jpeg"
+			meta.Extension = "jpg"
+
+			doc.PreviewUrl = svc.store.Preview(doc.ID, meta.Extension)
+
+			if err := svc.store.Save(doc.PreviewUrl, buf); err != nil {
+				return err
+			}
+
+			// Request that stored the attachment is done by now
+			ctx := context.Background()
+			return repository.Attachment(ctx, repository.DB(ctx)).UpdatePreview(&doc)
+		},
+	})
+}
+
 var _ AttachmentService = &attachment{}
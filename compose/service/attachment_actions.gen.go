@@ -199,6 +199,7 @@ func (p attachmentActionProps) serialize() actionlog.Meta {
 	}
 	if p.filter != nil {
 		m.Set("filter.filter", p.filter.Filter, true)
+		m.Set("filter.query", p.filter.Query, true)
 		m.Set("filter.kind", p.filter.Kind, true)
 		m.Set("filter.sort", p.filter.Sort, true)
 	}
@@ -298,11 +299,13 @@ func (p attachmentActionProps) tr(in string, err error) string {
 			"{filter}",
 			fns(
 				p.filter.Filter,
+				p.filter.Query,
 				p.filter.Kind,
 				p.filter.Sort,
 			),
 		)
 		pairs = append(pairs, "{filter.filter}", fns(p.filter.Filter))
+		pairs = append(pairs, "{filter.query}", fns(p.filter.Query))
 		pairs = append(pairs, "{filter.kind}", fns(p.filter.Kind))
 		pairs = append(pairs, "{filter.sort}", fns(p.filter.Sort))
 	}
@@ -902,6 +905,36 @@ func AttachmentErrInvalidRecordID(props ...*attachmentActionProps) *attachmentEr
 
 }
 
+// AttachmentErrInvalidQuery returns "compose:attachment.invalidQuery" audit event as actionlog.Warning
+//
+//
+// This function is auto-generated.
+//
+func AttachmentErrInvalidQuery(props ...*attachmentActionProps) *attachmentError {
+	var e = &attachmentError{
+		timestamp: time.Now(),
+		resource:  "compose:attachment",
+		error:     "invalidQuery",
+		action:    "error",
+		message:   "search query is required",
+		log:       "search query is required",
+		severity:  actionlog.Warning,
+		props: func() *attachmentActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
 // AttachmentErrNotAllowedToListAttachments returns "compose:attachment.notAllowedToListAttachments" audit event as actionlog.Alert
 //
 //
@@ -1052,6 +1085,126 @@ func AttachmentErrFailedToProcessImage(props ...*attachmentActionProps) *attachm
 
 }
 
+// AttachmentErrFailedToScanFile returns "compose:attachment.failedToScanFile" audit event as actionlog.Alert
+//
+//
+// This function is auto-generated.
+//
+func AttachmentErrFailedToScanFile(props ...*attachmentActionProps) *attachmentError {
+	var e = &attachmentError{
+		timestamp: time.Now(),
+		resource:  "compose:attachment",
+		error:     "failedToScanFile",
+		action:    "error",
+		message:   "could not scan file",
+		log:       "could not scan file",
+		severity:  actionlog.Alert,
+		props: func() *attachmentActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
+// AttachmentErrRejected returns "compose:attachment.rejected" audit event as actionlog.Alert
+//
+//
+// This function is auto-generated.
+//
+func AttachmentErrRejected(props ...*attachmentActionProps) *attachmentError {
+	var e = &attachmentError{
+		timestamp: time.Now(),
+		resource:  "compose:attachment",
+		error:     "rejected",
+		action:    "error",
+		message:   "{err}",
+		log:       "rejected upload of {name}: {err}",
+		severity:  actionlog.Alert,
+		props: func() *attachmentActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
+// AttachmentErrNamespaceQuotaExceeded returns "compose:attachment.namespaceQuotaExceeded" audit event as actionlog.Alert
+//
+//
+// This function is auto-generated.
+//
+func AttachmentErrNamespaceQuotaExceeded(props ...*attachmentActionProps) *attachmentError {
+	var e = &attachmentError{
+		timestamp: time.Now(),
+		resource:  "compose:attachment",
+		error:     "namespaceQuotaExceeded",
+		action:    "error",
+		message:   "namespace storage quota exceeded",
+		log:       "could not store {name} ({size} bytes), namespace storage quota exceeded",
+		severity:  actionlog.Alert,
+		props: func() *attachmentActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
+// AttachmentErrUserQuotaExceeded returns "compose:attachment.userQuotaExceeded" audit event as actionlog.Alert
+//
+//
+// This function is auto-generated.
+//
+func AttachmentErrUserQuotaExceeded(props ...*attachmentActionProps) *attachmentError {
+	var e = &attachmentError{
+		timestamp: time.Now(),
+		resource:  "compose:attachment",
+		error:     "userQuotaExceeded",
+		action:    "error",
+		message:   "user storage quota exceeded",
+		log:       "could not store {name} ({size} bytes), user storage quota exceeded",
+		severity:  actionlog.Alert,
+		props: func() *attachmentActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
 // AttachmentErrNotAllowedToReadModule returns "compose:attachment.notAllowedToReadModule" audit event as actionlog.Alert
 //
 //
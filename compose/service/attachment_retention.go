@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/cortezaproject/corteza-server/compose/repository"
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/app/options"
+	"github.com/cortezaproject/corteza-server/pkg/retention"
+	"github.com/cortezaproject/corteza-server/pkg/store"
+)
+
+type (
+	// attachmentRetention removes orphaned attachments
+	// (deleted ones and ones of deleted records) with their files
+	attachmentRetention struct {
+		logger *zap.Logger
+		store  store.Store
+
+		days     int
+		interval time.Duration
+		dryRun   bool
+	}
+
+	// AttachmentRetentionReport lists orphaned attachments found by the retention
+	AttachmentRetentionReport struct {
+		retention.Report
+
+		Attachments types.AttachmentSet
+	}
+)
+
+func AttachmentRetention(s store.Store, opt options.AttachmentOpt) *attachmentRetention {
+	return &attachmentRetention{
+		logger: DefaultLogger.Named("attachment-retention"),
+		store:  s,
+
+		days:     opt.RetentionDays,
+		interval: opt.RetentionInterval,
+		dryRun:   opt.RetentionDryRun,
+	}
+}
+
+// Days returns configured retention period
+func (svc *attachmentRetention) Days() int {
+	return svc.days
+}
+
+// Watch periodically removes (or only reports in dry-run mode) orphaned attachments
+//
+// Nothing is done when retention is not configured
+func (svc *attachmentRetention) Watch(ctx context.Context) {
+	retention.Watch(ctx, svc.logger, svc.days, svc.interval, svc.dryRun, func(ctx context.Context, before time.Time, dryRun bool) {
+		_, _ = svc.Apply(ctx, before, dryRun)
+	})
+}
+
+// Apply finds attachments orphaned before the given time and removes them
+//
+// Attachments are only reported (logged & returned) in dry-run mode
+func (svc *attachmentRetention) Apply(ctx context.Context, before time.Time, dryRun bool) (r *AttachmentRetentionReport, err error) {
+	var (
+		repo = repository.Attachment(ctx, repository.DB(ctx))
+	)
+
+	r = &AttachmentRetentionReport{}
+
+	if r.Attachments, err = repo.FindOrphaned(before); err != nil {
+		svc.logger.Error("could not load orphaned attachments", zap.Error(err))
+		return nil, err
+	}
+
+	oo := make([]retention.Orphan, len(r.Attachments))
+	for i, att := range r.Attachments {
+		oo[i] = retention.Orphan{
+			ID:    att.ID,
+			Size:  att.Meta.Original.Size,
+			Files: []string{att.Url, att.PreviewUrl},
+			Fields: []zap.Field{
+				zap.Uint64("namespaceID", att.NamespaceID),
+				zap.String("name", att.Name),
+			},
+		}
+	}
+
+	r.Report = retention.Apply(svc.logger, svc.store, before, dryRun, oo, repo.Purge)
+	return r, nil
+}
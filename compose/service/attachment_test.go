@@ -0,0 +1,26 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortezaproject/corteza-server/compose/types"
+)
+
+func TestAttachmentQuotaKeys(t *testing.T) {
+	var (
+		req = require.New(t)
+		svc = attachment{quota: &AttachmentQuota{Namespace: 100, User: 100}}
+		att = &types.Attachment{NamespaceID: 1, OwnerID: 2}
+	)
+
+	req.Equal([]string{"namespace:1", "user:2"}, svc.quotaKeys(att))
+
+	svc.quota = &AttachmentQuota{User: 100}
+	req.Equal([]string{"user:2"}, svc.quotaKeys(att))
+
+	// Nothing is locked without quota
+	svc.quota = &AttachmentQuota{}
+	req.Empty(svc.quotaKeys(att))
+}
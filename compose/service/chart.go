@@ -1,13 +1,19 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/titpetric/factory"
 
 	"github.com/cortezaproject/corteza-server/compose/repository"
 	"github.com/cortezaproject/corteza-server/compose/types"
 	"github.com/cortezaproject/corteza-server/pkg/actionlog"
+	chartRenderer "github.com/cortezaproject/corteza-server/pkg/chart"
 	"github.com/cortezaproject/corteza-server/pkg/handle"
 	"github.com/cortezaproject/corteza-server/pkg/permissions"
 )
@@ -21,6 +27,8 @@ type (
 
 		ac chartAccessController
 
+		record RecordService
+
 		chartRepo repository.ChartRepository
 		nsRepo    repository.NamespaceRepository
 	}
@@ -45,12 +53,26 @@ type (
 		Create(chart *types.Chart) (*types.Chart, error)
 		Update(chart *types.Chart) (*types.Chart, error)
 		DeleteByID(namespaceID, chartID uint64) error
+
+		Render(namespaceID, chartID uint64, format string, width, height int) ([]byte, error)
 	}
 )
 
+const (
+	chartRenderWidth  = 800
+	chartRenderHeight = 400
+)
+
+var (
+	// Fields & aggregate functions of the chart report are used to build report query
+	chartReportField     = regexp.MustCompile(`^[A-Za-z][0-9A-Za-z_]*(\.[A-Za-z][0-9A-Za-z_]*)?$`)
+	chartReportAggregate = regexp.MustCompile(`^[A-Za-z]+$`)
+)
+
 func Chart() ChartService {
 	return (&chart{
-		ac: DefaultAccessControl,
+		ac:     DefaultAccessControl,
+		record: DefaultRecord,
 	}).With(context.Background())
 }
 
@@ -64,6 +86,8 @@ func (svc chart) With(ctx context.Context) ChartService {
 
 		ac: svc.ac,
 
+		record: svc.record,
+
 		chartRepo: repository.Chart(ctx, db),
 		nsRepo:    repository.Namespace(ctx, db),
 	}
@@ -275,6 +299,51 @@ func (svc chart) DeleteByID(namespaceID, chartID uint64) (err error) {
 
 }
 
+// Render runs chart's reports and renders the chart as an image (png or svg)
+//
+// Records are reported with permissions of the current user
+func (svc chart) Render(namespaceID, chartID uint64, format string, width, height int) (out []byte, err error) {
+	var (
+		c      *types.Chart
+		rc     *chartRenderer.Chart
+		aProps = &chartActionProps{chart: &types.Chart{ID: chartID, NamespaceID: namespaceID}}
+	)
+
+	if width == 0 && height == 0 {
+		width, height = chartRenderWidth, chartRenderHeight
+	}
+
+	err = func() error {
+		if !chartRenderer.IsValidFormat(format) {
+			return ChartErrInvalidRenderFormat()
+		}
+
+		if width < chartRenderer.MinSize || width > chartRenderer.MaxSize || height < chartRenderer.MinSize || height > chartRenderer.MaxSize {
+			return ChartErrInvalidRenderSize()
+		}
+
+		if c, err = svc.FindByID(namespaceID, chartID); err != nil {
+			return err
+		}
+
+		aProps.setChart(c)
+
+		if rc, err = svc.renderable(c); err != nil {
+			return err
+		}
+
+		buf := &bytes.Buffer{}
+		if err = chartRenderer.Render(buf, rc, format, width, height); err != nil {
+			return err
+		}
+
+		out = buf.Bytes()
+		return nil
+	}()
+
+	return out, svc.recordAction(svc.ctx, aProps, ChartActionRender, err)
+}
+
 func (svc chart) UniqueCheck(c *types.Chart) (err error) {
 	if c.Handle != "" {
 		if e, _ := svc.chartRepo.FindByHandle(c.NamespaceID, c.Handle); e != nil && e.ID != c.ID {
@@ -300,3 +369,168 @@ func (svc chart) loadNamespace(namespaceID uint64) (ns *types.Namespace, err err
 
 	return
 }
+
+// renderable runs reports of the chart and converts results into datasets
+//
+// Each report metric is converted into one dataset; rows of all reports
+// are matched by the value of the report dimension (chart labels)
+func (svc chart) renderable(c *types.Chart) (*chartRenderer.Chart, error) {
+	var (
+		out = &chartRenderer.Chart{
+			Title:  c.Name,
+			Colors: chartRenderer.Scheme(c.Config.ColorScheme),
+		}
+
+		labels = map[string]int{}
+	)
+
+	for _, r := range c.Config.Reports {
+		metrics, dimensions, columns, err := chartReportQuery(r)
+		if err != nil {
+			return nil, err
+		}
+
+		report, err := svc.record.With(svc.ctx).Report(c.NamespaceID, r.ModuleID, r.Joins, metrics, dimensions, r.Filter)
+		if err != nil {
+			return nil, err
+		}
+
+		rows, _ := report.([]map[string]interface{})
+
+		dd := make([]*chartRenderer.Dataset, len(r.Metrics))
+		for i, m := range r.Metrics {
+			dd[i] = chartDataset(m)
+			out.Datasets = append(out.Datasets, dd[i])
+		}
+
+		for _, row := range rows {
+			label := chartReportLabel(row["dimension_0"], r.Dimensions[0]["default"])
+
+			l, has := labels[label]
+			if !has {
+				l = len(out.Labels)
+				labels[label] = l
+				out.Labels = append(out.Labels, label)
+			}
+
+			for i, ds := range dd {
+				for len(ds.Values) <= l {
+					ds.Values = append(ds.Values, 0)
+				}
+
+				ds.Values[l] = chartReportValue(row[columns[i]])
+			}
+		}
+	}
+
+	// Rows of some reports might not cover all labels
+	for _, ds := range out.Datasets {
+		for len(ds.Values) < len(out.Labels) {
+			ds.Values = append(ds.Values, 0)
+		}
+	}
+
+	return out, nil
+}
+
+// chartReportQuery builds report metrics & dimensions from the chart report configuration
+//
+// Returns name of the report column for each of the metrics;
+// "count" field uses count column that is included in every report
+func chartReportQuery(r *types.ChartConfigReport) (metrics, dimensions string, columns []string, err error) {
+	var mm []string
+
+	if len(r.Metrics) == 0 || len(r.Dimensions) == 0 {
+		return "", "", nil, ChartErrInvalidReport()
+	}
+
+	for i, m := range r.Metrics {
+		field, _ := m["field"].(string)
+		if field == "count" {
+			columns = append(columns, "count")
+			continue
+		}
+
+		aggregate, _ := m["aggregate"].(string)
+		if aggregate == "" {
+			aggregate = "SUM"
+		}
+
+		if !chartReportField.MatchString(field) || !chartReportAggregate.MatchString(aggregate) {
+			return "", "", nil, ChartErrInvalidReport()
+		}
+
+		columns = append(columns, fmt.Sprintf("metric_%d", i))
+		mm = append(mm, fmt.Sprintf("%s(%s) AS %s", strings.ToUpper(aggregate), field, columns[i]))
+	}
+
+	field, _ := r.Dimensions[0]["field"].(string)
+	if !chartReportField.MatchString(field) {
+		return "", "", nil, ChartErrInvalidReport()
+	}
+
+	modifier, _ := r.Dimensions[0]["modifier"].(string)
+	switch strings.ToUpper(modifier) {
+	case "DATE":
+		dimensions = fmt.Sprintf("DATE_FORMAT(%s, '%%Y-%%m-%%d')", field)
+	case "WEEK":
+		dimensions = fmt.Sprintf("DATE_FORMAT(%s, '%%x-%%v')", field)
+	case "MONTH":
+		dimensions = fmt.Sprintf("DATE_FORMAT(%s, '%%Y-%%m')", field)
+	case "QUARTER":
+		dimensions = fmt.Sprintf("CONCAT(YEAR(%s), '-Q', QUARTER(%s))", field, field)
+	case "YEAR":
+		dimensions = fmt.Sprintf("YEAR(%s)", field)
+	default:
+		dimensions = field
+	}
+
+	return strings.Join(mm, ", "), dimensions + " AS dimension_0", columns, nil
+}
+
+// chartDataset creates (empty) dataset from the chart report metric configuration
+func chartDataset(m map[string]interface{}) *chartRenderer.Dataset {
+	var (
+		ds = &chartRenderer.Dataset{Kind: chartRenderer.Bar}
+	)
+
+	if kind, _ := m["type"].(string); chartRenderer.IsValidKind(chartRenderer.Kind(kind)) {
+		ds.Kind = chartRenderer.Kind(kind)
+	}
+
+	if ds.Label, _ = m["label"].(string); ds.Label == "" {
+		ds.Label, _ = m["field"].(string)
+	}
+
+	if bg, _ := m["backgroundColor"].(string); bg != "" {
+		ds.Color, _ = chartRenderer.ParseColor(bg)
+	}
+
+	return ds
+}
+
+func chartReportLabel(v interface{}, def interface{}) string {
+	if v == nil || v == "" {
+		if def == nil {
+			return ""
+		}
+
+		v = def
+	}
+
+	return fmt.Sprintf("%v", v)
+}
+
+func chartReportValue(v interface{}) float64 {
+	switch n := v.(type) {
+	case string:
+		f, _ := strconv.ParseFloat(n, 64)
+		return f
+	case int64:
+		return float64(n)
+	case float64:
+		return n
+	}
+
+	return 0
+}
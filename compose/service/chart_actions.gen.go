@@ -515,6 +515,26 @@ func ChartActionReorder(props ...*chartActionProps) *chartAction {
 	return a
 }
 
+// ChartActionRender returns "compose:chart.render" error
+//
+// This function is auto-generated.
+//
+func ChartActionRender(props ...*chartActionProps) *chartAction {
+	a := &chartAction{
+		timestamp: time.Now(),
+		resource:  "compose:chart",
+		action:    "render",
+		log:       "rendered {chart}",
+		severity:  actionlog.Info,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
 // *********************************************************************************************************************
 // *********************************************************************************************************************
 // Error constructors
@@ -789,6 +809,96 @@ func ChartErrInvalidNamespaceID(props ...*chartActionProps) *chartError {
 
 }
 
+// ChartErrInvalidRenderFormat returns "compose:chart.invalidRenderFormat" audit event as actionlog.Warning
+//
+//
+// This function is auto-generated.
+//
+func ChartErrInvalidRenderFormat(props ...*chartActionProps) *chartError {
+	var e = &chartError{
+		timestamp: time.Now(),
+		resource:  "compose:chart",
+		error:     "invalidRenderFormat",
+		action:    "error",
+		message:   "unsupported chart image format",
+		log:       "unsupported chart image format",
+		severity:  actionlog.Warning,
+		props: func() *chartActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
+// ChartErrInvalidRenderSize returns "compose:chart.invalidRenderSize" audit event as actionlog.Warning
+//
+//
+// This function is auto-generated.
+//
+func ChartErrInvalidRenderSize(props ...*chartActionProps) *chartError {
+	var e = &chartError{
+		timestamp: time.Now(),
+		resource:  "compose:chart",
+		error:     "invalidRenderSize",
+		action:    "error",
+		message:   "invalid chart image size",
+		log:       "invalid chart image size",
+		severity:  actionlog.Warning,
+		props: func() *chartActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
+// ChartErrInvalidReport returns "compose:chart.invalidReport" audit event as actionlog.Warning
+//
+//
+// This function is auto-generated.
+//
+func ChartErrInvalidReport(props ...*chartActionProps) *chartError {
+	var e = &chartError{
+		timestamp: time.Now(),
+		resource:  "compose:chart",
+		error:     "invalidReport",
+		action:    "error",
+		message:   "invalid chart report configuration",
+		log:       "invalid chart report configuration",
+		severity:  actionlog.Warning,
+		props: func() *chartActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
 // ChartErrNotAllowedToRead returns "compose:chart.notAllowedToRead" audit event as actionlog.Error
 //
 //
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortezaproject/corteza-server/compose/types"
+)
+
+func TestChartReportQuery(t *testing.T) {
+	tests := []struct {
+		name       string
+		metrics    []map[string]interface{}
+		dimension  map[string]interface{}
+		qMetrics   string
+		qDimension string
+		columns    []string
+		err        bool
+	}{
+		{
+			name:       "count & aggregates",
+			metrics:    []map[string]interface{}{{"field": "count"}, {"field": "amount", "aggregate": "avg"}, {"field": "qty"}},
+			dimension:  map[string]interface{}{"field": "region"},
+			qMetrics:   "AVG(amount) AS metric_1, SUM(qty) AS metric_2",
+			qDimension: "region AS dimension_0",
+			columns:    []string{"count", "metric_1", "metric_2"},
+		},
+		{
+			name:       "date modifier",
+			metrics:    []map[string]interface{}{{"field": "count"}},
+			dimension:  map[string]interface{}{"field": "createdAt", "modifier": "MONTH"},
+			qDimension: "DATE_FORMAT(createdAt, '%Y-%m') AS dimension_0",
+			columns:    []string{"count"},
+		},
+		{
+			name:       "quarter of the joined field",
+			metrics:    []map[string]interface{}{{"field": "acc.amount", "aggregate": "MAX"}},
+			dimension:  map[string]interface{}{"field": "acc.closed", "modifier": "QUARTER"},
+			qMetrics:   "MAX(acc.amount) AS metric_0",
+			qDimension: "CONCAT(YEAR(acc.closed), '-Q', QUARTER(acc.closed)) AS dimension_0",
+			columns:    []string{"metric_0"},
+		},
+		{
+			name:      "expression instead of field",
+			metrics:   []map[string]interface{}{{"field": "amount) + (1"}},
+			dimension: map[string]interface{}{"field": "region"},
+			err:       true,
+		},
+		{
+			name:    "no dimensions",
+			metrics: []map[string]interface{}{{"field": "count"}},
+			err:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				req = require.New(t)
+				r   = &types.ChartConfigReport{Metrics: tt.metrics}
+			)
+
+			if tt.dimension != nil {
+				r.Dimensions = []map[string]interface{}{tt.dimension}
+			}
+
+			metrics, dimensions, columns, err := chartReportQuery(r)
+			if tt.err {
+				req.Error(err)
+				return
+			}
+
+			req.NoError(err)
+			req.Equal(tt.qMetrics, metrics)
+			req.Equal(tt.qDimension, dimensions)
+			req.Equal(tt.columns, columns)
+		})
+	}
+}
@@ -2,128 +2,381 @@ package service
 
 import (
 	"context"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
-	"sync"
+	"io"
+	"path"
+	"strings"
 	"time"
 
 	"github.com/titpetric/factory"
+	"go.uber.org/zap"
 
+	"github.com/cortezaproject/corteza-server/compose/decoder"
+	"github.com/cortezaproject/corteza-server/compose/repository"
+	"github.com/cortezaproject/corteza-server/compose/types"
 	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/mime"
+	"github.com/cortezaproject/corteza-server/pkg/sentry"
+	"github.com/cortezaproject/corteza-server/pkg/store"
+	systemService "github.com/cortezaproject/corteza-server/system/service"
 )
 
 type (
-	recordSet []*RecordImportSession
-
 	importSession struct {
-		l       sync.Mutex
-		records recordSet
+		logger *zap.Logger
+		store  store.Store
 	}
 
 	ImportSessionService interface {
-		FindByID(ctx context.Context, sessionID uint64) (*RecordImportSession, error)
-		SetByID(ctx context.Context, sessionID, namespaceID, moduleID uint64, fields map[string]string, progress *RecordImportProgress, decoder Decoder) (*RecordImportSession, error)
+		FindByID(ctx context.Context, sessionID uint64) (*types.RecordImportSession, error)
+		Create(ctx context.Context, ses *types.RecordImportSession, filename string, source io.ReadSeeker) (*types.RecordImportSession, error)
 		DeleteByID(ctx context.Context, sessionID uint64) error
+
+		// Decoder opens session source; returned func closes it
+		//
+		// Module (when given) is used to format typed spreadsheet (date) cells
+		Decoder(ses *types.RecordImportSession, m *types.Module) (Decoder, func(), error)
+
+		// Heartbeat periodically reports that the import is still running;
+		// returned func stops it
+		Heartbeat(ctx context.Context, sessionID uint64) func()
+
+		Watch(ctx context.Context)
 	}
 )
 
-func ImportSession() *importSession {
+const (
+	// How often we check for stale & expired sessions
+	importSessionWatchInterval = time.Minute
+
+	// How often running imports report heartbeat
+	importSessionHeartbeatInterval = time.Minute
+
+	// Running imports report heartbeat independently of (possibly slow) batches
+	// of imported records; imports that did not report heartbeat for this long
+	// are considered dead and are resumed
+	importSessionStaleAfter = time.Minute * 5
+
+	importSessionLifetime = time.Hour * 24 * 3
+)
+
+func ImportSession(s store.Store) *importSession {
 	return &importSession{
-		records: recordSet{},
+		logger: DefaultLogger.Named("import-session"),
+		store:  s,
 	}
 }
 
-func (svc importSession) indexOf(userID, sessionID uint64) int {
-	for i, r := range svc.records {
-		if r.SessionID == sessionID && r.UserID == userID {
-			return i
-		}
+func (svc importSession) repo(ctx context.Context) repository.RecordImportSessionRepository {
+	return repository.RecordImportSession(ctx, repository.DB(ctx))
+}
+
+func (svc *importSession) FindByID(ctx context.Context, sessionID uint64) (*types.RecordImportSession, error) {
+	ses, err := svc.repo(ctx).FindByID(sessionID)
+	if repository.ErrRecordImportSessionNotFound.Eq(err) || (err == nil && ses.UserID != auth.GetIdentityFromContext(ctx).Identity()) {
+		return nil, fmt.Errorf("compose.service.RecordImportSessionNotFound")
+	}
+
+	return ses, err
+}
+
+// Create stores the uploaded source and prepares new import session from it
+//
+// Session is expected to have namespace, module and (for spreadsheets) sheet options set
+func (svc *importSession) Create(ctx context.Context, ses *types.RecordImportSession, filename string, source io.ReadSeeker) (*types.RecordImportSession, error) {
+	ses.SessionID = factory.Sonyflake.NextID()
+	ses.UserID = auth.GetIdentityFromContext(ctx).Identity()
+	ses.Fields = types.RecordImportFields{}
+
+	ext, err := importSourceExt(filename, source)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = importDecoder(ext, nil, decoder.SpreadsheetOptions{}); err != nil {
+		return nil, err
+	}
+
+	ses.Source = svc.store.Original(ses.SessionID, ext)
+	if err = svc.store.Save(ses.Source, source); err != nil {
+		return nil, err
+	}
+
+	dec, closer, err := svc.Decoder(ses, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer closer()
+
+	if ses.Progress.EntryCount, err = dec.EntryCount(); err != nil {
+		return nil, err
+	}
+
+	for _, h := range dec.Header() {
+		ses.Fields[h] = ""
+	}
+
+	return svc.repo(ctx).Create(ses)
+}
+
+func (svc *importSession) DeleteByID(ctx context.Context, sessionID uint64) error {
+	ses, err := svc.FindByID(ctx, sessionID)
+	if err != nil {
+		return nil
 	}
 
-	return -1
+	return svc.delete(ctx, ses)
 }
 
-func (svc *importSession) FindByID(ctx context.Context, sessionID uint64) (*RecordImportSession, error) {
-	svc.l.Lock()
-	defer svc.l.Unlock()
+func (svc importSession) delete(ctx context.Context, ses *types.RecordImportSession) error {
+	if err := svc.store.Remove(ses.Source); err != nil {
+		svc.logger.Warn("could not remove import source", zap.String("source", ses.Source), zap.Error(err))
+	}
+
+	return svc.repo(ctx).DeleteByID(ses.SessionID)
+}
 
-	userID := auth.GetIdentityFromContext(ctx).Identity()
-	i := svc.indexOf(userID, sessionID)
-	if i >= 0 {
-		return svc.records[i], nil
+// Decoder opens session source from the store and
+// prepares decoder for it
+func (svc importSession) Decoder(ses *types.RecordImportSession, m *types.Module) (Decoder, func(), error) {
+	f, err := svc.store.Open(ses.Source)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	closer := func() {
+		if c, ok := f.(io.Closer); ok {
+			_ = c.Close()
+		}
 	}
-	return nil, fmt.Errorf("compose.service.RecordImportSessionNotFound")
+
+	opt := decoder.SpreadsheetOptions{
+		Sheet:          ses.Sheet,
+		HeaderRow:      ses.HeaderRow,
+		DatetimeFormat: importDatetimeFormat(m),
+	}
+
+	dec, err := importDecoder(strings.TrimLeft(path.Ext(ses.Source), "."), f, opt)
+	if err != nil {
+		closer()
+		return nil, nil, err
+	}
+
+	return dec, closer, nil
 }
 
-func (svc *importSession) SetByID(ctx context.Context, sessionID, namespaceID, moduleID uint64, fields map[string]string, progress *RecordImportProgress, decoder Decoder) (*RecordImportSession, error) {
-	svc.l.Lock()
-	defer svc.l.Unlock()
+// Heartbeat updates heartbeat of the running import in regular intervals
+//
+// Heartbeat is stored outside of the transaction with imported records
+// so that import is not considered dead while a batch is being imported
+func (svc *importSession) Heartbeat(ctx context.Context, sessionID uint64) func() {
+	return importHeartbeat(importSessionHeartbeatInterval, func() {
+		if err := svc.repo(ctx).Heartbeat(sessionID); err != nil {
+			svc.logger.Warn("could not update import heartbeat", zap.Uint64("sessionID", sessionID), zap.Error(err))
+		}
+	})
+}
+
+// importHeartbeat calls beat in given intervals until returned func is called
+func importHeartbeat(interval time.Duration, beat func()) func() {
+	var (
+		done   = make(chan struct{})
+		ticker = time.NewTicker(interval)
+	)
 
-	userID := auth.GetIdentityFromContext(ctx).Identity()
-	i := svc.indexOf(userID, sessionID)
-	var ris *RecordImportSession
+	go func() {
+		defer sentry.Recover()
+		defer ticker.Stop()
 
-	if i >= 0 {
-		ris = svc.records[i]
-	} else {
-		ris = &RecordImportSession{
-			SessionID: factory.Sonyflake.NextID(),
-			CreatedAt: time.Now(),
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				beat()
+			}
 		}
-		svc.records = append(svc.records, ris)
-		ris.UserID = userID
+	}()
+
+	return func() { close(done) }
+}
+
+// Watch resumes stale imports and removes expired sessions
+func (svc *importSession) Watch(ctx context.Context) {
+	go func() {
+		defer sentry.Recover()
+
+		var ticker = time.NewTicker(importSessionWatchInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				svc.resume(ctx)
+				svc.clean(ctx)
+			}
+		}
+	}()
+
+	svc.logger.Debug("watcher initialized")
+}
+
+// resume claims imports that stopped reporting progress (node running the import
+// was stopped or crashed) and continues them with the identity of the session owner
+func (svc *importSession) resume(ctx context.Context) {
+	var (
+		heartbeat = time.Now().Add(-importSessionStaleAfter)
+		repo      = svc.repo(ctx)
+	)
+
+	set, err := repo.FindStale(heartbeat)
+	if err != nil {
+		svc.logger.Error("could not load stale import sessions", zap.Error(err))
+		return
 	}
-	ris.UpdatedAt = time.Now()
 
-	if namespaceID > 0 {
-		ris.NamespaceID = namespaceID
+	for _, ses := range set {
+		if claimed, err := repo.Claim(ses.SessionID, heartbeat); err != nil || !claimed {
+			// Claimed by another node
+			continue
+		}
+
+		ictx, err := svc.identityContext(ctx, ses.UserID)
+		if err != nil {
+			svc.logger.Error("could not resume import", zap.Uint64("sessionID", ses.SessionID), zap.Error(err))
+			continue
+		}
+
+		svc.logger.Info("resuming import", zap.Uint64("sessionID", ses.SessionID), zap.Int("processed", ses.Progress.Processed()))
+
+		go func(ses *types.RecordImportSession) {
+			defer sentry.Recover()
+
+			if err := DefaultRecord.With(ictx).ImportResume(ses, svc); err != nil {
+				svc.logger.Error("import failed", zap.Uint64("sessionID", ses.SessionID), zap.Error(err))
+			}
+		}(ses)
 	}
-	if moduleID > 0 {
-		ris.ModuleID = moduleID
+}
+
+func (svc *importSession) clean(ctx context.Context) {
+	set, err := svc.repo(ctx).FindExpired(time.Now().Add(-importSessionLifetime))
+	if err != nil {
+		svc.logger.Error("could not load expired import sessions", zap.Error(err))
+		return
 	}
-	if fields != nil {
-		ris.Fields = fields
+
+	for _, ses := range set {
+		if err = svc.delete(ctx, ses); err != nil {
+			svc.logger.Error("could not remove expired import session", zap.Uint64("sessionID", ses.SessionID), zap.Error(err))
+		}
 	}
-	if progress != nil {
-		ris.Progress = *progress
+}
+
+// identityContext prepares context with the identity (and roles) of the session owner
+func (svc importSession) identityContext(ctx context.Context, userID uint64) (context.Context, error) {
+	mm, err := systemService.DefaultRole.With(ctx).Membership(userID)
+	if err != nil {
+		return nil, err
 	}
 
-	if ris.Progress.FinishedAt != nil {
-		ris.Decoder = nil
-	} else if decoder != nil {
-		ris.Decoder = decoder
+	roles := make([]uint64, len(mm))
+	for i := range mm {
+		roles[i] = mm[i].RoleID
 	}
 
-	return ris, nil
+	return auth.SetIdentityToContext(ctx, auth.NewIdentity(userID, roles...)), nil
 }
 
-// https://stackoverflow.com/a/37335777
-func remove(s recordSet, i int) recordSet {
-	s[len(s)-1], s[i] = s[i], s[len(s)-1]
-	return s[:len(s)-1]
+// importSourceExt determines format of the import source
+//
+// Content is checked first, extension of the uploaded file is used as last resort
+func importSourceExt(filename string, source io.ReadSeeker) (string, error) {
+	_, ext, err := mime.Type(source)
+	if err != nil {
+		return "", err
+	}
+
+	switch ext {
+	case "txt":
+		if is, err := mime.JsonL(source); err != nil {
+			return "", err
+		} else if is {
+			ext = "jsonl"
+		} else {
+			ext = strings.TrimLeft(path.Ext(filename), ".")
+		}
+
+	case "zip":
+		// Spreadsheets are zip archives
+		ext = strings.TrimLeft(path.Ext(filename), ".")
+	}
+
+	return strings.ToLower(ext), nil
 }
 
-func (svc *importSession) DeleteByID(ctx context.Context, sessionID uint64) error {
-	svc.l.Lock()
-	defer svc.l.Unlock()
+// importDecoder prepares decoder for the given source format
+//
+// When source is nil, only format is checked
+func importDecoder(ext string, source io.ReadSeeker, opt decoder.SpreadsheetOptions) (Decoder, error) {
+	switch ext {
+	case "json", "jsonl", "ldjson", "ndjson":
+		if source == nil {
+			return nil, nil
+		}
 
-	userID := auth.GetIdentityFromContext(ctx).Identity()
-	i := svc.indexOf(userID, sessionID)
+		return decoder.NewStructuredDecoder(json.NewDecoder(source), source), nil
 
-	if i >= 0 {
-		svc.records = remove(svc.records, i)
+	case "csv":
+		if source == nil {
+			return nil, nil
+		}
+
+		return decoder.NewFlatReader(csv.NewReader(source), source), nil
+
+	case "xlsx":
+		if source == nil {
+			return nil, nil
+		}
+
+		return decoder.NewXlsxDecoder(source, opt)
+
+	case "ods":
+		if source == nil {
+			return nil, nil
+		}
+
+		return decoder.NewOdsDecoder(source, opt)
+
+	default:
+		// copied here from service/errors.go for backward compatibility
+		// @todo use action/error pattern
+		return nil, fmt.Errorf("compose.service.RecordImportFormatNotSupported")
 	}
-	return nil
 }
 
-// @todo run this in some interval
-func (svc *importSession) clean(ctx context.Context) {
-	svc.l.Lock()
-	defer svc.l.Unlock()
+// importDatetimeFormat returns layouts for typed date cells of the spreadsheet
+// so that they are imported in the format of the module (datetime) field
+func importDatetimeFormat(m *types.Module) func(string) string {
+	return func(name string) string {
+		var f *types.ModuleField
+		if m != nil {
+			f = m.Fields.FindByName(name)
+		}
 
-	for i := len(svc.records) - 1; i >= 0; i-- {
-		r := svc.records[i]
-		if time.Now().After(r.UpdatedAt.Add(time.Hour * 24 * 3)) {
-			svc.records = remove(svc.records, i)
+		switch {
+		case f == nil || !f.IsDateTime():
+			return ""
+		case f.Options.Bool("onlyDate"):
+			return "2006-01-02"
+		case f.Options.Bool("onlyTime"):
+			return "15:04:05"
+		default:
+			return time.RFC3339
 		}
 	}
 }
@@ -1,110 +1,93 @@
 package service
 
 import (
-	"context"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
-	"go.uber.org/zap"
+
+	"github.com/cortezaproject/corteza-server/compose/decoder"
+	"github.com/cortezaproject/corteza-server/compose/types"
 )
 
-var ctx context.Context = context.WithValue(context.Background(), "testing", true)
-
-func TestImportSession_FindByID(t *testing.T) {
-	DefaultLogger = zap.New(nil, nil)
-	svc := ImportSession()
-	ss, _ := svc.SetByID(ctx, 1, 0, 0, nil, nil, nil)
-	sid := ss.SessionID
-
-	t.Run("Found", func(t *testing.T) {
-		s, err := svc.FindByID(ctx, sid)
-		require.True(t,
-			s != nil,
-			"Session should be found",
-		)
-
-		require.True(t,
-			err == nil,
-			"Returned with error",
-		)
-	})
-
-	t.Run("Not found", func(t *testing.T) {
-		s, err := svc.FindByID(ctx, sid+1)
-		require.True(t,
-			s == nil,
-			"Session should not be found",
-		)
-
-		require.True(t,
-			err != nil,
-			"Error should not be nil",
-		)
-	})
+func TestImportSourceExt(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		source   string
+		ext      string
+	}{
+		{name: "json lines", filename: "records.txt", source: "{\"a\":1}\n{\"a\":2}\n", ext: "jsonl"},
+		{name: "csv", filename: "records.CSV", source: "a,b\n1,2\n", ext: "csv"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ext, err := importSourceExt(tt.filename, strings.NewReader(tt.source))
+			require.NoError(t, err)
+			require.Equal(t, tt.ext, ext)
+		})
+	}
 }
 
-func TestImportSession_SetByID(t *testing.T) {
-	DefaultLogger = zap.New(nil, nil)
-	svc := ImportSession()
-
-	t.Run("New", func(t *testing.T) {
-		ss, err := svc.SetByID(ctx, 1, 0, 0, nil, nil, nil)
-		require.True(t,
-			len(svc.records) == 1 && ss != nil,
-			"Session should be created",
-		)
-
-		require.True(t,
-			err == nil,
-			"Returned with error",
-		)
-	})
-
-	t.Run("Existing", func(t *testing.T) {
-		svc := ImportSession()
-		ss, err := svc.SetByID(ctx, 1, 0, 0, nil, nil, nil)
-		ns, err := svc.SetByID(ctx, ss.SessionID, 0, 0, nil, nil, nil)
-		require.True(t,
-			len(svc.records) == 1 && ns != nil && ss.SessionID == ns.SessionID,
-			"Existing session should be edited",
-		)
-
-		require.True(t,
-			err == nil,
-			"Returned with error",
-		)
-	})
+func TestImportDecoder(t *testing.T) {
+	var (
+		req = require.New(t)
+	)
+
+	_, err := importDecoder("csv", nil, decoder.SpreadsheetOptions{})
+	req.NoError(err)
+
+	_, err = importDecoder("xlsx", nil, decoder.SpreadsheetOptions{})
+	req.NoError(err)
+
+	_, err = importDecoder("exe", nil, decoder.SpreadsheetOptions{})
+	req.Error(err)
+
+	dec, err := importDecoder("csv", strings.NewReader("name,email\nJohn,john@example.tld\n"), decoder.SpreadsheetOptions{})
+	req.NoError(err)
+	req.Equal([]string{"name", "email"}, dec.Header())
 }
 
-func TestImportSession_DeleteByID(t *testing.T) {
-	DefaultLogger = zap.New(nil, nil)
-	svc := ImportSession()
-	ss, _ := svc.SetByID(ctx, 1, 0, 0, nil, nil, nil)
-
-	t.Run("Delete existing", func(t *testing.T) {
-		err := svc.DeleteByID(ctx, ss.SessionID)
-		require.True(t,
-			len(svc.records) == 0,
-			"Session should be deleted",
-		)
-
-		require.True(t,
-			err == nil,
-			"Returned with error",
-		)
-	})
-
-	t.Run("Session not found", func(t *testing.T) {
-		ss, _ := svc.SetByID(ctx, 1, 0, 0, nil, nil, nil)
-		err := svc.DeleteByID(ctx, ss.SessionID+1)
-		require.True(t,
-			len(svc.records) == 1,
-			"Session should not deleted",
-		)
-
-		require.True(t,
-			err == nil,
-			"Returned with error",
-		)
-	})
+func TestImportDatetimeFormat(t *testing.T) {
+	var (
+		req = require.New(t)
+		m   = &types.Module{Fields: types.ModuleFieldSet{
+			&types.ModuleField{Name: "name", Kind: "String"},
+			&types.ModuleField{Name: "at", Kind: "DateTime"},
+			&types.ModuleField{Name: "on", Kind: "DateTime", Options: types.ModuleFieldOptions{"onlyDate": true}},
+			&types.ModuleField{Name: "time", Kind: "DateTime", Options: types.ModuleFieldOptions{"onlyTime": true}},
+		}}
+
+		format = importDatetimeFormat(m)
+	)
+
+	req.Equal("", format("name"))
+	req.Equal("", format("createdAt"))
+	req.Equal(time.RFC3339, format("at"))
+	req.Equal("2006-01-02", format("on"))
+	req.Equal("15:04:05", format("time"))
+	req.Equal("", importDatetimeFormat(nil)("at"))
+}
+
+func TestImportHeartbeat(t *testing.T) {
+	var (
+		req   = require.New(t)
+		beats int32
+	)
+
+	stop := importHeartbeat(time.Millisecond*10, func() { atomic.AddInt32(&beats, 1) })
+
+	// Heartbeat is reported while a slow batch is being imported
+	time.Sleep(time.Millisecond * 100)
+	req.True(atomic.LoadInt32(&beats) >= 3, "expecting heartbeat to be reported during the batch")
+
+	stop()
+	time.Sleep(time.Millisecond * 20)
+	stopped := atomic.LoadInt32(&beats)
+
+	time.Sleep(time.Millisecond * 50)
+	req.Equal(stopped, atomic.LoadInt32(&beats), "expecting no heartbeat after import is done")
 }
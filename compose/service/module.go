@@ -2,12 +2,14 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 
 	"github.com/titpetric/factory"
 
 	"github.com/cortezaproject/corteza-server/compose/repository"
 	"github.com/cortezaproject/corteza-server/compose/service/event"
+	"github.com/cortezaproject/corteza-server/compose/service/values"
 	"github.com/cortezaproject/corteza-server/compose/types"
 	"github.com/cortezaproject/corteza-server/pkg/actionlog"
 	"github.com/cortezaproject/corteza-server/pkg/eventbus"
@@ -232,6 +234,18 @@ func (svc module) Create(new *types.Module) (m *types.Module, err error) {
 			return err
 		}
 
+		if err = svc.formulaCheck(new, aProps); err != nil {
+			return err
+		}
+
+		if err = svc.rollupCheck(new, aProps); err != nil {
+			return err
+		}
+
+		if err = svc.validatorCheck(new, aProps); err != nil {
+			return err
+		}
+
 		if m, err = svc.moduleRepo.Create(new); err != nil {
 			return err
 		}
@@ -252,6 +266,7 @@ func (svc module) Create(new *types.Module) (m *types.Module, err error) {
 func (svc module) Update(upd *types.Module) (m *types.Module, err error) {
 	var (
 		ns     *types.Namespace
+		old    types.ModuleFieldSet
 		aProps = &moduleActionProps{changed: upd}
 	)
 
@@ -293,10 +308,28 @@ func (svc module) Update(upd *types.Module) (m *types.Module, err error) {
 			return err
 		}
 
+		if err = svc.formulaCheck(upd, aProps); err != nil {
+			return err
+		}
+
+		if err = svc.rollupCheck(upd, aProps); err != nil {
+			return err
+		}
+
+		if err = svc.validatorCheck(upd, aProps); err != nil {
+			return err
+		}
+
+		// keep existing fields to see what rollup fields need to be recalculated
+		if old, err = svc.moduleRepo.FindFields(m.ID); err != nil {
+			return err
+		}
+
 		m.Name = upd.Name
 		m.Handle = upd.Handle
 		m.Meta = upd.Meta
 		m.Fields = upd.Fields
+		m.Validators = upd.Validators
 
 		if m, err = svc.moduleRepo.Update(m); err != nil {
 			return err
@@ -313,6 +346,16 @@ func (svc module) Update(upd *types.Module) (m *types.Module, err error) {
 			return err
 		}
 
+		if rf.Count > 0 {
+			if err = svc.rollupBackfill(m, old); err != nil {
+				return err
+			}
+
+			if err = svc.formulaBackfill(m, old); err != nil {
+				return err
+			}
+		}
+
 		_ = svc.eventbus.WaitFor(svc.ctx, event.ModuleAfterUpdate(upd, m, ns))
 		return nil
 	})
@@ -382,6 +425,212 @@ func (svc module) UniqueCheck(m *types.Module) (err error) {
 	return nil
 }
 
+// formulaCheck verifies expressions of all formula fields
+func (svc module) formulaCheck(m *types.Module, aProps *moduleActionProps) error {
+	return m.Fields.Walk(func(f *types.ModuleField) error {
+		if !f.IsFormula() {
+			return nil
+		}
+
+		if _, err := values.ParseFormula(m, f.Options.Expression()); err != nil {
+			return ModuleErrInvalidFormula(aProps.setField(f.Name)).Wrap(err)
+		}
+
+		return nil
+	})
+}
+
+// rollupCheck verifies configuration of all rollup fields
+//
+// Aggregated module must be in the same namespace and have a record field
+// (and a value field for all aggregates except COUNT)
+func (svc module) rollupCheck(m *types.Module, aProps *moduleActionProps) error {
+	return m.Fields.Walk(func(f *types.ModuleField) (err error) {
+		if !f.IsRollup() {
+			return nil
+		}
+
+		var (
+			opt = f.Options
+			agg = &types.Module{ID: m.ID, Fields: m.Fields}
+
+			check = func() error {
+				switch opt.Aggregate() {
+				case "COUNT", "SUM", "MIN", "MAX", "AVG":
+				default:
+					return fmt.Errorf("unsupported aggregate %q", opt.Aggregate())
+				}
+
+				if opt.ModuleID() == 0 {
+					return fmt.Errorf("aggregated module not set")
+				}
+
+				if opt.ModuleID() != m.ID || m.ID == 0 {
+					if agg, err = svc.moduleRepo.FindByID(m.NamespaceID, opt.ModuleID()); err != nil {
+						return fmt.Errorf("aggregated module does not exist")
+					}
+
+					if agg.Fields, err = svc.moduleRepo.FindFields(agg.ID); err != nil {
+						return err
+					}
+				}
+
+				if ref := agg.Fields.FindByName(opt.RefField()); ref == nil || ref.Kind != "Record" {
+					return fmt.Errorf("unknown record field %q", opt.RefField())
+				}
+
+				if opt.Aggregate() == "COUNT" {
+					return nil
+				}
+
+				if val := agg.Fields.FindByName(opt.ValueField()); val == nil || !val.IsNumeric() {
+					return fmt.Errorf("unknown number field %q", opt.ValueField())
+				}
+
+				return nil
+			}
+		)
+
+		if err = check(); err != nil {
+			return ModuleErrInvalidRollup(aProps.setField(f.Name)).Wrap(err)
+		}
+
+		return nil
+	})
+}
+
+// rollupBackfill recalculates values of new and reconfigured rollup fields on all module's records
+//
+// Rollup values are otherwise only updated when aggregated records (or records with
+// rollup field) change. Newly created modules have no records and need no backfill
+func (svc module) rollupBackfill(m *types.Module, old types.ModuleFieldSet) (err error) {
+	var (
+		ff  types.ModuleFieldSet
+		set types.RecordSet
+		f   = types.RecordFilter{}
+	)
+
+	for _, fld := range m.Fields {
+		if fld.IsRollup() && !sameRollup(fld, old.FindByID(fld.ID)) {
+			ff = append(ff, fld)
+		}
+	}
+
+	if len(ff) == 0 {
+		return nil
+	}
+
+	f.Limit = recordWalkChunkSize
+	for {
+		if set, f, err = svc.recordRepo.Export(m, f); err != nil {
+			return err
+		}
+
+		for _, fld := range ff {
+			if err = storeRollupValues(svc.recordRepo, fld, set.IDs()...); err != nil {
+				return err
+			}
+		}
+
+		if f.NextPage == "" {
+			return nil
+		}
+
+		f.PageCursor, f.NextPage = f.NextPage, ""
+	}
+}
+
+// sameRollup checks if (existing) field is a rollup that aggregates the same values
+func sameRollup(f, e *types.ModuleField) bool {
+	return e != nil && e.IsRollup() &&
+		e.Options.ModuleID() == f.Options.ModuleID() &&
+		e.Options.RefField() == f.Options.RefField() &&
+		e.Options.ValueField() == f.Options.ValueField() &&
+		e.Options.Aggregate() == f.Options.Aggregate()
+}
+
+// formulaBackfill recomputes values of new and changed formula fields on all module's records
+//
+// Formula values are otherwise only computed when records are created or updated
+func (svc module) formulaBackfill(m *types.Module, old types.ModuleFieldSet) (err error) {
+	var (
+		ff      types.ModuleFieldSet
+		set     types.RecordSet
+		rvs     types.RecordValueSet
+		f       = types.RecordFilter{}
+		formula = values.Formula()
+	)
+
+	for _, fld := range m.Fields {
+		if fld.IsFormula() && !sameFormula(fld, old.FindByID(fld.ID)) {
+			ff = append(ff, fld)
+		}
+	}
+
+	if len(ff) == 0 {
+		return nil
+	}
+
+	f.Limit = recordWalkChunkSize
+	for {
+		if set, f, err = svc.recordRepo.Export(m, f); err != nil {
+			return err
+		}
+
+		if len(set) > 0 {
+			if rvs, err = svc.recordRepo.LoadValues(m.Fields.Names(), set.IDs()); err != nil {
+				return err
+			}
+		}
+
+		for _, r := range set {
+			computed := formula.Run(m, rvs.FilterByRecordID(r.ID))
+
+			for _, fld := range ff {
+				rv := &types.RecordValue{RecordID: r.ID, Name: fld.Name}
+				if vv := computed.FilterByName(fld.Name); len(vv) > 0 {
+					rv.Value = vv[0].Value
+				} else {
+					rv.DeletedAt = nowPtr()
+				}
+
+				if err = svc.recordRepo.PartialUpdateValues(rv); err != nil {
+					return err
+				}
+			}
+		}
+
+		if f.NextPage == "" {
+			return nil
+		}
+
+		f.PageCursor, f.NextPage = f.NextPage, ""
+	}
+}
+
+// sameFormula checks if (existing) field is a formula that computes the same values
+func sameFormula(f, e *types.ModuleField) bool {
+	return e != nil && e.IsFormula() &&
+		e.Options.Expression() == f.Options.Expression() &&
+		e.Options.Precision(types.ModuleFieldPrecisionMax) == f.Options.Precision(types.ModuleFieldPrecisionMax)
+}
+
+// validatorCheck verifies expressions of all module validators
+// and fields errors are reported on
+func (svc module) validatorCheck(m *types.Module, aProps *moduleActionProps) error {
+	for _, mv := range m.Validators {
+		if mv.Field != "" && m.Fields.FindByName(mv.Field) == nil {
+			return ModuleErrInvalidValidator(aProps).Wrap(fmt.Errorf("unknown field %q", mv.Field))
+		}
+
+		if _, err := values.ParseValidatorExpression(m, mv.Expression); err != nil {
+			return ModuleErrInvalidValidator(aProps).Wrap(err)
+		}
+	}
+
+	return nil
+}
+
 // Namespace loader
 //
 func (svc module) loadNamespace(namespaceID uint64) (ns *types.Namespace, err error) {
@@ -20,6 +20,7 @@ type (
 		changed   *types.Module
 		filter    *types.ModuleFilter
 		namespace *types.Namespace
+		field     string
 	}
 
 	moduleAction struct {
@@ -102,6 +103,17 @@ func (p *moduleActionProps) setNamespace(namespace *types.Namespace) *moduleActi
 	return p
 }
 
+// setField updates moduleActionProps's field
+//
+// Allows method chaining
+//
+// This function is auto-generated.
+//
+func (p *moduleActionProps) setField(field string) *moduleActionProps {
+	p.field = field
+	return p
+}
+
 // serialize converts moduleActionProps to actionlog.Meta
 //
 // This function is auto-generated.
@@ -142,6 +154,7 @@ func (p moduleActionProps) serialize() actionlog.Meta {
 		m.Set("namespace.slug", p.namespace.Slug, true)
 		m.Set("namespace.ID", p.namespace.ID, true)
 	}
+	m.Set("field", p.field, true)
 
 	return m
 }
@@ -266,6 +279,7 @@ func (p moduleActionProps) tr(in string, err error) string {
 		pairs = append(pairs, "{namespace.slug}", fns(p.namespace.Slug))
 		pairs = append(pairs, "{namespace.ID}", fns(p.namespace.ID))
 	}
+	pairs = append(pairs, "{field}", fns(p.field))
 	return strings.NewReplacer(pairs...).Replace(in)
 }
 
@@ -748,6 +762,96 @@ func ModuleErrStaleData(props ...*moduleActionProps) *moduleError {
 
 }
 
+// ModuleErrInvalidFormula returns "compose:module.invalidFormula" audit event as actionlog.Warning
+//
+//
+// This function is auto-generated.
+//
+func ModuleErrInvalidFormula(props ...*moduleActionProps) *moduleError {
+	var e = &moduleError{
+		timestamp: time.Now(),
+		resource:  "compose:module",
+		error:     "invalidFormula",
+		action:    "error",
+		message:   "invalid formula expression in field {field}: {err}",
+		log:       "invalid formula expression in field {field}: {err}",
+		severity:  actionlog.Warning,
+		props: func() *moduleActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
+// ModuleErrInvalidRollup returns "compose:module.invalidRollup" audit event as actionlog.Warning
+//
+//
+// This function is auto-generated.
+//
+func ModuleErrInvalidRollup(props ...*moduleActionProps) *moduleError {
+	var e = &moduleError{
+		timestamp: time.Now(),
+		resource:  "compose:module",
+		error:     "invalidRollup",
+		action:    "error",
+		message:   "invalid rollup configuration in field {field}: {err}",
+		log:       "invalid rollup configuration in field {field}: {err}",
+		severity:  actionlog.Warning,
+		props: func() *moduleActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
+// ModuleErrInvalidValidator returns "compose:module.invalidValidator" audit event as actionlog.Warning
+//
+//
+// This function is auto-generated.
+//
+func ModuleErrInvalidValidator(props ...*moduleActionProps) *moduleError {
+	var e = &moduleError{
+		timestamp: time.Now(),
+		resource:  "compose:module",
+		error:     "invalidValidator",
+		action:    "error",
+		message:   "invalid validation rule: {err}",
+		log:       "invalid validation rule: {err}",
+		severity:  actionlog.Warning,
+		props: func() *moduleActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
 // ModuleErrInvalidNamespaceID returns "compose:module.invalidNamespaceID" audit event as actionlog.Warning
 //
 //
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"net/http"
@@ -29,6 +30,11 @@ type (
 	notification struct {
 		actionlog actionlog.Recorder
 		users     systemService.UserService
+		charts    notificationChartRenderer
+	}
+
+	notificationChartRenderer interface {
+		With(ctx context.Context) ChartService
 	}
 
 	notificationUserFinder interface {
@@ -42,6 +48,8 @@ func Notification() *notification {
 
 		// See comment at DefaultSystemUser definition
 		users: DefaultSystemUser,
+
+		charts: DefaultChart,
 	}
 }
 
@@ -90,6 +98,10 @@ func (svc notification) SendEmail(ctx context.Context, n *types.EmailNotificatio
 			return err
 		}
 
+		if err = svc.procEmailChartAttachments(ctx, msg, n.ChartAttachments...); err != nil {
+			return err
+		}
+
 		return mail.Send(msg)
 	}()
 
@@ -211,3 +223,25 @@ func (svc notification) procEmailAttachments(ctx context.Context, message *gomai
 	wg.Wait()
 	return nil
 }
+
+// procEmailChartAttachments renders charts and attaches images to the message
+//
+// Charts are rendered with permissions of the user sending the email
+func (svc notification) procEmailChartAttachments(ctx context.Context, message *gomail.Message, cc ...*types.EmailChartAttachment) error {
+	for _, c := range cc {
+		aProps := &notificationActionProps{chartID: c.ChartID}
+
+		if c.Format == "" {
+			c.Format = "png"
+		}
+
+		img, err := svc.charts.With(ctx).Render(c.NamespaceID, c.ChartID, c.Format, c.Width, c.Height)
+		if err != nil {
+			return NotificationErrFailedToRenderChart(aProps).Wrap(err)
+		}
+
+		message.AttachReader(fmt.Sprintf("chart-%d.%s", c.ChartID, c.Format), bytes.NewReader(img))
+	}
+
+	return nil
+}
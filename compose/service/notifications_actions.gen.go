@@ -21,6 +21,7 @@ type (
 		attachmentURL  string
 		attachmentSize int64
 		attachmentType string
+		chartID        uint64
 	}
 
 	notificationAction struct {
@@ -114,6 +115,17 @@ func (p *notificationActionProps) setAttachmentType(attachmentType string) *noti
 	return p
 }
 
+// setChartID updates notificationActionProps's chartID
+//
+// Allows method chaining
+//
+// This function is auto-generated.
+//
+func (p *notificationActionProps) setChartID(chartID uint64) *notificationActionProps {
+	p.chartID = chartID
+	return p
+}
+
 // serialize converts notificationActionProps to actionlog.Meta
 //
 // This function is auto-generated.
@@ -136,6 +148,7 @@ func (p notificationActionProps) serialize() actionlog.Meta {
 	m.Set("attachmentURL", p.attachmentURL, true)
 	m.Set("attachmentSize", p.attachmentSize, true)
 	m.Set("attachmentType", p.attachmentType, true)
+	m.Set("chartID", p.chartID, true)
 
 	return m
 }
@@ -202,6 +215,7 @@ func (p notificationActionProps) tr(in string, err error) string {
 	pairs = append(pairs, "{attachmentURL}", fns(p.attachmentURL))
 	pairs = append(pairs, "{attachmentSize}", fns(p.attachmentSize))
 	pairs = append(pairs, "{attachmentType}", fns(p.attachmentType))
+	pairs = append(pairs, "{chartID}", fns(p.chartID))
 	return strings.NewReplacer(pairs...).Replace(in)
 }
 
@@ -514,6 +528,36 @@ func NotificationErrFailedToDownloadAttachment(props ...*notificationActionProps
 
 }
 
+// NotificationErrFailedToRenderChart returns "compose:notification.failedToRenderChart" audit event as actionlog.Error
+//
+//
+// This function is auto-generated.
+//
+func NotificationErrFailedToRenderChart(props ...*notificationActionProps) *notificationError {
+	var e = &notificationError{
+		timestamp: time.Now(),
+		resource:  "compose:notification",
+		error:     "failedToRenderChart",
+		action:    "error",
+		message:   "could not render chart {chartID} attachment: {err}",
+		log:       "could not render chart {chartID} attachment: {err}",
+		severity:  actionlog.Error,
+		props: func() *notificationActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
 // *********************************************************************************************************************
 // *********************************************************************************************************************
 
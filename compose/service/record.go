@@ -2,11 +2,10 @@ package service
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"regexp"
-	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/titpetric/factory"
@@ -18,13 +17,40 @@ import (
 	"github.com/cortezaproject/corteza-server/compose/types"
 	"github.com/cortezaproject/corteza-server/pkg/actionlog"
 	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/db/dialect"
 	"github.com/cortezaproject/corteza-server/pkg/eventbus"
+	"github.com/cortezaproject/corteza-server/pkg/rh"
+	"github.com/cortezaproject/corteza-server/pkg/sentry"
 )
 
 const (
 	IMPORT_ON_ERROR_SKIP         = "SKIP"
 	IMPORT_ON_ERROR_FAIL         = "FAIL"
 	IMPORT_ERROR_MAX_INDEX_COUNT = 500000
+
+	IMPORT_MODE_CREATE = "CREATE"
+	IMPORT_MODE_UPSERT = "UPSERT"
+
+	// Matches imported records with the existing ones by their ID
+	IMPORT_MATCH_RECORD_ID = "recordID"
+
+	// number of records loaded at once when
+	// walking through all records (export, iterator)
+	recordWalkChunkSize uint = 1000
+
+	// number of imported rows stored (committed) together
+	// with the import session progress
+	importCheckpointSize = 100
+
+	// max. time imported rows are kept uncommitted
+	importCheckpointInterval = time.Second * 10
+)
+
+// Outcomes of a single record import
+const (
+	importCreated = iota + 1
+	importUpdated
+	importSkipped
 )
 
 type (
@@ -37,17 +63,56 @@ type (
 		ac       recordAccessController
 		eventbus eventDispatcher
 
-		recordRepo repository.RecordRepository
-		moduleRepo repository.ModuleRepository
-		nsRepo     repository.NamespaceRepository
+		recordRepo        repository.RecordRepository
+		revisionRepo      repository.RecordRevisionRepository
+		importSessionRepo repository.RecordImportSessionRepository
+		viewRepo          repository.RecordViewRepository
+		moduleRepo        repository.ModuleRepository
+		nsRepo            repository.NamespaceRepository
 
 		formatter recordValuesFormatter
 		sanitizer recordValuesSanitizer
+		formula   recordValuesFormula
 		validator recordValuesValidator
 
+		reportCache *recordReportCache
+
 		optEmitEvents bool
 	}
 
+	// recordRollups holds rollup fields that aggregate values of module's records
+	// and references to the records that need to be recalculated
+	recordRollups map[*types.ModuleField][]uint64
+
+	// recordRemoval tracks records removed (and changed) by referential rules
+	//
+	// Actions of the applied rules are recorded and events are
+	// emitted only after the changes are committed
+	recordRemoval struct {
+		removed map[uint64]bool
+		applied []*recordRuleApplied
+	}
+
+	// recordRuleApplied holds record deleted or changed (when new is set) by a referential rule
+	recordRuleApplied struct {
+		aProps   *recordActionProps
+		action   func(...*recordActionProps) *recordAction
+		module   *types.Module
+		new, old *types.Record
+	}
+
+	// importEvents holds after-events of imported records
+	// until the batch they were imported in is committed
+	importEvents struct {
+		eventDispatcher
+		queued []eventbus.Event
+	}
+
+	// importBatch holds records imported in one transaction
+	importBatch struct {
+		*record
+	}
+
 	recordValuesFormatter interface {
 		Run(*types.Module, types.RecordValueSet) types.RecordValueSet
 	}
@@ -56,6 +121,10 @@ type (
 		Run(*types.Module, types.RecordValueSet) types.RecordValueSet
 	}
 
+	recordValuesFormula interface {
+		Run(*types.Module, types.RecordValueSet) types.RecordValueSet
+	}
+
 	recordValuesValidator interface {
 		Run(*types.Module, *types.Record) *types.RecordValueErrorSet
 		UniqueChecker(fn values.UniqueChecker)
@@ -71,6 +140,7 @@ type (
 		CanUpdateRecord(context.Context, *types.Module) bool
 		CanDeleteRecord(context.Context, *types.Module) bool
 		CanReadRecordValue(context.Context, *types.ModuleField) bool
+		CanReadRecordView(context.Context, *types.RecordView) bool
 		CanUpdateRecordValue(context.Context, *types.ModuleField) bool
 	}
 
@@ -79,10 +149,12 @@ type (
 
 		FindByID(namespaceID, recordID uint64) (*types.Record, error)
 
-		Report(namespaceID, moduleID uint64, metrics, dimensions, filter string) (interface{}, error)
+		Report(namespaceID, moduleID uint64, joins []*types.ChartConfigReportJoin, metrics, dimensions, filter string) (interface{}, error)
 		Find(filter types.RecordFilter) (set types.RecordSet, f types.RecordFilter, err error)
+		Search(filter types.RecordFilter) (set types.RecordSet, f types.RecordFilter, err error)
 		Export(types.RecordFilter, Encoder) error
-		Import(*RecordImportSession, ImportSessionService) error
+		Import(*types.RecordImportSession, ImportSessionService) error
+		ImportResume(*types.RecordImportSession, ImportSessionService) error
 
 		Create(record *types.Record) (*types.Record, error)
 		Update(record *types.Record) (*types.Record, error)
@@ -94,6 +166,10 @@ type (
 
 		Iterator(f types.RecordFilter, fn eventbus.HandlerFn, action string) (err error)
 
+		FindRevisions(filter types.RecordRevisionFilter) (types.RecordRevisionSet, types.RecordRevisionFilter, error)
+		DiffRevisions(namespaceID, moduleID, recordID, fromRevisionID, toRevisionID uint64) (types.RecordRevisionChangeSet, error)
+		RestoreRevision(namespaceID, moduleID, recordID, revisionID uint64) (*types.Record, error)
+
 		EventEmitting(enable bool)
 	}
 
@@ -106,46 +182,13 @@ type (
 		EntryCount() (uint64, error)
 		Records(fields map[string]string, Create decoder.RecordCreator) error
 	}
-
-	RecordImportSession struct {
-		Decoder     Decoder              `json:"-"`
-		CreatedAt   time.Time            `json:"createdAt"`
-		UpdatedAt   time.Time            `json:"updatedAt"`
-		OnError     string               `json:"onError"`
-		SessionID   uint64               `json:"sessionID,string"`
-		UserID      uint64               `json:"userID,string"`
-		NamespaceID uint64               `json:"namespaceID,string"`
-		ModuleID    uint64               `json:"moduleID,string"`
-		Fields      map[string]string    `json:"fields"`
-		Progress    RecordImportProgress `json:"progress"`
-	}
-
-	RecordImportProgress struct {
-		StartedAt  *time.Time `json:"startedAt"`
-		FinishedAt *time.Time `json:"finishedAt"`
-		EntryCount uint64     `json:"entryCount"`
-		Completed  uint64     `json:"completed"`
-		Failed     uint64     `json:"failed"`
-		FailReason string     `json:"failReason,omitempty"`
-		FailLog    *FailLog   `json:"failLog,omitempty"`
-	}
-
-	FailLog struct {
-		// Records holds an array of record indexes
-		Records          RecordIndex `json:"records"`
-		RecordsTruncated bool        `json:"recordsTruncated"`
-		// Errors specifies a map of occurred errors & the number of
-		Errors ErrorIndex `json:"errors"`
-	}
-
-	RecordIndex []int
-	ErrorIndex  map[string]int
 )
 
 func Record() RecordService {
 	return (&record{
 		ac:            DefaultAccessControl,
 		eventbus:      eventbus.Service(),
+		reportCache:   DefaultRecordReportCache,
 		optEmitEvents: true,
 	}).With(context.Background())
 }
@@ -196,14 +239,20 @@ func (svc record) With(ctx context.Context) RecordService {
 		ac:       svc.ac,
 		eventbus: svc.eventbus,
 
-		recordRepo: repository.Record(ctx, db),
-		moduleRepo: repository.Module(ctx, db),
-		nsRepo:     repository.Namespace(ctx, db),
+		recordRepo:        repository.Record(ctx, db),
+		revisionRepo:      repository.RecordRevision(ctx, db),
+		importSessionRepo: repository.RecordImportSession(ctx, db),
+		viewRepo:          repository.RecordView(ctx, db),
+		moduleRepo:        repository.Module(ctx, db),
+		nsRepo:            repository.Namespace(ctx, db),
 
 		formatter: values.Formatter(),
 		sanitizer: values.Sanitizer(),
+		formula:   values.Formula(),
 		validator: validator,
 
+		reportCache: svc.reportCache,
+
 		optEmitEvents: svc.optEmitEvents,
 	}
 }
@@ -294,6 +343,41 @@ func (svc record) loadModule(namespaceID, moduleID uint64) (m *types.Module, err
 	}()
 }
 
+// refResolver resolves fields of the modules referenced through record fields
+// (dot-path identifiers in filters, sorting and reports)
+//
+// Referenced records and values of the resolved fields must be readable
+func (svc record) refResolver(namespaceID uint64) repository.RecordRefResolver {
+	var loaded = map[uint64]*types.Module{}
+
+	return func(ref *types.ModuleField, name string) (*types.ModuleField, error) {
+		var (
+			moduleID = ref.Options.ModuleID()
+			m, ok    = loaded[moduleID]
+			err      error
+		)
+
+		if !ok {
+			if m, err = svc.loadModule(namespaceID, moduleID); err != nil {
+				return nil, err
+			}
+
+			if !svc.ac.CanReadRecord(svc.ctx, m) {
+				return nil, RecordErrNotAllowedToRead()
+			}
+
+			loaded[moduleID] = m
+		}
+
+		f := m.Fields.FindByName(name)
+		if f != nil && !svc.ac.CanReadRecordValue(svc.ctx, f) {
+			return nil, RecordErrNotAllowedToReadFieldValue(&recordActionProps{field: ref.Name + "." + name})
+		}
+
+		return f, nil
+	}
+}
+
 func (svc record) loadNamespace(namespaceID uint64) (ns *types.Namespace, err error) {
 	return ns, func() error {
 		if namespaceID == 0 {
@@ -317,10 +401,11 @@ func (svc record) loadNamespace(namespaceID uint64) (ns *types.Namespace, err er
 }
 
 // Report generates report for a given module using metrics, dimensions and filter
-func (svc record) Report(namespaceID, moduleID uint64, metrics, dimensions, filter string) (out interface{}, err error) {
+func (svc record) Report(namespaceID, moduleID uint64, joins []*types.ChartConfigReportJoin, metrics, dimensions, filter string) (out interface{}, err error) {
 	var (
 		ns     *types.Namespace
 		m      *types.Module
+		rjj    []*repository.RecordReportJoin
 		aProps = &recordActionProps{record: &types.Record{NamespaceID: namespaceID}}
 	)
 
@@ -337,135 +422,176 @@ func (svc record) Report(namespaceID, moduleID uint64, metrics, dimensions, filt
 
 		aProps.setModule(m)
 
-		out, err = svc.recordRepo.Report(m, metrics, dimensions, filter)
+		if rjj, err = svc.reportJoins(m, joins); err != nil {
+			return err
+		}
+
+		out, err = svc.report(m, rjj, joins, metrics, dimensions, filter)
 		return err
 	}()
 
 	return out, svc.recordAction(svc.ctx, aProps, RecordActionReport, err)
 }
 
-func (svc record) Find(filter types.RecordFilter) (set types.RecordSet, f types.RecordFilter, err error) {
+// report runs the report or returns its cached result
+//
+// Fields of the referenced modules are resolved (and checked) again
+// when cached result is used
+func (svc record) report(m *types.Module, rjj []*repository.RecordReportJoin, joins []*types.ChartConfigReportJoin, metrics, dimensions, filter string) (interface{}, error) {
 	var (
-		m      *types.Module
-		aProps = &recordActionProps{filter: &filter}
+		refs = svc.refResolver(m.NamespaceID)
 	)
 
-	err = func() error {
-		if m, err = svc.loadModule(filter.NamespaceID, filter.ModuleID); err != nil {
-			return err
-		}
+	if svc.reportCache == nil {
+		return svc.recordRepo.WithRefResolver(refs).Report(m, rjj, metrics, dimensions, filter)
+	}
 
-		set, f, err = svc.recordRepo.Find(m, filter)
-		if err != nil {
-			return err
-		}
+	var (
+		key   = svc.reportCache.key(svc.ctx, m, joins, metrics, dimensions, filter)
+		entry = svc.reportCache.get(key)
+	)
 
-		if err = svc.preloadValues(m, set...); err != nil {
-			return err
+	if entry != nil {
+		for _, ref := range entry.refs {
+			if _, err := refs(ref.field, ref.name); err != nil {
+				return nil, err
+			}
 		}
 
-		return nil
-	}()
+		return entry.result, nil
+	}
 
-	return set, f, svc.recordAction(svc.ctx, aProps, RecordActionSearch, err)
+	moduleIDs, err := svc.reportDependencies(m, rjj)
+	if err != nil {
+		return nil, err
+	}
+
+	entry = &recordReportCacheEntry{modules: svc.reportCache.snapshot(moduleIDs...)}
+
+	recording := func(ref *types.ModuleField, name string) (*types.ModuleField, error) {
+		entry.refs = append(entry.refs, recordReportRef{field: ref, name: name})
+		return refs(ref, name)
+	}
+
+	if entry.result, err = svc.recordRepo.WithRefResolver(recording).Report(m, rjj, metrics, dimensions, filter); err != nil {
+		return nil, err
+	}
+
+	svc.reportCache.set(key, entry)
+	return entry.result, nil
 }
 
-func (svc record) Import(ses *RecordImportSession, ssvc ImportSessionService) (err error) {
+// reportDependencies returns IDs of all modules that can affect the report
+//
+// Report depends on its module, joined modules and (recursively) on all modules
+// referenced through record fields (dot-path identifiers) and rollup fields
+func (svc record) reportDependencies(m *types.Module, rjj []*repository.RecordReportJoin) ([]uint64, error) {
 	var (
-		aProps = &recordActionProps{}
+		edges = map[uint64][]uint64{}
+		seen  = map[uint64]bool{}
+		queue = []uint64{m.ID}
 	)
 
-	if ses.Decoder == nil {
-		return nil
+	for _, kind := range []string{"Record", "Rollup"} {
+		ff, err := svc.moduleRepo.FindFieldsByKind(m.NamespaceID, kind)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, f := range ff {
+			edges[f.ModuleID] = append(edges[f.ModuleID], f.Options.ModuleID())
+		}
 	}
 
-	err = func() (err error) {
+	for _, rj := range rjj {
+		queue = append(queue, rj.Module.ID)
+	}
 
-		if ses.Progress.StartedAt != nil {
-			return fmt.Errorf("Unable to start import: Import session already active")
+	for len(queue) > 0 {
+		moduleID := queue[0]
+		queue = queue[1:]
+
+		if moduleID == 0 || seen[moduleID] {
+			continue
 		}
 
-		sa := time.Now()
-		ses.Progress.StartedAt = &sa
-		ssvc.SetByID(svc.ctx, ses.SessionID, 0, 0, nil, &ses.Progress, nil)
+		seen[moduleID] = true
+		queue = append(queue, edges[moduleID]...)
+	}
 
-		index := 0
-		err = ses.Decoder.Records(ses.Fields, func(rec *types.Record) error {
-			index++
+	var moduleIDs = make([]uint64, 0, len(seen))
+	for moduleID := range seen {
+		moduleIDs = append(moduleIDs, moduleID)
+	}
 
-			rec.NamespaceID = ses.NamespaceID
-			rec.ModuleID = ses.ModuleID
-			rec.OwnedBy = ses.UserID
+	return moduleIDs, nil
+}
 
-			_, err := svc.Create(rec)
-			if err != nil {
-				recErr, isRecErr := err.(*recordError)
+// reportJoins resolves modules joined into the report
+//
+// Records of the joined modules and values of the
+// record fields that link the modules must be readable
+//
+// Aliases are checked when report query is built
+func (svc record) reportJoins(m *types.Module, joins []*types.ChartConfigReportJoin) ([]*repository.RecordReportJoin, error) {
+	var (
+		rjj = make([]*repository.RecordReportJoin, 0, len(joins))
+	)
 
-				ses.Progress.Failed++
-				ses.Progress.FailReason = err.Error()
+	for _, j := range joins {
+		jm, err := svc.loadModule(m.NamespaceID, j.ModuleID)
+		if err != nil {
+			return nil, err
+		}
 
-				if ses.Progress.FailLog == nil {
-					ses.Progress.FailLog = &FailLog{
-						Errors: make(ErrorIndex),
-					}
-				}
+		if !svc.ac.CanReadRecord(svc.ctx, jm) {
+			return nil, RecordErrNotAllowedToRead()
+		}
 
-				if isRecErr {
-					if evErr, ok := recErr.wrap.(*types.RecordValueErrorSet); ok {
-						for _, ve := range evErr.Set {
-							for k, v := range ve.Meta {
-								ses.Progress.FailLog.Errors.Add(fmt.Sprintf("%s %s %v", ve.Kind, k, v))
-							}
-						}
-					} else {
-						ses.Progress.FailLog.Errors.Add(err.Error())
-					}
-				} else {
-					ses.Progress.FailLog.Errors.Add(err.Error())
-				}
+		var (
+			rj   = &repository.RecordReportJoin{Alias: j.Alias, Module: jm, Field: j.Field}
+			link *types.ModuleField
+		)
 
-				if len(ses.Progress.FailLog.Records) < IMPORT_ERROR_MAX_INDEX_COUNT {
-					ses.Progress.FailLog.Records = append(ses.Progress.FailLog.Records, index)
-				} else {
-					ses.Progress.FailLog.RecordsTruncated = true
-				}
+		if f := m.Fields.FindByName(j.Field); f != nil && f.Kind == "Record" && f.Options.ModuleID() == jm.ID {
+			link = f
+		} else if f = jm.Fields.FindByName(j.Field); f != nil && f.Kind == "Record" && f.Options.ModuleID() == m.ID {
+			link = f
+			rj.Inverse = true
+		} else {
+			return nil, RecordErrInvalidReportJoin(&recordActionProps{value: j.Alias})
+		}
 
-				if ses.OnError == IMPORT_ON_ERROR_FAIL {
-					fa := time.Now()
-					ses.Progress.FinishedAt = &fa
-					ssvc.SetByID(svc.ctx, ses.SessionID, 0, 0, nil, &ses.Progress, nil)
-					return err
-				}
-			} else {
-				ses.Progress.Completed++
-			}
-			return nil
-		})
+		if !svc.ac.CanReadRecordValue(svc.ctx, link) {
+			return nil, RecordErrNotAllowedToReadFieldValue(&recordActionProps{field: link.Name})
+		}
 
-		fa := time.Now()
-		ses.Progress.FinishedAt = &fa
-		ssvc.SetByID(svc.ctx, ses.SessionID, 0, 0, nil, &ses.Progress, nil)
-		return
-	}()
+		rjj = append(rjj, rj)
+	}
 
-	return svc.recordAction(svc.ctx, aProps, RecordActionImport, err)
+	return rjj, nil
 }
 
-// Export returns all records
-//
-// @todo better value handling
-func (svc record) Export(filter types.RecordFilter, enc Encoder) (err error) {
+func (svc record) Find(filter types.RecordFilter) (set types.RecordSet, f types.RecordFilter, err error) {
 	var (
+		m      *types.Module
 		aProps = &recordActionProps{filter: &filter}
 	)
 
 	err = func() error {
-		m, err := svc.loadModule(filter.NamespaceID, filter.ModuleID)
-		if err != nil {
+		if m, err = svc.loadModule(filter.NamespaceID, filter.ModuleID); err != nil {
+			return err
+		}
+
+		if filter, err = svc.applyView(m, filter); err != nil {
 			return err
 		}
 
-		set, err := svc.recordRepo.Export(m, filter)
+		if filter.Q != "" {
+			filter.QFields = map[uint64][]string{m.ID: svc.searchableFields(m)}
+		}
+
+		set, f, err = svc.recordRepo.WithRefResolver(svc.refResolver(m.NamespaceID)).Find(m, filter)
 		if err != nil {
 			return err
 		}
@@ -474,910 +600,2224 @@ func (svc record) Export(filter types.RecordFilter, enc Encoder) (err error) {
 			return err
 		}
 
-		return set.Walk(enc.Record)
+		return nil
 	}()
 
-	return svc.recordAction(svc.ctx, aProps, RecordActionExport, err)
+	return set, f, svc.recordAction(svc.ctx, aProps, RecordActionSearch, err)
 }
 
-// Bulk handles provided set of bulk record operations.
-// It's able to create, update or delete records in a single transaction.
-func (svc record) Bulk(oo ...*types.RecordBulkOperation) (rr types.RecordSet, err error) {
-	var pr *types.Record
-
-	err = func() error {
-		// pre-verify all
-		for _, p := range oo {
-			switch p.Operation {
-			case types.OperationTypeCreate, types.OperationTypeUpdate, types.OperationTypeDelete:
-				// ok
-			default:
-				return RecordErrUnknownBulkOperation(&recordActionProps{bulkOperation: string(p.Operation)})
-			}
-		}
-
-		var (
-			// in case we get record value errors from create or update operations
-			// we ll merge the errors into one slice and return it all together
-			//
-			// this is done under assumption that potential before-record-update/create automation
-			// scripts are playing by the rules and do not do any changes before any potential
-			// record value errors are returned
-			//
-			// @todo all records/values could and should be pre-validated
-			//       before we start storing any changes
-			rves = &types.RecordValueErrorSet{}
+// applyView merges filter and sort of the record view (when set) into the filter
+//
+// View is referenced by ID or, when ID is not set, by handle.
+// View's filter is combined with the given query; given sort takes precedence
+func (svc record) applyView(m *types.Module, f types.RecordFilter) (types.RecordFilter, error) {
+	var (
+		v   *types.RecordView
+		err error
+	)
 
-			action func(props ...*recordActionProps) *recordAction
-			r      *types.Record
+	switch {
+	case f.ViewID > 0:
+		v, err = svc.viewRepo.FindByID(m.NamespaceID, f.ViewID)
+	case f.ViewHandle != "":
+		v, err = svc.viewRepo.FindByHandle(m.NamespaceID, f.ViewHandle)
+	default:
+		return f, nil
+	}
 
-			aProp = &recordActionProps{}
-		)
+	if err != nil {
+		if repository.ErrRecordViewNotFound.Eq(err) {
+			return f, RecordErrViewNotFound()
+		}
 
-		for _, p := range oo {
-			r = p.Record
+		return f, err
+	}
 
-			aProp.setChanged(r)
+	if !svc.ac.CanReadRecordView(svc.ctx, v) {
+		return f, RecordErrNotAllowedToReadView()
+	}
 
-			// Handle any pre processing, such as defining parent recordID.
-			if p.LinkBy != "" {
-				// As is, we can use the first record as the master record.
-				// This is valid, since we do not allow this, if the master record is not defined
-				rv := &types.RecordValue{
-					Name: p.LinkBy,
-				}
-				if pr != nil {
-					rv.Value = strconv.FormatUint(rr[0].ID, 10)
-					rv.Ref = rr[0].ID
-				}
-				r.Values = r.Values.Set(rv)
-			}
+	if v.ModuleID != m.ID {
+		return f, RecordErrViewModuleMismatch()
+	}
 
-			switch p.Operation {
-			case types.OperationTypeCreate:
-				action = RecordActionCreate
-				r, err = svc.create(r)
+	switch {
+	case v.Filter == "":
+	case f.Query == "":
+		f.Query = v.Filter
+	default:
+		f.Query = "(" + v.Filter + ") AND (" + f.Query + ")"
+	}
 
-			case types.OperationTypeUpdate:
-				action = RecordActionUpdate
-				r, err = svc.update(r)
+	if f.Sort == "" {
+		f.Sort = v.Sort
+	}
 
-			case types.OperationTypeDelete:
-				action = RecordActionDelete
-				r, err = svc.delete(r.NamespaceID, r.ModuleID, r.ID)
-			}
+	return f, nil
+}
 
-			if rve := types.IsRecordValueErrorSet(err); rve != nil {
-				// Attach additional meta to each value error for FE identification
-				for _, re := range rve.Set {
-					re.Meta["id"] = p.ID
+// Search finds records of all modules in the namespace by free-text search
+//
+// Modules with records that current user can not read are skipped; only
+// readable values of the searchable fields are matched
+func (svc record) Search(filter types.RecordFilter) (set types.RecordSet, f types.RecordFilter, err error) {
+	var (
+		ns     *types.Namespace
+		mm     types.ModuleSet
+		aProps = &recordActionProps{filter: &filter}
+	)
 
-					rves.Push(re)
-				}
+	err = func() error {
+		if ns, err = svc.loadNamespace(filter.NamespaceID); err != nil {
+			return err
+		}
 
-				// log record value error for this record
-				_ = svc.recordAction(svc.ctx, aProp, action, err)
+		aProps.setNamespace(ns)
 
-				// do not return errors just yet, values on other records from the payload (if any)
-				// might have errors too
-				continue
-			}
+		if strings.TrimSpace(filter.Q) == "" {
+			return RecordErrSearchTermsMissing()
+		}
 
-			if err != nil {
-				return svc.recordAction(svc.ctx, aProp, action, err)
-			}
+		if mm, err = svc.searchableModules(ns.ID); err != nil {
+			return err
+		}
 
-			rr = append(rr, r)
-			if pr == nil {
-				pr = r
-			}
+		filter.QFields = make(map[uint64][]string, len(mm))
+		for _, m := range mm {
+			filter.QFields[m.ID] = svc.searchableFields(m)
 		}
 
-		if !rves.IsValid() {
-			// Any errors gathered?
-			return RecordErrValueInput().Wrap(rves)
+		if set, f, err = svc.recordRepo.Search(ns.ID, filter); err != nil {
+			return err
 		}
 
-		return nil
-	}()
+		return mm.Walk(func(m *types.Module) error {
+			rr, _ := set.Filter(func(r *types.Record) (bool, error) {
+				return r.ModuleID == m.ID, nil
+			})
 
-	if len(oo) == 1 {
-		// was not really a bulk operation and we already recorded the action
-		// inside transaction loop
-		return rr, err
+			return svc.preloadValues(m, rr...)
+		})
+	}()
+
+	return set, f, svc.recordAction(svc.ctx, aProps, RecordActionSearch, err)
+}
+
+// searchableModules loads modules (with fields) of the namespace
+// that current user can read records of
+func (svc record) searchableModules(namespaceID uint64) (types.ModuleSet, error) {
+	mm, _, err := svc.moduleRepo.Find(types.ModuleFilter{NamespaceID: namespaceID})
+	if err != nil {
+		return nil, err
+	}
+
+	mm, _ = mm.Filter(func(m *types.Module) (bool, error) {
+		return svc.ac.CanReadModule(svc.ctx, m) && svc.ac.CanReadRecord(svc.ctx, m), nil
+	})
+
+	ff, err := svc.moduleRepo.FindFields(mm.IDs()...)
+	if err != nil {
+		return nil, err
+	}
+
+	return mm, mm.Walk(func(m *types.Module) error {
+		m.Fields = ff.FilterByModule(m.ID)
+		return nil
+	})
+}
+
+// Import validates import session and starts the import in background
+//
+// Progress is stored together with imported records; see ImportResume
+func (svc record) Import(ses *types.RecordImportSession, ssvc ImportSessionService) (err error) {
+	var (
+		aProps = &recordActionProps{}
+	)
+
+	err = func() (err error) {
+		if ses.Progress.StartedAt != nil {
+			return fmt.Errorf("Unable to start import: Import session already active")
+		}
+
+		m, err := svc.loadModule(ses.NamespaceID, ses.ModuleID)
+		if err != nil {
+			return err
+		}
+
+		aProps.setModule(m)
+
+		if err = svc.importModeCheck(m, ses); err != nil {
+			return err
+		}
+
+		ses.Progress.StartedAt = nowPtr()
+		ses.HeartbeatAt = ses.Progress.StartedAt
+		if ses, err = svc.importSessionRepo.Update(ses); err != nil {
+			return err
+		}
+
+		// Import outlives the request that started it
+		bg := auth.SetIdentityToContext(context.Background(), auth.GetIdentityFromContext(svc.ctx))
+
+		go func() {
+			defer sentry.Recover()
+			_ = svc.With(bg).ImportResume(ses, ssvc)
+		}()
+
+		return nil
+	}()
+
+	return svc.recordAction(svc.ctx, aProps, RecordActionImport, err)
+}
+
+// ImportResume imports records from the session source
+//
+// Records that were already processed (imported or failed) are skipped.
+// Imported records are committed in batches, in the same transaction as the
+// import progress so that import can be resumed from the last checkpoint after a crash.
+// Changes of the failed record are rolled back to the savepoint set before it.
+//
+// Dry-run imports only check records; progress is stored without opening any transaction.
+//
+// After-create and after-update events of imported records are emitted
+// when their batch is committed
+func (svc record) ImportResume(ses *types.RecordImportSession, ssvc ImportSessionService) (err error) {
+	var (
+		aProps = &recordActionProps{}
+		events = &importEvents{eventDispatcher: svc.eventbus}
+	)
+
+	err = func() (err error) {
+		m, err := svc.loadModule(ses.NamespaceID, ses.ModuleID)
+		if err != nil {
+			return err
+		}
+
+		aProps.setModule(m)
+
+		dec, closer, err := ssvc.Decoder(ses, m)
+		if err != nil {
+			return err
+		}
+
+		defer closer()
+
+		// Heartbeat is stored outside of the batch transaction
+		defer ssvc.Heartbeat(svc.ctx, ses.SessionID)()
+
+		var (
+			processed = ses.Progress.Processed()
+			index     = 0
+
+			// rows imported since the last checkpoint
+			pending = 0
+
+			// when the first of the pending rows was imported
+			pendingSince time.Time
+
+			// open batch of imported rows; always nil on dry-run
+			batch *importBatch
+
+			// set when the batch could not be committed;
+			// import is resumed from the last checkpoint
+			aborted = false
+
+			// report is only stored when it changes
+			reported = false
+
+			// stores progress and commits imported rows
+			checkpoint = func() (err error) {
+				if batch == nil {
+					err = importProgress(svc.importSessionRepo, ses, reported)
+				} else {
+					err = batch.commit(ses, reported)
+					batch = nil
+				}
+
+				pending = 0
+
+				if err != nil {
+					aborted = true
+					events.discard()
+					return err
+				}
+
+				reported = false
+				events.flush(svc.ctx)
+				return nil
+			}
+		)
+
+		err = dec.Records(ses.Fields, func(rec *types.Record) (err error) {
+			index++
+
+			if index <= processed {
+				return nil
+			}
+
+			rec.NamespaceID = ses.NamespaceID
+			rec.ModuleID = ses.ModuleID
+			rec.OwnedBy = ses.UserID
+
+			if pending == 0 {
+				pendingSince = time.Now()
+
+				if !ses.DryRun {
+					if batch, err = svc.importBatch(events); err != nil {
+						aborted = true
+						return err
+					}
+				}
+			}
+
+			var recErr error
+
+			if batch == nil {
+				recErr = svc.importRow(m, ses, index, rec)
+			} else if recErr, err = batch.row(m, ses, index, rec); err != nil {
+				batch.rollback()
+				batch, pending, aborted = nil, 0, true
+				events.discard()
+				return err
+			}
+
+			pending++
+			reported = reported || recErr != nil
+
+			if recErr != nil && ses.OnError == IMPORT_ON_ERROR_FAIL {
+				ses.Progress.FinishedAt = nowPtr()
+				ses.HeartbeatAt = nil
+			} else {
+				ses.HeartbeatAt = nowPtr()
+			}
+
+			if pending >= importCheckpointSize || time.Since(pendingSince) >= importCheckpointInterval || ses.Progress.FinishedAt != nil {
+				if err = checkpoint(); err != nil {
+					return err
+				}
+			}
+
+			if ses.Progress.FinishedAt != nil {
+				return recErr
+			}
+
+			return nil
+		})
+
+		if ses.Progress.FinishedAt == nil && !aborted {
+			ses.Progress.FinishedAt = nowPtr()
+			ses.HeartbeatAt = nil
+
+			if cErr := checkpoint(); err == nil {
+				err = cErr
+			}
+		}
+
+		return
+	}()
+
+	return svc.recordAction(svc.ctx, aProps, RecordActionImport, err)
+}
+
+// importBatch begins new batch of imported rows
+//
+// Batch uses its own database handle; nested transactions of the failed rows
+// can not leave it in an inconsistent state for the rows of the next batch
+func (svc record) importBatch(events eventDispatcher) (*importBatch, error) {
+	b := &importBatch{record: svc.With(svc.ctx).(*record)}
+	b.eventbus = events
+
+	return b, b.db.Begin()
+}
+
+// row imports one record of the batch
+//
+// Changes of the failed record are rolled back
+func (b *importBatch) row(m *types.Module, ses *types.RecordImportSession, index int, rec *types.Record) (recErr, err error) {
+	create, rollback, release := dialect.Current().Savepoint("import_row")
+
+	if _, err = b.db.Exec(create); err != nil {
+		return
+	}
+
+	if recErr = b.importRow(m, ses, index, rec); recErr != nil {
+		if _, err = b.db.Exec(rollback); err != nil {
+			return
+		}
+	}
+
+	_, err = b.db.Exec(release)
+	return
+}
+
+// commit stores progress and commits records imported in the batch
+func (b *importBatch) commit(ses *types.RecordImportSession, reported bool) error {
+	if err := importProgress(b.importSessionRepo, ses, reported); err != nil {
+		b.rollback()
+		return err
+	}
+
+	return b.db.Tx.Commit()
+}
+
+// rollback drops records imported in the batch
+func (b *importBatch) rollback() {
+	_ = b.db.Tx.Rollback()
+}
+
+// importProgress stores import progress or, when the report changed, the whole session
+func importProgress(repo repository.RecordImportSessionRepository, ses *types.RecordImportSession, reported bool) (err error) {
+	if reported {
+		_, err = repo.Update(ses)
 	} else {
-		// when doing bulk op (updating and/or creating more than one record at once),
-		// we already log action for each operation
-		//
-		// to log the fact that the bulk op was done, we do one additional recording
-		// without any props
-		return rr, svc.recordAction(svc.ctx, &recordActionProps{}, RecordActionBulk, err)
+		err = repo.UpdateProgress(ses)
 	}
+
+	return
 }
 
-// Raw create function that is responsible for value validation, event dispatching
-// and creation.
-func (svc record) create(new *types.Record) (rec *types.Record, err error) {
+// WaitFor queues after-events, other events are passed through
+func (q *importEvents) WaitFor(ctx context.Context, ev eventbus.Event) error {
+	if strings.HasPrefix(ev.EventType(), "after") {
+		q.queued = append(q.queued, ev)
+		return nil
+	}
+
+	return q.eventDispatcher.WaitFor(ctx, ev)
+}
+
+// flush emits queued events
+func (q *importEvents) flush(ctx context.Context) {
+	for _, ev := range q.queued {
+		_ = q.eventDispatcher.WaitFor(ctx, ev)
+	}
+
+	q.queued = nil
+}
+
+// discard drops queued events of the records that were not committed
+func (q *importEvents) discard() {
+	q.queued = nil
+}
+
+// importRow imports one record and updates session progress
+//
+// Returns import error of the record
+func (svc record) importRow(m *types.Module, ses *types.RecordImportSession, index int, rec *types.Record) error {
+	mode, err := svc.importRecord(m, ses, rec)
+	if err == nil {
+		ses.Progress.Completed++
+
+		switch mode {
+		case importCreated:
+			ses.Progress.Created++
+		case importUpdated:
+			ses.Progress.Updated++
+		case importSkipped:
+			ses.Progress.Skipped++
+		}
+
+		return nil
+	}
+
+	recErr, isRecErr := err.(*recordError)
+
+	if len(ses.Report) < IMPORT_ERROR_MAX_INDEX_COUNT {
+		importReportAdd(&ses.Report, index, err)
+	}
+
+	ses.Progress.Failed++
+	ses.Progress.FailReason = err.Error()
+
+	if ses.Progress.FailLog == nil {
+		ses.Progress.FailLog = &types.FailLog{
+			Errors: make(types.ErrorIndex),
+		}
+	}
+
+	if isRecErr {
+		if evErr, ok := recErr.wrap.(*types.RecordValueErrorSet); ok {
+			for _, ve := range evErr.Set {
+				for k, v := range ve.Meta {
+					ses.Progress.FailLog.Errors.Add(fmt.Sprintf("%s %s %v", ve.Kind, k, v))
+				}
+			}
+		} else {
+			ses.Progress.FailLog.Errors.Add(err.Error())
+		}
+	} else {
+		ses.Progress.FailLog.Errors.Add(err.Error())
+	}
+
+	if len(ses.Progress.FailLog.Records) < IMPORT_ERROR_MAX_INDEX_COUNT {
+		ses.Progress.FailLog.Records = append(ses.Progress.FailLog.Records, index)
+	} else {
+		ses.Progress.FailLog.RecordsTruncated = true
+	}
+
+	return err
+}
+
+// importModeCheck verifies import mode and field imported records are matched on
+func (svc record) importModeCheck(m *types.Module, ses *types.RecordImportSession) error {
+	switch ses.Mode {
+	case "", IMPORT_MODE_CREATE:
+		return nil
+	case IMPORT_MODE_UPSERT:
+	default:
+		return RecordErrImportModeInvalid(&recordActionProps{value: ses.Mode})
+	}
+
+	if ses.MatchField == IMPORT_MATCH_RECORD_ID {
+		return nil
+	}
+
+	if f := m.Fields.FindByName(ses.MatchField); f == nil || f.Multi || !f.Options.IsUnique() {
+		return RecordErrImportMatchFieldInvalid(&recordActionProps{field: ses.MatchField})
+	}
+
+	return nil
+}
+
+// importRecord creates new record or, in upsert mode, updates the matching one
+//
+// Values of the fields that are not imported are kept on the updated records;
+// in dry-run mode records are only checked and not stored
+func (svc record) importRecord(m *types.Module, ses *types.RecordImportSession, rec *types.Record) (int, error) {
+	var (
+		old *types.Record
+		err error
+	)
+
+	if ses.Mode == IMPORT_MODE_UPSERT {
+		if old, err = svc.importMatch(m, ses.MatchField, rec); err != nil {
+			return 0, err
+		}
+	}
+
+	if old == nil {
+		if ses.DryRun {
+			return importCreated, svc.importCheck(m, nil, rec)
+		}
+
+		_, err = svc.Create(rec)
+		return importCreated, err
+	}
+
+	rec.ID = old.ID
+	rec.OwnedBy = old.OwnedBy
+
+	imported := make(map[string]bool)
+	for _, v := range rec.Values {
+		imported[v.Name] = true
+	}
+
+	for _, v := range old.Values {
+		if !imported[v.Name] {
+			rec.Values = append(rec.Values, v)
+		}
+	}
+
+	if len(types.RecordValueSetDiff(old.Values, rec.Values)) == 0 {
+		return importSkipped, nil
+	}
+
+	if ses.DryRun {
+		return importUpdated, svc.importCheck(m, old, rec)
+	}
+
+	_, err = svc.Update(rec)
+	return importUpdated, err
+}
+
+// importCheck runs imported record through the same checks, sanitization and
+// validation as create (or update, when old record is given) without storing it
+func (svc record) importCheck(m *types.Module, old, rec *types.Record) error {
+	var (
+		invokerID = auth.GetIdentityFromContext(svc.ctx).Identity()
+
+		rve *types.RecordValueErrorSet
+	)
+
+	if old == nil && !svc.ac.CanCreateRecord(svc.ctx, m) {
+		return RecordErrNotAllowedToCreate()
+	}
+
+	if old != nil && !svc.ac.CanUpdateRecord(svc.ctx, m) {
+		return RecordErrNotAllowedToUpdate()
+	}
+
+	if err := svc.generalValueSetValidation(m, rec.Values); err != nil {
+		return err
+	}
+
+	if old == nil {
+		rec.Values = svc.setDefaultValues(m, rec.Values)
+		rve = svc.procCreate(invokerID, m, rec)
+	} else {
+		rve = svc.procUpdate(invokerID, m, rec, old)
+	}
+
+	if !rve.IsValid() {
+		return RecordErrValueInput().Wrap(rve)
+	}
+
+	return nil
+}
+
+// importMatch finds existing record the imported one should update
+func (svc record) importMatch(m *types.Module, matchField string, rec *types.Record) (*types.Record, error) {
+	var (
+		recordID uint64
+		err      error
+	)
+
+	if matchField == IMPORT_MATCH_RECORD_ID {
+		recordID = rec.ID
+	} else if vv := rec.Values.FilterByName(matchField); len(vv) > 0 && vv[0].Value != "" {
+		if recordID, err = svc.recordRepo.ValueLookup(m.ID, matchField, vv[0].Value); err != nil {
+			return nil, err
+		}
+	}
+
+	if recordID == 0 {
+		return nil, nil
+	}
+
+	old, err := svc.FindByID(m.NamespaceID, recordID)
+	if RecordErrNotFound().Is(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else if old.ModuleID != m.ID {
+		return nil, nil
+	}
+
+	return old, nil
+}
+
+// Export returns all records
+//
+// @todo better value handling
+func (svc record) Export(filter types.RecordFilter, enc Encoder) (err error) {
+	var (
+		aProps = &recordActionProps{filter: &filter}
+	)
+
+	err = func() error {
+		m, err := svc.loadModule(filter.NamespaceID, filter.ModuleID)
+		if err != nil {
+			return err
+		}
+
+		if filter, err = svc.applyView(m, filter); err != nil {
+			return err
+		}
+
+		return svc.walk(m, filter, func(set types.RecordSet) error {
+			if err = svc.preloadValues(m, set...); err != nil {
+				return err
+			}
+
+			return set.Walk(enc.Record)
+		})
+	}()
+
+	return svc.recordAction(svc.ctx, aProps, RecordActionExport, err)
+}
+
+// Bulk handles provided set of bulk record operations.
+// It's able to create, update or delete records in a single transaction.
+func (svc record) Bulk(oo ...*types.RecordBulkOperation) (rr types.RecordSet, err error) {
+	var pr *types.Record
+
+	err = func() error {
+		// pre-verify all
+		for _, p := range oo {
+			switch p.Operation {
+			case types.OperationTypeCreate, types.OperationTypeUpdate, types.OperationTypeDelete:
+				// ok
+			default:
+				return RecordErrUnknownBulkOperation(&recordActionProps{bulkOperation: string(p.Operation)})
+			}
+		}
+
+		var (
+			// in case we get record value errors from create or update operations
+			// we ll merge the errors into one slice and return it all together
+			//
+			// this is done under assumption that potential before-record-update/create automation
+			// scripts are playing by the rules and do not do any changes before any potential
+			// record value errors are returned
+			//
+			// @todo all records/values could and should be pre-validated
+			//       before we start storing any changes
+			rves = &types.RecordValueErrorSet{}
+
+			action func(props ...*recordActionProps) *recordAction
+			r      *types.Record
+
+			aProp = &recordActionProps{}
+		)
+
+		for _, p := range oo {
+			r = p.Record
+
+			aProp.setChanged(r)
+
+			// Handle any pre processing, such as defining parent recordID.
+			if p.LinkBy != "" {
+				// As is, we can use the first record as the master record.
+				// This is valid, since we do not allow this, if the master record is not defined
+				rv := &types.RecordValue{
+					Name: p.LinkBy,
+				}
+				if pr != nil {
+					rv.Value = strconv.FormatUint(rr[0].ID, 10)
+					rv.Ref = rr[0].ID
+				}
+				r.Values = r.Values.Set(rv)
+			}
+
+			switch p.Operation {
+			case types.OperationTypeCreate:
+				action = RecordActionCreate
+				r, err = svc.create(r)
+
+			case types.OperationTypeUpdate:
+				action = RecordActionUpdate
+				r, err = svc.update(r, types.RecordRevisionUpdate)
+
+			case types.OperationTypeDelete:
+				action = RecordActionDelete
+				r, err = svc.delete(r.NamespaceID, r.ModuleID, r.ID)
+			}
+
+			if rve := types.IsRecordValueErrorSet(err); rve != nil {
+				// Attach additional meta to each value error for FE identification
+				for _, re := range rve.Set {
+					re.Meta["id"] = p.ID
+
+					rves.Push(re)
+				}
+
+				// log record value error for this record
+				_ = svc.recordAction(svc.ctx, aProp, action, err)
+
+				// do not return errors just yet, values on other records from the payload (if any)
+				// might have errors too
+				continue
+			}
+
+			if err != nil {
+				return svc.recordAction(svc.ctx, aProp, action, err)
+			}
+
+			rr = append(rr, r)
+			if pr == nil {
+				pr = r
+			}
+		}
+
+		if !rves.IsValid() {
+			// Any errors gathered?
+			return RecordErrValueInput().Wrap(rves)
+		}
+
+		return nil
+	}()
+
+	if len(oo) == 1 {
+		// was not really a bulk operation and we already recorded the action
+		// inside transaction loop
+		return rr, err
+	} else {
+		// when doing bulk op (updating and/or creating more than one record at once),
+		// we already log action for each operation
+		//
+		// to log the fact that the bulk op was done, we do one additional recording
+		// without any props
+		return rr, svc.recordAction(svc.ctx, &recordActionProps{}, RecordActionBulk, err)
+	}
+}
+
+// Raw create function that is responsible for value validation, event dispatching
+// and creation.
+func (svc record) create(new *types.Record) (rec *types.Record, err error) {
+	var (
+		aProps    = &recordActionProps{changed: new}
+		invokerID = auth.GetIdentityFromContext(svc.ctx).Identity()
+
+		ns *types.Namespace
+		m  *types.Module
+	)
+
+	ns, m, _, err = svc.loadCombo(new.NamespaceID, new.ModuleID, 0)
+	if err != nil {
+		return
+	}
+
+	aProps.setNamespace(ns)
+	aProps.setModule(m)
+
+	if !svc.ac.CanCreateRecord(svc.ctx, m) {
+		return nil, RecordErrNotAllowedToCreate()
+	}
+
+	if err = svc.generalValueSetValidation(m, new.Values); err != nil {
+		return
+	}
+
+	var (
+		rve *types.RecordValueErrorSet
+	)
+
+	if svc.optEmitEvents {
+		// Handle input payload
+		if rve = svc.procCreate(invokerID, m, new); !rve.IsValid() {
+			return nil, RecordErrValueInput().Wrap(rve)
+		}
+
+		new.Values = svc.formatter.Run(m, new.Values)
+		if err = svc.eventbus.WaitFor(svc.ctx, event.RecordBeforeCreate(new, nil, m, ns, rve)); err != nil {
+			return
+		} else if !rve.IsValid() {
+			return nil, RecordErrValueInput().Wrap(rve)
+		}
+	}
+
+	// Assign defaults (only on missing values)
+	new.Values = svc.setDefaultValues(m, new.Values)
+
+	// Handle payload from automation scripts
+	if rve = svc.procCreate(invokerID, m, new); !rve.IsValid() {
+		return nil, RecordErrValueInput().Wrap(rve)
+	}
+
+	err = svc.db.Transaction(func() error {
+		var rr recordRollups
+
+		if new, err = svc.recordRepo.Create(new); err != nil {
+			return err
+		}
+
+		if rr, err = svc.rollupRefs(m); err != nil {
+			return err
+		}
+
+		if err = svc.rollupValues(m, new); err != nil {
+			return err
+		}
+
+		if err = svc.revise(types.RecordRevisionCreate, m, new, new.Values); err != nil {
+			return err
+		}
+
+		if err = svc.recordRepo.UpdateValues(new.ID, new.Values); err != nil {
+			return err
+		}
+
+		return svc.updateRollups(rr, new.Values)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	// At this point we can return the value
+	rec = new
+
+	if svc.optEmitEvents {
+		new.Values = svc.formatter.Run(m, new.Values)
+		_ = svc.eventbus.WaitFor(svc.ctx, event.RecordAfterCreateImmutable(new, nil, m, ns, nil))
+	} else {
+		// Cached reports are otherwise invalidated by after-create handler
+		svc.reportCache.invalidate(m.ID)
+	}
+
+	return
+}
+
+// Raw update function that is responsible for value validation, event dispatching
+// and update.
+//
+// Revision operation is passed through to the stored record revision
+func (svc record) update(upd *types.Record, op types.RecordRevisionOperation) (rec *types.Record, err error) {
+	var (
+		aProps    = &recordActionProps{changed: upd}
+		invokerID = auth.GetIdentityFromContext(svc.ctx).Identity()
+
+		ns  *types.Namespace
+		m   *types.Module
+		old *types.Record
+	)
+
+	if upd.ID == 0 {
+		return nil, RecordErrInvalidID()
+	}
+
+	ns, m, old, err = svc.loadCombo(upd.NamespaceID, upd.ModuleID, upd.ID)
+	if err != nil {
+		return
+	}
+
+	aProps.setNamespace(ns)
+	aProps.setModule(m)
+	aProps.setRecord(old)
+
+	if !svc.ac.CanUpdateRecord(svc.ctx, m) {
+		return nil, RecordErrNotAllowedToUpdate()
+	}
+
+	// Test if stale (update has an older version of data)
+	if isStale(upd.UpdatedAt, old.UpdatedAt, old.CreatedAt) {
+		return nil, RecordErrStaleData()
+	}
+
+	if err = svc.generalValueSetValidation(m, upd.Values); err != nil {
+		return
+	}
+
+	// Preload old record values so we can send it together with event
+	if err = svc.preloadValues(m, old); err != nil {
+		return
+	}
+
+	var (
+		rve *types.RecordValueErrorSet
+	)
+
+	if svc.optEmitEvents {
+		// Handle input payload
+		if rve = svc.procUpdate(invokerID, m, upd, old); !rve.IsValid() {
+			return nil, RecordErrValueInput().Wrap(rve)
+		}
+
+		// Before we pass values to record-before-update handling events
+		// values needs do be cleaned up
+		//
+		// Value merge inside procUpdate sets delete flag we need
+		// when changes are applied but we do not want deleted values
+		// to be sent to handler
+		upd.Values = upd.Values.GetClean()
+
+		// Before we pass values to automation scripts, they should be formatted
+		upd.Values = svc.formatter.Run(m, upd.Values)
+
+		// Scripts can (besides simple error value) return complex record value error set
+		// that is passed back to the UI or any other API consumer
+		//
+		// rve (record-validation-errorset) struct is passed so it can be
+		// used & filled by automation scripts
+		if err = svc.eventbus.WaitFor(svc.ctx, event.RecordBeforeUpdate(upd, old, m, ns, rve)); err != nil {
+			return
+		} else if !rve.IsValid() {
+			return nil, RecordErrValueInput().Wrap(rve)
+		}
+	}
+
+	// Handle payload from automation scripts
+	if rve = svc.procUpdate(invokerID, m, upd, old); !rve.IsValid() {
+		return nil, RecordErrValueInput().Wrap(rve)
+	}
+
+	err = svc.db.Transaction(func() error {
+		var rr recordRollups
+
+		if upd, err = svc.recordRepo.Update(upd); err != nil {
+			return nil
+		}
+
+		if rr, err = svc.rollupRefs(m, upd.ID); err != nil {
+			return err
+		}
+
+		if err = svc.rollupValues(m, upd); err != nil {
+			return err
+		}
+
+		if err = svc.revise(op, m, upd, upd.Values); err != nil {
+			return err
+		}
+
+		if err = svc.recordRepo.UpdateValues(upd.ID, upd.Values); err != nil {
+			return err
+		}
+
+		return svc.updateRollups(rr, upd.Values.GetClean())
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Final value cleanup
+	// These (clean) values are returned (and sent to after-update handler)
+	upd.Values = upd.Values.GetClean()
+
+	// At this point we can return the value
+	rec = upd
+
+	if svc.optEmitEvents {
+		// Before we pass values to automation scripts, they should be formatted
+		upd.Values = svc.formatter.Run(m, upd.Values)
+		_ = svc.eventbus.WaitFor(svc.ctx, event.RecordAfterUpdateImmutable(upd, old, m, ns, nil))
+	} else {
+		svc.reportCache.invalidate(m.ID)
+	}
+	return
+}
+
+func (svc record) Create(new *types.Record) (rec *types.Record, err error) {
+	var (
+		aProps = &recordActionProps{changed: new}
+	)
+
+	err = func() error {
+		rec, err = svc.create(new)
+		aProps.setRecord(rec)
+		return err
+	}()
+
+	return rec, svc.recordAction(svc.ctx, aProps, RecordActionCreate, err)
+}
+
+// Runs value sanitization, sets values that should be used
+// and validates the final result
+//
+// This logic is kept in a utility function - it's used in the beginning
+// of the creation procedure and after results are back from the automation scripts
+//
+// Both these points introduce external data that need to be checked fully in the same manner
+func (svc record) procCreate(invokerID uint64, m *types.Module, new *types.Record) *types.RecordValueErrorSet {
+	// Mark all values as updated (new)
+	new.Values.SetUpdatedFlag(true)
+
+	// Before values are processed further and
+	// sent to automation scripts (if any)
+	// we need to make sure it does not get un-sanitized data
+	new.Values = svc.sanitizer.Run(m, new.Values)
+
+	// Compute values of formula fields from sanitized values
+	new.Values = svc.formula.Run(m, new.Values)
+
+	// Reset values to new record
+	// to make sure nobody slips in something we do not want
+	new.CreatedBy = invokerID
+	new.CreatedAt = *nowPtr()
+	new.UpdatedAt = nil
+	new.UpdatedBy = 0
+	new.DeletedAt = nil
+	new.DeletedBy = 0
+
+	if new.OwnedBy == 0 {
+		// If od owner is not set, make current user
+		// the owner of the record
+		new.OwnedBy = invokerID
+	}
+
+	rve := &types.RecordValueErrorSet{}
+	_ = new.Values.Walk(func(v *types.RecordValue) error {
+		// Formula and rollup values are computed and not set by the user
+		f := m.Fields.FindByName(v.Name)
+		if v.IsUpdated() && (f == nil || !f.IsComputed()) && !svc.ac.CanUpdateRecordValue(svc.ctx, f) {
+			rve.Push(types.RecordValueError{Kind: "updateDenied", Meta: map[string]interface{}{"field": v.Name, "value": v.Value}})
+		}
+
+		return nil
+	})
+
+	if !rve.IsValid() {
+		return rve
+	}
+
+	// Run validation of the updated records
+	return svc.validator.Run(m, new)
+}
+
+func (svc record) Update(upd *types.Record) (rec *types.Record, err error) {
+	var (
+		aProps = &recordActionProps{changed: upd}
+	)
+
+	err = func() error {
+		rec, err = svc.update(upd, types.RecordRevisionUpdate)
+		aProps.setRecord(rec)
+		return err
+	}()
+
+	return rec, svc.recordAction(svc.ctx, aProps, RecordActionUpdate, err)
+}
+
+// Runs value sanitization, copies values that should updated
+// and validates the final result
+//
+// This logic is kept in a utility function - it's used in the beginning
+// of the update procedure and after results are back from the automation scripts
+//
+// Both these points introduce external data that need to be checked fully in the same manner
+func (svc record) procUpdate(invokerID uint64, m *types.Module, upd *types.Record, old *types.Record) *types.RecordValueErrorSet {
+	// Mark all values as updated (new)
+	upd.Values.SetUpdatedFlag(true)
+
+	// First sanitization
+	//
+	// Before values are merged with existing data and
+	// sent to automation scripts (if any)
+	// we need to make sure it does not get sanitized data
+	upd.Values = svc.sanitizer.Run(m, upd.Values)
+
+	// Copy values to updated record
+	// to make sure nobody slips in something we do not want
+	upd.CreatedAt = old.CreatedAt
+	upd.CreatedBy = old.CreatedBy
+	upd.UpdatedAt = nowPtr()
+	upd.UpdatedBy = invokerID
+	upd.DeletedAt = old.DeletedAt
+	upd.DeletedBy = old.DeletedBy
+
+	if upd.OwnedBy == 0 {
+		if old.OwnedBy > 0 {
+			// Owner not set/send in the payload
+			//
+			// Fallback to old owner (if set)
+			upd.OwnedBy = old.OwnedBy
+		} else {
+			// If od owner is not set, make current user
+			// the owner of the record
+			upd.OwnedBy = invokerID
+		}
+	}
+
+	// Value merge process does not know anything about permissions so
+	// in case when new values are missing but do exist in the old set and their update/read is denied
+	// we need to copy them to ensure value merge process them correctly
+	for _, f := range m.Fields {
+		if len(upd.Values.FilterByName(f.Name)) == 0 && !svc.ac.CanUpdateRecordValue(svc.ctx, m.Fields.FindByName(f.Name)) {
+			// copy all fields from old to new
+			upd.Values = append(upd.Values, old.Values.FilterByName(f.Name).GetClean()...)
+		}
+	}
+
+	// Compute values of formula fields from sanitized values
+	// (incl. the ones copied from the old record)
+	upd.Values = svc.formula.Run(m, upd.Values)
+
+	// Merge new (updated) values with old ones
+	// This way we get list of updated, stale and deleted values
+	// that we can selectively update in the repository
+	upd.Values = old.Values.Merge(upd.Values)
+
+	rve := &types.RecordValueErrorSet{}
+	_ = upd.Values.Walk(func(v *types.RecordValue) error {
+		// Formula and rollup values are computed and not set by the user
+		f := m.Fields.FindByName(v.Name)
+		if v.IsUpdated() && (f == nil || !f.IsComputed()) && !svc.ac.CanUpdateRecordValue(svc.ctx, f) {
+			rve.Push(types.RecordValueError{Kind: "updateDenied", Meta: map[string]interface{}{"field": v.Name, "value": v.Value}})
+		}
+
+		return nil
+	})
+
+	if !rve.IsValid() {
+		return rve
+	}
+
+	// Run validation of the updated records
+	return svc.validator.Run(m, upd)
+}
+
+func (svc record) recordInfoUpdate(r *types.Record) {
+	now := time.Now()
+	r.UpdatedAt = &now
+	r.UpdatedBy = auth.GetIdentityFromContext(svc.ctx).Identity()
+}
+
+func (svc record) delete(namespaceID, moduleID, recordID uint64) (del *types.Record, err error) {
+	var (
+		ns *types.Namespace
+		m  *types.Module
+
+		invokerID = auth.GetIdentityFromContext(svc.ctx).Identity()
+	)
+
+	if namespaceID == 0 {
+		return nil, RecordErrInvalidNamespaceID()
+	}
+	if moduleID == 0 {
+		return nil, RecordErrInvalidModuleID()
+	}
+	if recordID == 0 {
+		return nil, RecordErrInvalidID()
+	}
+
+	ns, m, del, err = svc.loadCombo(namespaceID, moduleID, recordID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !svc.ac.CanDeleteRecord(svc.ctx, m) {
+		return nil, RecordErrNotAllowedToDelete()
+	}
+
+	if svc.optEmitEvents {
+		// Preload old record values so we can send it together with event
+		if err = svc.preloadValues(m, del); err != nil {
+			return nil, err
+		}
+
+		// Calling before-record-delete scripts
+		if err = svc.eventbus.WaitFor(svc.ctx, event.RecordBeforeDelete(nil, del, m, ns, nil)); err != nil {
+			return nil, err
+		}
+	}
+
+	del.DeletedAt = nowPtr()
+	del.DeletedBy = invokerID
+
+	rm := &recordRemoval{removed: map[uint64]bool{}}
+	err = svc.db.Transaction(func() error {
+		return svc.remove(m, del, rm)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if svc.optEmitEvents {
+		_ = svc.eventbus.WaitFor(svc.ctx, event.RecordAfterDeleteImmutable(nil, del, m, ns, nil))
+	} else {
+		svc.reportCache.invalidate(m.ID)
+	}
+
+	svc.referentialRulesApplied(ns, rm, svc.optEmitEvents)
+	return del, nil
+}
+
+// DeleteByID removes one or more records (all from the same module and namespace)
+//
+// Before and after each record is deleted beforeDelete and afterDelete events are emitted
+// If beforeRecord aborts the action it does so for that specific record only
+func (svc record) DeleteByID(namespaceID, moduleID uint64, recordIDs ...uint64) (err error) {
+	var (
+		aProps = &recordActionProps{
+			namespace: &types.Namespace{ID: namespaceID},
+			module:    &types.Module{ID: moduleID},
+		}
+
+		isBulkDelete = len(recordIDs) > 1
+
+		ns *types.Namespace
+		m  *types.Module
+		r  *types.Record
+	)
+
+	err = func() error {
+		if namespaceID == 0 {
+			return RecordErrInvalidNamespaceID()
+		}
+		if moduleID == 0 {
+			return RecordErrInvalidModuleID()
+		}
+
+		ns, m, _, err = svc.loadCombo(namespaceID, moduleID, 0)
+		if err != nil {
+			return err
+		}
+
+		aProps.setNamespace(ns)
+		aProps.setModule(m)
+
+		if !svc.ac.CanDeleteRecord(svc.ctx, m) {
+			return RecordErrNotAllowedToDelete()
+		}
+
+		return nil
+	}()
+
+	if err != nil {
+		return svc.recordAction(svc.ctx, aProps, RecordActionDelete, err)
+	}
+
+	for _, recordID := range recordIDs {
+		err := func() (err error) {
+			r, err = svc.delete(namespaceID, moduleID, recordID)
+			aProps.setRecord(r)
+
+			// Record each record deletion action
+			return svc.recordAction(svc.ctx, aProps, RecordActionDelete, err)
+		}()
+
+		// We'll not break for failed delete,
+		// if we are deleting records in bulk.
+		if err != nil && !isBulkDelete {
+			return err
+		}
+
+	}
+
+	// all errors (if any) were recorded
+	// and in case of error for a non-bulk record deletion
+	// error is already returned
+	return nil
+}
+
+func (svc record) Organize(namespaceID, moduleID, recordID uint64, posField, position, filter, grpField, group string) (err error) {
+	var (
+		ns *types.Namespace
+		m  *types.Module
+		r  *types.Record
+
+		recordValues = types.RecordValueSet{}
+
+		aProps = &recordActionProps{record: &types.Record{NamespaceID: namespaceID, ModuleID: moduleID, ID: recordID}}
+
+		reorderingRecords bool
+	)
+
+	err = func() error {
+		ns, m, r, err = svc.loadCombo(namespaceID, moduleID, recordID)
+		if err != nil {
+			return err
+		}
+
+		aProps.setNamespace(ns)
+		aProps.setModule(m)
+		aProps.setRecord(r)
+
+		if !svc.ac.CanUpdateRecord(svc.ctx, m) {
+			return RecordErrNotAllowedToUpdate()
+		}
+
+		if posField != "" {
+			reorderingRecords = true
+
+			if !regexp.MustCompile(`^[0-9]+$`).MatchString(position) {
+				return fmt.Errorf("expecting number for sorting position %q", posField)
+			}
+
+			// Check field existence and permissions
+			// check if numeric -- we can not reorder on any other field type
+
+			sf := m.Fields.FindByName(posField)
+			if sf == nil {
+				return fmt.Errorf("no such field %q", posField)
+			}
+
+			if !sf.IsNumeric() {
+				return fmt.Errorf("can not reorder on non numeric field %q", posField)
+			}
+
+			if sf.Multi {
+				return fmt.Errorf("can not reorder on multi-value field %q", posField)
+			}
+
+			if !svc.ac.CanUpdateRecordValue(svc.ctx, sf) {
+				return RecordErrNotAllowedToUpdate()
+			}
+
+			// Set new position
+			recordValues = recordValues.Set(&types.RecordValue{
+				RecordID: recordID,
+				Name:     posField,
+				Value:    position,
+			})
+		}
+
+		if grpField != "" {
+			// Check field existence and permissions
+
+			vf := m.Fields.FindByName(grpField)
+			if vf == nil {
+				return fmt.Errorf("no such field %q", grpField)
+			}
+
+			if vf.Multi {
+				return fmt.Errorf("can not update multi-value field %q", posField)
+			}
+
+			if !svc.ac.CanUpdateRecordValue(svc.ctx, vf) {
+				return RecordErrNotAllowedToUpdate()
+			}
+
+			// Set new value
+			recordValues = recordValues.Set(&types.RecordValue{
+				RecordID: recordID,
+				Name:     grpField,
+				Value:    group,
+			})
+		}
+
+		return svc.db.Transaction(func() error {
+			if len(recordValues) > 0 {
+				svc.recordInfoUpdate(r)
+				if _, err = svc.recordRepo.Update(r); err != nil {
+					return err
+				}
+
+				// Apply partial changes to stored values so we can make a revision
+				//
+				// Changed positions of the records that follow are not revised.
+				var rvs types.RecordValueSet
+				if rvs, err = svc.recordRepo.LoadValues(m.Fields.Names(), []uint64{r.ID}); err != nil {
+					return err
+				}
+
+				for _, v := range recordValues {
+					rvs = rvs.Set(v)
+				}
+
+				if err = svc.revise(types.RecordRevisionUpdate, m, r, rvs); err != nil {
+					return err
+				}
+
+				var rr recordRollups
+				if rr, err = svc.rollupRefs(m, r.ID); err != nil {
+					return err
+				}
+
+				if err = svc.recordRepo.PartialUpdateValues(recordValues...); err != nil {
+					return err
+				}
+
+				if err = svc.updateRollups(rr, recordValues); err != nil {
+					return err
+				}
+			}
+
+			if reorderingRecords {
+				var (
+					set              types.RecordSet
+					recordOrderPlace uint64
+				)
+
+				// If we already have filter, wrap it in parenthesis
+				if filter != "" {
+					filter = fmt.Sprintf("(%s) AND ", filter)
+				}
+
+				if recordOrderPlace, err = strconv.ParseUint(position, 0, 64); err != nil {
+					return err
+				}
+
+				// Assemble record filter:
+				// We are interested only in records that have value of a sorting field greater than
+				// the place we're moving our record to.
+				// and sort the set with sorting field
+				set, _, err = svc.recordRepo.Find(m, types.RecordFilter{
+					Query: fmt.Sprintf("%s(%s >= %d)", filter, posField, recordOrderPlace),
+					Sort:  posField,
+				})
+
+				if err != nil {
+					return err
+				}
+
+				// Update value on each record
+				return set.Walk(func(r *types.Record) error {
+					recordOrderPlace++
+
+					// Update each and every set
+					return svc.recordRepo.PartialUpdateValues(&types.RecordValue{
+						RecordID: r.ID,
+						Name:     posField,
+						Value:    strconv.FormatUint(recordOrderPlace, 10),
+					})
+				})
+			}
+
+			return nil
+		})
+	}()
+
+	if err == nil && m != nil {
+		// Organizing does not emit any events
+		svc.reportCache.invalidate(m.ID)
+	}
+
+	return svc.recordAction(svc.ctx, aProps, RecordActionOrganize, err)
+
+}
+
+// Iterator loads and iterates through list of records
+//
+// For each record, RecordOnIteration is generated and passed to fn()
+// to be then passed to automation script that invoked the iteration
+//
+// No other triggers (before/after update/delete/create) are fired when (if)
+// records are changed
+//
+// action arg enables one of the following scenarios:
+//   - clone:   make new record (unless aborted)
+//   - update:  update records (unless aborted)
+//   - delete:  delete records (unless aborted)
+//   - default: only iterates over records, records are not changed, return value is ignored
+//
+//
+// Iterator can be invoked only when defined in corredor script:
+//
+// return default {
+//   iterator (each) {
+//     return each({
+//       resourceType: 'compose:record',
+//       // action: 'update',
+//       filter: {
+//         namespace: '122709101053521922',
+//         module: '122709116471783426',
+//         query: 'Status = "foo"',
+//         sort: 'Status DESC',
+//         limit: 3,
+//       },
+//     })
+//   },
+//
+//   // this is required in case of a deferred iterator
+//   // security: { runAs: .... } }
+//
+//   // exec gets called for every record found by iterator
+//   exec () { ... }
+// }
+func (svc record) Iterator(f types.RecordFilter, fn eventbus.HandlerFn, action string) (err error) {
 	var (
-		aProps    = &recordActionProps{changed: new}
-		invokerID = auth.GetIdentityFromContext(svc.ctx).Identity()
-
 		ns *types.Namespace
 		m  *types.Module
-	)
-
-	ns, m, _, err = svc.loadCombo(new.NamespaceID, new.ModuleID, 0)
-	if err != nil {
-		return
-	}
-
-	aProps.setNamespace(ns)
-	aProps.setModule(m)
-
-	if !svc.ac.CanCreateRecord(svc.ctx, m) {
-		return nil, RecordErrNotAllowedToCreate()
-	}
 
-	if err = svc.generalValueSetValidation(m, new.Values); err != nil {
-		return
-	}
-
-	var (
-		rve *types.RecordValueErrorSet
+		aProps = &recordActionProps{}
 	)
 
-	if svc.optEmitEvents {
-		// Handle input payload
-		if rve = svc.procCreate(invokerID, m, new); !rve.IsValid() {
-			return nil, RecordErrValueInput().Wrap(rve)
+	err = func() error {
+		if ns, m, _, err = svc.loadCombo(f.NamespaceID, f.ModuleID, 0); err != nil {
+			return err
 		}
 
-		new.Values = svc.formatter.Run(m, new.Values)
-		if err = svc.eventbus.WaitFor(svc.ctx, event.RecordBeforeCreate(new, nil, m, ns, rve)); err != nil {
-			return
-		} else if !rve.IsValid() {
-			return nil, RecordErrValueInput().Wrap(rve)
+		if !svc.ac.CanReadRecord(svc.ctx, m) {
+			return RecordErrNotAllowedToRead()
 		}
-	}
 
-	// Assign defaults (only on missing values)
-	new.Values = svc.setDefaultValues(m, new.Values)
+		switch action {
+		case "clone":
+			if !svc.ac.CanCreateRecord(svc.ctx, m) {
+				return RecordErrNotAllowedToCreate()
+			}
 
-	// Handle payload from automation scripts
-	if rve = svc.procCreate(invokerID, m, new); !rve.IsValid() {
-		return nil, RecordErrValueInput().Wrap(rve)
-	}
+		case "update":
+			if !svc.ac.CanUpdateRecord(svc.ctx, m) {
+				return RecordErrNotAllowedToUpdate()
+			}
 
-	err = svc.db.Transaction(func() error {
-		if new, err = svc.recordRepo.Create(new); err != nil {
-			return err
+		case "delete":
+			if !svc.ac.CanDeleteRecord(svc.ctx, m) {
+				return RecordErrNotAllowedToDelete()
+			}
 		}
 
-		return svc.recordRepo.UpdateValues(new.ID, new.Values)
-	})
+		if f, err = svc.applyView(m, f); err != nil {
+			return err
+		}
 
-	if err != nil {
-		return nil, err
-	}
+		return svc.walk(m, f, func(set types.RecordSet) error {
+			if err = svc.preloadValues(m, set...); err != nil {
+				return err
+			}
 
-	// At this point we can return the value
-	rec = new
+			return svc.iterate(ns, m, set, fn, action, aProps)
+		})
+	}()
 
-	if svc.optEmitEvents {
-		new.Values = svc.formatter.Run(m, new.Values)
-		_ = svc.eventbus.WaitFor(svc.ctx, event.RecordAfterCreateImmutable(new, nil, m, ns, nil))
-	}
+	return svc.recordAction(svc.ctx, aProps, RecordActionIteratorInvoked, err)
 
-	return
 }
 
-// Raw update function that is responsible for value validation, event dispatching
-// and update.
-func (svc record) update(upd *types.Record) (rec *types.Record, err error) {
+// iterate runs iteration handler and iterator action for each record in the set
+func (svc record) iterate(ns *types.Namespace, m *types.Module, set types.RecordSet, fn eventbus.HandlerFn, action string, aProps *recordActionProps) (err error) {
 	var (
-		aProps    = &recordActionProps{changed: upd}
 		invokerID = auth.GetIdentityFromContext(svc.ctx).Identity()
-
-		ns  *types.Namespace
-		m   *types.Module
-		old *types.Record
 	)
 
-	if upd.ID == 0 {
-		return nil, RecordErrInvalidID()
-	}
+	for _, rec := range set {
+		recordableAction := RecordActionIteratorIteration
 
-	ns, m, old, err = svc.loadCombo(upd.NamespaceID, upd.ModuleID, upd.ID)
-	if err != nil {
-		return
-	}
+		err = func() error {
+			if err = fn(svc.ctx, event.RecordOnIteration(rec, nil, m, ns, nil)); err != nil {
+				if err.Error() != "Aborted" {
+					// When script was softly aborted (return false),
+					// proceed with iteration but do not clone, update or delete
+					// current record!
+					return nil
+				}
+			}
 
-	aProps.setNamespace(ns)
-	aProps.setModule(m)
-	aProps.setRecord(old)
+			switch action {
+			case "clone":
+				recordableAction = RecordActionIteratorClone
 
-	if !svc.ac.CanUpdateRecord(svc.ctx, m) {
-		return nil, RecordErrNotAllowedToUpdate()
-	}
+				var cln *types.Record
 
-	// Test if stale (update has an older version of data)
-	if isStale(upd.UpdatedAt, old.UpdatedAt, old.CreatedAt) {
-		return nil, RecordErrStaleData()
-	}
+				// Assign defaults (only on missing values)
+				rec.Values = svc.setDefaultValues(m, rec.Values)
 
-	if err = svc.generalValueSetValidation(m, upd.Values); err != nil {
-		return
-	}
+				// Handle payload from automation scripts
+				if rve := svc.procCreate(invokerID, m, rec); !rve.IsValid() {
+					return RecordErrValueInput().Wrap(rve)
+				}
 
-	// Preload old record values so we can send it together with event
-	if err = svc.preloadValues(m, old); err != nil {
-		return
+				return svc.db.Transaction(func() error {
+					var rr recordRollups
+
+					if cln, err = svc.recordRepo.Create(rec); err != nil {
+						return err
+					} else if rr, err = svc.rollupRefs(m); err != nil {
+						return err
+					} else if err = svc.rollupValues(m, cln); err != nil {
+						return err
+					} else if err = svc.revise(types.RecordRevisionCreate, m, cln, cln.Values); err != nil {
+						return err
+					} else if err = svc.recordRepo.UpdateValues(cln.ID, cln.Values); err != nil {
+						return err
+					}
+
+					return svc.updateRollups(rr, cln.Values)
+				})
+			case "update":
+				recordableAction = RecordActionIteratorUpdate
+
+				// Handle input payload
+				if rve := svc.procUpdate(invokerID, m, rec, rec); !rve.IsValid() {
+					return RecordErrValueInput().Wrap(rve)
+				}
+
+				return svc.db.Transaction(func() error {
+					var rr recordRollups
+
+					if rec, err = svc.recordRepo.Update(rec); err != nil {
+						return err
+					} else if rr, err = svc.rollupRefs(m, rec.ID); err != nil {
+						return err
+					} else if err = svc.rollupValues(m, rec); err != nil {
+						return err
+					} else if err = svc.revise(types.RecordRevisionUpdate, m, rec, rec.Values); err != nil {
+						return err
+					} else if err = svc.recordRepo.UpdateValues(rec.ID, rec.Values); err != nil {
+						return err
+					}
+
+					return svc.updateRollups(rr, rec.Values.GetClean())
+				})
+			case "delete":
+				recordableAction = RecordActionIteratorDelete
+
+				rm := &recordRemoval{removed: map[uint64]bool{}}
+				if err = svc.db.Transaction(func() error { return svc.remove(m, rec, rm) }); err != nil {
+					return err
+				}
+
+				svc.referentialRulesApplied(ns, rm, false)
+				return nil
+			}
+
+			return nil
+		}()
+
+		if err == nil && (action == "clone" || action == "update" || action == "delete") {
+			// Records changed by the iterator do not emit any events
+			svc.reportCache.invalidate(m.ID)
+		}
+
+		// record iteration action and
+		// break the loop in case of an error
+		_ = svc.recordAction(svc.ctx, aProps, recordableAction, err)
+		if err != nil {
+			return err
+		}
 	}
 
+	return nil
+}
+
+// walk loads records in chunks and calls fn() for each chunk
+//
+// Chunks are loaded with page cursor so that changes made to
+// already processed records do not shift the records that follow.
+//
+// Limit (or perPage) on the filter caps the number of all walked records;
+// walk starts at the page cursor, offset or page (if set)
+func (svc record) walk(m *types.Module, f types.RecordFilter, fn func(types.RecordSet) error) (err error) {
 	var (
-		rve *types.RecordValueErrorSet
+		set   types.RecordSet
+		total uint
+		max   uint
 	)
 
-	if svc.optEmitEvents {
-		// Handle input payload
-		if rve = svc.procUpdate(invokerID, m, upd, old); !rve.IsValid() {
-			return nil, RecordErrValueInput().Wrap(rve)
-		}
-
-		// Before we pass values to record-before-update handling events
-		// values needs do be cleaned up
-		//
-		// Value merge inside procUpdate sets delete flag we need
-		// when changes are applied but we do not want deleted values
-		// to be sent to handler
-		upd.Values = upd.Values.GetClean()
+	f.Offset, max = walkPaging(f.PageFilter)
+	f.PageFilter = rh.PageFilter{Offset: f.Offset}
 
-		// Before we pass values to automation scripts, they should be formatted
-		upd.Values = svc.formatter.Run(m, upd.Values)
+	for {
+		f.Limit = recordWalkChunkSize
+		if max > 0 && max-total < f.Limit {
+			f.Limit = max - total
+		}
 
-		// Scripts can (besides simple error value) return complex record value error set
-		// that is passed back to the UI or any other API consumer
-		//
-		// rve (record-validation-errorset) struct is passed so it can be
-		// used & filled by automation scripts
-		if err = svc.eventbus.WaitFor(svc.ctx, event.RecordBeforeUpdate(upd, old, m, ns, rve)); err != nil {
+		if set, f, err = svc.recordRepo.WithRefResolver(svc.refResolver(m.NamespaceID)).Export(m, f); err != nil {
 			return
-		} else if !rve.IsValid() {
-			return nil, RecordErrValueInput().Wrap(rve)
 		}
-	}
 
-	// Handle payload from automation scripts
-	if rve = svc.procUpdate(invokerID, m, upd, old); !rve.IsValid() {
-		return nil, RecordErrValueInput().Wrap(rve)
-	}
+		if err = fn(set); err != nil {
+			return
+		}
 
-	err = svc.db.Transaction(func() error {
-		if upd, err = svc.recordRepo.Update(upd); err != nil {
+		total += uint(len(set))
+		if f.NextPage == "" || (max > 0 && total >= max) {
 			return nil
 		}
 
-		return svc.recordRepo.UpdateValues(upd.ID, upd.Values)
-
-	})
-
-	if err != nil {
-		return nil, err
+		f.PageCursor, f.NextPage, f.Offset = f.NextPage, "", 0
 	}
+}
 
-	// Final value cleanup
-	// These (clean) values are returned (and sent to after-update handler)
-	upd.Values = upd.Values.GetClean()
+// walkPaging converts paging params into offset of the first walked record
+// and max number of walked records (0 for all)
+//
+// Page is converted the same way as with paged fetching: when limit & offset are
+// not set, offset is calculated from page & perPage
+func walkPaging(pf rh.PageFilter) (offset, max uint) {
+	offset, max = pf.Offset, pf.Limit
 
-	// At this point we can return the value
-	rec = upd
+	if pf.Limit+pf.Offset == 0 && pf.Page > 1 {
+		offset = (pf.Page - 1) * pf.PerPage
+	}
 
-	if svc.optEmitEvents {
-		// Before we pass values to automation scripts, they should be formatted
-		upd.Values = svc.formatter.Run(m, upd.Values)
-		_ = svc.eventbus.WaitFor(svc.ctx, event.RecordAfterUpdateImmutable(upd, old, m, ns, nil))
+	if max == 0 {
+		max = pf.PerPage
 	}
+
 	return
 }
 
-func (svc record) Create(new *types.Record) (rec *types.Record, err error) {
+// FindRevisions returns revisions of a record
+//
+// Changes and values of fields that current user is not allowed to read are removed
+func (svc record) FindRevisions(filter types.RecordRevisionFilter) (set types.RecordRevisionSet, f types.RecordRevisionFilter, err error) {
 	var (
-		aProps = &recordActionProps{changed: new}
+		m      *types.Module
+		r      *types.Record
+		aProps = &recordActionProps{record: &types.Record{NamespaceID: filter.NamespaceID, ModuleID: filter.ModuleID, ID: filter.RecordID}}
 	)
 
 	err = func() error {
-		rec, err = svc.create(new)
-		aProps.setRecord(rec)
-		return err
-	}()
-
-	return rec, svc.recordAction(svc.ctx, aProps, RecordActionCreate, err)
-}
-
-// Runs value sanitization, sets values that should be used
-// and validates the final result
-//
-// This logic is kept in a utility function - it's used in the beginning
-// of the creation procedure and after results are back from the automation scripts
-//
-// Both these points introduce external data that need to be checked fully in the same manner
-func (svc record) procCreate(invokerID uint64, m *types.Module, new *types.Record) *types.RecordValueErrorSet {
-	// Mark all values as updated (new)
-	new.Values.SetUpdatedFlag(true)
-
-	// Before values are processed further and
-	// sent to automation scripts (if any)
-	// we need to make sure it does not get un-sanitized data
-	new.Values = svc.sanitizer.Run(m, new.Values)
-
-	// Reset values to new record
-	// to make sure nobody slips in something we do not want
-	new.CreatedBy = invokerID
-	new.CreatedAt = *nowPtr()
-	new.UpdatedAt = nil
-	new.UpdatedBy = 0
-	new.DeletedAt = nil
-	new.DeletedBy = 0
-
-	if new.OwnedBy == 0 {
-		// If od owner is not set, make current user
-		// the owner of the record
-		new.OwnedBy = invokerID
-	}
-
-	rve := &types.RecordValueErrorSet{}
-	_ = new.Values.Walk(func(v *types.RecordValue) error {
-		if v.IsUpdated() && !svc.ac.CanUpdateRecordValue(svc.ctx, m.Fields.FindByName(v.Name)) {
-			rve.Push(types.RecordValueError{Kind: "updateDenied", Meta: map[string]interface{}{"field": v.Name, "value": v.Value}})
+		if m, r, err = svc.loadRevisable(filter.NamespaceID, filter.ModuleID, filter.RecordID); err != nil {
+			return err
 		}
 
-		return nil
-	})
+		aProps.setModule(m)
+		aProps.setRecord(r)
 
-	if !rve.IsValid() {
-		return rve
-	}
+		if set, f, err = svc.revisionRepo.Find(filter); err != nil {
+			return err
+		}
 
-	// Run validation of the updated records
-	return svc.validator.Run(m, new)
+		svc.cleanRevisions(m, set...)
+		return nil
+	}()
+
+	return set, f, svc.recordAction(svc.ctx, aProps, RecordActionRevisionSearch, err)
 }
 
-func (svc record) Update(upd *types.Record) (rec *types.Record, err error) {
+// DiffRevisions compares values of two record revisions
+//
+// Changes of fields that current user is not allowed to read are removed
+func (svc record) DiffRevisions(namespaceID, moduleID, recordID, fromRevisionID, toRevisionID uint64) (cc types.RecordRevisionChangeSet, err error) {
 	var (
-		aProps = &recordActionProps{changed: upd}
+		m        *types.Module
+		r        *types.Record
+		from, to *types.RecordRevision
+		aProps   = &recordActionProps{record: &types.Record{NamespaceID: namespaceID, ModuleID: moduleID, ID: recordID}}
 	)
 
 	err = func() error {
-		rec, err = svc.update(upd)
-		aProps.setRecord(rec)
-		return err
+		if m, r, err = svc.loadRevisable(namespaceID, moduleID, recordID); err != nil {
+			return err
+		}
+
+		aProps.setModule(m)
+		aProps.setRecord(r)
+
+		if from, err = svc.loadRevision(namespaceID, recordID, fromRevisionID); err != nil {
+			return err
+		}
+
+		if to, err = svc.loadRevision(namespaceID, recordID, toRevisionID); err != nil {
+			return err
+		}
+
+		svc.cleanRevisions(m, from, to)
+		cc = types.RecordValueSetDiff(from.Values, to.Values)
+		return nil
 	}()
 
-	return rec, svc.recordAction(svc.ctx, aProps, RecordActionUpdate, err)
+	return cc, svc.recordAction(svc.ctx, aProps, RecordActionRevisionDiff, err)
 }
 
-// Runs value sanitization, copies values that should updated
-// and validates the final result
-//
-// This logic is kept in a utility function - it's used in the beginning
-// of the update procedure and after results are back from the automation scripts
+// RestoreRevision updates record with values from one of its revisions
 //
-// Both these points introduce external data that need to be checked fully in the same manner
-func (svc record) procUpdate(invokerID uint64, m *types.Module, upd *types.Record, old *types.Record) *types.RecordValueErrorSet {
-	// Mark all values as updated (new)
-	upd.Values.SetUpdatedFlag(true)
+// Update goes through the same procedure (validation, events, permissions) as any other record update
+func (svc record) RestoreRevision(namespaceID, moduleID, recordID, revisionID uint64) (rec *types.Record, err error) {
+	var (
+		r      *types.Record
+		rev    *types.RecordRevision
+		aProps = &recordActionProps{record: &types.Record{NamespaceID: namespaceID, ModuleID: moduleID, ID: recordID}}
+	)
 
-	// First sanitization
-	//
-	// Before values are merged with existing data and
-	// sent to automation scripts (if any)
-	// we need to make sure it does not get sanitized data
-	upd.Values = svc.sanitizer.Run(m, upd.Values)
+	err = func() error {
+		if _, r, err = svc.loadRevisable(namespaceID, moduleID, recordID); err != nil {
+			return err
+		}
 
-	// Copy values to updated record
-	// to make sure nobody slips in something we do not want
-	upd.CreatedAt = old.CreatedAt
-	upd.CreatedBy = old.CreatedBy
-	upd.UpdatedAt = nowPtr()
-	upd.UpdatedBy = invokerID
-	upd.DeletedAt = old.DeletedAt
-	upd.DeletedBy = old.DeletedBy
+		aProps.setRecord(r)
 
-	if upd.OwnedBy == 0 {
-		if old.OwnedBy > 0 {
-			// Owner not set/send in the payload
-			//
-			// Fallback to old owner (if set)
-			upd.OwnedBy = old.OwnedBy
-		} else {
-			// If od owner is not set, make current user
-			// the owner of the record
-			upd.OwnedBy = invokerID
+		if r.DeletedAt != nil {
+			return RecordErrRevisionRestoreDeleted(aProps)
 		}
-	}
 
-	// Value merge process does not know anything about permissions so
-	// in case when new values are missing but do exist in the old set and their update/read is denied
-	// we need to copy them to ensure value merge process them correctly
-	for _, f := range m.Fields {
-		if len(upd.Values.FilterByName(f.Name)) == 0 && !svc.ac.CanUpdateRecordValue(svc.ctx, m.Fields.FindByName(f.Name)) {
-			// copy all fields from old to new
-			upd.Values = append(upd.Values, old.Values.FilterByName(f.Name).GetClean()...)
+		if rev, err = svc.loadRevision(namespaceID, recordID, revisionID); err != nil {
+			return err
 		}
-	}
-
-	// Merge new (updated) values with old ones
-	// This way we get list of updated, stale and deleted values
-	// that we can selectively update in the repository
-	upd.Values = old.Values.Merge(upd.Values)
 
-	rve := &types.RecordValueErrorSet{}
-	_ = upd.Values.Walk(func(v *types.RecordValue) error {
-		if v.IsUpdated() && !svc.ac.CanUpdateRecordValue(svc.ctx, m.Fields.FindByName(v.Name)) {
-			rve.Push(types.RecordValueError{Kind: "updateDenied", Meta: map[string]interface{}{"field": v.Name, "value": v.Value}})
-		}
+		aProps.setRevision(rev)
 
-		return nil
-	})
+		rec, err = svc.update(&types.Record{
+			ID:          r.ID,
+			ModuleID:    r.ModuleID,
+			NamespaceID: r.NamespaceID,
+			OwnedBy:     r.OwnedBy,
+			Values:      rev.Values,
+		}, types.RecordRevisionRestore)
 
-	if !rve.IsValid() {
-		return rve
-	}
+		return err
+	}()
 
-	// Run validation of the updated records
-	return svc.validator.Run(m, upd)
+	return rec, svc.recordAction(svc.ctx, aProps, RecordActionRevisionRestore, err)
 }
 
-func (svc record) recordInfoUpdate(r *types.Record) {
-	now := time.Now()
-	r.UpdatedAt = &now
-	r.UpdatedBy = auth.GetIdentityFromContext(svc.ctx).Identity()
-}
+// loadRevisable loads module and record and checks if current user can read it
+//
+// Deleted records are loaded as well so that their history can be inspected
+func (svc record) loadRevisable(namespaceID, moduleID, recordID uint64) (m *types.Module, r *types.Record, err error) {
+	if recordID == 0 {
+		return nil, nil, RecordErrInvalidID()
+	}
 
-func (svc record) delete(namespaceID, moduleID, recordID uint64) (del *types.Record, err error) {
-	var (
-		ns *types.Namespace
-		m  *types.Module
+	if _, err = svc.loadNamespace(namespaceID); err != nil {
+		return nil, nil, err
+	}
 
-		invokerID = auth.GetIdentityFromContext(svc.ctx).Identity()
-	)
+	if r, err = svc.recordRepo.FindByIDWithDeleted(namespaceID, recordID); err != nil {
+		if repository.ErrRecordNotFound.Eq(err) {
+			return nil, nil, RecordErrNotFound()
+		}
 
-	if namespaceID == 0 {
-		return nil, RecordErrInvalidNamespaceID()
-	}
-	if moduleID == 0 {
-		return nil, RecordErrInvalidModuleID()
-	}
-	if recordID == 0 {
-		return nil, RecordErrInvalidID()
+		return nil, nil, err
 	}
 
-	ns, m, del, err = svc.loadCombo(namespaceID, moduleID, recordID)
-	if err != nil {
-		return nil, err
+	if r.ModuleID != moduleID {
+		return nil, nil, RecordErrInvalidModuleID()
 	}
 
-	if !svc.ac.CanDeleteRecord(svc.ctx, m) {
-		return nil, RecordErrNotAllowedToDelete()
+	if m, err = svc.loadModule(namespaceID, moduleID); err != nil {
+		return nil, nil, err
 	}
 
-	if svc.optEmitEvents {
-		// Preload old record values so we can send it together with event
-		if err = svc.preloadValues(m, del); err != nil {
-			return nil, err
-		}
-
-		// Calling before-record-delete scripts
-		if err = svc.eventbus.WaitFor(svc.ctx, event.RecordBeforeDelete(nil, del, m, ns, nil)); err != nil {
-			return nil, err
-		}
+	if !svc.ac.CanReadRecord(svc.ctx, m) {
+		return nil, nil, RecordErrNotAllowedToRead()
 	}
 
-	del.DeletedAt = nowPtr()
-	del.DeletedBy = invokerID
+	return
+}
 
-	err = svc.db.Transaction(func() error {
-		if err = svc.recordRepo.Delete(del); err != nil {
-			return err
+// loadRevision loads revision and makes sure it belongs to the given record
+func (svc record) loadRevision(namespaceID, recordID, revisionID uint64) (rev *types.RecordRevision, err error) {
+	if rev, err = svc.revisionRepo.FindByID(namespaceID, revisionID); err != nil {
+		if repository.ErrRecordRevisionNotFound.Eq(err) {
+			return nil, RecordErrRevisionNotFound()
 		}
 
-		return svc.recordRepo.DeleteValues(del)
-	})
-
-	if err != nil {
 		return nil, err
 	}
 
-	if svc.optEmitEvents {
-		_ = svc.eventbus.WaitFor(svc.ctx, event.RecordAfterDeleteImmutable(nil, del, m, ns, nil))
+	if rev.RecordID != recordID {
+		return nil, RecordErrRevisionNotFound()
 	}
 
-	return del, nil
+	return rev, nil
 }
 
-// DeleteByID removes one or more records (all from the same module and namespace)
-//
-// Before and after each record is deleted beforeDelete and afterDelete events are emitted
-// If beforeRecord aborts the action it does so for that specific record only
-func (svc record) DeleteByID(namespaceID, moduleID uint64, recordIDs ...uint64) (err error) {
+// cleanRevisions removes changes and values of fields that current user is not allowed to read
+func (svc record) cleanRevisions(m *types.Module, rr ...*types.RecordRevision) {
 	var (
-		aProps = &recordActionProps{
-			namespace: &types.Namespace{ID: namespaceID},
-			module:    &types.Module{ID: moduleID},
-		}
-
-		isBulkDelete = len(recordIDs) > 1
-
-		ns *types.Namespace
-		m  *types.Module
-		r  *types.Record
+		readable = make(map[string]bool)
 	)
 
-	err = func() error {
-		if namespaceID == 0 {
-			return RecordErrInvalidNamespaceID()
-		}
-		if moduleID == 0 {
-			return RecordErrInvalidModuleID()
-		}
+	for _, name := range svc.readableFields(m) {
+		readable[name] = true
+	}
 
-		ns, m, _, err = svc.loadCombo(namespaceID, moduleID, 0)
-		if err != nil {
-			return err
+	for _, rev := range rr {
+		cc := types.RecordRevisionChangeSet{}
+		for _, c := range rev.Changes {
+			if readable[c.Name] {
+				cc = append(cc, c)
+			}
 		}
 
-		aProps.setNamespace(ns)
-		aProps.setModule(m)
-
-		if !svc.ac.CanDeleteRecord(svc.ctx, m) {
-			return RecordErrNotAllowedToDelete()
+		vv := types.RecordValueSet{}
+		for _, v := range rev.Values {
+			if readable[v.Name] {
+				vv = append(vv, v)
+			}
 		}
 
-		return nil
-	}()
+		rev.Changes, rev.Values = cc, vv
+	}
+}
 
+// revise stores a new record revision with all changes between stored and new values
+//
+// Must be called (inside transaction) before new values are stored
+func (svc record) revise(op types.RecordRevisionOperation, m *types.Module, r *types.Record, new types.RecordValueSet) error {
+	old, err := svc.recordRepo.LoadValues(m.Fields.Names(), []uint64{r.ID})
 	if err != nil {
-		return svc.recordAction(svc.ctx, aProps, RecordActionDelete, err)
+		return err
 	}
 
-	for _, recordID := range recordIDs {
-		err := func() (err error) {
-			r, err = svc.delete(namespaceID, moduleID, recordID)
-			aProps.setRecord(r)
-
-			// Record each record deletion action
-			return svc.recordAction(svc.ctx, aProps, RecordActionDelete, err)
-		}()
+	rev := types.NewRecordRevision(op, r, old, new)
+	rev.CreatedBy = auth.GetIdentityFromContext(svc.ctx).Identity()
 
-		// We'll not break for failed delete,
-		// if we are deleting records in bulk.
-		if err != nil && !isBulkDelete {
-			return err
-		}
+	_, err = svc.revisionRepo.Create(rev)
+	return err
+}
 
-	}
+// remove deletes stored record with its values and applies referential rules
+// of the record fields referencing it
+//
+// Must be called inside transaction; removed records are tracked
+// to prevent endless loops on circular references
+func (svc record) remove(m *types.Module, r *types.Record, rm *recordRemoval) (err error) {
+	var rr recordRollups
 
-	// all errors (if any) were recorded
-	// and in case of error for a non-bulk record deletion
-	// error is already returned
-	return nil
-}
+	rm.removed[r.ID] = true
 
-func (svc record) Organize(namespaceID, moduleID, recordID uint64, posField, position, filter, grpField, group string) (err error) {
-	var (
-		ns *types.Namespace
-		m  *types.Module
-		r  *types.Record
+	if r.DeletedAt == nil {
+		r.DeletedAt = nowPtr()
+		r.DeletedBy = auth.GetIdentityFromContext(svc.ctx).Identity()
+	}
 
-		recordValues = types.RecordValueSet{}
+	if err = svc.applyReferentialRules(m, r, rm); err != nil {
+		return err
+	}
 
-		aProps = &recordActionProps{record: &types.Record{NamespaceID: namespaceID, ModuleID: moduleID, ID: recordID}}
+	if rr, err = svc.rollupRefs(m, r.ID); err != nil {
+		return err
+	}
 
-		reorderingRecords bool
-	)
+	if err = svc.recordRepo.Delete(r); err != nil {
+		return err
+	}
 
-	err = func() error {
-		ns, m, r, err = svc.loadCombo(namespaceID, moduleID, recordID)
-		if err != nil {
-			return err
-		}
+	if err = svc.revise(types.RecordRevisionDelete, m, r, nil); err != nil {
+		return err
+	}
 
-		aProps.setNamespace(ns)
-		aProps.setModule(m)
-		aProps.setRecord(r)
+	if err = svc.recordRepo.DeleteValues(r); err != nil {
+		return err
+	}
 
-		if !svc.ac.CanUpdateRecord(svc.ctx, m) {
-			return RecordErrNotAllowedToUpdate()
-		}
+	return svc.updateRollups(rr, nil)
+}
 
-		if posField != "" {
-			reorderingRecords = true
+// applyReferentialRules handles records that reference the deleted record
+// through record fields with onDelete option
+//
+//   - restrict: prevents deletion
+//   - cascade: deletes referencing records
+//   - setNull: removes references from referencing records
+//
+// Affected records are recorded in the actionlog after the changes are committed
+// (see referentialRulesApplied)
+func (svc record) applyReferentialRules(m *types.Module, del *types.Record, rm *recordRemoval) error {
+	ff, err := svc.moduleRepo.FindFieldsByKind(m.NamespaceID, "Record")
+	if err != nil {
+		return err
+	}
 
-			if !regexp.MustCompile(`^[0-9]+$`).MatchString(position) {
-				return fmt.Errorf("expecting number for sorting position %q", posField)
-			}
+	for _, f := range ff {
+		var (
+			refm *types.Module
+			ref  *types.Record
+			IDs  []uint64
 
-			// Check field existence and permissions
-			// check if numeric -- we can not reorder on any other field type
+			rule   = f.Options.OnDelete()
+			aProps = &recordActionProps{namespace: &types.Namespace{ID: m.NamespaceID}}
+		)
 
-			sf := m.Fields.FindByName(posField)
-			if sf == nil {
-				return fmt.Errorf("no such field %q", posField)
-			}
+		if f.Options.ModuleID() != m.ID || rule == "" {
+			continue
+		}
 
-			if !sf.IsNumeric() {
-				return fmt.Errorf("can not reorder on non numeric field %q", posField)
-			}
+		aProps.setField(f.Name)
 
-			if sf.Multi {
-				return fmt.Errorf("can not reorder on multi-value field %q", posField)
-			}
+		if IDs, err = svc.recordRepo.RefValueRecords(f.ModuleID, f.Name, del.ID); err != nil {
+			return err
+		}
 
-			if !svc.ac.CanUpdateRecordValue(svc.ctx, sf) {
-				return RecordErrNotAllowedToUpdate()
-			}
+		if len(IDs) == 0 {
+			continue
+		}
 
-			// Set new position
-			recordValues = recordValues.Set(&types.RecordValue{
-				RecordID: recordID,
-				Name:     posField,
-				Value:    position,
-			})
+		if rule == types.ModuleFieldOnDeleteRestrict {
+			return RecordErrDeleteRestricted(aProps.setRecord(del))
 		}
 
-		if grpField != "" {
-			// Check field existence and permissions
+		if refm, err = svc.loadModule(m.NamespaceID, f.ModuleID); err != nil {
+			return err
+		}
 
-			vf := m.Fields.FindByName(grpField)
-			if vf == nil {
-				return fmt.Errorf("no such field %q", grpField)
+		aProps.setModule(refm)
+
+		for _, ID := range IDs {
+			if rm.removed[ID] {
+				continue
 			}
 
-			if vf.Multi {
-				return fmt.Errorf("can not update multi-value field %q", posField)
+			if ref, err = svc.recordRepo.FindByID(m.NamespaceID, ID); err != nil {
+				return err
 			}
 
-			if !svc.ac.CanUpdateRecordValue(svc.ctx, vf) {
-				return RecordErrNotAllowedToUpdate()
+			if ref.Values, err = svc.recordRepo.LoadValues(refm.Fields.Names(), []uint64{ref.ID}); err != nil {
+				return err
 			}
 
-			// Set new value
-			recordValues = recordValues.Set(&types.RecordValue{
-				RecordID: recordID,
-				Name:     grpField,
-				Value:    group,
-			})
-		}
+			applied := &recordRuleApplied{
+				aProps: &recordActionProps{namespace: aProps.namespace, module: refm, field: f.Name, record: ref},
+				module: refm,
+				old:    ref,
+			}
 
-		return svc.db.Transaction(func() error {
-			if len(recordValues) > 0 {
-				svc.recordInfoUpdate(r)
-				if _, err = svc.recordRepo.Update(r); err != nil {
-					return err
+			switch rule {
+			case types.ModuleFieldOnDeleteCascade:
+				if !svc.ac.CanDeleteRecord(svc.ctx, refm) {
+					return RecordErrNotAllowedToDelete(aProps.setRecord(ref))
 				}
 
-				if err = svc.recordRepo.PartialUpdateValues(recordValues...); err != nil {
+				if err = svc.remove(refm, ref, rm); err != nil {
 					return err
 				}
-			}
-
-			if reorderingRecords {
-				var (
-					set              types.RecordSet
-					recordOrderPlace uint64
-				)
 
-				// If we already have filter, wrap it in parenthesis
-				if filter != "" {
-					filter = fmt.Sprintf("(%s) AND ", filter)
-				}
+				applied.action = RecordActionCascadeDelete
 
-				if recordOrderPlace, err = strconv.ParseUint(position, 0, 64); err != nil {
-					return err
+			case types.ModuleFieldOnDeleteSetNull:
+				if !svc.ac.CanUpdateRecord(svc.ctx, refm) {
+					return RecordErrNotAllowedToUpdate(aProps.setRecord(ref))
 				}
 
-				// Assemble record filter:
-				// We are interested only in records that have value of a sorting field greater than
-				// the place we're moving our record to.
-				// and sort the set with sorting field
-				set, _, err = svc.recordRepo.Find(m, types.RecordFilter{
-					Query: fmt.Sprintf("%s(%s >= %d)", filter, posField, recordOrderPlace),
-					Sort:  posField,
-				})
+				old := *ref
+				applied.old = &old
 
-				if err != nil {
+				if err = svc.clearReference(refm, ref, f.Name, del.ID); err != nil {
 					return err
 				}
 
-				// Update value on each record
-				return set.Walk(func(r *types.Record) error {
-					recordOrderPlace++
-
-					// Update each and every set
-					return svc.recordRepo.PartialUpdateValues(&types.RecordValue{
-						RecordID: r.ID,
-						Name:     posField,
-						Value:    strconv.FormatUint(recordOrderPlace, 10),
-					})
-				})
+				applied.new = ref
+				applied.action = RecordActionReferenceClear
 			}
 
-			return nil
-		})
-	}()
-
-	return svc.recordAction(svc.ctx, aProps, RecordActionOrganize, err)
+			rm.applied = append(rm.applied, applied)
+		}
+	}
 
+	return nil
 }
 
-// Iterator loads and iterates through list of records
-//
-// For each record, RecordOnIteration is generated and passed to fn()
-// to be then passed to automation script that invoked the iteration
-//
-// No other triggers (before/after update/delete/create) are fired when (if)
-// records are changed
+// referentialRulesApplied records actions of the applied referential rules
+// and emits after-delete and after-update events for the affected records
 //
-// action arg enables one of the following scenarios:
-//   - clone:   make new record (unless aborted)
-//   - update:  update records (unless aborted)
-//   - delete:  delete records (unless aborted)
-//   - default: only iterates over records, records are not changed, return value is ignored
-//
-//
-// Iterator can be invoked only when defined in corredor script:
-//
-// return default {
-//   iterator (each) {
-//     return each({
-//       resourceType: 'compose:record',
-//       // action: 'update',
-//       filter: {
-//         namespace: '122709101053521922',
-//         module: '122709116471783426',
-//         query: 'Status = "foo"',
-//         sort: 'Status DESC',
-//         limit: 3,
-//       },
-//     })
-//   },
-//
-//   // this is required in case of a deferred iterator
-//   // security: { runAs: .... } }
-//
-//   // exec gets called for every record found by iterator
-//   exec () { ... }
-// }
-func (svc record) Iterator(f types.RecordFilter, fn eventbus.HandlerFn, action string) (err error) {
-	var (
-		invokerID = auth.GetIdentityFromContext(svc.ctx).Identity()
+// Must be called after changes are committed. Before-delete and before-update
+// events are not emitted for records affected by referential rules
+func (svc record) referentialRulesApplied(ns *types.Namespace, rm *recordRemoval, emitEvents bool) {
+	for _, a := range rm.applied {
+		_ = svc.recordAction(svc.ctx, a.aProps, a.action, nil)
+
+		switch {
+		case !emitEvents:
+			svc.reportCache.invalidate(a.module.ID)
+		case a.new == nil:
+			_ = svc.eventbus.WaitFor(svc.ctx, event.RecordAfterDeleteImmutable(nil, a.old, a.module, ns, nil))
+		default:
+			_ = svc.eventbus.WaitFor(svc.ctx, event.RecordAfterUpdateImmutable(a.new, a.old, a.module, ns, nil))
+		}
+	}
+}
 
-		ns  *types.Namespace
-		m   *types.Module
-		set types.RecordSet
+// clearReference removes values of a record field that reference the deleted record
+func (svc record) clearReference(m *types.Module, r *types.Record, field string, refID uint64) error {
+	old, err := svc.recordRepo.LoadValues(m.Fields.Names(), []uint64{r.ID})
+	if err != nil {
+		return err
+	}
 
-		aProps = &recordActionProps{}
+	var (
+		vv    = make(types.RecordValueSet, 0, len(old))
+		place uint
 	)
 
-	err = func() error {
-		if ns, m, _, err = svc.loadCombo(f.NamespaceID, f.ModuleID, 0); err != nil {
-			return err
+	for _, v := range old {
+		if v.Name != field {
+			vv = append(vv, v)
+		} else if v.Ref != refID {
+			// Keep remaining (multi) values without gaps
+			v.Place = place
+			vv = append(vv, v)
+			place++
 		}
+	}
 
-		if !svc.ac.CanReadRecord(svc.ctx, m) {
-			return RecordErrNotAllowedToRead()
-		}
+	svc.recordInfoUpdate(r)
+	if _, err = svc.recordRepo.Update(r); err != nil {
+		return err
+	}
 
-		switch action {
-		case "clone":
-			if !svc.ac.CanCreateRecord(svc.ctx, m) {
-				return RecordErrNotAllowedToCreate()
-			}
+	if err = svc.revise(types.RecordRevisionUpdate, m, r, vv); err != nil {
+		return err
+	}
 
-		case "update":
-			if !svc.ac.CanUpdateRecord(svc.ctx, m) {
-				return RecordErrNotAllowedToUpdate()
-			}
+	r.Values = vv
+	return svc.recordRepo.UpdateValues(r.ID, vv)
+}
 
-		case "delete":
-			if !svc.ac.CanDeleteRecord(svc.ctx, m) {
-				return RecordErrNotAllowedToDelete()
-			}
+// rollupRefs finds rollup fields that aggregate records of the module
+// and collects references from stored values of the given records
+//
+// Must be called (inside transaction) before stored values are changed
+func (svc record) rollupRefs(m *types.Module, recordIDs ...uint64) (rr recordRollups, err error) {
+	var (
+		ff types.ModuleFieldSet
+		vv types.RecordValueSet
+	)
+
+	if ff, err = svc.moduleRepo.FindFieldsByKind(m.NamespaceID, "Rollup"); err != nil {
+		return
+	}
+
+	rr = recordRollups{}
+	for _, f := range ff {
+		if f.Options.ModuleID() != m.ID {
+			continue
 		}
 
-		// @todo might be good to split set into smaller chunks
-		set, f, err = svc.recordRepo.Find(m, f)
-		if err != nil {
-			return err
+		rr[f] = nil
+
+		if len(recordIDs) == 0 {
+			continue
 		}
 
-		if err = svc.preloadValues(m, set...); err != nil {
-			return err
+		if vv, err = svc.recordRepo.LoadValues([]string{f.Options.RefField()}, recordIDs); err != nil {
+			return
 		}
 
-		for _, rec := range set {
-			recordableAction := RecordActionIteratorIteration
+		rr.add(f, vv)
+	}
 
-			err = func() error {
-				if err = fn(svc.ctx, event.RecordOnIteration(rec, nil, m, ns, nil)); err != nil {
-					if err.Error() != "Aborted" {
-						// When script was softly aborted (return false),
-						// proceed with iteration but do not clone, update or delete
-						// current record!
-						return nil
-					}
-				}
+	return
+}
 
-				switch action {
-				case "clone":
-					recordableAction = RecordActionIteratorClone
+// updateRollups recalculates rollup fields on all records referenced
+// by the old (collected) and new values
+func (svc record) updateRollups(rr recordRollups, new types.RecordValueSet) error {
+	for f := range rr {
+		rr.add(f, new)
 
-					var cln *types.Record
+		if err := storeRollupValues(svc.recordRepo, f, rr[f]...); err != nil {
+			return err
+		}
+	}
 
-					// Assign defaults (only on missing values)
-					rec.Values = svc.setDefaultValues(m, rec.Values)
+	return nil
+}
 
-					// Handle payload from automation scripts
-					if rve := svc.procCreate(invokerID, m, rec); !rve.IsValid() {
-						return RecordErrValueInput().Wrap(rve)
-					}
+// storeRollupValues aggregates and stores values of the rollup field on the given records
+func storeRollupValues(repo repository.RecordRepository, f *types.ModuleField, recordIDs ...uint64) error {
+	values, err := repo.Rollup(f, recordIDs...)
+	if err != nil {
+		return err
+	}
 
-					return svc.db.Transaction(func() error {
-						if cln, err = svc.recordRepo.Create(rec); err != nil {
-							return err
-						} else if err = svc.recordRepo.UpdateValues(cln.ID, cln.Values); err != nil {
-							return err
-						}
+	for _, recordID := range recordIDs {
+		value, ok := values[recordID]
+		if !ok {
+			// Not a record with this rollup field
+			continue
+		}
 
-						return nil
-					})
-				case "update":
-					recordableAction = RecordActionIteratorUpdate
+		rv := &types.RecordValue{RecordID: recordID, Name: f.Name, Value: value}
+		if value == "" {
+			rv.DeletedAt = nowPtr()
+		}
 
-					// Handle input payload
-					if rve := svc.procUpdate(invokerID, m, rec, rec); !rve.IsValid() {
-						return RecordErrValueInput().Wrap(rve)
-					}
+		if err = repo.PartialUpdateValues(rv); err != nil {
+			return err
+		}
+	}
 
-					return svc.db.Transaction(func() error {
-						if rec, err = svc.recordRepo.Update(rec); err != nil {
-							return err
-						} else if err = svc.recordRepo.UpdateValues(rec.ID, rec.Values); err != nil {
-							return err
-						}
+	return nil
+}
 
-						return nil
-					})
-				case "delete":
-					recordableAction = RecordActionIteratorDelete
+// rollupValues replaces values of module's rollup fields with the aggregated ones
+//
+// Must be called (inside transaction) after record is stored
+func (svc record) rollupValues(m *types.Module, r *types.Record) error {
+	var (
+		vv = make(types.RecordValueSet, 0, len(r.Values))
+	)
 
-					return svc.db.Transaction(func() error {
-						if err = svc.recordRepo.Delete(rec); err != nil {
-							return err
-						} else if err = svc.recordRepo.DeleteValues(rec); err != nil {
-							return err
-						}
+	for _, v := range r.Values {
+		if f := m.Fields.FindByName(v.Name); f == nil || !f.IsRollup() {
+			vv = append(vv, v)
+		}
+	}
 
-						return nil
-					})
-				}
+	for _, f := range m.Fields {
+		if !f.IsRollup() {
+			continue
+		}
 
-				return nil
-			}()
+		values, err := svc.recordRepo.Rollup(f, r.ID)
+		if err != nil {
+			return err
+		}
 
-			// record iteration action and
-			// break the loop in case of an error
-			_ = svc.recordAction(svc.ctx, aProps, recordableAction, err)
-			if err != nil {
-				return err
-			}
+		if values[r.ID] != "" {
+			vv = append(vv, &types.RecordValue{RecordID: r.ID, Name: f.Name, Value: values[r.ID], Updated: true})
 		}
+	}
 
-		return nil
-	}()
+	r.Values = vv
+	return nil
+}
 
-	return svc.recordAction(svc.ctx, aProps, RecordActionIteratorInvoked, err)
+// add appends references from the values of rollup's ref field
+func (rr recordRollups) add(f *types.ModuleField, vv types.RecordValueSet) {
+	for _, v := range vv.FilterByName(f.Options.RefField()) {
+		if v.Ref == 0 || v.IsDeleted() {
+			continue
+		}
+
+		var has bool
+		for _, ref := range rr[f] {
+			has = has || ref == v.Ref
+		}
 
+		if !has {
+			rr[f] = append(rr[f], v.Ref)
+		}
+	}
 }
 
 // loadCombo Loads everything we need for record manipulation
@@ -1513,37 +2953,53 @@ func (svc record) readableFields(m *types.Module) []string {
 	return ff
 }
 
-func (ei ErrorIndex) Add(err string) {
-	if _, has := ei[err]; has {
-		ei[err]++
-	} else {
-		ei[err] = 1
-	}
-}
+// searchableFields creates a slice of readable module fields that are included in free-text search
+//
+// Values of string, email, url and select fields are searched
+func (svc record) searchableFields(m *types.Module) []string {
+	ff := make([]string, 0)
+
+	_ = m.Fields.Walk(func(f *types.ModuleField) error {
+		switch f.Kind {
+		case "String", "Email", "Url", "Select":
+			if svc.ac.CanReadRecordValue(svc.ctx, f) {
+				ff = append(ff, f.Name)
+			}
+		}
 
-func (ri RecordIndex) MarshalJSON() ([]byte, error) {
-	sort.Ints(ri)
+		return nil
+	})
 
-	rr := make([][]int, 0, len(ri))
-	start := -1
-	crt := -1
+	return ff
+}
 
-	for i := 0; i < len(ri); i++ {
-		if start == -1 {
-			start = ri[i]
-			crt = ri[i]
-			continue
-		}
+// importReportAdd adds errors of the failed record to the report
+//
+// Record value errors are reported individually
+func importReportAdd(rep *types.RecordImportReport, row int, err error) {
+	recErr, isRecErr := err.(*recordError)
+	if !isRecErr {
+		*rep = append(*rep, &types.RecordImportReportEntry{Row: row, Message: err.Error()})
+		return
+	}
 
-		// If the index increases for more then 1, the set is complete
-		if ri[i]-crt > 1 {
-			rr = append(rr, []int{start, crt})
-			start = ri[i]
+	if rve, ok := recErr.wrap.(*types.RecordValueErrorSet); ok {
+		for _, ve := range rve.Set {
+			*rep = append(*rep, &types.RecordImportReportEntry{
+				Row:     row,
+				Field:   fmt.Sprintf("%v", ve.Meta["field"]),
+				Kind:    ve.Kind,
+				Message: ve.Message,
+			})
 		}
 
-		crt = ri[i]
+		return
+	}
+
+	e := &types.RecordImportReportEntry{Row: row, Kind: recErr.error, Message: err.Error()}
+	if recErr.props != nil {
+		e.Field = recErr.props.field
 	}
 
-	rr = append(rr, []int{start, crt})
-	return json.Marshal(rr)
+	*rep = append(*rep, e)
 }
@@ -25,6 +25,7 @@ type (
 		field         string
 		value         string
 		valueErrors   *types.RecordValueErrorSet
+		revision      *types.RecordRevision
 	}
 
 	recordAction struct {
@@ -162,6 +163,17 @@ func (p *recordActionProps) setValueErrors(valueErrors *types.RecordValueErrorSe
 	return p
 }
 
+// setRevision updates recordActionProps's revision
+//
+// Allows method chaining
+//
+// This function is auto-generated.
+//
+func (p *recordActionProps) setRevision(revision *types.RecordRevision) *recordActionProps {
+	p.revision = revision
+	return p
+}
+
 // serialize converts recordActionProps to actionlog.Meta
 //
 // This function is auto-generated.
@@ -185,6 +197,8 @@ func (p recordActionProps) serialize() actionlog.Meta {
 	}
 	if p.filter != nil {
 		m.Set("filter.query", p.filter.Query, true)
+		m.Set("filter.Q", p.filter.Q, true)
+		m.Set("filter.viewID", p.filter.ViewID, true)
 		m.Set("filter.namespaceID", p.filter.NamespaceID, true)
 		m.Set("filter.moduleID", p.filter.ModuleID, true)
 		m.Set("filter.deleted", p.filter.Deleted, true)
@@ -193,6 +207,7 @@ func (p recordActionProps) serialize() actionlog.Meta {
 		m.Set("filter.offset", p.filter.Offset, true)
 		m.Set("filter.page", p.filter.Page, true)
 		m.Set("filter.perPage", p.filter.PerPage, true)
+		m.Set("filter.pageCursor", p.filter.PageCursor, true)
 	}
 	if p.namespace != nil {
 		m.Set("namespace.name", p.namespace.Name, true)
@@ -211,6 +226,11 @@ func (p recordActionProps) serialize() actionlog.Meta {
 	if p.valueErrors != nil {
 		m.Set("valueErrors.set", p.valueErrors.Set, true)
 	}
+	if p.revision != nil {
+		m.Set("revision.ID", p.revision.ID, true)
+		m.Set("revision.recordID", p.revision.RecordID, true)
+		m.Set("revision.operation", p.revision.Operation, true)
+	}
 
 	return m
 }
@@ -293,6 +313,8 @@ func (p recordActionProps) tr(in string, err error) string {
 			"{filter}",
 			fns(
 				p.filter.Query,
+				p.filter.Q,
+				p.filter.ViewID,
 				p.filter.NamespaceID,
 				p.filter.ModuleID,
 				p.filter.Deleted,
@@ -301,9 +323,12 @@ func (p recordActionProps) tr(in string, err error) string {
 				p.filter.Offset,
 				p.filter.Page,
 				p.filter.PerPage,
+				p.filter.PageCursor,
 			),
 		)
 		pairs = append(pairs, "{filter.query}", fns(p.filter.Query))
+		pairs = append(pairs, "{filter.Q}", fns(p.filter.Q))
+		pairs = append(pairs, "{filter.viewID}", fns(p.filter.ViewID))
 		pairs = append(pairs, "{filter.namespaceID}", fns(p.filter.NamespaceID))
 		pairs = append(pairs, "{filter.moduleID}", fns(p.filter.ModuleID))
 		pairs = append(pairs, "{filter.deleted}", fns(p.filter.Deleted))
@@ -312,6 +337,7 @@ func (p recordActionProps) tr(in string, err error) string {
 		pairs = append(pairs, "{filter.offset}", fns(p.filter.Offset))
 		pairs = append(pairs, "{filter.page}", fns(p.filter.Page))
 		pairs = append(pairs, "{filter.perPage}", fns(p.filter.PerPage))
+		pairs = append(pairs, "{filter.pageCursor}", fns(p.filter.PageCursor))
 	}
 
 	if p.namespace != nil {
@@ -362,6 +388,22 @@ func (p recordActionProps) tr(in string, err error) string {
 		)
 		pairs = append(pairs, "{valueErrors.set}", fns(p.valueErrors.Set))
 	}
+
+	if p.revision != nil {
+		// replacement for "{revision}" (in order how fields are defined)
+		pairs = append(
+			pairs,
+			"{revision}",
+			fns(
+				p.revision.ID,
+				p.revision.RecordID,
+				p.revision.Operation,
+			),
+		)
+		pairs = append(pairs, "{revision.ID}", fns(p.revision.ID))
+		pairs = append(pairs, "{revision.recordID}", fns(p.revision.RecordID))
+		pairs = append(pairs, "{revision.operation}", fns(p.revision.Operation))
+	}
 	return strings.NewReplacer(pairs...).Replace(in)
 }
 
@@ -800,6 +842,106 @@ func RecordActionIteratorDelete(props ...*recordActionProps) *recordAction {
 	return a
 }
 
+// RecordActionRevisionSearch returns "compose:record.revisionSearch" error
+//
+// This function is auto-generated.
+//
+func RecordActionRevisionSearch(props ...*recordActionProps) *recordAction {
+	a := &recordAction{
+		timestamp: time.Now(),
+		resource:  "compose:record",
+		action:    "revisionSearch",
+		log:       "searched for revisions of {record}",
+		severity:  actionlog.Info,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
+// RecordActionRevisionDiff returns "compose:record.revisionDiff" error
+//
+// This function is auto-generated.
+//
+func RecordActionRevisionDiff(props ...*recordActionProps) *recordAction {
+	a := &recordAction{
+		timestamp: time.Now(),
+		resource:  "compose:record",
+		action:    "revisionDiff",
+		log:       "compared revisions of {record}",
+		severity:  actionlog.Info,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
+// RecordActionRevisionRestore returns "compose:record.revisionRestore" error
+//
+// This function is auto-generated.
+//
+func RecordActionRevisionRestore(props ...*recordActionProps) *recordAction {
+	a := &recordAction{
+		timestamp: time.Now(),
+		resource:  "compose:record",
+		action:    "revisionRestore",
+		log:       "restored {record} to revision {revision}",
+		severity:  actionlog.Notice,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
+// RecordActionCascadeDelete returns "compose:record.cascadeDelete" error
+//
+// This function is auto-generated.
+//
+func RecordActionCascadeDelete(props ...*recordActionProps) *recordAction {
+	a := &recordAction{
+		timestamp: time.Now(),
+		resource:  "compose:record",
+		action:    "cascadeDelete",
+		log:       "deleted {record} referencing deleted record through {field}",
+		severity:  actionlog.Notice,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
+// RecordActionReferenceClear returns "compose:record.referenceClear" error
+//
+// This function is auto-generated.
+//
+func RecordActionReferenceClear(props ...*recordActionProps) *recordAction {
+	a := &recordAction{
+		timestamp: time.Now(),
+		resource:  "compose:record",
+		action:    "referenceClear",
+		log:       "cleared {field} reference to deleted record on {record}",
+		severity:  actionlog.Notice,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
 // *********************************************************************************************************************
 // *********************************************************************************************************************
 // Error constructors
@@ -864,6 +1006,66 @@ func RecordErrNotFound(props ...*recordActionProps) *recordError {
 
 }
 
+// RecordErrRevisionNotFound returns "compose:record.revisionNotFound" audit event as actionlog.Warning
+//
+//
+// This function is auto-generated.
+//
+func RecordErrRevisionNotFound(props ...*recordActionProps) *recordError {
+	var e = &recordError{
+		timestamp: time.Now(),
+		resource:  "compose:record",
+		error:     "revisionNotFound",
+		action:    "error",
+		message:   "record revision not found",
+		log:       "record revision not found",
+		severity:  actionlog.Warning,
+		props: func() *recordActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
+// RecordErrRevisionRestoreDeleted returns "compose:record.revisionRestoreDeleted" audit event as actionlog.Warning
+//
+//
+// This function is auto-generated.
+//
+func RecordErrRevisionRestoreDeleted(props ...*recordActionProps) *recordError {
+	var e = &recordError{
+		timestamp: time.Now(),
+		resource:  "compose:record",
+		error:     "revisionRestoreDeleted",
+		action:    "error",
+		message:   "can not restore revision of deleted record",
+		log:       "can not restore revision of deleted record",
+		severity:  actionlog.Warning,
+		props: func() *recordActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
 // RecordErrNamespaceNotFound returns "compose:record.namespaceNotFound" audit event as actionlog.Warning
 //
 //
@@ -924,6 +1126,66 @@ func RecordErrModuleNotFoundModule(props ...*recordActionProps) *recordError {
 
 }
 
+// RecordErrViewNotFound returns "compose:record.viewNotFound" audit event as actionlog.Warning
+//
+//
+// This function is auto-generated.
+//
+func RecordErrViewNotFound(props ...*recordActionProps) *recordError {
+	var e = &recordError{
+		timestamp: time.Now(),
+		resource:  "compose:record",
+		error:     "viewNotFound",
+		action:    "error",
+		message:   "record view not found",
+		log:       "record view not found",
+		severity:  actionlog.Warning,
+		props: func() *recordActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
+// RecordErrViewModuleMismatch returns "compose:record.viewModuleMismatch" audit event as actionlog.Warning
+//
+//
+// This function is auto-generated.
+//
+func RecordErrViewModuleMismatch(props ...*recordActionProps) *recordError {
+	var e = &recordError{
+		timestamp: time.Now(),
+		resource:  "compose:record",
+		error:     "viewModuleMismatch",
+		action:    "error",
+		message:   "record view does not belong to this module",
+		log:       "record view does not belong to this module",
+		severity:  actionlog.Warning,
+		props: func() *recordActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
 // RecordErrInvalidID returns "compose:record.invalidID" audit event as actionlog.Warning
 //
 //
@@ -1134,6 +1396,36 @@ func RecordErrNotAllowedToReadModule(props ...*recordActionProps) *recordError {
 
 }
 
+// RecordErrNotAllowedToReadView returns "compose:record.notAllowedToReadView" audit event as actionlog.Error
+//
+//
+// This function is auto-generated.
+//
+func RecordErrNotAllowedToReadView(props ...*recordActionProps) *recordError {
+	var e = &recordError{
+		timestamp: time.Now(),
+		resource:  "compose:record",
+		error:     "notAllowedToReadView",
+		action:    "error",
+		message:   "not allowed to read record view",
+		log:       "failed to read record view; insufficient permissions",
+		severity:  actionlog.Error,
+		props: func() *recordActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
 // RecordErrNotAllowedToListRecords returns "compose:record.notAllowedToListRecords" audit event as actionlog.Error
 //
 //
@@ -1314,6 +1606,36 @@ func RecordErrNotAllowedToChangeFieldValue(props ...*recordActionProps) *recordE
 
 }
 
+// RecordErrNotAllowedToReadFieldValue returns "compose:record.notAllowedToReadFieldValue" audit event as actionlog.Error
+//
+//
+// This function is auto-generated.
+//
+func RecordErrNotAllowedToReadFieldValue(props ...*recordActionProps) *recordError {
+	var e = &recordError{
+		timestamp: time.Now(),
+		resource:  "compose:record",
+		error:     "notAllowedToReadFieldValue",
+		action:    "error",
+		message:   "not allowed to read value of field {field}",
+		log:       "failed to read value of field {field}; insufficient permissions",
+		severity:  actionlog.Error,
+		props: func() *recordActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
 // RecordErrImportSessionAlreadActive returns "compose:record.importSessionAlreadActive" audit event as actionlog.Error
 //
 //
@@ -1344,6 +1666,66 @@ func RecordErrImportSessionAlreadActive(props ...*recordActionProps) *recordErro
 
 }
 
+// RecordErrImportModeInvalid returns "compose:record.importModeInvalid" audit event as actionlog.Error
+//
+//
+// This function is auto-generated.
+//
+func RecordErrImportModeInvalid(props ...*recordActionProps) *recordError {
+	var e = &recordError{
+		timestamp: time.Now(),
+		resource:  "compose:record",
+		error:     "importModeInvalid",
+		action:    "error",
+		message:   "invalid import mode {value}",
+		log:       "invalid import mode {value}",
+		severity:  actionlog.Error,
+		props: func() *recordActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
+// RecordErrImportMatchFieldInvalid returns "compose:record.importMatchFieldInvalid" audit event as actionlog.Error
+//
+//
+// This function is auto-generated.
+//
+func RecordErrImportMatchFieldInvalid(props ...*recordActionProps) *recordError {
+	var e = &recordError{
+		timestamp: time.Now(),
+		resource:  "compose:record",
+		error:     "importMatchFieldInvalid",
+		action:    "error",
+		message:   "invalid import match field {field}, expecting recordID or a unique field",
+		log:       "invalid import match field {field}, expecting recordID or a unique field",
+		severity:  actionlog.Error,
+		props: func() *recordActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
 // RecordErrFieldNotFound returns "compose:record.fieldNotFound" audit event as actionlog.Error
 //
 //
@@ -1494,6 +1876,96 @@ func RecordErrValueInput(props ...*recordActionProps) *recordError {
 
 }
 
+// RecordErrSearchTermsMissing returns "compose:record.searchTermsMissing" audit event as actionlog.Error
+//
+//
+// This function is auto-generated.
+//
+func RecordErrSearchTermsMissing(props ...*recordActionProps) *recordError {
+	var e = &recordError{
+		timestamp: time.Now(),
+		resource:  "compose:record",
+		error:     "searchTermsMissing",
+		action:    "error",
+		message:   "free-text search terms are required",
+		log:       "free-text search terms are required",
+		severity:  actionlog.Error,
+		props: func() *recordActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
+// RecordErrInvalidReportJoin returns "compose:record.invalidReportJoin" audit event as actionlog.Error
+//
+//
+// This function is auto-generated.
+//
+func RecordErrInvalidReportJoin(props ...*recordActionProps) *recordError {
+	var e = &recordError{
+		timestamp: time.Now(),
+		resource:  "compose:record",
+		error:     "invalidReportJoin",
+		action:    "error",
+		message:   "invalid report join {value}",
+		log:       "invalid report join {value}",
+		severity:  actionlog.Error,
+		props: func() *recordActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
+// RecordErrDeleteRestricted returns "compose:record.deleteRestricted" audit event as actionlog.Warning
+//
+//
+// This function is auto-generated.
+//
+func RecordErrDeleteRestricted(props ...*recordActionProps) *recordError {
+	var e = &recordError{
+		timestamp: time.Now(),
+		resource:  "compose:record",
+		error:     "deleteRestricted",
+		action:    "error",
+		message:   "can not delete record referenced through field {field}",
+		log:       "can not delete record referenced through field {field}",
+		severity:  actionlog.Warning,
+		props: func() *recordActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
 // *********************************************************************************************************************
 // *********************************************************************************************************************
 
@@ -0,0 +1,253 @@
+package service
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/eventbus"
+	"github.com/cortezaproject/corteza-server/pkg/permissions"
+)
+
+type (
+	// recordReportCache keeps results of record reports
+	//
+	// Entries are invalidated per module: each module has a generation that is
+	// increased on every change of its records. Entry is valid for as long as
+	// generations of all modules it depends on stay the same (and TTL is not reached)
+	//
+	// Permission rules of the user's roles are part of the cache key
+	// so that changed rules never serve reports made under the old ones
+	recordReportCache struct {
+		l     sync.RWMutex
+		ttl   time.Duration
+		rules recordReportCacheRules
+
+		entries     map[string]*recordReportCacheEntry
+		generations map[uint64]uint64
+
+		// time of the last removal of the expired entries
+		swept time.Time
+	}
+
+	recordReportCacheEntry struct {
+		result  interface{}
+		expires time.Time
+
+		// generations of the modules report depends on
+		modules map[uint64]uint64
+
+		// fields of the referenced modules resolved when report was made;
+		// resolved again (and checked) when cached report is used
+		refs []recordReportRef
+	}
+
+	recordReportRef struct {
+		field *types.ModuleField
+		name  string
+	}
+
+	recordReportCacheRules interface {
+		FindRulesByRoleID(roleID uint64) permissions.RuleSet
+	}
+)
+
+var (
+	recordReportCacheRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "compose",
+			Subsystem: "record_report_cache",
+			Name:      "requests_total",
+			Help:      "Number of record report cache lookups by result (hit, miss)",
+		},
+		[]string{"result"},
+	)
+
+	recordReportCacheInvalidations = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "compose",
+			Subsystem: "record_report_cache",
+			Name:      "invalidations_total",
+			Help:      "Number of module invalidations of the record report cache",
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(recordReportCacheRequests, recordReportCacheInvalidations)
+}
+
+func RecordReportCache(ttl time.Duration, rules recordReportCacheRules) *recordReportCache {
+	return &recordReportCache{
+		ttl:         ttl,
+		rules:       rules,
+		entries:     make(map[string]*recordReportCacheEntry),
+		generations: make(map[uint64]uint64),
+		swept:       time.Now(),
+	}
+}
+
+// get returns valid cache entry under the given key
+func (c *recordReportCache) get(key string) *recordReportCacheEntry {
+	if c == nil {
+		return nil
+	}
+
+	c.l.RLock()
+	defer c.l.RUnlock()
+
+	if e, ok := c.entries[key]; ok && c.valid(e) {
+		recordReportCacheRequests.WithLabelValues("hit").Inc()
+		return e
+	}
+
+	recordReportCacheRequests.WithLabelValues("miss").Inc()
+	return nil
+}
+
+func (c *recordReportCache) valid(e *recordReportCacheEntry) bool {
+	if time.Now().After(e.expires) {
+		return false
+	}
+
+	for moduleID, gen := range e.modules {
+		if c.generations[moduleID] != gen {
+			return false
+		}
+	}
+
+	return true
+}
+
+// snapshot returns current generations of the given modules
+//
+// Must be taken before report is made so that changes
+// made while the report is running are not missed
+func (c *recordReportCache) snapshot(moduleIDs ...uint64) map[uint64]uint64 {
+	c.l.RLock()
+	defer c.l.RUnlock()
+
+	var gg = make(map[uint64]uint64, len(moduleIDs))
+	for _, moduleID := range moduleIDs {
+		gg[moduleID] = c.generations[moduleID]
+	}
+
+	return gg
+}
+
+// set stores the entry unless modules it depends on
+// were changed in the meantime
+func (c *recordReportCache) set(key string, e *recordReportCacheEntry) {
+	if c == nil {
+		return
+	}
+
+	c.l.Lock()
+	defer c.l.Unlock()
+
+	var now = time.Now()
+
+	if now.Sub(c.swept) > c.ttl {
+		for k, old := range c.entries {
+			if !c.valid(old) {
+				delete(c.entries, k)
+			}
+		}
+
+		c.swept = now
+	}
+
+	e.expires = now.Add(c.ttl)
+	if !c.valid(e) {
+		return
+	}
+
+	c.entries[key] = e
+}
+
+// invalidate invalidates all cached reports that depend on the module
+func (c *recordReportCache) invalidate(moduleID uint64) {
+	if c == nil || moduleID == 0 {
+		return
+	}
+
+	c.l.Lock()
+	defer c.l.Unlock()
+
+	c.generations[moduleID]++
+	recordReportCacheInvalidations.Inc()
+}
+
+// register invalidates cached reports on record & module changes
+func (c *recordReportCache) register(bus interface {
+	Register(h eventbus.HandlerFn, ops ...eventbus.HandlerRegOp) uintptr
+}) {
+	bus.Register(
+		func(ctx context.Context, ev eventbus.Event) error {
+			if e, ok := ev.(interface{ Module() *types.Module }); ok && e.Module() != nil {
+				c.invalidate(e.Module().ID)
+			}
+
+			if e, ok := ev.(interface{ OldModule() *types.Module }); ok && e.OldModule() != nil {
+				c.invalidate(e.OldModule().ID)
+			}
+
+			return nil
+		},
+		eventbus.For("compose:record", "compose:module"),
+		eventbus.On("afterCreate", "afterUpdate", "afterDelete"),
+	)
+}
+
+// key returns cache key for the report
+func (c *recordReportCache) key(ctx context.Context, m *types.Module, joins []*types.ChartConfigReportJoin, metrics, dimensions, filter string) string {
+	return recordReportCacheKey(ctx, c.rules, m, joins, metrics, dimensions, filter)
+}
+
+// recordReportCacheKey returns cache key for the report
+//
+// Reports made by users with the same set of roles share cached results
+// for as long as permission rules of these roles stay the same
+func recordReportCacheKey(ctx context.Context, rules recordReportCacheRules, m *types.Module, joins []*types.ChartConfigReportJoin, metrics, dimensions, filter string) string {
+	var (
+		i     = auth.GetIdentityFromContext(ctx)
+		roles = append([]uint64{}, i.Roles()...)
+		rr    []string
+	)
+
+	sort.Slice(roles, func(a, b int) bool { return roles[a] < roles[b] })
+
+	if rules != nil {
+		for _, roleID := range roles {
+			_ = rules.FindRulesByRoleID(roleID).Walk(func(r *permissions.Rule) error {
+				rr = append(rr, r.String())
+				return nil
+			})
+		}
+
+		sort.Strings(rr)
+	}
+
+	key, _ := json.Marshal(struct {
+		NamespaceID uint64
+		ModuleID    uint64
+		Joins       []*types.ChartConfigReportJoin
+		Metrics     string
+		Dimensions  string
+		Filter      string
+		Roles       []uint64
+		Rules       []string
+		SuperUser   bool
+	}{m.NamespaceID, m.ID, joins, metrics, dimensions, filter, roles, rr, auth.IsSuperUser(i)})
+
+	sum := sha1.Sum(key)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/permissions"
+)
+
+type (
+	recordReportCacheRulesMock permissions.RuleSet
+)
+
+func (rr recordReportCacheRulesMock) FindRulesByRoleID(roleID uint64) permissions.RuleSet {
+	return permissions.RuleSet(rr).ByRole(roleID)
+}
+
+func TestRecordReportCache(t *testing.T) {
+	var (
+		req = require.New(t)
+		c   = RecordReportCache(time.Minute, nil)
+	)
+
+	req.Nil(c.get("k"))
+
+	c.set("k", &recordReportCacheEntry{result: 42, modules: c.snapshot(1, 2)})
+	req.NotNil(c.get("k"))
+	req.Equal(42, c.get("k").result)
+
+	// change of an unrelated module
+	c.invalidate(3)
+	req.NotNil(c.get("k"))
+
+	// change of the module report depends on
+	c.invalidate(2)
+	req.Nil(c.get("k"))
+
+	// module changed while report was made
+	snapshot := c.snapshot(1)
+	c.invalidate(1)
+	c.set("k", &recordReportCacheEntry{result: 42, modules: snapshot})
+	req.Nil(c.get("k"))
+
+	// disabled cache
+	c = nil
+	c.set("k", &recordReportCacheEntry{result: 42})
+	c.invalidate(1)
+	req.Nil(c.get("k"))
+}
+
+func TestRecordReportCache_expiration(t *testing.T) {
+	var (
+		req = require.New(t)
+		c   = RecordReportCache(time.Millisecond, nil)
+	)
+
+	c.set("k", &recordReportCacheEntry{result: 42, modules: c.snapshot(1)})
+	req.NotNil(c.get("k"))
+
+	time.Sleep(time.Millisecond * 5)
+	req.Nil(c.get("k"))
+
+	// expired entries are removed when new entry is stored
+	c.set("j", &recordReportCacheEntry{result: 42, modules: c.snapshot(1)})
+	req.Len(c.entries, 1)
+}
+
+func TestRecordReportCacheKey(t *testing.T) {
+	var (
+		req = require.New(t)
+		m   = &types.Module{ID: 2, NamespaceID: 1}
+
+		key = func(ctx context.Context, filter string) string {
+			return recordReportCacheKey(ctx, nil, m, nil, "count", "a", filter)
+		}
+
+		ctx  = context.Background()
+		ctxA = auth.SetIdentityToContext(ctx, auth.NewIdentity(10, 1, 2))
+		ctxB = auth.SetIdentityToContext(ctx, auth.NewIdentity(20, 2, 1))
+		ctxC = auth.SetIdentityToContext(ctx, auth.NewIdentity(30, 3))
+	)
+
+	req.Equal(key(ctxA, ""), key(ctxB, ""), "users with the same roles share reports")
+	req.NotEqual(key(ctxA, ""), key(ctxC, ""))
+	req.NotEqual(key(ctxA, ""), key(ctxA, "a > 1"))
+	req.NotEqual(key(ctx, ""), key(auth.SetSuperUserContext(ctx), ""))
+}
+
+func TestRecordReportCacheKey_rules(t *testing.T) {
+	var (
+		req = require.New(t)
+		m   = &types.Module{ID: 2, NamespaceID: 1}
+		ctx = auth.SetIdentityToContext(context.Background(), auth.NewIdentity(10, 1, 2))
+
+		rules = recordReportCacheRulesMock{
+			permissions.AllowRule(1, m.PermissionResource(), "read"),
+		}
+
+		key = func(rules recordReportCacheRules) string {
+			return recordReportCacheKey(ctx, rules, m, nil, "count", "a", "")
+		}
+	)
+
+	req.Equal(key(rules), key(append(recordReportCacheRulesMock{}, rules...)))
+
+	req.Equal(
+		key(rules),
+		key(append(rules, permissions.DenyRule(3, m.PermissionResource(), "read"))),
+		"rules of other roles do not affect the key",
+	)
+
+	req.NotEqual(
+		key(rules),
+		key(append(rules, permissions.DenyRule(2, m.PermissionResource(), "read"))),
+		"new rule of user's role changes the key",
+	)
+
+	req.NotEqual(
+		key(rules),
+		key(recordReportCacheRulesMock{permissions.DenyRule(1, m.PermissionResource(), "read")}),
+		"changed rule of user's role changes the key",
+	)
+}
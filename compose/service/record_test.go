@@ -1,14 +1,18 @@
 package service
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/cortezaproject/corteza-server/compose/service/event"
 	"github.com/cortezaproject/corteza-server/compose/service/values"
 	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/eventbus"
 	"github.com/cortezaproject/corteza-server/pkg/permissions"
+	"github.com/cortezaproject/corteza-server/pkg/rh"
 )
 
 func TestGeneralValueSetValidation(t *testing.T) {
@@ -97,6 +101,7 @@ func TestProcUpdateOwnerPreservation(t *testing.T) {
 
 		svc = record{
 			sanitizer: values.Sanitizer(),
+			formula:   values.Formula(),
 			validator: values.Validator(),
 		}
 
@@ -126,6 +131,7 @@ func TestProcUpdateOwnerChanged(t *testing.T) {
 
 		svc = record{
 			sanitizer: values.Sanitizer(),
+			formula:   values.Formula(),
 			validator: values.Validator(),
 		}
 
@@ -148,3 +154,76 @@ func TestProcUpdateOwnerChanged(t *testing.T) {
 	svc.procUpdate(10, mod, newRec, oldRec)
 	a.Equal(newRec.OwnedBy, uint64(9))
 }
+
+func TestRecordImportReportAdd(t *testing.T) {
+	var (
+		req = require.New(t)
+		rep = types.RecordImportReport{}
+	)
+
+	importReportAdd(&rep, 1, RecordErrFieldNotFound(&recordActionProps{field: "unknown"}))
+	importReportAdd(&rep, 2, RecordErrValueInput().Wrap(&types.RecordValueErrorSet{Set: []types.RecordValueError{
+		{Kind: "empty", Meta: map[string]interface{}{"field": "name"}},
+		{Kind: "ruleViolation", Message: "end before start", Meta: map[string]interface{}{"field": "end"}},
+	}}))
+
+	req.Len(rep, 3)
+	req.Equal(types.RecordImportReportEntry{Row: 1, Field: "unknown", Kind: "fieldNotFound", Message: "no such field unknown"}, *rep[0])
+	req.Equal(types.RecordImportReportEntry{Row: 2, Field: "name", Kind: "empty"}, *rep[1])
+	req.Equal(types.RecordImportReportEntry{Row: 2, Field: "end", Kind: "ruleViolation", Message: "end before start"}, *rep[2])
+}
+
+func TestWalkPaging(t *testing.T) {
+	var (
+		req = require.New(t)
+
+		offset, max uint
+	)
+
+	offset, max = walkPaging(rh.PageFilter{})
+	req.Equal([]uint{0, 0}, []uint{offset, max})
+
+	offset, max = walkPaging(rh.PageFilter{Limit: 10, Offset: 20})
+	req.Equal([]uint{20, 10}, []uint{offset, max})
+
+	offset, max = walkPaging(rh.PageFilter{Page: 1, PerPage: 10})
+	req.Equal([]uint{0, 10}, []uint{offset, max})
+
+	offset, max = walkPaging(rh.PageFilter{Page: 3, PerPage: 10})
+	req.Equal([]uint{20, 10}, []uint{offset, max})
+
+	// limit & offset take precedence over page
+	offset, max = walkPaging(rh.PageFilter{Offset: 5, Page: 3, PerPage: 10})
+	req.Equal([]uint{5, 10}, []uint{offset, max})
+}
+
+func TestImportEvents(t *testing.T) {
+	var (
+		req     = require.New(t)
+		ctx     = context.Background()
+		bus     = eventbus.New()
+		emitted []string
+
+		m   = &types.Module{ID: 1}
+		rec = &types.Record{ID: 2, ModuleID: 1}
+	)
+
+	bus.Register(func(_ context.Context, ev eventbus.Event) error {
+		emitted = append(emitted, ev.EventType())
+		return nil
+	}, eventbus.For("compose:record"), eventbus.On("beforeCreate", "afterCreate"))
+
+	q := &importEvents{eventDispatcher: bus}
+
+	req.NoError(q.WaitFor(ctx, event.RecordBeforeCreate(rec, nil, m, nil, nil)))
+	req.NoError(q.WaitFor(ctx, event.RecordAfterCreateImmutable(rec, nil, m, nil, nil)))
+	req.Equal([]string{"beforeCreate"}, emitted, "expecting after-events to wait for commit")
+
+	q.flush(ctx)
+	req.Equal([]string{"beforeCreate", "afterCreate"}, emitted)
+
+	req.NoError(q.WaitFor(ctx, event.RecordAfterCreateImmutable(rec, nil, m, nil, nil)))
+	q.discard()
+	q.flush(ctx)
+	req.Len(emitted, 2, "expecting events of rolled back records to be dropped")
+}
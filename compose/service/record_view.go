@@ -0,0 +1,400 @@
+package service
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/titpetric/factory"
+
+	"github.com/cortezaproject/corteza-server/compose/repository"
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/actionlog"
+	"github.com/cortezaproject/corteza-server/pkg/handle"
+	"github.com/cortezaproject/corteza-server/pkg/permissions"
+	"github.com/cortezaproject/corteza-server/pkg/ql"
+)
+
+type (
+	recordView struct {
+		db  *factory.DB
+		ctx context.Context
+
+		actionlog actionlog.Recorder
+
+		ac recordViewAccessController
+
+		viewRepo   repository.RecordViewRepository
+		moduleRepo repository.ModuleRepository
+		nsRepo     repository.NamespaceRepository
+	}
+
+	recordViewAccessController interface {
+		CanReadNamespace(context.Context, *types.Namespace) bool
+		CanReadModule(context.Context, *types.Module) bool
+		CanCreateRecordView(context.Context, *types.Namespace) bool
+		CanReadRecordView(context.Context, *types.RecordView) bool
+		CanUpdateRecordView(context.Context, *types.RecordView) bool
+		CanDeleteRecordView(context.Context, *types.RecordView) bool
+
+		FilterReadableRecordViews(ctx context.Context) *permissions.ResourceFilter
+	}
+
+	RecordViewService interface {
+		With(ctx context.Context) RecordViewService
+
+		FindByID(namespaceID, viewID uint64) (*types.RecordView, error)
+		FindByHandle(namespaceID uint64, handle string) (*types.RecordView, error)
+		FindByAny(namespaceID uint64, identifier interface{}) (*types.RecordView, error)
+		Find(filter types.RecordViewFilter) (set types.RecordViewSet, f types.RecordViewFilter, err error)
+
+		Create(view *types.RecordView) (*types.RecordView, error)
+		Update(view *types.RecordView) (*types.RecordView, error)
+		DeleteByID(namespaceID, viewID uint64) error
+	}
+)
+
+func RecordView() RecordViewService {
+	return (&recordView{
+		ac: DefaultAccessControl,
+	}).With(context.Background())
+}
+
+func (svc recordView) With(ctx context.Context) RecordViewService {
+	db := repository.DB(ctx)
+	return &recordView{
+		db:  db,
+		ctx: ctx,
+
+		actionlog: DefaultActionlog,
+
+		ac: svc.ac,
+
+		viewRepo:   repository.RecordView(ctx, db),
+		moduleRepo: repository.Module(ctx, db),
+		nsRepo:     repository.Namespace(ctx, db),
+	}
+}
+
+// lookup fn() orchestrates record view lookup, namespace preload and check
+func (svc recordView) lookup(namespaceID uint64, lookup func(*recordViewActionProps) (*types.RecordView, error)) (v *types.RecordView, err error) {
+	var aProps = &recordViewActionProps{view: &types.RecordView{NamespaceID: namespaceID}}
+
+	err = svc.db.Transaction(func() error {
+		if ns, err := svc.loadNamespace(namespaceID); err != nil {
+			return err
+		} else {
+			aProps.setNamespace(ns)
+		}
+
+		if v, err = lookup(aProps); err != nil {
+			if repository.ErrRecordViewNotFound.Eq(err) {
+				return RecordViewErrNotFound()
+			}
+
+			return err
+		}
+
+		aProps.setView(v)
+
+		if !svc.ac.CanReadRecordView(svc.ctx, v) {
+			return RecordViewErrNotAllowedToRead()
+		}
+
+		return nil
+	})
+
+	return v, svc.recordAction(svc.ctx, aProps, RecordViewActionLookup, err)
+}
+
+func (svc recordView) FindByID(namespaceID, viewID uint64) (*types.RecordView, error) {
+	return svc.lookup(namespaceID, func(aProps *recordViewActionProps) (*types.RecordView, error) {
+		if viewID == 0 {
+			return nil, RecordViewErrInvalidID()
+		}
+
+		aProps.view.ID = viewID
+		return svc.viewRepo.FindByID(namespaceID, viewID)
+	})
+}
+
+func (svc recordView) FindByHandle(namespaceID uint64, h string) (*types.RecordView, error) {
+	return svc.lookup(namespaceID, func(aProps *recordViewActionProps) (*types.RecordView, error) {
+		if !handle.IsValid(h) {
+			return nil, RecordViewErrInvalidHandle()
+		}
+
+		aProps.view.Handle = h
+		return svc.viewRepo.FindByHandle(namespaceID, h)
+	})
+}
+
+// FindByAny tries to find record view in a particular namespace by id or handle
+func (svc recordView) FindByAny(namespaceID uint64, identifier interface{}) (*types.RecordView, error) {
+	if ID, ok := identifier.(uint64); ok {
+		return svc.FindByID(namespaceID, ID)
+	} else if strIdentifier, ok := identifier.(string); ok {
+		if ID, _ := strconv.ParseUint(strIdentifier, 10, 64); ID > 0 {
+			return svc.FindByID(namespaceID, ID)
+		}
+
+		return svc.FindByHandle(namespaceID, strIdentifier)
+	}
+
+	// force invalid ID error
+	// we do that to wrap error with lookup action context
+	return svc.FindByID(namespaceID, 0)
+}
+
+func (svc recordView) Find(filter types.RecordViewFilter) (set types.RecordViewSet, f types.RecordViewFilter, err error) {
+	var (
+		aProps = &recordViewActionProps{filter: &filter}
+	)
+
+	f = filter
+	f.IsReadable = svc.ac.FilterReadableRecordViews(svc.ctx)
+
+	err = svc.db.Transaction(func() error {
+		if ns, err := svc.loadNamespace(f.NamespaceID); err != nil {
+			return err
+		} else {
+			aProps.setNamespace(ns)
+		}
+
+		if set, f, err = svc.viewRepo.Find(f); err != nil {
+			return err
+		}
+
+		return nil
+	})
+
+	return set, f, svc.recordAction(svc.ctx, aProps, RecordViewActionSearch, err)
+}
+
+func (svc recordView) Create(new *types.RecordView) (v *types.RecordView, err error) {
+	var (
+		ns     *types.Namespace
+		m      *types.Module
+		aProps = &recordViewActionProps{changed: new}
+	)
+
+	err = svc.db.Transaction(func() error {
+		if !handle.IsValid(new.Handle) {
+			return RecordViewErrInvalidHandle()
+		}
+
+		if ns, err = svc.loadNamespace(new.NamespaceID); err != nil {
+			return err
+		}
+
+		aProps.setNamespace(ns)
+
+		if !svc.ac.CanCreateRecordView(svc.ctx, ns) {
+			return RecordViewErrNotAllowedToCreate()
+		}
+
+		if m, err = svc.loadModule(new.NamespaceID, new.ModuleID); err != nil {
+			return err
+		}
+
+		aProps.setModule(m)
+
+		if err = svc.validate(m, new); err != nil {
+			return err
+		}
+
+		if err = svc.UniqueCheck(new); err != nil {
+			return err
+		}
+
+		v, err = svc.viewRepo.Create(new)
+		return err
+	})
+
+	return v, svc.recordAction(svc.ctx, aProps, RecordViewActionCreate, err)
+}
+
+func (svc recordView) Update(upd *types.RecordView) (v *types.RecordView, err error) {
+	var (
+		ns     *types.Namespace
+		m      *types.Module
+		aProps = &recordViewActionProps{changed: upd}
+	)
+
+	err = svc.db.Transaction(func() error {
+		if !handle.IsValid(upd.Handle) {
+			return RecordViewErrInvalidHandle()
+		}
+
+		if upd.ID == 0 {
+			return RecordViewErrInvalidID()
+		}
+
+		if ns, err = svc.loadNamespace(upd.NamespaceID); err != nil {
+			return err
+		}
+
+		aProps.setNamespace(ns)
+
+		if v, err = svc.viewRepo.FindByID(upd.NamespaceID, upd.ID); err != nil {
+			if repository.ErrRecordViewNotFound.Eq(err) {
+				return RecordViewErrNotFound()
+			}
+
+			return err
+		}
+
+		aProps.setView(v)
+
+		if isStale(upd.UpdatedAt, v.UpdatedAt, v.CreatedAt) {
+			return RecordViewErrStaleData()
+		}
+
+		if !svc.ac.CanUpdateRecordView(svc.ctx, v) {
+			return RecordViewErrNotAllowedToUpdate()
+		}
+
+		if m, err = svc.loadModule(upd.NamespaceID, upd.ModuleID); err != nil {
+			return err
+		}
+
+		aProps.setModule(m)
+
+		if err = svc.validate(m, upd); err != nil {
+			return err
+		}
+
+		if err = svc.UniqueCheck(upd); err != nil {
+			return err
+		}
+
+		v.Handle = upd.Handle
+		v.Name = upd.Name
+		v.ModuleID = upd.ModuleID
+		v.Filter = upd.Filter
+		v.Sort = upd.Sort
+		v.Columns = upd.Columns
+
+		v, err = svc.viewRepo.Update(v)
+		return err
+	})
+
+	return v, svc.recordAction(svc.ctx, aProps, RecordViewActionUpdate, err)
+}
+
+func (svc recordView) DeleteByID(namespaceID, viewID uint64) (err error) {
+	var (
+		ns     *types.Namespace
+		v      *types.RecordView
+		aProps = &recordViewActionProps{view: &types.RecordView{ID: viewID, NamespaceID: namespaceID}}
+	)
+
+	err = svc.db.Transaction(func() (err error) {
+		if viewID == 0 {
+			return RecordViewErrInvalidID()
+		}
+
+		if ns, err = svc.loadNamespace(namespaceID); err != nil {
+			return err
+		}
+
+		aProps.setNamespace(ns)
+
+		if v, err = svc.viewRepo.FindByID(namespaceID, viewID); err != nil {
+			if repository.ErrRecordViewNotFound.Eq(err) {
+				return RecordViewErrNotFound()
+			}
+
+			return err
+		}
+
+		aProps.setView(v)
+
+		if !svc.ac.CanDeleteRecordView(svc.ctx, v) {
+			return RecordViewErrNotAllowedToDelete()
+		}
+
+		return svc.viewRepo.DeleteByID(namespaceID, viewID)
+	})
+
+	return svc.recordAction(svc.ctx, aProps, RecordViewActionDelete, err)
+}
+
+func (svc recordView) UniqueCheck(v *types.RecordView) (err error) {
+	if v.Handle != "" {
+		if e, _ := svc.viewRepo.FindByHandle(v.NamespaceID, v.Handle); e != nil && e.ID != v.ID {
+			return RecordViewErrHandleNotUnique()
+		}
+	}
+
+	return nil
+}
+
+// validate checks filter & sort syntax and if all columns are module or record fields
+//
+// Identifiers in the filter and sort are resolved (and checked) when records are listed
+func (svc recordView) validate(m *types.Module, v *types.RecordView) error {
+	if v.Filter != "" {
+		if _, err := ql.NewParser().ParseExpression(v.Filter); err != nil {
+			return RecordViewErrInvalidFilter()
+		}
+	}
+
+	if v.Sort != "" {
+		if _, err := ql.NewParser().ParseColumns(v.Sort); err != nil {
+			return RecordViewErrInvalidSort()
+		}
+	}
+
+	for _, c := range v.Columns {
+		switch c {
+		case "recordID", "ownedBy", "createdBy", "createdAt", "updatedBy", "updatedAt", "deletedBy", "deletedAt":
+			continue
+		}
+
+		if m.Fields.FindByName(c) == nil {
+			return RecordViewErrInvalidColumn()
+		}
+	}
+
+	return nil
+}
+
+func (svc recordView) loadNamespace(namespaceID uint64) (ns *types.Namespace, err error) {
+	if namespaceID == 0 {
+		return nil, RecordViewErrInvalidNamespaceID()
+	}
+
+	if ns, err = svc.nsRepo.FindByID(namespaceID); err != nil {
+		if repository.ErrNamespaceNotFound.Eq(err) {
+			return nil, RecordViewErrNamespaceNotFound()
+		}
+
+		return
+	}
+
+	if !svc.ac.CanReadNamespace(svc.ctx, ns) {
+		return nil, RecordViewErrNotAllowedToReadNamespace()
+	}
+
+	return
+}
+
+func (svc recordView) loadModule(namespaceID, moduleID uint64) (m *types.Module, err error) {
+	if m, err = svc.moduleRepo.FindByID(namespaceID, moduleID); err != nil {
+		if repository.ErrModuleNotFound.Eq(err) {
+			return nil, RecordViewErrModuleNotFound()
+		}
+
+		return
+	}
+
+	if !svc.ac.CanReadModule(svc.ctx, m) {
+		return nil, RecordViewErrNotAllowedToReadModule()
+	}
+
+	if m.Fields, err = svc.moduleRepo.FindFields(m.ID); err != nil {
+		return nil, err
+	}
+
+	return
+}
@@ -0,0 +1,1195 @@
+package service
+
+// This file is auto-generated from compose/service/record_view_actions.yaml
+//
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/actionlog"
+)
+
+type (
+	recordViewActionProps struct {
+		view      *types.RecordView
+		changed   *types.RecordView
+		filter    *types.RecordViewFilter
+		namespace *types.Namespace
+		module    *types.Module
+	}
+
+	recordViewAction struct {
+		timestamp time.Time
+		resource  string
+		action    string
+		log       string
+		severity  actionlog.Severity
+
+		// prefix for error when action fails
+		errorMessage string
+
+		props *recordViewActionProps
+	}
+
+	recordViewError struct {
+		timestamp time.Time
+		error     string
+		resource  string
+		action    string
+		message   string
+		log       string
+		severity  actionlog.Severity
+
+		wrap error
+
+		props *recordViewActionProps
+	}
+)
+
+var (
+	// just a placeholder to cover template cases w/o fmt package use
+	_ = fmt.Println
+)
+
+// *********************************************************************************************************************
+// *********************************************************************************************************************
+// Props methods
+// setView updates recordViewActionProps's view
+//
+// Allows method chaining
+//
+// This function is auto-generated.
+//
+func (p *recordViewActionProps) setView(view *types.RecordView) *recordViewActionProps {
+	p.view = view
+	return p
+}
+
+// setChanged updates recordViewActionProps's changed
+//
+// Allows method chaining
+//
+// This function is auto-generated.
+//
+func (p *recordViewActionProps) setChanged(changed *types.RecordView) *recordViewActionProps {
+	p.changed = changed
+	return p
+}
+
+// setFilter updates recordViewActionProps's filter
+//
+// Allows method chaining
+//
+// This function is auto-generated.
+//
+func (p *recordViewActionProps) setFilter(filter *types.RecordViewFilter) *recordViewActionProps {
+	p.filter = filter
+	return p
+}
+
+// setNamespace updates recordViewActionProps's namespace
+//
+// Allows method chaining
+//
+// This function is auto-generated.
+//
+func (p *recordViewActionProps) setNamespace(namespace *types.Namespace) *recordViewActionProps {
+	p.namespace = namespace
+	return p
+}
+
+// setModule updates recordViewActionProps's module
+//
+// Allows method chaining
+//
+// This function is auto-generated.
+//
+func (p *recordViewActionProps) setModule(module *types.Module) *recordViewActionProps {
+	p.module = module
+	return p
+}
+
+// serialize converts recordViewActionProps to actionlog.Meta
+//
+// This function is auto-generated.
+//
+func (p recordViewActionProps) serialize() actionlog.Meta {
+	var (
+		m = make(actionlog.Meta)
+	)
+
+	if p.view != nil {
+		m.Set("view.name", p.view.Name, true)
+		m.Set("view.handle", p.view.Handle, true)
+		m.Set("view.ID", p.view.ID, true)
+		m.Set("view.namespaceID", p.view.NamespaceID, true)
+		m.Set("view.moduleID", p.view.ModuleID, true)
+	}
+	if p.changed != nil {
+		m.Set("changed.name", p.changed.Name, true)
+		m.Set("changed.handle", p.changed.Handle, true)
+		m.Set("changed.ID", p.changed.ID, true)
+		m.Set("changed.namespaceID", p.changed.NamespaceID, true)
+		m.Set("changed.moduleID", p.changed.ModuleID, true)
+		m.Set("changed.filter", p.changed.Filter, true)
+		m.Set("changed.sort", p.changed.Sort, true)
+		m.Set("changed.columns", p.changed.Columns, true)
+	}
+	if p.filter != nil {
+		m.Set("filter.query", p.filter.Query, true)
+		m.Set("filter.handle", p.filter.Handle, true)
+		m.Set("filter.namespaceID", p.filter.NamespaceID, true)
+		m.Set("filter.moduleID", p.filter.ModuleID, true)
+		m.Set("filter.sort", p.filter.Sort, true)
+		m.Set("filter.limit", p.filter.Limit, true)
+		m.Set("filter.offset", p.filter.Offset, true)
+		m.Set("filter.page", p.filter.Page, true)
+		m.Set("filter.perPage", p.filter.PerPage, true)
+	}
+	if p.namespace != nil {
+		m.Set("namespace.name", p.namespace.Name, true)
+		m.Set("namespace.slug", p.namespace.Slug, true)
+		m.Set("namespace.ID", p.namespace.ID, true)
+	}
+	if p.module != nil {
+		m.Set("module.name", p.module.Name, true)
+		m.Set("module.handle", p.module.Handle, true)
+		m.Set("module.ID", p.module.ID, true)
+	}
+
+	return m
+}
+
+// tr translates string and replaces meta value placeholder with values
+//
+// This function is auto-generated.
+//
+func (p recordViewActionProps) tr(in string, err error) string {
+	var (
+		pairs = []string{"{err}"}
+		// first non-empty string
+		fns = func(ii ...interface{}) string {
+			for _, i := range ii {
+				if s := fmt.Sprintf("%v", i); len(s) > 0 {
+					return s
+				}
+			}
+
+			return ""
+		}
+	)
+
+	if err != nil {
+		for {
+			// Unwrap errors
+			ue := errors.Unwrap(err)
+			if ue == nil {
+				break
+			}
+
+			err = ue
+		}
+
+		pairs = append(pairs, err.Error())
+	} else {
+		pairs = append(pairs, "nil")
+	}
+
+	if p.view != nil {
+		// replacement for "{view}" (in order how fields are defined)
+		pairs = append(
+			pairs,
+			"{view}",
+			fns(
+				p.view.Name,
+				p.view.Handle,
+				p.view.ID,
+				p.view.NamespaceID,
+				p.view.ModuleID,
+			),
+		)
+		pairs = append(pairs, "{view.name}", fns(p.view.Name))
+		pairs = append(pairs, "{view.handle}", fns(p.view.Handle))
+		pairs = append(pairs, "{view.ID}", fns(p.view.ID))
+		pairs = append(pairs, "{view.namespaceID}", fns(p.view.NamespaceID))
+		pairs = append(pairs, "{view.moduleID}", fns(p.view.ModuleID))
+	}
+
+	if p.changed != nil {
+		// replacement for "{changed}" (in order how fields are defined)
+		pairs = append(
+			pairs,
+			"{changed}",
+			fns(
+				p.changed.Name,
+				p.changed.Handle,
+				p.changed.ID,
+				p.changed.NamespaceID,
+				p.changed.ModuleID,
+				p.changed.Filter,
+				p.changed.Sort,
+				p.changed.Columns,
+			),
+		)
+		pairs = append(pairs, "{changed.name}", fns(p.changed.Name))
+		pairs = append(pairs, "{changed.handle}", fns(p.changed.Handle))
+		pairs = append(pairs, "{changed.ID}", fns(p.changed.ID))
+		pairs = append(pairs, "{changed.namespaceID}", fns(p.changed.NamespaceID))
+		pairs = append(pairs, "{changed.moduleID}", fns(p.changed.ModuleID))
+		pairs = append(pairs, "{changed.filter}", fns(p.changed.Filter))
+		pairs = append(pairs, "{changed.sort}", fns(p.changed.Sort))
+		pairs = append(pairs, "{changed.columns}", fns(p.changed.Columns))
+	}
+
+	if p.filter != nil {
+		// replacement for "{filter}" (in order how fields are defined)
+		pairs = append(
+			pairs,
+			"{filter}",
+			fns(
+				p.filter.Query,
+				p.filter.Handle,
+				p.filter.NamespaceID,
+				p.filter.ModuleID,
+				p.filter.Sort,
+				p.filter.Limit,
+				p.filter.Offset,
+				p.filter.Page,
+				p.filter.PerPage,
+			),
+		)
+		pairs = append(pairs, "{filter.query}", fns(p.filter.Query))
+		pairs = append(pairs, "{filter.handle}", fns(p.filter.Handle))
+		pairs = append(pairs, "{filter.namespaceID}", fns(p.filter.NamespaceID))
+		pairs = append(pairs, "{filter.moduleID}", fns(p.filter.ModuleID))
+		pairs = append(pairs, "{filter.sort}", fns(p.filter.Sort))
+		pairs = append(pairs, "{filter.limit}", fns(p.filter.Limit))
+		pairs = append(pairs, "{filter.offset}", fns(p.filter.Offset))
+		pairs = append(pairs, "{filter.page}", fns(p.filter.Page))
+		pairs = append(pairs, "{filter.perPage}", fns(p.filter.PerPage))
+	}
+
+	if p.namespace != nil {
+		// replacement for "{namespace}" (in order how fields are defined)
+		pairs = append(
+			pairs,
+			"{namespace}",
+			fns(
+				p.namespace.Name,
+				p.namespace.Slug,
+				p.namespace.ID,
+			),
+		)
+		pairs = append(pairs, "{namespace.name}", fns(p.namespace.Name))
+		pairs = append(pairs, "{namespace.slug}", fns(p.namespace.Slug))
+		pairs = append(pairs, "{namespace.ID}", fns(p.namespace.ID))
+	}
+
+	if p.module != nil {
+		// replacement for "{module}" (in order how fields are defined)
+		pairs = append(
+			pairs,
+			"{module}",
+			fns(
+				p.module.Name,
+				p.module.Handle,
+				p.module.ID,
+			),
+		)
+		pairs = append(pairs, "{module.name}", fns(p.module.Name))
+		pairs = append(pairs, "{module.handle}", fns(p.module.Handle))
+		pairs = append(pairs, "{module.ID}", fns(p.module.ID))
+	}
+	return strings.NewReplacer(pairs...).Replace(in)
+}
+
+// *********************************************************************************************************************
+// *********************************************************************************************************************
+// Action methods
+
+// String returns loggable description as string
+//
+// This function is auto-generated.
+//
+func (a *recordViewAction) String() string {
+	var props = &recordViewActionProps{}
+
+	if a.props != nil {
+		props = a.props
+	}
+
+	return props.tr(a.log, nil)
+}
+
+func (e *recordViewAction) LoggableAction() *actionlog.Action {
+	return &actionlog.Action{
+		Timestamp:   e.timestamp,
+		Resource:    e.resource,
+		Action:      e.action,
+		Severity:    e.severity,
+		Description: e.String(),
+		Meta:        e.props.serialize(),
+	}
+}
+
+// *********************************************************************************************************************
+// *********************************************************************************************************************
+// Error methods
+
+// String returns loggable description as string
+//
+// It falls back to message if log is not set
+//
+// This function is auto-generated.
+//
+func (e *recordViewError) String() string {
+	var props = &recordViewActionProps{}
+
+	if e.props != nil {
+		props = e.props
+	}
+
+	if e.wrap != nil && !strings.Contains(e.log, "{err}") {
+		// Suffix error log with {err} to ensure
+		// we log the cause for this error
+		e.log += ": {err}"
+	}
+
+	return props.tr(e.log, e.wrap)
+}
+
+// Error satisfies
+//
+// This function is auto-generated.
+//
+func (e *recordViewError) Error() string {
+	var props = &recordViewActionProps{}
+
+	if e.props != nil {
+		props = e.props
+	}
+
+	return props.tr(e.message, e.wrap)
+}
+
+// Is fn for error equality check
+//
+// This function is auto-generated.
+//
+func (e *recordViewError) Is(Resource error) bool {
+	t, ok := Resource.(*recordViewError)
+	if !ok {
+		return false
+	}
+
+	return t.resource == e.resource && t.error == e.error
+}
+
+// Wrap wraps recordViewError around another error
+//
+// This function is auto-generated.
+//
+func (e *recordViewError) Wrap(err error) *recordViewError {
+	e.wrap = err
+	return e
+}
+
+// Unwrap returns wrapped error
+//
+// This function is auto-generated.
+//
+func (e *recordViewError) Unwrap() error {
+	return e.wrap
+}
+
+func (e *recordViewError) LoggableAction() *actionlog.Action {
+	return &actionlog.Action{
+		Timestamp:   e.timestamp,
+		Resource:    e.resource,
+		Action:      e.action,
+		Severity:    e.severity,
+		Description: e.String(),
+		Error:       e.Error(),
+		Meta:        e.props.serialize(),
+	}
+}
+
+// *********************************************************************************************************************
+// *********************************************************************************************************************
+// Action constructors
+
+// RecordViewActionSearch returns "compose:record_view.search" error
+//
+// This function is auto-generated.
+//
+func RecordViewActionSearch(props ...*recordViewActionProps) *recordViewAction {
+	a := &recordViewAction{
+		timestamp: time.Now(),
+		resource:  "compose:record_view",
+		action:    "search",
+		log:       "searched for record views",
+		severity:  actionlog.Info,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
+// RecordViewActionLookup returns "compose:record_view.lookup" error
+//
+// This function is auto-generated.
+//
+func RecordViewActionLookup(props ...*recordViewActionProps) *recordViewAction {
+	a := &recordViewAction{
+		timestamp: time.Now(),
+		resource:  "compose:record_view",
+		action:    "lookup",
+		log:       "looked-up for a {view}",
+		severity:  actionlog.Info,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
+// RecordViewActionCreate returns "compose:record_view.create" error
+//
+// This function is auto-generated.
+//
+func RecordViewActionCreate(props ...*recordViewActionProps) *recordViewAction {
+	a := &recordViewAction{
+		timestamp: time.Now(),
+		resource:  "compose:record_view",
+		action:    "create",
+		log:       "created {view}",
+		severity:  actionlog.Notice,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
+// RecordViewActionUpdate returns "compose:record_view.update" error
+//
+// This function is auto-generated.
+//
+func RecordViewActionUpdate(props ...*recordViewActionProps) *recordViewAction {
+	a := &recordViewAction{
+		timestamp: time.Now(),
+		resource:  "compose:record_view",
+		action:    "update",
+		log:       "updated {view}",
+		severity:  actionlog.Notice,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
+// RecordViewActionDelete returns "compose:record_view.delete" error
+//
+// This function is auto-generated.
+//
+func RecordViewActionDelete(props ...*recordViewActionProps) *recordViewAction {
+	a := &recordViewAction{
+		timestamp: time.Now(),
+		resource:  "compose:record_view",
+		action:    "delete",
+		log:       "deleted {view}",
+		severity:  actionlog.Notice,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
+// *********************************************************************************************************************
+// *********************************************************************************************************************
+// Error constructors
+
+// RecordViewErrGeneric returns "compose:record_view.generic" audit event as actionlog.Error
+//
+//
+// This function is auto-generated.
+//
+func RecordViewErrGeneric(props ...*recordViewActionProps) *recordViewError {
+	var e = &recordViewError{
+		timestamp: time.Now(),
+		resource:  "compose:record_view",
+		error:     "generic",
+		action:    "error",
+		message:   "failed to complete request due to internal error",
+		log:       "{err}",
+		severity:  actionlog.Error,
+		props: func() *recordViewActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
+// RecordViewErrNotFound returns "compose:record_view.notFound" audit event as actionlog.Warning
+//
+//
+// This function is auto-generated.
+//
+func RecordViewErrNotFound(props ...*recordViewActionProps) *recordViewError {
+	var e = &recordViewError{
+		timestamp: time.Now(),
+		resource:  "compose:record_view",
+		error:     "notFound",
+		action:    "error",
+		message:   "record view does not exist",
+		log:       "record view does not exist",
+		severity:  actionlog.Warning,
+		props: func() *recordViewActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
+// RecordViewErrNamespaceNotFound returns "compose:record_view.namespaceNotFound" audit event as actionlog.Warning
+//
+//
+// This function is auto-generated.
+//
+func RecordViewErrNamespaceNotFound(props ...*recordViewActionProps) *recordViewError {
+	var e = &recordViewError{
+		timestamp: time.Now(),
+		resource:  "compose:record_view",
+		error:     "namespaceNotFound",
+		action:    "error",
+		message:   "namespace does not exist",
+		log:       "namespace does not exist",
+		severity:  actionlog.Warning,
+		props: func() *recordViewActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
+// RecordViewErrModuleNotFound returns "compose:record_view.moduleNotFound" audit event as actionlog.Warning
+//
+//
+// This function is auto-generated.
+//
+func RecordViewErrModuleNotFound(props ...*recordViewActionProps) *recordViewError {
+	var e = &recordViewError{
+		timestamp: time.Now(),
+		resource:  "compose:record_view",
+		error:     "moduleNotFound",
+		action:    "error",
+		message:   "module does not exist",
+		log:       "module does not exist",
+		severity:  actionlog.Warning,
+		props: func() *recordViewActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
+// RecordViewErrInvalidID returns "compose:record_view.invalidID" audit event as actionlog.Warning
+//
+//
+// This function is auto-generated.
+//
+func RecordViewErrInvalidID(props ...*recordViewActionProps) *recordViewError {
+	var e = &recordViewError{
+		timestamp: time.Now(),
+		resource:  "compose:record_view",
+		error:     "invalidID",
+		action:    "error",
+		message:   "invalid ID",
+		log:       "invalid ID",
+		severity:  actionlog.Warning,
+		props: func() *recordViewActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
+// RecordViewErrInvalidHandle returns "compose:record_view.invalidHandle" audit event as actionlog.Warning
+//
+//
+// This function is auto-generated.
+//
+func RecordViewErrInvalidHandle(props ...*recordViewActionProps) *recordViewError {
+	var e = &recordViewError{
+		timestamp: time.Now(),
+		resource:  "compose:record_view",
+		error:     "invalidHandle",
+		action:    "error",
+		message:   "invalid handle",
+		log:       "invalid handle",
+		severity:  actionlog.Warning,
+		props: func() *recordViewActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
+// RecordViewErrHandleNotUnique returns "compose:record_view.handleNotUnique" audit event as actionlog.Warning
+//
+//
+// This function is auto-generated.
+//
+func RecordViewErrHandleNotUnique(props ...*recordViewActionProps) *recordViewError {
+	var e = &recordViewError{
+		timestamp: time.Now(),
+		resource:  "compose:record_view",
+		error:     "handleNotUnique",
+		action:    "error",
+		message:   "handle not unique",
+		log:       "used duplicate handle ({view.handle}) for record view",
+		severity:  actionlog.Warning,
+		props: func() *recordViewActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
+// RecordViewErrInvalidFilter returns "compose:record_view.invalidFilter" audit event as actionlog.Warning
+//
+//
+// This function is auto-generated.
+//
+func RecordViewErrInvalidFilter(props ...*recordViewActionProps) *recordViewError {
+	var e = &recordViewError{
+		timestamp: time.Now(),
+		resource:  "compose:record_view",
+		error:     "invalidFilter",
+		action:    "error",
+		message:   "invalid filter",
+		log:       "could not parse filter of {view}",
+		severity:  actionlog.Warning,
+		props: func() *recordViewActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
+// RecordViewErrInvalidSort returns "compose:record_view.invalidSort" audit event as actionlog.Warning
+//
+//
+// This function is auto-generated.
+//
+func RecordViewErrInvalidSort(props ...*recordViewActionProps) *recordViewError {
+	var e = &recordViewError{
+		timestamp: time.Now(),
+		resource:  "compose:record_view",
+		error:     "invalidSort",
+		action:    "error",
+		message:   "invalid sort",
+		log:       "could not parse sort of {view}",
+		severity:  actionlog.Warning,
+		props: func() *recordViewActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
+// RecordViewErrInvalidColumn returns "compose:record_view.invalidColumn" audit event as actionlog.Warning
+//
+//
+// This function is auto-generated.
+//
+func RecordViewErrInvalidColumn(props ...*recordViewActionProps) *recordViewError {
+	var e = &recordViewError{
+		timestamp: time.Now(),
+		resource:  "compose:record_view",
+		error:     "invalidColumn",
+		action:    "error",
+		message:   "invalid column",
+		log:       "column of {view} is not a field of {module}",
+		severity:  actionlog.Warning,
+		props: func() *recordViewActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
+// RecordViewErrModuleMismatch returns "compose:record_view.moduleMismatch" audit event as actionlog.Warning
+//
+//
+// This function is auto-generated.
+//
+func RecordViewErrModuleMismatch(props ...*recordViewActionProps) *recordViewError {
+	var e = &recordViewError{
+		timestamp: time.Now(),
+		resource:  "compose:record_view",
+		error:     "moduleMismatch",
+		action:    "error",
+		message:   "record view does not belong to this module",
+		log:       "{view} does not belong to {module}",
+		severity:  actionlog.Warning,
+		props: func() *recordViewActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
+// RecordViewErrStaleData returns "compose:record_view.staleData" audit event as actionlog.Warning
+//
+//
+// This function is auto-generated.
+//
+func RecordViewErrStaleData(props ...*recordViewActionProps) *recordViewError {
+	var e = &recordViewError{
+		timestamp: time.Now(),
+		resource:  "compose:record_view",
+		error:     "staleData",
+		action:    "error",
+		message:   "stale data",
+		log:       "stale data",
+		severity:  actionlog.Warning,
+		props: func() *recordViewActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
+// RecordViewErrInvalidNamespaceID returns "compose:record_view.invalidNamespaceID" audit event as actionlog.Warning
+//
+//
+// This function is auto-generated.
+//
+func RecordViewErrInvalidNamespaceID(props ...*recordViewActionProps) *recordViewError {
+	var e = &recordViewError{
+		timestamp: time.Now(),
+		resource:  "compose:record_view",
+		error:     "invalidNamespaceID",
+		action:    "error",
+		message:   "invalid or missing namespace ID",
+		log:       "invalid or missing namespace ID",
+		severity:  actionlog.Warning,
+		props: func() *recordViewActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
+// RecordViewErrNotAllowedToRead returns "compose:record_view.notAllowedToRead" audit event as actionlog.Error
+//
+//
+// This function is auto-generated.
+//
+func RecordViewErrNotAllowedToRead(props ...*recordViewActionProps) *recordViewError {
+	var e = &recordViewError{
+		timestamp: time.Now(),
+		resource:  "compose:record_view",
+		error:     "notAllowedToRead",
+		action:    "error",
+		message:   "not allowed to read this record view",
+		log:       "could not read {view}; insufficient permissions",
+		severity:  actionlog.Error,
+		props: func() *recordViewActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
+// RecordViewErrNotAllowedToReadNamespace returns "compose:record_view.notAllowedToReadNamespace" audit event as actionlog.Error
+//
+//
+// This function is auto-generated.
+//
+func RecordViewErrNotAllowedToReadNamespace(props ...*recordViewActionProps) *recordViewError {
+	var e = &recordViewError{
+		timestamp: time.Now(),
+		resource:  "compose:record_view",
+		error:     "notAllowedToReadNamespace",
+		action:    "error",
+		message:   "not allowed to read this namespace",
+		log:       "could not read namespace {namespace}; insufficient permissions",
+		severity:  actionlog.Error,
+		props: func() *recordViewActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
+// RecordViewErrNotAllowedToReadModule returns "compose:record_view.notAllowedToReadModule" audit event as actionlog.Error
+//
+//
+// This function is auto-generated.
+//
+func RecordViewErrNotAllowedToReadModule(props ...*recordViewActionProps) *recordViewError {
+	var e = &recordViewError{
+		timestamp: time.Now(),
+		resource:  "compose:record_view",
+		error:     "notAllowedToReadModule",
+		action:    "error",
+		message:   "not allowed to read this module",
+		log:       "could not read module {module}; insufficient permissions",
+		severity:  actionlog.Error,
+		props: func() *recordViewActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
+// RecordViewErrNotAllowedToCreate returns "compose:record_view.notAllowedToCreate" audit event as actionlog.Error
+//
+//
+// This function is auto-generated.
+//
+func RecordViewErrNotAllowedToCreate(props ...*recordViewActionProps) *recordViewError {
+	var e = &recordViewError{
+		timestamp: time.Now(),
+		resource:  "compose:record_view",
+		error:     "notAllowedToCreate",
+		action:    "error",
+		message:   "not allowed to create record views",
+		log:       "could not create record views; insufficient permissions",
+		severity:  actionlog.Error,
+		props: func() *recordViewActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
+// RecordViewErrNotAllowedToUpdate returns "compose:record_view.notAllowedToUpdate" audit event as actionlog.Error
+//
+//
+// This function is auto-generated.
+//
+func RecordViewErrNotAllowedToUpdate(props ...*recordViewActionProps) *recordViewError {
+	var e = &recordViewError{
+		timestamp: time.Now(),
+		resource:  "compose:record_view",
+		error:     "notAllowedToUpdate",
+		action:    "error",
+		message:   "not allowed to update this record view",
+		log:       "could not update {view}; insufficient permissions",
+		severity:  actionlog.Error,
+		props: func() *recordViewActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
+// RecordViewErrNotAllowedToDelete returns "compose:record_view.notAllowedToDelete" audit event as actionlog.Error
+//
+//
+// This function is auto-generated.
+//
+func RecordViewErrNotAllowedToDelete(props ...*recordViewActionProps) *recordViewError {
+	var e = &recordViewError{
+		timestamp: time.Now(),
+		resource:  "compose:record_view",
+		error:     "notAllowedToDelete",
+		action:    "error",
+		message:   "not allowed to delete this record view",
+		log:       "could not delete {view}; insufficient permissions",
+		severity:  actionlog.Error,
+		props: func() *recordViewActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
+// *********************************************************************************************************************
+// *********************************************************************************************************************
+
+// recordAction is a service helper function wraps function that can return error
+//
+// context is used to enrich audit log entry with current user info, request ID, IP address...
+// props are collected action/error properties
+// action (optional) fn will be used to construct recordViewAction struct from given props (and error)
+// err is any error that occurred while action was happening
+//
+// Action has success and fail (error) state:
+//  - when recorded without an error (4th param), action is recorded as successful.
+//  - when an additional error is given (4th param), action is used to wrap
+//    the additional error
+//
+// This function is auto-generated.
+//
+func (svc recordView) recordAction(ctx context.Context, props *recordViewActionProps, action func(...*recordViewActionProps) *recordViewAction, err error) error {
+	var (
+		ok bool
+
+		// Return error
+		retError *recordViewError
+
+		// Recorder error
+		recError *recordViewError
+	)
+
+	if err != nil {
+		if retError, ok = err.(*recordViewError); !ok {
+			// got non-recordView error, wrap it with RecordViewErrGeneric
+			retError = RecordViewErrGeneric(props).Wrap(err)
+
+			if action != nil {
+				// copy action to returning and recording error
+				retError.action = action().action
+			}
+
+			// we'll use RecordViewErrGeneric for recording too
+			// because it can hold more info
+			recError = retError
+		} else if retError != nil {
+			if action != nil {
+				// copy action to returning and recording error
+				retError.action = action().action
+			}
+			// start with copy of return error for recording
+			// this will be updated with tha root cause as we try and
+			// unwrap the error
+			recError = retError
+
+			// find the original recError for this error
+			// for the purpose of logging
+			var unwrappedError error = retError
+			for {
+				if unwrappedError = errors.Unwrap(unwrappedError); unwrappedError == nil {
+					// nothing wrapped
+					break
+				}
+
+				// update recError ONLY of wrapped error is of type recordViewError
+				if unwrappedSinkError, ok := unwrappedError.(*recordViewError); ok {
+					recError = unwrappedSinkError
+				}
+			}
+
+			if retError.props == nil {
+				// set props on returning error if empty
+				retError.props = props
+			}
+
+			if recError.props == nil {
+				// set props on recording error if empty
+				recError.props = props
+			}
+		}
+	}
+
+	if svc.actionlog != nil {
+		if retError != nil {
+			// failed action, log error
+			svc.actionlog.Record(ctx, recError)
+		} else if action != nil {
+			// successful
+			svc.actionlog.Record(ctx, action(props))
+		}
+	}
+
+	if err == nil {
+		// retError not an interface and that WILL (!!) cause issues
+		// with nil check (== nil) when it is not explicitly returned
+		return nil
+	}
+
+	return retError
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/cortezaproject/corteza-server/pkg/corredor"
 	"github.com/cortezaproject/corteza-server/pkg/eventbus"
 	"github.com/cortezaproject/corteza-server/pkg/permissions"
+	"github.com/cortezaproject/corteza-server/pkg/scan"
 	"github.com/cortezaproject/corteza-server/pkg/settings"
 	"github.com/cortezaproject/corteza-server/pkg/store"
 	"github.com/cortezaproject/corteza-server/pkg/store/minio"
@@ -33,6 +34,9 @@ type (
 		ActionLog        options.ActionLogOpt
 		Storage          options.StorageOpt
 		GRPCClientSystem options.GRPCServerOpt
+		ReportCache      options.ReportCacheOpt
+		UploadScan       options.UploadScanOpt
+		Attachment       options.AttachmentOpt
 	}
 
 	eventDispatcher interface {
@@ -44,6 +48,15 @@ type (
 var (
 	DefaultStore store.Store
 
+	// DefaultUploadScanner inspects uploaded files before they are stored
+	DefaultUploadScanner scan.Scanner
+
+	// DefaultAttachmentQuota limits number of bytes stored by attachments
+	DefaultAttachmentQuota *AttachmentQuota
+
+	// DefaultAttachmentRetention removes orphaned attachments
+	DefaultAttachmentRetention *attachmentRetention
+
 	DefaultLogger *zap.Logger
 
 	DefaultActionlog actionlog.Recorder
@@ -64,10 +77,14 @@ var (
 	DefaultRecord        RecordService
 	DefaultModule        ModuleService
 	DefaultChart         ChartService
+	DefaultRecordView    RecordViewService
 	DefaultPage          PageService
 	DefaultAttachment    AttachmentService
 	DefaultNotification  *notification
 
+	// DefaultRecordReportCache keeps results of record reports (nil when disabled)
+	DefaultRecordReportCache *recordReportCache
+
 	// DefaultSystemUser is a bridge to users in a system service
 	// @todo this is ad-hoc solution that connects compose to system it breaks microservice
 	//       architecture and service separation and should be refactored properly
@@ -85,7 +102,9 @@ func Initialize(ctx context.Context, log *zap.Logger, c Config) (err error) {
 
 	DefaultLogger = log.Named("service")
 
-	{
+	if DefaultActionlog == nil {
+		// Do not override action log service stored under DefaultActionlog
+		// to allow integration tests to inject own recorder
 		tee := zap.NewNop()
 		policy := actionlog.MakeProductionPolicy()
 
@@ -138,6 +157,7 @@ func Initialize(ctx context.Context, log *zap.Logger, c Config) (err error) {
 				SecretAccessKey: c.Storage.MinioSecretKey,
 
 				ServerSideEncryptKey: []byte(c.Storage.MinioSSECKey),
+				PresignTTL:           c.Storage.MinioPresignTTL,
 			})
 
 			log.Info("initializing minio",
@@ -160,6 +180,19 @@ func Initialize(ctx context.Context, log *zap.Logger, c Config) (err error) {
 		}
 	}
 
+	if DefaultUploadScanner == nil {
+		// Do not override scanner stored under DefaultUploadScanner
+		// to allow integration tests to inject own scanner
+		DefaultUploadScanner = scan.New(c.UploadScan)
+	}
+
+	DefaultAttachmentQuota = &AttachmentQuota{
+		Namespace: int64(c.Attachment.NamespaceQuota),
+		User:      int64(c.Attachment.ComposeUserQuota),
+	}
+
+	DefaultAttachmentRetention = AttachmentRetention(DefaultStore, c.Attachment)
+
 	DefaultNamespace = Namespace()
 	DefaultModule = Module()
 
@@ -174,10 +207,16 @@ func Initialize(ctx context.Context, log *zap.Logger, c Config) (err error) {
 	//}
 	DefaultSystemUser = systemService.DefaultUser
 
-	DefaultImportSession = ImportSession()
+	if c.ReportCache.Enabled {
+		DefaultRecordReportCache = RecordReportCache(c.ReportCache.TTL, DefaultPermissions)
+		DefaultRecordReportCache.register(eventbus.Service())
+	}
+
+	DefaultImportSession = ImportSession(DefaultStore)
 	DefaultRecord = Record()
 	DefaultPage = Page()
 	DefaultChart = Chart()
+	DefaultRecordView = RecordView()
 	DefaultNotification = Notification()
 	DefaultAttachment = Attachment(DefaultStore)
 
@@ -199,6 +238,12 @@ func Activate(ctx context.Context) (err error) {
 func Watchers(ctx context.Context) {
 	// Reloading permissions on change
 	DefaultPermissions.Watch(ctx)
+
+	// Resuming interrupted imports, removing expired import sessions
+	DefaultImportSession.Watch(ctx)
+
+	// Removing orphaned attachments
+	DefaultAttachmentRetention.Watch(ctx)
 }
 
 func RegisterIteratorProviders() {
@@ -229,6 +274,15 @@ func RegisterIteratorProviders() {
 				rf.ModuleID = m.ID
 			}
 
+			if vLookup, has := f["view"]; has {
+				// Records can be iterated through saved record view (by ID or handle)
+				if v, err := DefaultRecordView.With(ctx).FindByAny(rf.NamespaceID, vLookup); err != nil {
+					return err
+				} else {
+					rf.ViewID = v.ID
+				}
+			}
+
 			return DefaultRecord.With(ctx).Iterator(rf, h, action)
 		},
 	)
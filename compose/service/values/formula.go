@@ -0,0 +1,552 @@
+package values
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/ql"
+)
+
+type (
+	formula struct{}
+
+	// evaluates parsed formula expression against a set of record values
+	formulaEvaluator struct {
+		module *types.Module
+		values types.RecordValueSet
+	}
+)
+
+var (
+	// operators, grouped and ordered by their precedence
+	formulaOperatorPrecedence = [][]string{
+		{"*", "/"},
+		{"+", "-"},
+		{"=", "!=", "<>", "<", ">", "<=", ">=", "LIKE", "NOT LIKE", "IS", "IS NOT"},
+		{"AND"},
+		{"OR", "XOR"},
+	}
+)
+
+// Formula initializes formula field processor
+//
+// Not really needed, following pattern in the package
+func Formula() *formula {
+	return &formula{}
+}
+
+// ParseFormula parses formula expression of a module field
+//
+// Expression can only reference existing, single-value fields of the same module
+// that are not computed themselves and use one of the supported functions
+func ParseFormula(m *types.Module, expr string) (ql.ASTNode, error) {
+	return parseExpression(m, expr, func(f *types.ModuleField) error {
+		if f.IsComputed() {
+			return errors.Errorf("can not reference computed field %q", f.Name)
+		}
+
+		return nil
+	})
+}
+
+// ParseValidatorExpression parses expression of a module validator
+//
+// Same rules as for formulas apply with the exception of formula fields;
+// they are computed before validation and can be referenced
+func ParseValidatorExpression(m *types.Module, expr string) (ql.ASTNode, error) {
+	return parseExpression(m, expr, func(f *types.ModuleField) error {
+		if f.IsRollup() {
+			return errors.Errorf("can not reference rollup field %q", f.Name)
+		}
+
+		return nil
+	})
+}
+
+func parseExpression(m *types.Module, expr string, check func(*types.ModuleField) error) (ql.ASTNode, error) {
+	if strings.TrimSpace(expr) == "" {
+		return nil, errors.New("empty expression")
+	}
+
+	p := ql.NewParser()
+
+	p.OnIdent = func(i ql.Ident) (ql.Ident, error) {
+		f := m.Fields.FindByName(i.Value)
+		switch {
+		case f == nil:
+			return i, errors.Errorf("unknown field %q", i.Value)
+		case f.Multi:
+			return i, errors.Errorf("can not reference multi-value field %q", i.Value)
+		}
+
+		return i, check(f)
+	}
+
+	p.OnFunction = func(f ql.Function) (ql.Function, error) {
+		switch strings.ToUpper(f.Name) {
+		case "CONCAT", "COALESCE", "IF", "ROUND", "ABS", "FLOOR", "CEIL", "UPPER", "LOWER", "TRIM", "LENGTH":
+			return f, nil
+		default:
+			return f, errors.Errorf("unsupported function %q", f.Name)
+		}
+	}
+
+	n, err := p.ParseExpression(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Evaluate expression against an empty set of values
+	// to detect structural errors that parser does not
+	if _, err = (formulaEvaluator{module: m}).eval(n); err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
+
+// Run computes values of all formula fields
+//
+// Values for formula fields that are part of the input are discarded
+// and replaced with the computed ones. Expressions that can not be parsed or
+// evaluated, or expressions that result in NULL leave the field empty
+func (formula) Run(m *types.Module, vv types.RecordValueSet) (out types.RecordValueSet) {
+	out = make([]*types.RecordValue, 0, len(vv))
+
+	for _, v := range vv {
+		if f := m.Fields.FindByName(v.Name); f != nil && f.IsFormula() {
+			continue
+		}
+
+		out = append(out, v)
+	}
+
+	var (
+		e = formulaEvaluator{module: m, values: out.GetClean()}
+
+		expr   ql.ASTNode
+		result interface{}
+		err    error
+	)
+
+	for _, f := range m.Fields {
+		if !f.IsFormula() {
+			continue
+		}
+
+		if expr, err = ParseFormula(m, f.Options.Expression()); err != nil {
+			continue
+		}
+
+		if result, err = e.eval(expr); err != nil || result == nil {
+			continue
+		}
+
+		out = append(out, &types.RecordValue{Name: f.Name, Value: formatFormulaResult(f, result), Updated: true})
+	}
+
+	return
+}
+
+func (e formulaEvaluator) eval(n ql.ASTNode) (interface{}, error) {
+	switch n := n.(type) {
+	case ql.LNull:
+		return nil, nil
+	case ql.LBoolean:
+		return n.Value, nil
+	case ql.LNumber:
+		return strconv.ParseFloat(n.Value, 64)
+	case ql.LString:
+		return n.Value, nil
+	case ql.Ident:
+		return e.ident(n)
+	case ql.Function:
+		return e.function(n)
+	case ql.ASTNodes:
+		return e.expression(n)
+	case ql.ASTSet:
+		if len(n) == 1 {
+			return e.eval(n[0])
+		}
+	}
+
+	return nil, errors.Errorf("unsupported expression %q", n)
+}
+
+// Resolves field value
+//
+// Only the first value is used, missing values are treated as NULL
+func (e formulaEvaluator) ident(i ql.Ident) (interface{}, error) {
+	var (
+		f  = e.module.Fields.FindByName(i.Value)
+		vv = e.values.FilterByName(i.Value)
+	)
+
+	if f == nil {
+		return nil, errors.Errorf("unknown field %q", i.Value)
+	}
+
+	if len(vv) == 0 {
+		return nil, nil
+	}
+
+	switch {
+	case f.IsBoolean():
+		return vv[0].Value == strBoolTrue, nil
+	case f.IsNumeric():
+		if n, err := strconv.ParseFloat(vv[0].Value, 64); err == nil {
+			return n, nil
+		}
+
+		return nil, nil
+	}
+
+	return vv[0].Value, nil
+}
+
+func (e formulaEvaluator) function(f ql.Function) (interface{}, error) {
+	var (
+		name = strings.ToUpper(f.Name)
+		args = make([]interface{}, len(f.Arguments))
+		err  error
+	)
+
+	for i := range f.Arguments {
+		if args[i], err = e.eval(f.Arguments[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	switch name {
+	case "CONCAT":
+		var out string
+		for _, a := range args {
+			if a != nil {
+				out += formulaString(a)
+			}
+		}
+		return out, nil
+
+	case "COALESCE":
+		for _, a := range args {
+			if a != nil {
+				return a, nil
+			}
+		}
+		return nil, nil
+
+	case "IF":
+		if len(args) != 3 {
+			return nil, errors.Errorf("function %s expects 3 arguments", name)
+		}
+
+		if formulaBool(args[0]) {
+			return args[1], nil
+		}
+
+		return args[2], nil
+	}
+
+	if len(args) == 0 || len(args) > 2 || (len(args) == 2 && name != "ROUND") {
+		return nil, errors.Errorf("invalid number of arguments for function %s", name)
+	}
+
+	if args[0] == nil {
+		return nil, nil
+	}
+
+	switch name {
+	case "UPPER":
+		return strings.ToUpper(formulaString(args[0])), nil
+	case "LOWER":
+		return strings.ToLower(formulaString(args[0])), nil
+	case "TRIM":
+		return strings.TrimSpace(formulaString(args[0])), nil
+	case "LENGTH":
+		return float64(len([]rune(formulaString(args[0])))), nil
+	}
+
+	n, ok := formulaNumber(args[0])
+	if !ok {
+		return nil, nil
+	}
+
+	switch name {
+	case "ABS":
+		return math.Abs(n), nil
+	case "FLOOR":
+		return math.Floor(n), nil
+	case "CEIL":
+		return math.Ceil(n), nil
+	case "ROUND":
+		var p float64
+		if len(args) == 2 {
+			if p, ok = formulaNumber(args[1]); !ok {
+				return nil, nil
+			}
+		}
+
+		pow := math.Pow(10, math.Trunc(p))
+		return math.Round(n*pow) / pow, nil
+	}
+
+	return nil, errors.Errorf("unsupported function %q", f.Name)
+}
+
+// Evaluates stream of operands and operators
+//
+// Operators with higher precedence are reduced first, left to right
+func (e formulaEvaluator) expression(nn ql.ASTNodes) (interface{}, error) {
+	var (
+		operands  = make([]interface{}, 0, len(nn)/2+1)
+		operators = make([]string, 0, len(nn)/2)
+	)
+
+	for i, n := range nn {
+		if op, ok := n.(ql.Operator); ok {
+			if i%2 == 0 {
+				return nil, errors.Errorf("unexpected operator %q", op.Kind)
+			}
+
+			operators = append(operators, strings.ToUpper(op.Kind))
+			continue
+		}
+
+		if i%2 == 1 {
+			return nil, errors.Errorf("expecting operator, got %q", n)
+		}
+
+		if v, err := e.eval(n); err != nil {
+			return nil, err
+		} else {
+			operands = append(operands, v)
+		}
+	}
+
+	if len(operands) != len(operators)+1 {
+		return nil, errors.New("malformed expression")
+	}
+
+	for _, group := range formulaOperatorPrecedence {
+		for i := 0; i < len(operators); {
+			if !inStrings(operators[i], group) {
+				i++
+				continue
+			}
+
+			r, err := formulaOperation(operators[i], operands[i], operands[i+1])
+			if err != nil {
+				return nil, err
+			}
+
+			operands = append(operands[:i], append([]interface{}{r}, operands[i+2:]...)...)
+			operators = append(operators[:i], operators[i+1:]...)
+		}
+	}
+
+	if len(operators) > 0 {
+		return nil, errors.Errorf("unsupported operator %q", operators[0])
+	}
+
+	return operands[0], nil
+}
+
+func formulaOperation(op string, l, r interface{}) (interface{}, error) {
+	switch op {
+	case "IS":
+		return l == r, nil
+	case "IS NOT":
+		return l != r, nil
+	case "AND":
+		return formulaBool(l) && formulaBool(r), nil
+	case "OR":
+		return formulaBool(l) || formulaBool(r), nil
+	case "XOR":
+		return formulaBool(l) != formulaBool(r), nil
+	}
+
+	if l == nil || r == nil {
+		// Same as in SQL, any operation with NULL results in NULL
+		return nil, nil
+	}
+
+	switch op {
+	case "LIKE", "NOT LIKE":
+		var (
+			pattern = strings.ToLower(formulaString(r))
+			value   = strings.ToLower(formulaString(l))
+			match   = likeMatch(value, pattern)
+		)
+
+		return match == (op == "LIKE"), nil
+	}
+
+	ln, lok := formulaNumber(l)
+	rn, rok := formulaNumber(r)
+
+	switch op {
+	case "*", "/", "+", "-":
+		if !lok || !rok {
+			return nil, errors.Errorf("can not use operator %q on non-numeric values", op)
+		}
+
+		switch op {
+		case "*":
+			return ln * rn, nil
+		case "/":
+			if rn == 0 {
+				return nil, nil
+			}
+			return ln / rn, nil
+		case "+":
+			return ln + rn, nil
+		default:
+			return ln - rn, nil
+		}
+	}
+
+	var cmp int
+	if lok && rok {
+		switch {
+		case ln < rn:
+			cmp = -1
+		case ln > rn:
+			cmp = 1
+		}
+	} else {
+		cmp = strings.Compare(formulaString(l), formulaString(r))
+	}
+
+	switch op {
+	case "=":
+		return cmp == 0, nil
+	case "!=", "<>":
+		return cmp != 0, nil
+	case "<":
+		return cmp < 0, nil
+	case ">":
+		return cmp > 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case ">=":
+		return cmp >= 0, nil
+	}
+
+	return nil, errors.Errorf("unsupported operator %q", op)
+}
+
+// Simplified LIKE; supports % wildcard only
+func likeMatch(value, pattern string) bool {
+	var parts = strings.Split(pattern, "%")
+
+	if len(parts) == 1 {
+		return value == pattern
+	}
+
+	if !strings.HasPrefix(value, parts[0]) {
+		return false
+	}
+
+	value = value[len(parts[0]):]
+	for _, p := range parts[1 : len(parts)-1] {
+		i := strings.Index(value, p)
+		if i < 0 {
+			return false
+		}
+
+		value = value[i+len(p):]
+	}
+
+	return strings.HasSuffix(value, parts[len(parts)-1])
+}
+
+func formulaNumber(v interface{}) (float64, bool) {
+	switch c := v.(type) {
+	case float64:
+		return c, true
+	case bool:
+		if c {
+			return 1, true
+		}
+		return 0, true
+	case string:
+		n, err := strconv.ParseFloat(strings.TrimSpace(c), 64)
+		return n, err == nil
+	}
+
+	return 0, false
+}
+
+func formulaBool(v interface{}) bool {
+	switch c := v.(type) {
+	case bool:
+		return c
+	case float64:
+		return c != 0
+	case string:
+		return truthy.MatchString(strings.ToLower(c))
+	}
+
+	return false
+}
+
+func formulaString(v interface{}) string {
+	switch c := v.(type) {
+	case string:
+		return c
+	case float64:
+		return formulaFormatNumber(c, types.ModuleFieldPrecisionMax)
+	case bool:
+		if c {
+			return strBoolTrue
+		}
+		return strBoolFalse
+	}
+
+	return fmt.Sprintf("%v", v)
+}
+
+// Formats formula result
+//
+// Numbers are rounded to precision from field options or to the max.
+// precision when not set, so that float errors (0.1 * 3) are not stored
+func formatFormulaResult(f *types.ModuleField, result interface{}) string {
+	n, ok := result.(float64)
+	if !ok {
+		return formulaString(result)
+	}
+
+	return formulaFormatNumber(n, f.Options.Precision(types.ModuleFieldPrecisionMax))
+}
+
+// Formats number with given number of decimal places, trailing zeros are removed
+func formulaFormatNumber(n float64, precision uint) string {
+	var v = strconv.FormatFloat(n, 'f', int(precision), 64)
+
+	if strings.Contains(v, ".") {
+		v = strings.TrimRight(v, "0")
+		v = strings.TrimRight(v, ".")
+	}
+
+	if v == "-0" {
+		// Tiny negative numbers rounded to zero
+		return "0"
+	}
+
+	return v
+}
+
+func inStrings(s string, ss []string) bool {
+	for i := range ss {
+		if ss[i] == s {
+			return true
+		}
+	}
+
+	return false
+}
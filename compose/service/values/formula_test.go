@@ -0,0 +1,123 @@
+package values
+
+import (
+	"testing"
+
+	"github.com/cortezaproject/corteza-server/compose/types"
+)
+
+func Test_formula_Run(t *testing.T) {
+	var (
+		module = &types.Module{
+			Fields: types.ModuleFieldSet{
+				&types.ModuleField{Name: "price", Kind: "Number"},
+				&types.ModuleField{Name: "quantity", Kind: "Number"},
+				&types.ModuleField{Name: "firstName", Kind: "String"},
+				&types.ModuleField{Name: "lastName", Kind: "String"},
+				&types.ModuleField{Name: "active", Kind: "Bool"},
+			},
+		}
+
+		values = types.RecordValueSet{
+			{Name: "price", Value: "2.5"},
+			{Name: "quantity", Value: "4"},
+			{Name: "firstName", Value: "John"},
+			{Name: "lastName", Value: "Doe"},
+			{Name: "active", Value: "1"},
+		}
+	)
+
+	tests := []struct {
+		name    string
+		expr    string
+		options map[string]interface{}
+		output  string
+		empty   bool
+	}{
+		{name: "multiplication", expr: "price * quantity", output: "10"},
+		{name: "operator precedence", expr: "1 + price * quantity - 1", output: "10"},
+		{name: "parenthesis", expr: "(1 + price) * quantity", output: "14"},
+		{name: "division by zero", expr: "price / 0", empty: true},
+		{name: "concatenation", expr: "CONCAT(firstName, ' ', lastName)", output: "John Doe"},
+		{name: "nested functions", expr: "UPPER(CONCAT(firstName, lastName))", output: "JOHNDOE"},
+		{name: "comparison", expr: "price * quantity > 5", output: "1"},
+		{name: "logical operators", expr: "active AND quantity < 2", output: "0"},
+		{name: "conditional", expr: "IF(active, 'yes', 'no')", output: "yes"},
+		{name: "null checks", expr: "missing IS NULL", output: "1"},
+		{name: "nulls in arithmetics", expr: "price + missing", empty: true},
+		{name: "coalesce", expr: "COALESCE(missing, 42)", output: "42"},
+		{name: "rounding", expr: "ROUND(price / 3, 2)", output: "0.83"},
+		{name: "precision", expr: "price / 3", options: map[string]interface{}{"precision": 1}, output: "0.8"},
+		{name: "default precision", expr: "0.1 * 3", output: "0.3"},
+		{name: "default precision limit", expr: "price / 3", output: "0.833333"},
+		{name: "negative zero", expr: "0 - 0.0000001", output: "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				m = &types.Module{Fields: append(module.Fields,
+					&types.ModuleField{Name: "missing", Kind: "Number"},
+					&types.ModuleField{Name: "formula", Kind: "Formula", Options: types.ModuleFieldOptions{"expression": tt.expr}},
+				)}
+			)
+
+			for k, v := range tt.options {
+				m.Fields.FindByName("formula").Options[k] = v
+			}
+
+			out := Formula().Run(m, append(values, &types.RecordValue{Name: "formula", Value: "overwritten"}))
+			vv := out.FilterByName("formula")
+
+			if tt.empty {
+				if len(vv) > 0 {
+					t.Errorf("expecting formula value to be empty, got %q", vv[0].Value)
+				}
+				return
+			}
+
+			if len(vv) != 1 {
+				t.Fatalf("expecting exactly one formula value, got %d", len(vv))
+			}
+
+			if vv[0].Value != tt.output {
+				t.Errorf("expecting formula value to be %q, got %q", tt.output, vv[0].Value)
+			}
+		})
+	}
+}
+
+func Test_ParseFormula(t *testing.T) {
+	var (
+		module = &types.Module{
+			Fields: types.ModuleFieldSet{
+				&types.ModuleField{Name: "price", Kind: "Number"},
+				&types.ModuleField{Name: "tags", Kind: "String", Multi: true},
+				&types.ModuleField{Name: "total", Kind: "Formula", Options: types.ModuleFieldOptions{"expression": "price"}},
+			},
+		}
+	)
+
+	tests := []struct {
+		name  string
+		expr  string
+		valid bool
+	}{
+		{name: "valid", expr: "ROUND(price * 1.2, 2)", valid: true},
+		{name: "empty", expr: " "},
+		{name: "unknown field", expr: "price * quantity"},
+		{name: "multi-value field", expr: "CONCAT(tags)"},
+		{name: "formula field", expr: "total * 2"},
+		{name: "unsupported function", expr: "NOW()"},
+		{name: "malformed", expr: "price *"},
+		{name: "invalid no. of arguments", expr: "IF(price, 1)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseFormula(module, tt.expr); (err == nil) != tt.valid {
+				t.Errorf("unexpected result for %q: %v", tt.expr, err)
+			}
+		})
+	}
+}
@@ -202,16 +202,7 @@ func (sanitizer) sNumber(v *types.RecordValue, f *types.ModuleField, m *types.Mo
 	}
 
 	// calculate percision
-	var p = 0
-	if f.Options != nil {
-		p = int(f.Options.Int64(fieldOpt_Number_precision))
-
-		if p < fieldOpt_Number_precision_min {
-			p = fieldOpt_Number_precision_min
-		} else if p > fieldOpt_Number_precision_max {
-			p = fieldOpt_Number_precision_max
-		}
-	}
+	var p = int(f.Options.Precision(0))
 
 	// Format the value to the desired precision
 	v.Value = strconv.FormatFloat(base, 'f', p, 64)
@@ -56,6 +56,14 @@ func makeDuplicateValueErr(field *types.ModuleField, recordID uint64) types.Reco
 	return types.RecordValueError{Kind: "duplicateValue", Meta: map[string]interface{}{"field": field.Name, "recordID": recordID}}
 }
 
+func makeRuleViolationErr(mv *types.ModuleValidator) types.RecordValueError {
+	return types.RecordValueError{Kind: "ruleViolation", Message: mv.Message, Meta: map[string]interface{}{"field": mv.Field, "expression": mv.Expression}}
+}
+
+func makeInvalidRuleErr(mv *types.ModuleValidator, err error) types.RecordValueError {
+	return types.RecordValueError{Kind: "internal", Message: err.Error(), Meta: map[string]interface{}{"field": mv.Field, "expression": mv.Expression}}
+}
+
 // Simple wrapper for easier error returning from validation functions
 func e2s(ee ...types.RecordValueError) []types.RecordValueError {
 	return ee
@@ -90,6 +98,7 @@ func (vldtr *validator) FileRefChecker(fn ReferenceChecker) {
 //   - check if required values are present
 //   - check for unique-multi-value in multi value fields
 //   - field-kind specific validation on all values
+//   - module validation rules
 //   - unique check on all all values
 func (vldtr validator) Run(m *types.Module, r *types.Record) (out *types.RecordValueErrorSet) {
 	var (
@@ -169,6 +178,8 @@ fields:
 		}
 	}
 
+	out.Push(vldtr.vRules(m, r)...)
+
 	// This is the most resource-heavy operation
 	// we'll do in at the end
 	for _, v := range r.Values {
@@ -196,6 +207,36 @@ fields:
 	return out
 }
 
+// vRules evaluates module validation rules against record values
+//
+// Rule is violated when expression evaluates to false; expressions that
+// can not be evaluated (null values) are not considered as violations
+func (vldtr validator) vRules(m *types.Module, r *types.Record) (ee []types.RecordValueError) {
+	var (
+		e = formulaEvaluator{module: m, values: r.Values.GetClean()}
+	)
+
+	for _, mv := range m.Validators {
+		n, err := ParseValidatorExpression(m, mv.Expression)
+		if err != nil {
+			ee = append(ee, makeInvalidRuleErr(mv, err))
+			continue
+		}
+
+		result, err := e.eval(n)
+		if err != nil {
+			ee = append(ee, makeInvalidRuleErr(mv, err))
+			continue
+		}
+
+		if result != nil && !formulaBool(result) {
+			ee = append(ee, makeRuleViolationErr(mv))
+		}
+	}
+
+	return
+}
+
 func (vldtr validator) vBool(v *types.RecordValue, f *types.ModuleField, r *types.Record, m *types.Module) []types.RecordValueError {
 	if v.Value == "" {
 		return nil
@@ -218,3 +218,55 @@ func Test_validator_vSelect(t *testing.T) {
 		})
 	}
 }
+
+func Test_validator_vRules(t *testing.T) {
+	var (
+		vldtr  = validator{}
+		module = &types.Module{
+			Fields: types.ModuleFieldSet{
+				&types.ModuleField{Name: "start", Kind: "Number"},
+				&types.ModuleField{Name: "end", Kind: "Number"},
+			},
+		}
+
+		rule = &types.ModuleValidator{Expression: "end >= start", Message: "end before start", Field: "end"}
+
+		tests = []struct {
+			name   string
+			values types.RecordValueSet
+			rule   *types.ModuleValidator
+			want   []types.RecordValueError
+		}{
+			{
+				name:   "valid",
+				values: types.RecordValueSet{{Name: "start", Value: "1"}, {Name: "end", Value: "2"}},
+				rule:   rule,
+			},
+			{
+				name:   "null values",
+				values: types.RecordValueSet{{Name: "start", Value: "1"}},
+				rule:   rule,
+			},
+			{
+				name:   "violation",
+				values: types.RecordValueSet{{Name: "start", Value: "2"}, {Name: "end", Value: "1"}},
+				rule:   rule,
+				want:   e2s(types.RecordValueError{Kind: "ruleViolation", Message: "end before start", Meta: map[string]interface{}{"field": "end", "expression": "end >= start"}}),
+			},
+			{
+				name: "invalid expression",
+				rule: &types.ModuleValidator{Expression: "unknown > 1"},
+				want: e2s(types.RecordValueError{Kind: "internal", Message: `unknown field "unknown"`, Meta: map[string]interface{}{"field": "", "expression": "unknown > 1"}}),
+			},
+		}
+	)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &types.Module{Fields: module.Fields, Validators: types.ModuleValidatorSet{tt.rule}}
+			if got := vldtr.vRules(m, &types.Record{Values: tt.values}); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("vRules() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
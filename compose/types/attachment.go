@@ -7,6 +7,7 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/cortezaproject/corteza-server/pkg/permissions"
 	"github.com/cortezaproject/corteza-server/pkg/rh"
 )
 
@@ -20,8 +21,14 @@ type (
 		Name       string         `db:"name"        json:"name,omitempty"`
 		Meta       attachmentMeta `db:"meta"        json:"meta"`
 
+		// Size of the original file (for storage accounting)
+		Size int64 `db:"size" json:"-"`
+
 		NamespaceID uint64 `db:"rel_namespace" json:"namespaceID,string"`
 
+		// Page the attachment was uploaded to (page attachments only)
+		PageID uint64 `db:"rel_page" json:"pageID,string,omitempty"`
+
 		CreatedAt time.Time  `db:"created_at" json:"createdAt,omitempty"`
 		UpdatedAt *time.Time `db:"updated_at" json:"updatedAt,omitempty"`
 		DeletedAt *time.Time `db:"deleted_at" json:"deletedAt,omitempty"`
@@ -37,8 +44,17 @@ type (
 		FieldName   string `json:"fieldName,omitempty"`
 		Filter      string `json:"filter"`
 
+		// Free-text query, matched against the indexed content
+		Query string `json:"query,omitempty"`
+
 		Sort string `json:"sort"`
 
+		// Namespace, page, record & record value read permission check filters
+		IsReadable            *permissions.ResourceFilter `json:"-"`
+		IsPageReadable        *permissions.ResourceFilter `json:"-"`
+		IsRecordReadable      *permissions.ResourceFilter `json:"-"`
+		IsRecordValueReadable *permissions.ResourceFilter `json:"-"`
+
 		// Standard paging fields & helpers
 		rh.PageFilter
 	}
@@ -33,6 +33,7 @@ type (
 	ChartConfigReport struct {
 		Filter     string                   `json:"filter"                    yaml:",omitempty"`
 		ModuleID   uint64                   `json:"moduleID,string,omitempty" yaml:"moduleID,omitempty"`
+		Joins      []*ChartConfigReportJoin `json:"joins,omitempty"           yaml:",omitempty"`
 		Metrics    []map[string]interface{} `json:"metrics,omitempty"         yaml:",omitempty"`
 		Dimensions []map[string]interface{} `json:"dimensions,omitempty"      yaml:",omitempty"`
 		YAxis      map[string]interface{}   `json:"yAxis,omitempty"           yaml:",omitempty"`
@@ -41,6 +42,25 @@ type (
 		} `json:"renderer,omitempty" yaml:",omitempty"`
 	}
 
+	// ChartConfigReportJoin joins records of another module into the report
+	//
+	// Modules are joined through a record field, either a field of the report's
+	// module that references the joined module or a field of the joined module
+	// that references the report's module.
+	//
+	// Fields of the joined module are prefixed with the alias (alias.field)
+	// in report metrics, dimensions and filter
+	//
+	// When joined through the field of the joined module, each record of the
+	// report's module is repeated for each of the joined records. Report's count
+	// still counts every record once, but only COUNTD, MIN and MAX can be used
+	// on fields of the report's module; other aggregates are rejected
+	ChartConfigReportJoin struct {
+		Alias    string `json:"alias"                     yaml:",omitempty"`
+		ModuleID uint64 `json:"moduleID,string,omitempty" yaml:"moduleID,omitempty"`
+		Field    string `json:"field"                     yaml:",omitempty"`
+	}
+
 	ChartFilter struct {
 		NamespaceID uint64 `json:"namespaceID,string"`
 		Handle      string `json:"handle"`
@@ -17,6 +17,8 @@ type (
 		Meta   types.JSONText `json:"meta" db:"json"`
 		Fields ModuleFieldSet `json:"fields" db:"-"`
 
+		Validators ModuleValidatorSet `json:"validators" db:"validators"`
+
 		NamespaceID uint64 `json:"namespaceID,string" db:"rel_namespace"`
 
 		CreatedAt time.Time  `db:"created_at" json:"createdAt,omitempty"`
@@ -118,6 +118,21 @@ func (f ModuleField) IsDateTime() bool {
 	return f.Kind == "DateTime"
 }
 
+// IsFormula tells us if value of this field is computed from other fields
+func (f ModuleField) IsFormula() bool {
+	return f.Kind == "Formula"
+}
+
+// IsRollup tells us if value of this field is aggregated from records referencing it
+func (f ModuleField) IsRollup() bool {
+	return f.Kind == "Rollup"
+}
+
+// IsComputed tells us if value of this field is computed and can not be set directly
+func (f ModuleField) IsComputed() bool {
+	return f.IsFormula() || f.IsRollup()
+}
+
 // IsRef tells us if value of this field be a reference to something
 // (another record, file , user)?
 func (f ModuleField) IsRef() bool {
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"github.com/pkg/errors"
 	"strconv"
+	"strings"
 )
 
 type (
@@ -15,6 +16,26 @@ type (
 const (
 	moduleFieldOptionIsUnique           = "isUnique"
 	moduleFieldOptionIsUniqueMultiValue = "isUniqueMultiValue"
+	moduleFieldOptionExpression         = "expression"
+	moduleFieldOptionResultKind         = "resultKind"
+	moduleFieldOptionModuleID           = "moduleID"
+	moduleFieldOptionRefField           = "refField"
+	moduleFieldOptionValueField         = "valueField"
+	moduleFieldOptionAggregate          = "aggregate"
+	moduleFieldOptionOnDelete           = "onDelete"
+	moduleFieldOptionPrecision          = "precision"
+)
+
+const (
+	// Maximal number of decimal places of numeric values
+	ModuleFieldPrecisionMax = 6
+)
+
+const (
+	// Referential rules for record fields; applied when referenced record is deleted
+	ModuleFieldOnDeleteRestrict = "restrict"
+	ModuleFieldOnDeleteCascade  = "cascade"
+	ModuleFieldOnDeleteSetNull  = "setNull"
 )
 
 func (opt *ModuleFieldOptions) Scan(value interface{}) error {
@@ -78,6 +99,32 @@ func (opt ModuleFieldOptions) Int64Def(key string, def int64) int64 {
 	return def
 }
 
+// UInt64 returns option value for key as unsigned integer
+//
+// IDs are usually stored as strings; invalid, non-existing are returned as 0
+func (opt ModuleFieldOptions) UInt64(key string) uint64 {
+	if val, has := opt[key]; has && val != nil {
+		if id, err := strconv.ParseUint(fmt.Sprintf("%v", val), 10, 64); err == nil {
+			return id
+		}
+	}
+
+	return 0
+}
+
+// String returns option value for key as string
+//
+// Invalid, non-existing are returned as empty string
+func (opt ModuleFieldOptions) String(key string) string {
+	if _, has := opt[key]; has {
+		if v, ok := opt[key].(string); ok {
+			return v
+		}
+	}
+
+	return ""
+}
+
 // Strings returns option value for key as slice of strings
 //
 // Invalid, non-existing are returned as nil
@@ -110,3 +157,74 @@ func (opt ModuleFieldOptions) SetIsUniqueMultiValue(value bool) {
 	// SetIsUniqueMultiValue - should value in this field be unique in the multi-value set?
 	opt[moduleFieldOptionIsUniqueMultiValue] = value
 }
+
+// Expression - formula expression used to compute value of the field
+func (opt ModuleFieldOptions) Expression() string {
+	return opt.String(moduleFieldOptionExpression)
+}
+
+// ResultKind - kind of the value computed by formula expression
+//
+// Defaults to String
+func (opt ModuleFieldOptions) ResultKind() string {
+	if k := opt.String(moduleFieldOptionResultKind); k != "" {
+		return k
+	}
+
+	return "String"
+}
+
+// ModuleID - module of the records that rollup field aggregates
+func (opt ModuleFieldOptions) ModuleID() uint64 {
+	return opt.UInt64(moduleFieldOptionModuleID)
+}
+
+// RefField - field on the aggregated records that references record with rollup field
+func (opt ModuleFieldOptions) RefField() string {
+	return opt.String(moduleFieldOptionRefField)
+}
+
+// ValueField - field on the aggregated records with values that rollup field aggregates
+func (opt ModuleFieldOptions) ValueField() string {
+	return opt.String(moduleFieldOptionValueField)
+}
+
+// Aggregate - rollup aggregation function (SUM, COUNT, MIN, MAX or AVG)
+//
+// Defaults to COUNT
+func (opt ModuleFieldOptions) Aggregate() string {
+	if a := opt.String(moduleFieldOptionAggregate); a != "" {
+		return strings.ToUpper(a)
+	}
+
+	return "COUNT"
+}
+
+// OnDelete - referential rule of a record field, applied when referenced record is deleted
+//
+// Empty (default) leaves references as they are.
+//
+// Records deleted (cascade) or changed (setNull) by the rule emit only afterDelete
+// and afterUpdate events; beforeDelete and beforeUpdate events are not emitted for them
+func (opt ModuleFieldOptions) OnDelete() string {
+	switch r := opt.String(moduleFieldOptionOnDelete); r {
+	case ModuleFieldOnDeleteRestrict, ModuleFieldOnDeleteCascade, ModuleFieldOnDeleteSetNull:
+		return r
+	}
+
+	return ""
+}
+
+// Precision - number of decimal places of numeric values, limited to 0-6
+//
+// Returns def when option is not set
+func (opt ModuleFieldOptions) Precision(def uint) uint {
+	switch p := opt.Int64Def(moduleFieldOptionPrecision, int64(def)); {
+	case p < 0:
+		return 0
+	case p > ModuleFieldPrecisionMax:
+		return ModuleFieldPrecisionMax
+	default:
+		return uint(p)
+	}
+}
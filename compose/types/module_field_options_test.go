@@ -31,3 +31,26 @@ func TestModuleFieldOptions_Int64Def(t *testing.T) {
 		})
 	}
 }
+
+func TestModuleFieldOptions_Precision(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  ModuleFieldOptions
+		want uint
+	}{
+		{"unexisting", ModuleFieldOptions{}, 6},
+		{"nil", ModuleFieldOptions{"precision": nil}, 6},
+		{"int", ModuleFieldOptions{"precision": 2}, 2},
+		{"float", ModuleFieldOptions{"precision": 2.0}, 2},
+		{"stringed", ModuleFieldOptions{"precision": "3"}, 3},
+		{"negative", ModuleFieldOptions{"precision": -1}, 0},
+		{"too-large", ModuleFieldOptions{"precision": 12}, 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.opt.Precision(ModuleFieldPrecisionMax); got != tt.want {
+				t.Errorf("Precision() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
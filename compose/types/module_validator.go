@@ -0,0 +1,43 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	// ModuleValidator is a module-level record validation rule
+	//
+	// Expression can reference fields of the module and must evaluate to true
+	// for a record to be valid
+	ModuleValidator struct {
+		Expression string `json:"expression"`
+		Message    string `json:"message"`
+
+		// Optional; field the validation error is reported on
+		Field string `json:"field,omitempty"`
+	}
+
+	ModuleValidatorSet []*ModuleValidator
+)
+
+func (set *ModuleValidatorSet) Scan(value interface{}) error {
+	//lint:ignore S1034 This typecast is intentional, we need to get []byte out of a []uint8
+	switch value.(type) {
+	case nil:
+		*set = ModuleValidatorSet{}
+	case []uint8:
+		b := value.([]byte)
+		if err := json.Unmarshal(b, set); err != nil {
+			return errors.Wrapf(err, "Can not scan '%v' into ModuleValidatorSet", string(b))
+		}
+	}
+
+	return nil
+}
+
+func (set ModuleValidatorSet) Value() (driver.Value, error) {
+	return json.Marshal(set)
+}
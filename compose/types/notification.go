@@ -9,5 +9,15 @@ type (
 		ContentPlain      string
 		ContentHTML       string
 		RemoteAttachments []string
+		ChartAttachments  []*EmailChartAttachment
+	}
+
+	// EmailChartAttachment is rendered (see chart's render endpoint) and attached to the email
+	EmailChartAttachment struct {
+		NamespaceID uint64 `json:"namespaceID,string"`
+		ChartID     uint64 `json:"chartID,string"`
+		Format      string `json:"format"`
+		Width       int    `json:"width"`
+		Height      int    `json:"height"`
 	}
 )
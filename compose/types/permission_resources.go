@@ -10,3 +10,4 @@ const ChartPermissionResource = permissions.Resource("compose:chart:")
 const ModulePermissionResource = permissions.Resource("compose:module:")
 const ModuleFieldPermissionResource = permissions.Resource("compose:module-field:")
 const PagePermissionResource = permissions.Resource("compose:page:")
+const RecordViewPermissionResource = permissions.Resource("compose:record-view:")
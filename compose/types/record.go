@@ -54,9 +54,28 @@ type (
 		Query       string `json:"query"`
 		Sort        string `json:"sort"`
 
+		// ViewID (when set) applies filter and sort of the saved record view;
+		// query is combined with the view's filter, sort overrides the view's sort
+		ViewID uint64 `json:"viewID,string,omitempty"`
+
+		// ViewHandle references the record view by handle when ViewID is not set
+		ViewHandle string `json:"viewHandle,omitempty"`
+
+		// Q is free-text search across record values
+		//
+		// Only values of the searchable fields (QFields, by module ID) are matched;
+		// set by the service to (readable) fields that the current user can search in
+		Q       string              `json:"q"`
+		QFields map[uint64][]string `json:"-"`
+
 		// Standard paging fields & helpers
 		rh.PageFilter
 
+		// PageCursor (when set) is used instead of offset or page.
+		// NextPage holds cursor for the next page (if there is one)
+		PageCursor string `json:"pageCursor,omitempty"`
+		NextPage   string `json:"nextPage,omitempty"`
+
 		Deleted rh.FilterState `json:"deleted"`
 	}
 )
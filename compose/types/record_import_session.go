@@ -0,0 +1,203 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	// RecordImportSession is a stored row in the `record_import_session` table
+	//
+	// Uploaded source is kept in the store for the lifetime of the session
+	// so that import can be (re)started from any node
+	RecordImportSession struct {
+		SessionID   uint64 `json:"sessionID,string"   db:"id"`
+		NamespaceID uint64 `json:"namespaceID,string" db:"rel_namespace"`
+		ModuleID    uint64 `json:"moduleID,string"    db:"rel_module"`
+		UserID      uint64 `json:"userID,string"      db:"rel_user"`
+
+		// Location of the uploaded source in the store
+		Source string `json:"-" db:"source"`
+
+		// Sheet and header row of the spreadsheet (xlsx, ods) source
+		Sheet     string `json:"sheet,omitempty" db:"sheet"`
+		HeaderRow uint   `json:"headerRow"       db:"header_row"`
+
+		Fields     RecordImportFields   `json:"fields"               db:"fields"`
+		OnError    string               `json:"onError"              db:"on_error"`
+		Mode       string               `json:"mode"                 db:"mode"`
+		MatchField string               `json:"matchField,omitempty" db:"match_field"`
+		DryRun     bool                 `json:"dryRun"               db:"dry_run"`
+		Progress   RecordImportProgress `json:"progress"             db:"progress"`
+		Report     RecordImportReport   `json:"-"                    db:"report"`
+
+		CreatedAt time.Time `json:"createdAt" db:"created_at"`
+		UpdatedAt time.Time `json:"updatedAt" db:"updated_at"`
+
+		// Set by the node running the import; sessions with
+		// outdated heartbeat are resumed by other nodes
+		HeartbeatAt *time.Time `json:"-" db:"heartbeat_at"`
+	}
+
+	RecordImportSessionSet []*RecordImportSession
+
+	// RecordImportFields maps source columns to module fields
+	RecordImportFields map[string]string
+
+	RecordImportProgress struct {
+		StartedAt  *time.Time `json:"startedAt"`
+		FinishedAt *time.Time `json:"finishedAt"`
+		EntryCount uint64     `json:"entryCount"`
+		Completed  uint64     `json:"completed"`
+		Created    uint64     `json:"created"`
+		Updated    uint64     `json:"updated"`
+		// Skipped counts matched records that would not be changed by the import
+		Skipped    uint64   `json:"skipped"`
+		Failed     uint64   `json:"failed"`
+		FailReason string   `json:"failReason,omitempty"`
+		FailLog    *FailLog `json:"failLog,omitempty"`
+	}
+
+	FailLog struct {
+		// Records holds an array of record indexes
+		Records          RecordIndex `json:"records"`
+		RecordsTruncated bool        `json:"recordsTruncated"`
+		// Errors specifies a map of occurred errors & the number of
+		Errors ErrorIndex `json:"errors"`
+	}
+
+	RecordIndex []int
+	ErrorIndex  map[string]int
+
+	// RecordImportReport holds errors of all failed records (rows) of the import
+	RecordImportReport []*RecordImportReportEntry
+
+	RecordImportReportEntry struct {
+		Row     int    `json:"row"`
+		Field   string `json:"field"`
+		Kind    string `json:"kind"`
+		Message string `json:"message"`
+	}
+)
+
+// Processed returns number of source records (rows) that were already imported or failed
+func (p RecordImportProgress) Processed() int {
+	return int(p.Completed + p.Failed)
+}
+
+func (ei ErrorIndex) Add(err string) {
+	if _, has := ei[err]; has {
+		ei[err]++
+	} else {
+		ei[err] = 1
+	}
+}
+
+func (ri RecordIndex) MarshalJSON() ([]byte, error) {
+	sort.Ints(ri)
+
+	rr := make([][]int, 0, len(ri))
+	start := -1
+	crt := -1
+
+	for i := 0; i < len(ri); i++ {
+		if start == -1 {
+			start = ri[i]
+			crt = ri[i]
+			continue
+		}
+
+		// If the index increases for more then 1, the set is complete
+		if ri[i]-crt > 1 {
+			rr = append(rr, []int{start, crt})
+			start = ri[i]
+		}
+
+		crt = ri[i]
+	}
+
+	rr = append(rr, []int{start, crt})
+	return json.Marshal(rr)
+}
+
+// UnmarshalJSON expands ranges of indexes encoded by MarshalJSON
+func (ri *RecordIndex) UnmarshalJSON(b []byte) error {
+	var rr [][]int
+	if err := json.Unmarshal(b, &rr); err != nil {
+		return err
+	}
+
+	*ri = RecordIndex{}
+	for _, r := range rr {
+		if len(r) != 2 || r[0] < 0 {
+			continue
+		}
+
+		for i := r[0]; i <= r[1]; i++ {
+			*ri = append(*ri, i)
+		}
+	}
+
+	return nil
+}
+
+func (ff *RecordImportFields) Scan(value interface{}) error {
+	//lint:ignore S1034 This typecast is intentional, we need to get []byte out of a []uint8
+	switch value.(type) {
+	case nil:
+		*ff = RecordImportFields{}
+	case []uint8:
+		b := value.([]byte)
+		if err := json.Unmarshal(b, ff); err != nil {
+			return errors.Wrapf(err, "Can not scan '%v' into RecordImportFields", string(b))
+		}
+	}
+
+	return nil
+}
+
+func (ff RecordImportFields) Value() (driver.Value, error) {
+	return json.Marshal(ff)
+}
+
+func (p *RecordImportProgress) Scan(value interface{}) error {
+	//lint:ignore S1034 This typecast is intentional, we need to get []byte out of a []uint8
+	switch value.(type) {
+	case nil:
+		*p = RecordImportProgress{}
+	case []uint8:
+		b := value.([]byte)
+		if err := json.Unmarshal(b, p); err != nil {
+			return errors.Wrapf(err, "Can not scan '%v' into RecordImportProgress", string(b))
+		}
+	}
+
+	return nil
+}
+
+func (p RecordImportProgress) Value() (driver.Value, error) {
+	return json.Marshal(p)
+}
+
+func (rep *RecordImportReport) Scan(value interface{}) error {
+	//lint:ignore S1034 This typecast is intentional, we need to get []byte out of a []uint8
+	switch value.(type) {
+	case nil:
+		*rep = RecordImportReport{}
+	case []uint8:
+		b := value.([]byte)
+		if err := json.Unmarshal(b, rep); err != nil {
+			return errors.Wrapf(err, "Can not scan '%v' into RecordImportReport", string(b))
+		}
+	}
+
+	return nil
+}
+
+func (rep RecordImportReport) Value() (driver.Value, error) {
+	return json.Marshal(rep)
+}
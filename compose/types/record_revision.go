@@ -0,0 +1,155 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/cortezaproject/corteza-server/pkg/rh"
+)
+
+type (
+	// RecordRevision is a stored row in the `record_revision` table
+	//
+	// Each revision holds a complete snapshot of record values
+	// (as they were after the operation) and a list of field-level changes
+	RecordRevision struct {
+		ID          uint64 `json:"revisionID,string" db:"id"`
+		RecordID    uint64 `json:"recordID,string"   db:"rel_record"`
+		ModuleID    uint64 `json:"moduleID,string"   db:"rel_module"`
+		NamespaceID uint64 `json:"namespaceID,string" db:"rel_namespace"`
+
+		Operation RecordRevisionOperation `json:"operation" db:"operation"`
+
+		Changes RecordRevisionChangeSet `json:"changes" db:"changes"`
+		Values  RecordValueSet          `json:"values"  db:"snapshot"`
+
+		CreatedAt time.Time `json:"createdAt" db:"created_at"`
+		CreatedBy uint64    `json:"createdBy,string" db:"created_by"`
+	}
+
+	RecordRevisionFilter struct {
+		NamespaceID uint64 `json:"namespaceID,string"`
+		ModuleID    uint64 `json:"moduleID,string"`
+		RecordID    uint64 `json:"recordID,string"`
+
+		// Standard paging fields & helpers
+		rh.PageFilter
+	}
+
+	RecordRevisionOperation string
+
+	// RecordRevisionChange holds old & new values of one (single or multi-value) field
+	RecordRevisionChange struct {
+		Name string   `json:"name"`
+		Old  []string `json:"old"`
+		New  []string `json:"new"`
+	}
+
+	RecordRevisionChangeSet []*RecordRevisionChange
+)
+
+const (
+	RecordRevisionCreate  RecordRevisionOperation = "create"
+	RecordRevisionUpdate  RecordRevisionOperation = "update"
+	RecordRevisionDelete  RecordRevisionOperation = "delete"
+	RecordRevisionRestore RecordRevisionOperation = "restore"
+)
+
+// NewRecordRevision prepares revision of a record
+//
+// Changes are calculated from old & new values; deleted values are ignored
+func NewRecordRevision(op RecordRevisionOperation, r *Record, old, new RecordValueSet) *RecordRevision {
+	return &RecordRevision{
+		RecordID:    r.ID,
+		ModuleID:    r.ModuleID,
+		NamespaceID: r.NamespaceID,
+		Operation:   op,
+		Changes:     RecordValueSetDiff(old, new),
+		Values:      new.GetClean(),
+	}
+}
+
+// RecordValueSetDiff compares two sets of values and returns list of changed fields
+//
+// Values are compared per field (name) and by their place; deleted values are ignored
+func RecordValueSetDiff(old, new RecordValueSet) (cc RecordRevisionChangeSet) {
+	var (
+		names = make([]string, 0)
+		seen  = make(map[string]bool)
+
+		collect = func(set RecordValueSet) {
+			for _, v := range set {
+				if !seen[v.Name] && !v.IsDeleted() {
+					seen[v.Name] = true
+					names = append(names, v.Name)
+				}
+			}
+		}
+
+		values = func(set RecordValueSet) (vv []string) {
+			set = set.GetClean()
+			sort.Stable(set)
+
+			vv = make([]string, len(set))
+			for i := range set {
+				vv[i] = set[i].Value
+			}
+
+			return
+		}
+	)
+
+	collect(old)
+	collect(new)
+	sort.Strings(names)
+
+	cc = RecordRevisionChangeSet{}
+	for _, name := range names {
+		var (
+			o = values(old.FilterByName(name))
+			n = values(new.FilterByName(name))
+		)
+
+		if !equalStrings(o, n) {
+			cc = append(cc, &RecordRevisionChange{Name: name, Old: o, New: n})
+		}
+	}
+
+	return
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (set *RecordRevisionChangeSet) Scan(value interface{}) error {
+	//lint:ignore S1034 This typecast is intentional, we need to get []byte out of a []uint8
+	switch value.(type) {
+	case nil:
+		*set = RecordRevisionChangeSet{}
+	case []uint8:
+		if err := json.Unmarshal(value.([]byte), set); err != nil {
+			return errors.Wrapf(err, "Can not scan '%v' into RecordRevisionChangeSet", value)
+		}
+	}
+
+	return nil
+}
+
+func (set RecordRevisionChangeSet) Value() (driver.Value, error) {
+	return json.Marshal(set)
+}
@@ -0,0 +1,69 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRecordValueSetDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		old  RecordValueSet
+		new  RecordValueSet
+		want RecordRevisionChangeSet
+	}{
+		{
+			name: "empty",
+			old:  RecordValueSet{},
+			new:  RecordValueSet{},
+			want: RecordRevisionChangeSet{},
+		},
+		{
+			name: "new values",
+			old:  RecordValueSet{},
+			new:  RecordValueSet{{Name: "b", Value: "2"}, {Name: "a", Value: "1"}},
+			want: RecordRevisionChangeSet{
+				{Name: "a", Old: []string{}, New: []string{"1"}},
+				{Name: "b", Old: []string{}, New: []string{"2"}},
+			},
+		},
+		{
+			name: "unchanged values",
+			old:  RecordValueSet{{Name: "a", Value: "1"}},
+			new:  RecordValueSet{{Name: "a", Value: "1"}},
+			want: RecordRevisionChangeSet{},
+		},
+		{
+			name: "changed and removed values",
+			old:  RecordValueSet{{Name: "a", Value: "1"}, {Name: "b", Value: "2"}},
+			new:  RecordValueSet{{Name: "a", Value: "3"}},
+			want: RecordRevisionChangeSet{
+				{Name: "a", Old: []string{"1"}, New: []string{"3"}},
+				{Name: "b", Old: []string{"2"}, New: []string{}},
+			},
+		},
+		{
+			name: "deleted values are ignored",
+			old:  RecordValueSet{{Name: "a", Value: "1"}},
+			new:  RecordValueSet{{Name: "a", Value: "1"}, {Name: "b", Value: "2", DeletedAt: &time.Time{}}},
+			want: RecordRevisionChangeSet{},
+		},
+		{
+			name: "multi-value order",
+			old:  RecordValueSet{{Name: "m", Value: "1", Place: 0}, {Name: "m", Value: "2", Place: 1}},
+			new:  RecordValueSet{{Name: "m", Value: "2", Place: 0}, {Name: "m", Value: "1", Place: 1}},
+			want: RecordRevisionChangeSet{
+				{Name: "m", Old: []string{"1", "2"}, New: []string{"2", "1"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RecordValueSetDiff(tt.old, tt.new); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RecordValueSetDiff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
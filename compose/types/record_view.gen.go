@@ -0,0 +1,67 @@
+package types
+
+// 	Hello! This file is auto-generated.
+
+type (
+
+	// RecordViewSet slice of RecordView
+	//
+	// This type is auto-generated.
+	RecordViewSet []*RecordView
+)
+
+// Walk iterates through every slice item and calls w(RecordView) err
+//
+// This function is auto-generated.
+func (set RecordViewSet) Walk(w func(*RecordView) error) (err error) {
+	for i := range set {
+		if err = w(set[i]); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// Filter iterates through every slice item, calls f(RecordView) (bool, err) and return filtered slice
+//
+// This function is auto-generated.
+func (set RecordViewSet) Filter(f func(*RecordView) (bool, error)) (out RecordViewSet, err error) {
+	var ok bool
+	out = RecordViewSet{}
+	for i := range set {
+		if ok, err = f(set[i]); err != nil {
+			return
+		} else if ok {
+			out = append(out, set[i])
+		}
+	}
+
+	return
+}
+
+// FindByID finds items from slice by its ID property
+//
+// This function is auto-generated.
+func (set RecordViewSet) FindByID(ID uint64) *RecordView {
+	for i := range set {
+		if set[i].ID == ID {
+			return set[i]
+		}
+	}
+
+	return nil
+}
+
+// IDs returns a slice of uint64s from all items in the set
+//
+// This function is auto-generated.
+func (set RecordViewSet) IDs() (IDs []uint64) {
+	IDs = make([]uint64, len(set))
+
+	for i := range set {
+		IDs[i] = set[i].ID
+	}
+
+	return
+}
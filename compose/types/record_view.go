@@ -0,0 +1,91 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/cortezaproject/corteza-server/pkg/permissions"
+	"github.com/cortezaproject/corteza-server/pkg/rh"
+)
+
+type (
+	// RecordView is a saved (and shared) list of module records
+	//
+	// Filter and sort are record query (ql) expressions, same as in the
+	// record filter; columns are names of the module fields that are visible
+	RecordView struct {
+		ID     uint64 `json:"viewID,string" db:"id"`
+		Handle string `json:"handle" db:"handle"`
+		Name   string `json:"name" db:"name"`
+
+		NamespaceID uint64 `json:"namespaceID,string" db:"rel_namespace"`
+		ModuleID    uint64 `json:"moduleID,string" db:"rel_module"`
+
+		Filter  string            `json:"filter" db:"filter"`
+		Sort    string            `json:"sort" db:"sort"`
+		Columns RecordViewColumns `json:"columns" db:"columns"`
+
+		CreatedAt time.Time  `db:"created_at" json:"createdAt,omitempty"`
+		UpdatedAt *time.Time `db:"updated_at" json:"updatedAt,omitempty"`
+		DeletedAt *time.Time `db:"deleted_at" json:"deletedAt,omitempty"`
+	}
+
+	RecordViewColumns []string
+
+	RecordViewFilter struct {
+		NamespaceID uint64 `json:"namespaceID,string"`
+		ModuleID    uint64 `json:"moduleID,string"`
+		Handle      string `json:"handle"`
+		Query       string `json:"query"`
+
+		Sort string `json:"sort"`
+
+		// Standard paging fields & helpers
+		rh.PageFilter
+
+		// Resource permission check filter
+		IsReadable *permissions.ResourceFilter `json:"-"`
+	}
+)
+
+// Resource returns a system resource ID for this type
+func (v RecordView) PermissionResource() permissions.Resource {
+	return RecordViewPermissionResource.AppendID(v.ID)
+}
+
+// FindByHandle finds record view by it's handle
+func (set RecordViewSet) FindByHandle(handle string) *RecordView {
+	for i := range set {
+		if set[i].Handle == handle {
+			return set[i]
+		}
+	}
+
+	return nil
+}
+
+func (cc *RecordViewColumns) Scan(value interface{}) error {
+	//lint:ignore S1034 This typecast is intentional, we need to get []byte out of a []uint8
+	switch value.(type) {
+	case nil:
+		*cc = RecordViewColumns{}
+	case []uint8:
+		b := value.([]byte)
+		if err := json.Unmarshal(b, cc); err != nil {
+			return errors.Wrapf(err, "Can not scan '%v' into RecordViewColumns", string(b))
+		}
+	}
+
+	return nil
+}
+
+func (cc RecordViewColumns) Value() (driver.Value, error) {
+	if cc == nil {
+		cc = RecordViewColumns{}
+	}
+
+	return json.Marshal(cc)
+}
@@ -67,8 +67,10 @@ func (app *App) Upgrade(ctx context.Context) (err error) {
 func (app *App) Initialize(ctx context.Context) (err error) {
 	// Connects to all services it needs to
 	err = service.Initialize(ctx, app.Log, service.Config{
-		ActionLog: app.Opts.ActionLog,
-		Storage:   app.Opts.Storage,
+		ActionLog:  app.Opts.ActionLog,
+		Storage:    app.Opts.Storage,
+		UploadScan: app.Opts.UploadScan,
+		Attachment: app.Opts.Attachment,
 	})
 
 	if err != nil {
@@ -108,5 +110,6 @@ func (app *App) RegisterCliCommands(p *cobra.Command) {
 	p.AddCommand(
 		commands.Importer(),
 		commands.Exporter(),
+		commands.Attachments(),
 	)
 }
@@ -0,0 +1,3 @@
+-- Size of the original file is used for storage accounting (quotas);
+-- it was kept only in the attachment meta until now
+UPDATE `messaging_attachment` SET `size` = JSON_EXTRACT(`meta`, '$.original.size') WHERE `size` IS NULL;
@@ -0,0 +1,136 @@
+-- PostgreSQL schema, equivalent to the state of the MySQL schema after 20200602090000 migration
+
+CREATE TABLE IF NOT EXISTS messaging_channel (
+  id                BIGINT                   NOT NULL,
+  name              TEXT                     NOT NULL,
+  topic             TEXT                     NOT NULL,
+  meta              JSON                     NOT NULL,
+
+  type              VARCHAR(16)                  NULL CHECK (type IN ('private', 'public', 'group')),
+  membership_policy VARCHAR(16)              NOT NULL DEFAULT '' CHECK (membership_policy IN ('featured', 'forced', '')),
+
+  rel_organisation  BIGINT                   NOT NULL,
+  rel_creator       BIGINT                   NOT NULL,
+
+  created_at        TIMESTAMPTZ              NOT NULL DEFAULT NOW(),
+  updated_at        TIMESTAMPTZ                  NULL,
+  archived_at       TIMESTAMPTZ                  NULL,
+  deleted_at        TIMESTAMPTZ                  NULL,
+
+  rel_last_message  BIGINT                   NOT NULL DEFAULT 0,
+
+  PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS messaging_channel_member (
+  rel_channel       BIGINT                   NOT NULL,
+  rel_user          BIGINT                   NOT NULL,
+
+  type              VARCHAR(16)                  NULL CHECK (type IN ('owner', 'member', 'invitee')),
+  flag              VARCHAR(16)              NOT NULL DEFAULT '' CHECK (flag IN ('pinned', 'hidden', 'ignored', '')),
+
+  created_at        TIMESTAMPTZ              NOT NULL DEFAULT NOW(),
+  updated_at        TIMESTAMPTZ                  NULL,
+
+  PRIMARY KEY (rel_channel, rel_user)
+);
+
+CREATE TABLE IF NOT EXISTS messaging_message (
+  id                BIGINT                   NOT NULL,
+  type              TEXT,
+  message           TEXT                     NOT NULL,
+  meta              JSON,
+  rel_user          BIGINT                   NOT NULL,
+  rel_channel       BIGINT                   NOT NULL,
+  reply_to          BIGINT                   NOT NULL DEFAULT 0,
+  replies           INTEGER                  NOT NULL DEFAULT 0,
+
+  created_at        TIMESTAMPTZ              NOT NULL DEFAULT NOW(),
+  updated_at        TIMESTAMPTZ                  NULL,
+  deleted_at        TIMESTAMPTZ                  NULL,
+
+  PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS messaging_message_flag (
+  id                BIGINT                   NOT NULL,
+  rel_channel       BIGINT                   NOT NULL,
+  rel_message       BIGINT                   NOT NULL,
+  rel_user          BIGINT                   NOT NULL,
+  flag              TEXT,
+
+  created_at        TIMESTAMPTZ              NOT NULL DEFAULT NOW(),
+
+  PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS messaging_mention (
+  id                BIGINT                   NOT NULL,
+  rel_channel       BIGINT                   NOT NULL,
+  rel_message       BIGINT                   NOT NULL,
+  rel_user          BIGINT                   NOT NULL,
+  rel_mentioned_by  BIGINT                   NOT NULL,
+
+  created_at        TIMESTAMPTZ              NOT NULL DEFAULT NOW(),
+
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX lookup_mentions ON messaging_mention (rel_mentioned_by);
+
+CREATE TABLE IF NOT EXISTS messaging_unread (
+  rel_channel       BIGINT                   NOT NULL DEFAULT 0,
+  rel_reply_to      BIGINT                   NOT NULL,
+  rel_user          BIGINT                   NOT NULL DEFAULT 0,
+  rel_last_message  BIGINT                   NOT NULL DEFAULT 0,
+  count             INTEGER                  NOT NULL DEFAULT 0,
+
+  PRIMARY KEY (rel_channel, rel_reply_to, rel_user)
+);
+
+CREATE TABLE IF NOT EXISTS messaging_attachment (
+  id                BIGINT                   NOT NULL,
+  rel_user          BIGINT                   NOT NULL,
+
+  url               VARCHAR(512),
+  preview_url       VARCHAR(512),
+
+  size              INTEGER,
+  mimetype          VARCHAR(255),
+  name              TEXT,
+
+  meta              JSON,
+
+  created_at        TIMESTAMPTZ              NOT NULL DEFAULT NOW(),
+  updated_at        TIMESTAMPTZ                  NULL,
+  deleted_at        TIMESTAMPTZ                  NULL,
+
+  PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS messaging_message_attachment (
+  rel_message       BIGINT                   NOT NULL,
+  rel_attachment    BIGINT                   NOT NULL,
+
+  PRIMARY KEY (rel_message)
+);
+
+CREATE TABLE IF NOT EXISTS messaging_permission_rules (
+  rel_role          BIGINT                   NOT NULL,
+  resource          VARCHAR(128)             NOT NULL,
+  operation         VARCHAR(128)             NOT NULL,
+  access            SMALLINT                 NOT NULL,
+
+  PRIMARY KEY (rel_role, resource, operation)
+);
+
+CREATE TABLE IF NOT EXISTS messaging_settings (
+  rel_owner         BIGINT                   NOT NULL DEFAULT 0, -- value owner, 0 for global settings
+  name              VARCHAR(200)             NOT NULL,
+  value             JSON,
+
+  updated_at        TIMESTAMPTZ              NOT NULL DEFAULT NOW(),
+  updated_by        BIGINT                   NOT NULL DEFAULT 0,
+
+  PRIMARY KEY (name, rel_owner)
+);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS messaging_attachment_content (
+  rel_attachment   BIGINT                   NOT NULL REFERENCES messaging_attachment (id),
+  content          TEXT                     NOT NULL, -- text extracted from the attachment
+
+  PRIMARY KEY (rel_attachment)
+);
+
+-- Expression must match the one used by the full-text search conditions
+CREATE INDEX messaging_attachment_content_fulltext ON messaging_attachment_content USING GIN (to_tsvector('simple', COALESCE(content, '')));
@@ -0,0 +1,3 @@
+-- Size of the original file is used for storage accounting (quotas);
+-- it was kept only in the attachment meta until now
+UPDATE messaging_attachment SET size = (meta->'original'->>'size')::INTEGER WHERE size IS NULL;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS messaging_attachment_content (
+  rel_attachment   BIGINT                   NOT NULL REFERENCES messaging_attachment (id),
+  content          TEXT                     NOT NULL, -- text extracted from the attachment
+
+  PRIMARY KEY (rel_attachment)
+);
+
+-- External content full-text index of attachment contents, kept in sync by triggers
+CREATE VIRTUAL TABLE IF NOT EXISTS messaging_attachment_content_fts USING fts4(content="messaging_attachment_content", content, tokenize=unicode61);
+
+CREATE TRIGGER messaging_attachment_content_fts_bu BEFORE UPDATE ON messaging_attachment_content BEGIN DELETE FROM messaging_attachment_content_fts WHERE docid = old.rowid; END;
+CREATE TRIGGER messaging_attachment_content_fts_bd BEFORE DELETE ON messaging_attachment_content BEGIN DELETE FROM messaging_attachment_content_fts WHERE docid = old.rowid; END;
+CREATE TRIGGER messaging_attachment_content_fts_au AFTER UPDATE ON messaging_attachment_content BEGIN INSERT INTO messaging_attachment_content_fts (docid, content) VALUES (new.rowid, new.content); END;
+CREATE TRIGGER messaging_attachment_content_fts_ai AFTER INSERT ON messaging_attachment_content BEGIN INSERT INTO messaging_attachment_content_fts (docid, content) VALUES (new.rowid, new.content); END;
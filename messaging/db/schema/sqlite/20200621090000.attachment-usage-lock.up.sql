@@ -0,0 +1,8 @@
+-- Rows locked while usage of the messaging attachment quota is checked and the new attachment is stored,
+-- serializing concurrent uploads across all server instances
+CREATE TABLE IF NOT EXISTS messaging_attachment_usage_lock (
+  lock_key         VARCHAR(64)              NOT NULL, -- locked scope (channel or user)
+  locks            BIGINT                   NOT NULL DEFAULT 0, -- number of times lock was acquired
+
+  PRIMARY KEY (lock_key)
+);
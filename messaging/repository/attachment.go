@@ -2,12 +2,14 @@ package repository
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/titpetric/factory"
 
 	"github.com/cortezaproject/corteza-server/messaging/types"
+	"github.com/cortezaproject/corteza-server/pkg/db/dialect"
 	"github.com/cortezaproject/corteza-server/pkg/rh"
 )
 
@@ -17,11 +19,20 @@ type (
 
 		FindAttachmentByID(id uint64) (*types.Attachment, error)
 		FindAttachmentByMessageID(IDs ...uint64) (types.MessageAttachmentSet, error)
+		SearchAttachments(filter types.AttachmentFilter) (types.MessageAttachmentSet, error)
 
 		CreateAttachment(mod *types.Attachment) (*types.Attachment, error)
+		UpdateAttachmentPreview(mod *types.Attachment) error
 		DeleteAttachmentByID(id uint64) error
 
 		BindAttachment(attachmentId, messageId uint64) error
+
+		IndexAttachmentContent(attachmentID uint64, content string) error
+
+		AttachmentUsage(channelID, userID uint64) (int64, error)
+		LockAttachmentUsage(keys ...string) error
+		FindOrphanedAttachments(before time.Time) ([]*types.Attachment, error)
+		PurgeAttachment(attachmentID uint64) error
 	}
 
 	attachment struct {
@@ -47,10 +58,18 @@ func (r attachment) table() string {
 	return "messaging_attachment"
 }
 
+func (r attachment) tableUsageLock() string {
+	return "messaging_attachment_usage_lock"
+}
+
 func (r attachment) tableMessage() string {
 	return "messaging_message_attachment"
 }
 
+func (r attachment) tableContent() string {
+	return "messaging_attachment_content"
+}
+
 func (r attachment) columns() []string {
 	return []string{
 		"a.id",
@@ -111,6 +130,45 @@ func (r attachment) FindAttachmentByMessageID(IDs ...uint64) (rval types.Message
 	return rval, rh.FetchAll(r.db(), query, &rval)
 }
 
+// SearchAttachments finds posted attachments with the indexed content matching the free-text query
+//
+// Results are ordered by relevance
+func (r attachment) SearchAttachments(f types.AttachmentFilter) (rval types.MessageAttachmentSet, err error) {
+	rval = types.MessageAttachmentSet{}
+
+	if len(f.ChannelID) == 0 {
+		return
+	}
+
+	var (
+		d = dialect.Current()
+
+		match, matchArgs = d.FullTextMatch(r.tableContent(), "ac", "content", f.Query)
+		rank, rankArgs   = d.FullTextRank(r.tableContent(), "ac", "content", f.Query)
+	)
+
+	query := r.query().
+		Columns("ma.rel_message", "m.rel_channel").
+		Join(r.tableMessage()+" AS ma ON (a.id = ma.rel_attachment)").
+		Join("messaging_message AS m ON (m.id = ma.rel_message)").
+		Join(r.tableContent()+" AS ac ON (ac.rel_attachment = a.id)").
+		Where("m.deleted_at IS NULL").
+		Where(squirrel.Eq{"m.rel_channel": f.ChannelID}).
+		Where(match, matchArgs...).
+		OrderByClause(rank+" DESC", rankArgs...).
+		OrderBy("a.id DESC")
+
+	if join, joinArgs := d.FullTextJoin(r.tableContent(), "ac", "content", f.Query); join != "" {
+		query = query.Join(join, joinArgs...)
+	}
+
+	if f.Limit > 0 {
+		query = query.Limit(uint64(f.Limit))
+	}
+
+	return rval, rh.FetchAll(r.db(), query, &rval)
+}
+
 func (r attachment) CreateAttachment(mod *types.Attachment) (*types.Attachment, error) {
 	if mod.ID == 0 {
 		mod.ID = factory.Sonyflake.NextID()
@@ -121,6 +179,18 @@ func (r attachment) CreateAttachment(mod *types.Attachment) (*types.Attachment,
 	return mod, r.db().Insert(r.table(), mod)
 }
 
+// UpdateAttachmentPreview stores preview (url & meta) of the attachment
+func (r attachment) UpdateAttachmentPreview(mod *types.Attachment) error {
+	now := time.Now()
+	mod.UpdatedAt = &now
+
+	return rh.UpdateColumns(r.db(), r.table(), rh.Set{
+		"preview_url": mod.PreviewUrl,
+		"meta":        mod.Meta,
+		"updated_at":  mod.UpdatedAt,
+	}, squirrel.Eq{"id": mod.ID})
+}
+
 func (r attachment) DeleteAttachmentByID(ID uint64) error {
 	return rh.UpdateColumns(r.db(), r.table(), rh.Set{"deleted_at": time.Now()}, squirrel.Eq{"id": ID})
 }
@@ -133,3 +203,125 @@ func (r attachment) BindAttachment(attachmentId, messageId uint64) error {
 
 	return r.db().Insert(r.tableMessage(), bond)
 }
+
+// IndexAttachmentContent stores (or replaces) text content of the attachment
+func (r attachment) IndexAttachmentContent(attachmentID uint64, content string) error {
+	_, err := r.db().Exec("DELETE FROM "+r.tableContent()+" WHERE rel_attachment = ?", attachmentID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db().Exec(
+		"INSERT INTO "+r.tableContent()+" (rel_attachment, content) VALUES (?, ?)",
+		attachmentID,
+		content,
+	)
+
+	return err
+}
+
+// LockAttachmentUsage locks attachment usage of the given scopes until the end of the transaction
+//
+// Lock rows are created on the first use and updated to acquire the row lock
+// (on SQLite, the database write lock); concurrent uploads, also the ones handled
+// by other server instances, wait until the transaction holding the lock ends
+func (r attachment) LockAttachmentUsage(keys ...string) error {
+	const update = "UPDATE %s SET locks = locks + 1 WHERE lock_key = ?"
+
+	for _, key := range keys {
+		res, err := r.db().Exec(fmt.Sprintf(update, r.tableUsageLock()), key)
+		if err != nil {
+			return err
+		}
+
+		if n, err := res.RowsAffected(); err != nil || n > 0 {
+			return err
+		}
+
+		if _, err = r.db().Exec(dialect.Current().InsertIgnore(r.tableUsageLock(), "lock_key"), key); err != nil {
+			return err
+		}
+
+		if _, err = r.db().Exec(fmt.Sprintf(update, r.tableUsageLock()), key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AttachmentUsage returns number of bytes stored by (non-deleted) attachments
+// posted to the channel and/or uploaded by the user
+func (r attachment) AttachmentUsage(channelID, userID uint64) (size int64, err error) {
+	query := squirrel.
+		Select("COALESCE(SUM(a.size), 0)").
+		From(r.table() + " AS a").
+		Where("a.deleted_at IS NULL")
+
+	if channelID > 0 {
+		query = query.
+			Join(r.tableMessage()+" AS ma ON (a.id = ma.rel_attachment)").
+			Join("messaging_message AS m ON (m.id = ma.rel_message)").
+			Where("m.deleted_at IS NULL").
+			Where("m.rel_channel = ?", channelID)
+	}
+
+	if userID > 0 {
+		query = query.Where("a.rel_user = ?", userID)
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	return size, r.db().Get(&size, sql, args...)
+}
+
+// FindOrphanedAttachments finds attachments that are no longer used since before the given time
+//
+// These are deleted attachments and attachments without any
+// (non-deleted) message they were posted with
+func (r attachment) FindOrphanedAttachments(before time.Time) (set []*types.Attachment, err error) {
+	referenced := squirrel.
+		Select("1").
+		From(r.tableMessage() + " AS ma").
+		Join("messaging_message AS m ON (m.id = ma.rel_message)").
+		Where("ma.rel_attachment = a.id").
+		Where(squirrel.Or{
+			squirrel.Expr("m.deleted_at IS NULL"),
+			squirrel.Gt{"m.deleted_at": before},
+		})
+
+	sql, args, err := referenced.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	query := squirrel.
+		Select(r.columns()...).
+		From(r.table() + " AS a").
+		Where(squirrel.Or{
+			squirrel.Lt{"a.deleted_at": before},
+			squirrel.And{
+				squirrel.Lt{"a.created_at": before},
+				squirrel.Expr("NOT EXISTS ("+sql+")", args...),
+			},
+		}).
+		OrderBy("a.id")
+
+	return set, rh.FetchAll(r.db(), query, &set)
+}
+
+// PurgeAttachment removes attachment, its bindings to messages and indexed content from the database
+func (r attachment) PurgeAttachment(attachmentID uint64) error {
+	if err := rh.Delete(r.db(), r.tableContent(), squirrel.Eq{"rel_attachment": attachmentID}); err != nil {
+		return err
+	}
+
+	if err := rh.Delete(r.db(), r.tableMessage(), squirrel.Eq{"rel_attachment": attachmentID}); err != nil {
+		return err
+	}
+
+	return rh.Delete(r.db(), r.table(), squirrel.Eq{"id": attachmentID})
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/titpetric/factory"
 
 	"github.com/cortezaproject/corteza-server/messaging/types"
+	"github.com/cortezaproject/corteza-server/pkg/db/dialect"
 	"github.com/cortezaproject/corteza-server/pkg/rh"
 )
 
@@ -99,7 +100,7 @@ func (r channelMember) queryExactMembers(memberIDs ...uint64) squirrel.SelectBui
 		GroupBy("cm.rel_channel").
 		Having(squirrel.Eq{
 			"COUNT(*)": len(memberIDs),
-			"CONCAT(GROUP_CONCAT(cm.rel_user ORDER BY 1 ASC SEPARATOR ','),',')": membersConcat,
+			"CONCAT(" + dialect.Current().GroupConcat("cm.rel_user") + ",',')": membersConcat,
 		})
 }
 
@@ -10,6 +10,7 @@ import (
 	"github.com/titpetric/factory"
 
 	"github.com/cortezaproject/corteza-server/messaging/types"
+	"github.com/cortezaproject/corteza-server/pkg/db/dialect"
 	"github.com/cortezaproject/corteza-server/pkg/rh"
 )
 
@@ -312,7 +313,7 @@ func (r *message) Create(mod *types.Message) (*types.Message, error) {
 func (r *message) Update(mod *types.Message) (*types.Message, error) {
 	rh.SetCurrentTimeRounded(&mod.UpdatedAt)
 
-	return mod, r.db().Replace("messaging_message", mod)
+	return mod, dialect.Replace(r.db(), "messaging_message", mod, "id")
 }
 
 func (r *message) BindAvatar(in *types.Message, avatar io.Reader) (*types.Message, error) {
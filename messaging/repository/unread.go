@@ -7,6 +7,7 @@ import (
 	"github.com/titpetric/factory"
 
 	"github.com/cortezaproject/corteza-server/messaging/types"
+	"github.com/cortezaproject/corteza-server/pkg/db/dialect"
 	"github.com/cortezaproject/corteza-server/pkg/rh"
 )
 
@@ -43,16 +44,17 @@ const (
                                   SET count = count - 1
                                 WHERE rel_channel = ? AND rel_reply_to = ? AND count > 0`
 
-	sqlResetCount = `REPLACE INTO messaging_unread (rel_channel, rel_reply_to, rel_user, count) VALUES (?, ?, ?, 0)`
-
-	sqlUnreadPresetChannel = `INSERT IGNORE INTO messaging_unread (rel_channel, rel_reply_to, rel_user) VALUES (?, ?, ?)`
-	sqlUnreadPresetThreads = `INSERT IGNORE INTO messaging_unread (rel_channel, rel_reply_to, rel_user) 
-                     SELECT rel_channel, id, ? 
+	sqlUnreadPresetThreads = `SELECT rel_channel, id, ? 
                        FROM messaging_message 
                       WHERE rel_channel = ?
                         AND replies > 0`
 )
 
+var (
+	// unread records are kept for each user in a channel and its threads
+	unreadKeys = []string{"rel_channel", "rel_reply_to", "rel_user"}
+)
+
 // Unread creates new instance of channel member repository
 func Unread(ctx context.Context, db *factory.DB) UnreadRepository {
 	return (&unread{}).With(ctx, db)
@@ -172,7 +174,7 @@ func (r unread) Preset(channelID, threadID uint64, userIDs ...uint64) (err error
 			continue
 		}
 
-		_, err = r.db().Exec(sqlUnreadPresetChannel, channelID, threadID, userID)
+		_, err = r.db().Exec(dialect.Current().InsertIgnore(r.table(), unreadKeys...), channelID, threadID, userID)
 
 		if err != nil {
 			return
@@ -180,7 +182,7 @@ func (r unread) Preset(channelID, threadID uint64, userIDs ...uint64) (err error
 
 		if threadID == 0 {
 			// Preset for all threads in the channel
-			_, err = r.db().Exec(sqlUnreadPresetThreads, userID, channelID)
+			_, err = r.db().Exec(dialect.Current().InsertIgnoreSelect(r.table(), unreadKeys, sqlUnreadPresetThreads), userID, channelID)
 
 			if err != nil {
 				return
@@ -201,7 +203,7 @@ func (r *unread) Record(userID, channelID, threadID, lastReadMessageID uint64, c
 		Count:         count,
 	}
 
-	return r.db().Replace("messaging_unread", mod)
+	return dialect.Replace(r.db(), r.table(), mod, unreadKeys...)
 }
 
 // Inc increments unread message count on a channel/thread for all but one user
@@ -220,7 +222,10 @@ func (r *unread) Dec(channelID, threadID, userID uint64) (err error) {
 	if err != nil {
 		return err
 	}
-	_, err = r.db().Exec(sqlResetCount, channelID, threadID, userID)
+	_, err = r.db().Exec(
+		dialect.Current().Replace(r.table(), unreadKeys, append(unreadKeys, "count")...),
+		channelID, threadID, userID, 0,
+	)
 	if err != nil {
 		return err
 	}
@@ -12,6 +12,7 @@ import (
 	"github.com/cortezaproject/corteza-server/messaging/rest/request"
 	"github.com/cortezaproject/corteza-server/messaging/service"
 	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/store"
 )
 
 type (
@@ -75,6 +76,15 @@ func (ctrl Attachment) serve(ctx context.Context, ID uint64, preview, download b
 			return
 		}
 
+		// Let client download the file directly from the store when possible
+		if u, err := ctrl.att.PresignedURL(att, preview, download); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		} else if u != "" {
+			http.Redirect(w, req, u, http.StatusFound)
+			return
+		}
+
 		var fh io.ReadSeeker
 
 		if preview {
@@ -88,14 +98,8 @@ func (ctrl Attachment) serve(ctx context.Context, ID uint64, preview, download b
 			return
 		}
 
-		name := url.QueryEscape(att.Name)
-
-		if download {
-			w.Header().Add("Content-Disposition", "attachment; filename="+name)
-		} else {
-			w.Header().Add("Content-Disposition", "inline; filename="+name)
-		}
+		w.Header().Add("Content-Disposition", store.ContentDisposition(att.Name, download))
 
-		http.ServeContent(w, req, name, att.CreatedAt, fh)
+		http.ServeContent(w, req, url.QueryEscape(att.Name), att.CreatedAt, fh)
 	}, nil
 }
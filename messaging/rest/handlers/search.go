@@ -31,12 +31,14 @@ import (
 type SearchAPI interface {
 	Messages(context.Context, *request.SearchMessages) (interface{}, error)
 	Threads(context.Context, *request.SearchThreads) (interface{}, error)
+	Attachments(context.Context, *request.SearchAttachments) (interface{}, error)
 }
 
 // HTTP API interface
 type Search struct {
-	Messages func(http.ResponseWriter, *http.Request)
-	Threads  func(http.ResponseWriter, *http.Request)
+	Messages    func(http.ResponseWriter, *http.Request)
+	Threads     func(http.ResponseWriter, *http.Request)
+	Attachments func(http.ResponseWriter, *http.Request)
 }
 
 func NewSearch(h SearchAPI) *Search {
@@ -81,6 +83,26 @@ func NewSearch(h SearchAPI) *Search {
 				resputil.JSON(w, value)
 			}
 		},
+		Attachments: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewSearchAttachments()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("Search.Attachments", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.Attachments(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("Search.Attachments", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("Search.Attachments", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
 	}
 }
 
@@ -89,5 +111,6 @@ func (h Search) MountRoutes(r chi.Router, middlewares ...func(http.Handler) http
 		r.Use(middlewares...)
 		r.Get("/search/messages", h.Messages)
 		r.Get("/search/threads", h.Threads)
+		r.Get("/search/attachments", h.Attachments)
 	})
 }
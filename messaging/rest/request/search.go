@@ -306,6 +306,91 @@ func (r *SearchThreads) Fill(req *http.Request) (err error) {
 
 var _ RequestFiller = NewSearchThreads()
 
+// SearchAttachments request parameters
+type SearchAttachments struct {
+	hasChannelID bool
+	rawChannelID []string
+	ChannelID    []string
+
+	hasLimit bool
+	rawLimit string
+	Limit    uint
+
+	hasQuery bool
+	rawQuery string
+	Query    string
+}
+
+// NewSearchAttachments request
+func NewSearchAttachments() *SearchAttachments {
+	return &SearchAttachments{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r SearchAttachments) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["channelID"] = r.ChannelID
+	out["limit"] = r.Limit
+	out["query"] = r.Query
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *SearchAttachments) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	if val, ok := urlQuery["channelID[]"]; ok {
+		r.hasChannelID = true
+		r.rawChannelID = val
+		r.ChannelID = parseStrings(val)
+	} else if val, ok = urlQuery["channelID"]; ok {
+		r.hasChannelID = true
+		r.rawChannelID = val
+		r.ChannelID = parseStrings(val)
+	}
+
+	if val, ok := get["limit"]; ok {
+		r.hasLimit = true
+		r.rawLimit = val
+		r.Limit = parseUint(val)
+	}
+	if val, ok := get["query"]; ok {
+		r.hasQuery = true
+		r.rawQuery = val
+		r.Query = val
+	}
+
+	return err
+}
+
+var _ RequestFiller = NewSearchAttachments()
+
 // HasChannelID returns true if channelID was set
 func (r *SearchMessages) HasChannelID() bool {
 	return r.hasChannelID
@@ -530,3 +615,48 @@ func (r *SearchThreads) RawQuery() string {
 func (r *SearchThreads) GetQuery() string {
 	return r.Query
 }
+
+// HasChannelID returns true if channelID was set
+func (r *SearchAttachments) HasChannelID() bool {
+	return r.hasChannelID
+}
+
+// RawChannelID returns raw value of channelID parameter
+func (r *SearchAttachments) RawChannelID() []string {
+	return r.rawChannelID
+}
+
+// GetChannelID returns casted value of  channelID parameter
+func (r *SearchAttachments) GetChannelID() []string {
+	return r.ChannelID
+}
+
+// HasLimit returns true if limit was set
+func (r *SearchAttachments) HasLimit() bool {
+	return r.hasLimit
+}
+
+// RawLimit returns raw value of limit parameter
+func (r *SearchAttachments) RawLimit() string {
+	return r.rawLimit
+}
+
+// GetLimit returns casted value of  limit parameter
+func (r *SearchAttachments) GetLimit() uint {
+	return r.Limit
+}
+
+// HasQuery returns true if query was set
+func (r *SearchAttachments) HasQuery() bool {
+	return r.hasQuery
+}
+
+// RawQuery returns raw value of query parameter
+func (r *SearchAttachments) RawQuery() string {
+	return r.rawQuery
+}
+
+// GetQuery returns casted value of  query parameter
+func (r *SearchAttachments) GetQuery() string {
+	return r.Query
+}
@@ -17,12 +17,14 @@ var _ = errors.Wrap
 type Search struct {
 	svc struct {
 		msg service.MessageService
+		att service.AttachmentService
 	}
 }
 
 func (Search) New() *Search {
 	ctrl := &Search{}
 	ctrl.svc.msg = service.DefaultMessage
+	ctrl.svc.att = service.DefaultAttachment
 	return ctrl
 
 }
@@ -59,6 +61,21 @@ func (ctrl *Search) Threads(ctx context.Context, r *request.SearchThreads) (inte
 
 }
 
+func (ctrl *Search) Attachments(ctx context.Context, r *request.SearchAttachments) (interface{}, error) {
+	aa, err := ctrl.svc.att.With(ctx).Search(types.AttachmentFilter{
+		ChannelID: payload.ParseUInt64s(r.ChannelID),
+		Limit:     r.Limit,
+
+		Query: r.Query,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return payload.MessageAttachments(ctx, aa), nil
+}
+
 func (ctrl *Search) wrapSet(ctx context.Context, mm types.MessageSet, err error) (*outgoing.MessageSet, error) {
 	if err != nil {
 		return nil, err
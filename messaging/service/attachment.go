@@ -7,7 +7,6 @@ import (
 	"image"
 	"image/gif"
 	"io"
-	"net/http"
 	"path"
 	"strings"
 
@@ -15,12 +14,18 @@ import (
 	"github.com/edwvee/exiffix"
 
 	"github.com/titpetric/factory"
+	"go.uber.org/zap"
 
 	"github.com/cortezaproject/corteza-server/messaging/repository"
 	"github.com/cortezaproject/corteza-server/messaging/types"
 	"github.com/cortezaproject/corteza-server/pkg/actionlog"
 	intAuth "github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/lock"
+	"github.com/cortezaproject/corteza-server/pkg/mime"
+	"github.com/cortezaproject/corteza-server/pkg/preview"
+	"github.com/cortezaproject/corteza-server/pkg/scan"
 	"github.com/cortezaproject/corteza-server/pkg/store"
+	"github.com/cortezaproject/corteza-server/pkg/textract"
 )
 
 const (
@@ -37,8 +42,11 @@ type (
 
 		ac attachmentAccessController
 
-		store store.Store
-		event EventService
+		store    store.Store
+		scanner  scan.Scanner
+		quota    *AttachmentQuota
+		event    EventService
+		channels ChannelService
 
 		attachment repository.AttachmentRepository
 		message    repository.MessageRepository
@@ -53,16 +61,39 @@ type (
 		With(ctx context.Context) AttachmentService
 
 		FindByID(id uint64) (*types.Attachment, error)
+		Search(filter types.AttachmentFilter) (types.MessageAttachmentSet, error)
 		CreateMessageAttachment(name string, size int64, fh io.ReadSeeker, channelId, replyTo uint64) (*types.Attachment, error)
 		OpenOriginal(att *types.Attachment) (io.ReadSeeker, error)
 		OpenPreview(att *types.Attachment) (io.ReadSeeker, error)
+		PresignedURL(att *types.Attachment, preview, download bool) (string, error)
 	}
+
+	// AttachmentQuota limits number of bytes stored per channel and per user
+	//
+	// User's usage only includes attachments stored in messaging
+	// (see options.AttachmentOpt.MessagingUserQuota).
+	// Only sizes of the original files are counted, not of their previews
+	// (see options.AttachmentOpt).
+	// Limit is not enforced when set to 0
+	AttachmentQuota struct {
+		Channel int64
+		User    int64
+	}
+)
+
+var (
+	// Serializes quota checks of uploads handled by this server instance
+	// before they wait for the usage lock in the database (see quotaKeys)
+	attachmentQuotaLocks = &lock.Keyed{}
 )
 
 func Attachment(ctx context.Context, store store.Store) AttachmentService {
 	return (&attachment{
-		ac:    DefaultAccessControl,
-		store: store,
+		ac:       DefaultAccessControl,
+		store:    store,
+		scanner:  DefaultUploadScanner,
+		quota:    DefaultAttachmentQuota,
+		channels: DefaultChannel,
 	}).With(ctx)
 }
 
@@ -75,8 +106,11 @@ func (svc attachment) With(ctx context.Context) AttachmentService {
 
 		actionlog: DefaultActionlog,
 
-		store: svc.store,
-		event: Event(ctx),
+		store:    svc.store,
+		scanner:  svc.scanner,
+		quota:    svc.quota,
+		event:    Event(ctx),
+		channels: svc.channels,
 
 		attachment: repository.Attachment(ctx, db),
 		message:    repository.Message(ctx, db),
@@ -88,6 +122,38 @@ func (svc attachment) FindByID(id uint64) (*types.Attachment, error) {
 	return svc.attachment.FindAttachmentByID(id)
 }
 
+// Search finds attachments by their (indexed) content
+//
+// Only attachments posted to readable channels are returned
+func (svc attachment) Search(filter types.AttachmentFilter) (aa types.MessageAttachmentSet, err error) {
+	var (
+		aProps = &attachmentActionProps{query: filter.Query}
+	)
+
+	err = svc.db.Transaction(func() error {
+		filter.Query = strings.TrimSpace(filter.Query)
+		if filter.Query == "" {
+			return AttachmentErrInvalidQuery()
+		}
+
+		cc, _, err := svc.channels.With(svc.ctx).Find(types.ChannelFilter{
+			ChannelID:      filter.ChannelID,
+			IncludeDeleted: true,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		filter.ChannelID = cc.IDs()
+
+		aa, err = svc.attachment.SearchAttachments(filter)
+		return err
+	})
+
+	return aa, svc.recordAction(svc.ctx, aProps, AttachmentActionSearch, err)
+}
+
 func (svc attachment) OpenOriginal(att *types.Attachment) (io.ReadSeeker, error) {
 	if len(att.Url) == 0 {
 		return nil, nil
@@ -104,6 +170,22 @@ func (svc attachment) OpenPreview(att *types.Attachment) (io.ReadSeeker, error)
 	return svc.store.Open(att.PreviewUrl)
 }
 
+// PresignedURL returns short-lived URL for downloading the file directly from the store
+//
+// Empty string is returned when store does not support it and file needs to be streamed
+func (svc attachment) PresignedURL(att *types.Attachment, preview, download bool) (string, error) {
+	var filename = att.Url
+	if preview {
+		filename = att.PreviewUrl
+	}
+
+	if len(filename) == 0 {
+		return "", nil
+	}
+
+	return store.PresignedGet(svc.store, filename, store.ContentDisposition(att.Name, download))
+}
+
 func (svc attachment) CreateMessageAttachment(name string, size int64, fh io.ReadSeeker, channelID, replyTo uint64) (att *types.Attachment, err error) {
 	var (
 		aProps = &attachmentActionProps{channel: &types.Channel{ID: channelID}, replyTo: replyTo}
@@ -112,7 +194,7 @@ func (svc attachment) CreateMessageAttachment(name string, size int64, fh io.Rea
 		ch            *types.Channel
 	)
 
-	err = svc.db.Transaction(func() (err error) {
+	err = func() (err error) {
 		if ch, err = svc.channel.FindByID(channelID); err != nil {
 			if repository.ErrChannelNotFound.Eq(err) {
 				return AttachmentErrChannelNotFound()
@@ -125,6 +207,14 @@ func (svc attachment) CreateMessageAttachment(name string, size int64, fh io.Rea
 			return AttachmentErrNotAllowedToAttachToChannel()
 		}
 
+		aProps.setName(name)
+		aProps.setSize(size)
+
+		// Fail early, before the file is scanned and stored
+		if err = svc.checkQuota(ch.ID, currentUserID, size, aProps); err != nil {
+			return err
+		}
+
 		att = &types.Attachment{
 			ID:     factory.Sonyflake.NextID(),
 			UserID: currentUserID,
@@ -136,37 +226,66 @@ func (svc attachment) CreateMessageAttachment(name string, size int64, fh io.Rea
 			return err
 		}
 
-		if att, err = svc.attachment.CreateAttachment(att); err != nil {
-			return err
-		}
+		err = func() error {
+			var keys = svc.quotaKeys(ch.ID, currentUserID)
 
-		msg := &types.Message{
-			Attachment: att,
-			Message:    name,
-			Type:       types.MessageTypeAttachment,
-			ChannelID:  channelID,
-			ReplyTo:    replyTo,
-			UserID:     currentUserID,
-		}
+			defer attachmentQuotaLocks.Lock(keys...)()
 
-		if strings.HasPrefix(att.Meta.Original.Mimetype, "image/") {
-			msg.Type = types.MessageTypeInlineImage
-		}
+			return svc.db.Transaction(func() (err error) {
+				// Quota is checked again, serialized with the other uploads,
+				// until the attachment is created
+				if err = svc.attachment.LockAttachmentUsage(keys...); err != nil {
+					return err
+				}
 
-		// Create the first message, doing this directly with repository to circumvent
-		// message service constraints
-		if msg, err = svc.message.Create(msg); err != nil {
-			return
-		}
+				if err = svc.checkQuota(ch.ID, currentUserID, size, aProps); err != nil {
+					return err
+				}
 
-		aProps.setMessageID(msg.ID)
+				if att, err = svc.attachment.CreateAttachment(att); err != nil {
+					return err
+				}
 
-		if err = svc.attachment.BindAttachment(att.ID, msg.ID); err != nil {
-			return
+				msg := &types.Message{
+					Attachment: att,
+					Message:    name,
+					Type:       types.MessageTypeAttachment,
+					ChannelID:  channelID,
+					ReplyTo:    replyTo,
+					UserID:     currentUserID,
+				}
+
+				if strings.HasPrefix(att.Meta.Original.Mimetype, "image/") {
+					msg.Type = types.MessageTypeInlineImage
+				}
+
+				// Create the first message, doing this directly with repository to circumvent
+				// message service constraints
+				if msg, err = svc.message.Create(msg); err != nil {
+					return
+				}
+
+				aProps.setMessageID(msg.ID)
+
+				if err = svc.attachment.BindAttachment(att.ID, msg.ID); err != nil {
+					return
+				}
+
+				return svc.sendEvent(msg)
+			})
+		}()
+
+		if err != nil {
+			svc.removeStored(att)
+			return err
 		}
 
-		return svc.sendEvent(msg)
-	})
+		return svc.indexContent(fh, att)
+	}()
+
+	if err == nil {
+		svc.processDocument(att)
+	}
 
 	return att, svc.recordAction(svc.ctx, aProps, AttachmentActionCreate, err)
 }
@@ -186,11 +305,22 @@ func (svc attachment) create(name string, size int64, fh io.ReadSeeker, att *typ
 	// Extract extension but make sure path.Ext is not confused by any leading/trailing dots
 	att.Meta.Original.Extension = strings.Trim(path.Ext(strings.Trim(name, ".")), ".")
 
+	att.Size = size
 	att.Meta.Original.Size = size
-	if att.Meta.Original.Mimetype, err = svc.extractMimetype(fh); err != nil {
+	if att.Meta.Original.Mimetype, err = mime.Detect(fh); err != nil {
 		return AttachmentErrFailedToExtractMimeType(aProps).Wrap(err)
 	}
 
+	if svc.scanner != nil {
+		// Inspect the file before it is stored
+		err = svc.scanner.Scan(fh, att.Meta.Original.Mimetype, att.Meta.Original.Extension)
+		if scan.IsRejected(err) {
+			return AttachmentErrRejected(aProps).Wrap(err)
+		} else if err != nil {
+			return AttachmentErrFailedToScanFile(aProps).Wrap(err)
+		}
+	}
+
 	att.Url = svc.store.Original(att.ID, att.Meta.Original.Extension)
 	aProps.setUrl(att.Url)
 
@@ -207,27 +337,100 @@ func (svc attachment) create(name string, size int64, fh io.ReadSeeker, att *typ
 	return nil
 }
 
-func (svc attachment) extractMimetype(file io.ReadSeeker) (mimetype string, err error) {
-	if _, err = file.Seek(0, 0); err != nil {
-		return
+// removeStored removes files of the attachment that could not be created
+func (svc attachment) removeStored(att *types.Attachment) {
+	for _, filename := range []string{att.Url, att.PreviewUrl} {
+		if filename == "" {
+			continue
+		}
+
+		if err := svc.store.Remove(filename); err != nil {
+			DefaultLogger.Named("attachment").Warn(
+				"could not remove stored file",
+				zap.Uint64("attachmentID", att.ID),
+				zap.String("filename", filename),
+				zap.Error(err),
+			)
+		}
 	}
+}
 
-	// Make sure we rewind when we're done
-	defer file.Seek(0, 0)
+// enabled checks if any of the limits is set
+func (q *AttachmentQuota) enabled() bool {
+	return q != nil && (q.Channel > 0 || q.User > 0)
+}
 
-	// See http.DetectContentType about 512 bytes
-	var buf = make([]byte, 512)
-	if _, err = file.Read(buf); err != nil {
-		return
+// quotaKeys returns keys of the channel and the user
+// (when quota is enforced on them) for locking their usage
+//
+// Channel is always locked before the user
+func (svc attachment) quotaKeys(channelID, userID uint64) (keys []string) {
+	if !svc.quota.enabled() {
+		return nil
+	}
+
+	if svc.quota.Channel > 0 {
+		keys = append(keys, fmt.Sprintf("channel:%d", channelID))
+	}
+
+	if svc.quota.User > 0 {
+		keys = append(keys, fmt.Sprintf("user:%d", userID))
 	}
 
-	return http.DetectContentType(buf), nil
+	return keys
+}
+
+// checkQuota makes sure that storing the file
+// does not exceed channel or user quota
+//
+// Authoritative check must be made under the usage lock (see quotaKeys),
+// in the same transaction the attachment is created
+func (svc attachment) checkQuota(channelID, userID uint64, size int64, aProps *attachmentActionProps) error {
+	if !svc.quota.enabled() {
+		return nil
+	}
+
+	if svc.quota.Channel > 0 {
+		used, err := svc.attachment.AttachmentUsage(channelID, 0)
+		if err != nil {
+			return err
+		}
+
+		if used+size > svc.quota.Channel {
+			return AttachmentErrChannelQuotaExceeded(aProps)
+		}
+	}
+
+	if svc.quota.User > 0 {
+		used, err := svc.attachment.AttachmentUsage(0, userID)
+		if err != nil {
+			return err
+		}
+
+		if used+size > svc.quota.User {
+			return AttachmentErrUserQuotaExceeded(aProps)
+		}
+	}
+
+	return nil
+}
+
+// indexContent stores text extracted from the attachment for searching
+//
+// Failed extraction is not fatal, attachment is just not indexed
+func (svc attachment) indexContent(original io.ReadSeeker, att *types.Attachment) error {
+	text, err := textract.Extract(original, att.Meta.Original.Mimetype, att.Meta.Original.Extension)
+	if err != nil || text == "" {
+		return nil
+	}
+
+	return svc.attachment.IndexAttachmentContent(att.ID, text)
 }
 
 func (svc attachment) processImage(original io.ReadSeeker, att *types.Attachment) (err error) {
 	if !strings.HasPrefix(att.Meta.Original.Mimetype, "image/") {
-		// Only supporting previews from images (for now)
-		return
+		// Previews of documents are made in the background (see processDocument)
+		return nil
 	}
 
 	var (
@@ -328,3 +531,40 @@ func (svc attachment) processImage(original io.ReadSeeker, att *types.Attachment
 func (svc attachment) sendEvent(msg *types.Message) (err error) {
 	return svc.event.Message(msg)
 }
+
+// processDocument makes preview of PDFs (first page), plain-text & office documents (text snippet)
+//
+// Previews of documents are made in the background, after attachment is stored (see preview.Queue)
+func (svc attachment) processDocument(att *types.Attachment) {
+	// Attachment is returned to the caller, preview is set on a copy
+	var doc = *att
+
+	preview.Queue(preview.Document{
+		ID:        doc.ID,
+		Mimetype:  doc.Meta.Original.Mimetype,
+		Extension: doc.Meta.Original.Extension,
+		MaxWidth:  attachmentPreviewMaxWidth,
+		MaxHeight: attachmentPreviewMaxHeight,
+
+		Open: func() (io.ReadSeeker, error) {
+			return svc.store.Open(doc.Url)
+		},
+
+		Save: func(buf *bytes.Buffer, width, height int) error {
+			meta := doc.SetPreviewImageMeta(width, height, false)
+			meta.Size = int64(buf.Len())
+			meta.Mimetype = "image/jpeg"
+			meta.Extension = "jpg"
+
+			doc.PreviewUrl = svc.store.Preview(doc.ID, meta.Extension)
+
+			if err := svc.store.Save(doc.PreviewUrl, buf); err != nil {
+				return err
+			}
+
+			// Request that stored the attachment is done by now
+			ctx := context.Background()
+			return repository.Attachment(ctx, repository.DB(ctx)).UpdateAttachmentPreview(&doc)
+		},
+	})
+}
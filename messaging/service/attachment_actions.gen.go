@@ -22,6 +22,7 @@ type (
 		name       string
 		mimetype   string
 		url        string
+		query      string
 		attachment *types.Attachment
 		channel    *types.Channel
 	}
@@ -128,6 +129,17 @@ func (p *attachmentActionProps) setUrl(url string) *attachmentActionProps {
 	return p
 }
 
+// setQuery updates attachmentActionProps's query
+//
+// Allows method chaining
+//
+// This function is auto-generated.
+//
+func (p *attachmentActionProps) setQuery(query string) *attachmentActionProps {
+	p.query = query
+	return p
+}
+
 // setAttachment updates attachmentActionProps's attachment
 //
 // Allows method chaining
@@ -165,6 +177,7 @@ func (p attachmentActionProps) serialize() actionlog.Meta {
 	m.Set("name", p.name, true)
 	m.Set("mimetype", p.mimetype, true)
 	m.Set("url", p.url, true)
+	m.Set("query", p.query, true)
 	if p.attachment != nil {
 		m.Set("attachment.name", p.attachment.Name, true)
 		m.Set("attachment.url", p.attachment.Url, true)
@@ -223,6 +236,7 @@ func (p attachmentActionProps) tr(in string, err error) string {
 	pairs = append(pairs, "{name}", fns(p.name))
 	pairs = append(pairs, "{mimetype}", fns(p.mimetype))
 	pairs = append(pairs, "{url}", fns(p.url))
+	pairs = append(pairs, "{query}", fns(p.query))
 
 	if p.attachment != nil {
 		// replacement for "{attachment}" (in order how fields are defined)
@@ -585,6 +599,36 @@ func AttachmentErrInvalidID(props ...*attachmentActionProps) *attachmentError {
 
 }
 
+// AttachmentErrInvalidQuery returns "messaging:attachment.invalidQuery" audit event as actionlog.Warning
+//
+//
+// This function is auto-generated.
+//
+func AttachmentErrInvalidQuery(props ...*attachmentActionProps) *attachmentError {
+	var e = &attachmentError{
+		timestamp: time.Now(),
+		resource:  "messaging:attachment",
+		error:     "invalidQuery",
+		action:    "error",
+		message:   "search query is required",
+		log:       "search query is required",
+		severity:  actionlog.Warning,
+		props: func() *attachmentActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
 // AttachmentErrNotAllowedToListAttachments returns "messaging:attachment.notAllowedToListAttachments" audit event as actionlog.Alert
 //
 //
@@ -735,6 +779,126 @@ func AttachmentErrFailedToProcessImage(props ...*attachmentActionProps) *attachm
 
 }
 
+// AttachmentErrFailedToScanFile returns "messaging:attachment.failedToScanFile" audit event as actionlog.Alert
+//
+//
+// This function is auto-generated.
+//
+func AttachmentErrFailedToScanFile(props ...*attachmentActionProps) *attachmentError {
+	var e = &attachmentError{
+		timestamp: time.Now(),
+		resource:  "messaging:attachment",
+		error:     "failedToScanFile",
+		action:    "error",
+		message:   "could not scan file",
+		log:       "could not scan file",
+		severity:  actionlog.Alert,
+		props: func() *attachmentActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
+// AttachmentErrRejected returns "messaging:attachment.rejected" audit event as actionlog.Alert
+//
+//
+// This function is auto-generated.
+//
+func AttachmentErrRejected(props ...*attachmentActionProps) *attachmentError {
+	var e = &attachmentError{
+		timestamp: time.Now(),
+		resource:  "messaging:attachment",
+		error:     "rejected",
+		action:    "error",
+		message:   "{err}",
+		log:       "rejected upload of {name}: {err}",
+		severity:  actionlog.Alert,
+		props: func() *attachmentActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
+// AttachmentErrChannelQuotaExceeded returns "messaging:attachment.channelQuotaExceeded" audit event as actionlog.Alert
+//
+//
+// This function is auto-generated.
+//
+func AttachmentErrChannelQuotaExceeded(props ...*attachmentActionProps) *attachmentError {
+	var e = &attachmentError{
+		timestamp: time.Now(),
+		resource:  "messaging:attachment",
+		error:     "channelQuotaExceeded",
+		action:    "error",
+		message:   "channel storage quota exceeded",
+		log:       "could not store {name} ({size} bytes), channel storage quota exceeded",
+		severity:  actionlog.Alert,
+		props: func() *attachmentActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
+// AttachmentErrUserQuotaExceeded returns "messaging:attachment.userQuotaExceeded" audit event as actionlog.Alert
+//
+//
+// This function is auto-generated.
+//
+func AttachmentErrUserQuotaExceeded(props ...*attachmentActionProps) *attachmentError {
+	var e = &attachmentError{
+		timestamp: time.Now(),
+		resource:  "messaging:attachment",
+		error:     "userQuotaExceeded",
+		action:    "error",
+		message:   "user storage quota exceeded",
+		log:       "could not store {name} ({size} bytes), user storage quota exceeded",
+		severity:  actionlog.Alert,
+		props: func() *attachmentActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
 // AttachmentErrNotAllowedToAttachToChannel returns "messaging:attachment.notAllowedToAttachToChannel" audit event as actionlog.Alert
 //
 //
@@ -14,6 +14,7 @@ import (
 	"github.com/cortezaproject/corteza-server/pkg/app/options"
 	intAuth "github.com/cortezaproject/corteza-server/pkg/auth"
 	"github.com/cortezaproject/corteza-server/pkg/permissions"
+	"github.com/cortezaproject/corteza-server/pkg/scan"
 	"github.com/cortezaproject/corteza-server/pkg/settings"
 	"github.com/cortezaproject/corteza-server/pkg/store"
 	"github.com/cortezaproject/corteza-server/pkg/store/minio"
@@ -31,8 +32,10 @@ type (
 	}
 
 	Config struct {
-		ActionLog options.ActionLogOpt
-		Storage   options.StorageOpt
+		ActionLog  options.ActionLogOpt
+		Storage    options.StorageOpt
+		UploadScan options.UploadScanOpt
+		Attachment options.AttachmentOpt
 	}
 )
 
@@ -40,6 +43,15 @@ var (
 	DefaultStore       store.Store
 	DefaultPermissions permissionServicer
 
+	// DefaultUploadScanner inspects uploaded files before they are stored
+	DefaultUploadScanner scan.Scanner
+
+	// DefaultAttachmentQuota limits number of bytes stored by attachments
+	DefaultAttachmentQuota *AttachmentQuota
+
+	// DefaultAttachmentRetention removes orphaned attachments
+	DefaultAttachmentRetention *attachmentRetention
+
 	DefaultLogger *zap.Logger
 
 	DefaultActionlog actionlog.Recorder
@@ -114,6 +126,7 @@ func Initialize(ctx context.Context, log *zap.Logger, c Config) (err error) {
 				SecretAccessKey: c.Storage.MinioSecretKey,
 
 				ServerSideEncryptKey: []byte(c.Storage.MinioSSECKey),
+				PresignTTL:           c.Storage.MinioPresignTTL,
 			})
 
 			log.Info("initializing minio",
@@ -136,6 +149,19 @@ func Initialize(ctx context.Context, log *zap.Logger, c Config) (err error) {
 
 	hcd.Add(store.Healthcheck(DefaultStore), "Store/Messaging")
 
+	if DefaultUploadScanner == nil {
+		// Do not override scanner stored under DefaultUploadScanner
+		// to allow integration tests to inject own scanner
+		DefaultUploadScanner = scan.New(c.UploadScan)
+	}
+
+	DefaultAttachmentQuota = &AttachmentQuota{
+		Channel: int64(c.Attachment.ChannelQuota),
+		User:    int64(c.Attachment.MessagingUserQuota),
+	}
+
+	DefaultAttachmentRetention = AttachmentRetention(DefaultStore, c.Attachment)
+
 	DefaultEvent = Event(ctx)
 	DefaultChannel = Channel(ctx)
 	DefaultAttachment = Attachment(ctx, DefaultStore)
@@ -157,6 +183,9 @@ func Activate(ctx context.Context) (err error) {
 
 func Watchers(ctx context.Context) {
 	DefaultPermissions.Watch(ctx)
+
+	// Removing orphaned attachments
+	DefaultAttachmentRetention.Watch(ctx)
 }
 
 func timeNowPtr() *time.Time {
@@ -16,6 +16,7 @@ type (
 		PreviewUrl string         `db:"preview_url"json:"previewUrl,omitempty"`
 		Name       string         `db:"name"       json:"name,omitempty"`
 		Meta       attachmentMeta `db:"meta"       json:"meta"`
+		Size       int64          `db:"size"       json:"-"`
 		CreatedAt  time.Time      `db:"created_at" json:"createdAt,omitempty"`
 		UpdatedAt  *time.Time     `db:"updated_at" json:"updatedAt,omitempty"`
 		DeletedAt  *time.Time     `db:"deleted_at" json:"deletedAt,omitempty"`
@@ -42,6 +43,17 @@ type (
 	MessageAttachment struct {
 		Attachment
 		MessageID uint64 `db:"rel_message" json:"-"`
+		ChannelID uint64 `db:"rel_channel" json:"-"`
+	}
+
+	AttachmentFilter struct {
+		// Free-text query, matched against the indexed content
+		Query string
+
+		// Only attachments posted to these channels
+		ChannelID []uint64
+
+		Limit uint
 	}
 )
 
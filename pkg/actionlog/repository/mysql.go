@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"github.com/Masterminds/squirrel"
 	"github.com/cortezaproject/corteza-server/pkg/actionlog"
+	"github.com/cortezaproject/corteza-server/pkg/db/dialect"
 	"github.com/cortezaproject/corteza-server/pkg/rh"
 	"github.com/titpetric/factory"
 	"time"
@@ -135,7 +136,7 @@ func (r *mysql) Record(ctx context.Context, e *actionlog.Action) error {
 		return fmt.Errorf("could not format auditlog event: %w", err)
 	}
 
-	return r.dbh.With(ctx).InsertIgnore(r.tbl, event{
+	return dialect.InsertIgnore(r.dbh.With(ctx), r.tbl, event{
 		Timestamp:     e.Timestamp,
 		RequestOrigin: e.RequestOrigin,
 		RequestID:     e.RequestID,
@@ -6,21 +6,24 @@ import (
 
 type (
 	Options struct {
-		ActionLog  options.ActionLogOpt
-		SMTP       options.SMTPOpt
-		Auth       options.AuthOpt
-		HTTPClient options.HTTPClientOpt
-		DB         options.DBOpt
-		Upgrade    options.UpgradeOpt
-		Provision  options.ProvisionOpt
-		Sentry     options.SentryOpt
-		Storage    options.StorageOpt
-		Corredor   options.CorredorOpt
-		Monitor    options.MonitorOpt
-		WaitFor    options.WaitForOpt
-		HTTPServer options.HTTPServerOpt
-		GRPCServer options.GRPCServerOpt
-		Websocket  options.WebsocketOpt
+		ActionLog   options.ActionLogOpt
+		SMTP        options.SMTPOpt
+		Auth        options.AuthOpt
+		HTTPClient  options.HTTPClientOpt
+		DB          options.DBOpt
+		Upgrade     options.UpgradeOpt
+		Provision   options.ProvisionOpt
+		Sentry      options.SentryOpt
+		Storage     options.StorageOpt
+		Corredor    options.CorredorOpt
+		Monitor     options.MonitorOpt
+		WaitFor     options.WaitForOpt
+		HTTPServer  options.HTTPServerOpt
+		GRPCServer  options.GRPCServerOpt
+		Websocket   options.WebsocketOpt
+		ReportCache options.ReportCacheOpt
+		UploadScan  options.UploadScanOpt
+		Attachment  options.AttachmentOpt
 	}
 )
 
@@ -31,20 +34,23 @@ func NewOptions(prefix ...string) *Options {
 	}
 
 	return &Options{
-		ActionLog:  *options.ActionLog(),
-		Auth:       *options.Auth(),
-		SMTP:       *options.SMTP(p),
-		HTTPClient: *options.HttpClient(p),
-		DB:         *options.DB(p),
-		Upgrade:    *options.Upgrade(p),
-		Provision:  *options.Provision(p),
-		Sentry:     *options.Sentry(p),
-		Storage:    *options.Storage(p),
-		Corredor:   *options.Corredor(),
-		Monitor:    *options.Monitor(p),
-		WaitFor:    *options.WaitFor(p),
-		HTTPServer: *options.HTTP(p),
-		GRPCServer: *options.GRPCServer(p),
-		Websocket:  *options.Websocket(p),
+		ActionLog:   *options.ActionLog(),
+		Auth:        *options.Auth(),
+		SMTP:        *options.SMTP(p),
+		HTTPClient:  *options.HttpClient(p),
+		DB:          *options.DB(p),
+		Upgrade:     *options.Upgrade(p),
+		Provision:   *options.Provision(p),
+		Sentry:      *options.Sentry(p),
+		Storage:     *options.Storage(p),
+		Corredor:    *options.Corredor(),
+		Monitor:     *options.Monitor(p),
+		WaitFor:     *options.WaitFor(p),
+		HTTPServer:  *options.HTTP(p),
+		GRPCServer:  *options.GRPCServer(p),
+		Websocket:   *options.Websocket(p),
+		ReportCache: *options.ReportCache(p),
+		UploadScan:  *options.UploadScan(p),
+		Attachment:  *options.Attachment(p),
 	}
 }
@@ -0,0 +1,39 @@
+package options
+
+import (
+	"time"
+)
+
+type (
+	AttachmentOpt struct {
+		// Max number of bytes stored per namespace (compose), channel (messaging)
+		// and per user; quota is not enforced when 0
+		//
+		// User quotas are accounted per service: compose and messaging each
+		// limit only attachments stored by them, system attachments are not limited
+		//
+		// Only uploaded (original) files are counted; previews are generated
+		// by the server, are a few KB at most (320x180 JPEG) and can not be
+		// influenced by the user
+		NamespaceQuota     int `env:"ATTACHMENT_NAMESPACE_QUOTA"`
+		ChannelQuota       int `env:"ATTACHMENT_CHANNEL_QUOTA"`
+		ComposeUserQuota   int `env:"ATTACHMENT_COMPOSE_USER_QUOTA"`
+		MessagingUserQuota int `env:"ATTACHMENT_MESSAGING_USER_QUOTA"`
+
+		// Orphaned attachments (of deleted records or messages) are removed
+		// after the given number of days; retention is disabled when 0
+		RetentionDays     int           `env:"ATTACHMENT_RETENTION_DAYS"`
+		RetentionInterval time.Duration `env:"ATTACHMENT_RETENTION_INTERVAL"`
+		RetentionDryRun   bool          `env:"ATTACHMENT_RETENTION_DRY_RUN"`
+	}
+)
+
+func Attachment(pfix string) (o *AttachmentOpt) {
+	o = &AttachmentOpt{
+		RetentionInterval: time.Hour * 24,
+	}
+
+	fill(o, pfix)
+
+	return
+}
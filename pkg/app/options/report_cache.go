@@ -0,0 +1,23 @@
+package options
+
+import (
+	"time"
+)
+
+type (
+	ReportCacheOpt struct {
+		Enabled bool          `env:"REPORT_CACHE_ENABLED"`
+		TTL     time.Duration `env:"REPORT_CACHE_TTL"`
+	}
+)
+
+func ReportCache(pfix string) (o *ReportCacheOpt) {
+	o = &ReportCacheOpt{
+		Enabled: false,
+		TTL:     time.Minute * 5,
+	}
+
+	fill(o, pfix)
+
+	return
+}
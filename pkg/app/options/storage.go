@@ -1,5 +1,9 @@
 package options
 
+import (
+	"time"
+)
+
 type (
 	StorageOpt struct {
 		Path string `env:"STORAGE_PATH"`
@@ -11,6 +15,10 @@ type (
 		MinioSSECKey   string `env:"MINIO_SSEC_KEY"`
 		MinioBucket    string `env:"MINIO_BUCKET"`
 		MinioStrict    bool   `env:"MINIO_STRICT"`
+
+		// How long are pre-signed (direct download and upload) URLs valid;
+		// files are streamed through the server when 0
+		MinioPresignTTL time.Duration `env:"MINIO_PRESIGN_TTL"`
 	}
 )
 
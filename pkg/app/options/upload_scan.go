@@ -0,0 +1,31 @@
+package options
+
+import (
+	"time"
+)
+
+type (
+	UploadScanOpt struct {
+		// Address of the ClamAV daemon (tcp://host:port or unix:///path/to/clamd.ctl),
+		// files are not scanned for malware when empty
+		ClamdAddr    string        `env:"UPLOAD_SCAN_CLAMD_ADDR"`
+		ClamdTimeout time.Duration `env:"UPLOAD_SCAN_CLAMD_TIMEOUT"`
+
+		// Comma separated lists of (sniffed) mimetypes and file extensions;
+		// mimetypes can use wildcards (image/*)
+		AllowedMimetypes  string `env:"UPLOAD_SCAN_ALLOWED_MIMETYPES"`
+		DeniedMimetypes   string `env:"UPLOAD_SCAN_DENIED_MIMETYPES"`
+		AllowedExtensions string `env:"UPLOAD_SCAN_ALLOWED_EXTENSIONS"`
+		DeniedExtensions  string `env:"UPLOAD_SCAN_DENIED_EXTENSIONS"`
+	}
+)
+
+func UploadScan(pfix string) (o *UploadScanOpt) {
+	o = &UploadScanOpt{
+		ClamdTimeout: time.Second * 30,
+	}
+
+	fill(o, pfix)
+
+	return
+}
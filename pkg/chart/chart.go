@@ -0,0 +1,496 @@
+package chart
+
+import (
+	"fmt"
+	"image/color"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type (
+	Kind string
+
+	// Chart holds labels and datasets of the rendered chart
+	Chart struct {
+		Title    string
+		Labels   []string
+		Datasets []*Dataset
+
+		// Colors are used for datasets (and slices of pie & doughnut charts)
+		// without an explicit color
+		Colors []color.RGBA
+	}
+
+	Dataset struct {
+		Label  string
+		Kind   Kind
+		Values []float64
+
+		// Transparent color (zero value) is replaced with one of the chart colors
+		Color color.RGBA
+	}
+
+	// canvas is implemented by each of the supported image formats
+	canvas interface {
+		polygon(c color.RGBA, pp ...point)
+		polyline(c color.RGBA, width float64, pp ...point)
+		text(c color.RGBA, x, y float64, a anchor, s string)
+		encode(w io.Writer) error
+	}
+
+	point struct {
+		x, y float64
+	}
+
+	anchor int
+)
+
+const (
+	Bar      Kind = "bar"
+	Line     Kind = "line"
+	Pie      Kind = "pie"
+	Doughnut Kind = "doughnut"
+
+	SVG = "svg"
+	PNG = "png"
+
+	MinSize = 100
+	MaxSize = 4096
+
+	// Layout is measured in size of the (monospaced) font glyphs
+	padding    = 10.0
+	charWidth  = 7.0
+	lineHeight = 13.0
+	ascent     = 10.0
+	swatchSize = 10.0
+)
+
+const (
+	anchorStart anchor = iota
+	anchorMiddle
+	anchorEnd
+)
+
+var (
+	textColor = color.RGBA{R: 0x33, G: 0x33, B: 0x33, A: 0xff}
+	gridColor = color.RGBA{R: 0xe0, G: 0xe0, B: 0xe0, A: 0xff}
+	axisColor = color.RGBA{R: 0x99, G: 0x99, B: 0x99, A: 0xff}
+	white     = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+)
+
+// IsValidKind checks if the kind of the dataset is supported
+func IsValidKind(k Kind) bool {
+	switch k {
+	case Bar, Line, Pie, Doughnut:
+		return true
+	}
+
+	return false
+}
+
+// IsValidFormat checks if chart can be rendered in the given image format
+func IsValidFormat(format string) bool {
+	return format == SVG || format == PNG
+}
+
+// Render draws the chart as an image of the given format (svg or png) and size
+func Render(w io.Writer, c *Chart, format string, width, height int) error {
+	var cv canvas
+
+	if width < MinSize || width > MaxSize || height < MinSize || height > MaxSize {
+		return fmt.Errorf("chart size must be between %d and %d pixels", MinSize, MaxSize)
+	}
+
+	switch format {
+	case SVG:
+		cv = newSvgCanvas(width, height)
+	case PNG:
+		cv = newPngCanvas(width, height)
+	default:
+		return fmt.Errorf("unsupported chart image format %q", format)
+	}
+
+	c.draw(cv, float64(width), float64(height))
+	return cv.encode(w)
+}
+
+// color returns explicit color of the dataset or i-th chart color
+func (c *Chart) color(i int, explicit color.RGBA) color.RGBA {
+	if explicit.A > 0 {
+		return explicit
+	}
+
+	if len(c.Colors) == 0 {
+		return Scheme(DefaultScheme)[i%len(Scheme(DefaultScheme))]
+	}
+
+	return c.Colors[i%len(c.Colors)]
+}
+
+// isRadial returns true when chart starts with pie or doughnut dataset
+//
+// Cartesian datasets (bars, lines) can not be mixed with radial ones;
+// only datasets of the same group as the first one are drawn
+func (c *Chart) isRadial() bool {
+	return len(c.Datasets) > 0 && (c.Datasets[0].Kind == Pie || c.Datasets[0].Kind == Doughnut)
+}
+
+func (c *Chart) draw(cv canvas, width, height float64) {
+	var (
+		x, y, w, h = padding, padding, width - 2*padding, height - 2*padding
+	)
+
+	cv.polygon(white, point{0, 0}, point{width, 0}, point{width, height}, point{0, height})
+
+	if c.Title != "" {
+		cv.text(textColor, width/2, y+ascent, anchorMiddle, c.Title)
+		y += lineHeight + padding
+		h -= lineHeight + padding
+	}
+
+	h -= c.drawLegend(cv, x, y+h, w)
+
+	if w <= 0 || h <= 0 {
+		return
+	}
+
+	if c.isRadial() {
+		c.drawRadial(cv, x, y, w, h)
+	} else {
+		c.drawCartesian(cv, x, y, w, h)
+	}
+}
+
+// drawLegend draws legend (one item per dataset or per slice) at the bottom
+// and returns height it takes
+func (c *Chart) drawLegend(cv canvas, x, bottom, w float64) float64 {
+	type item struct {
+		label string
+		color color.RGBA
+	}
+
+	var (
+		items []item
+		rows  [][]item
+		row   []item
+		rowW  float64
+	)
+
+	if c.isRadial() {
+		for i, l := range c.Labels {
+			items = append(items, item{l, c.color(i, color.RGBA{})})
+		}
+	} else {
+		for i, ds := range c.Datasets {
+			if ds.Kind == Pie || ds.Kind == Doughnut {
+				continue
+			}
+
+			items = append(items, item{ds.Label, c.color(i, ds.Color)})
+		}
+	}
+
+	if len(items) == 0 {
+		return 0
+	}
+
+	itemWidth := func(i item) float64 {
+		return swatchSize + padding/2 + textWidth(i.label) + padding
+	}
+
+	for _, i := range items {
+		if len(row) > 0 && rowW+itemWidth(i) > w {
+			rows = append(rows, row)
+			row, rowW = nil, 0
+		}
+
+		row = append(row, i)
+		rowW += itemWidth(i)
+	}
+
+	rows = append(rows, row)
+
+	for r, row := range rows {
+		var (
+			rowW float64
+			ry   = bottom - float64(len(rows)-r)*lineHeight
+		)
+
+		for _, i := range row {
+			rowW += itemWidth(i)
+		}
+
+		// Rows are centered
+		ix := x + math.Max(0, (w-rowW)/2)
+		for _, i := range row {
+			cv.polygon(i.color, rect(ix, ry+1, swatchSize, swatchSize)...)
+			cv.text(textColor, ix+swatchSize+padding/2, ry+ascent, anchorStart, i.label)
+			ix += itemWidth(i)
+		}
+	}
+
+	return float64(len(rows))*lineHeight + padding
+}
+
+// drawRadial draws pie & doughnut datasets as concentric rings
+func (c *Chart) drawRadial(cv canvas, x, y, w, h float64) {
+	var (
+		dd []*Dataset
+
+		cx, cy = x + w/2, y + h/2
+		radius = math.Min(w, h) / 2
+		cutout float64
+	)
+
+	for _, ds := range c.Datasets {
+		if ds.Kind == Pie || ds.Kind == Doughnut {
+			dd = append(dd, ds)
+		}
+	}
+
+	if dd[0].Kind == Doughnut {
+		cutout = radius / 2
+	}
+
+	band := (radius - cutout) / float64(len(dd))
+
+	for d, ds := range dd {
+		var (
+			outer = radius - float64(d)*band
+			inner = outer - band
+			total float64
+			angle = -math.Pi / 2
+		)
+
+		for _, v := range ds.Values {
+			total += math.Max(0, v)
+		}
+
+		if total == 0 {
+			continue
+		}
+
+		for i, v := range ds.Values {
+			if v <= 0 {
+				continue
+			}
+
+			sweep := 2 * math.Pi * v / total
+			cv.polygon(c.color(i, color.RGBA{}), slice(cx, cy, inner, outer, angle, sweep)...)
+
+			// White separator between slices
+			cv.polyline(white, 1, polar(cx, cy, inner, angle), polar(cx, cy, outer, angle))
+			angle += sweep
+		}
+	}
+}
+
+// drawCartesian draws bar & line datasets with value (y) and category (x) axes
+func (c *Chart) drawCartesian(cv canvas, x, y, w, h float64) {
+	var (
+		bars  []*Dataset
+		lines []*Dataset
+
+		min, max float64
+		n        = len(c.Labels)
+	)
+
+	for _, ds := range c.Datasets {
+		switch ds.Kind {
+		case Bar:
+			bars = append(bars, ds)
+		case Line:
+			lines = append(lines, ds)
+		default:
+			continue
+		}
+
+		for _, v := range ds.Values {
+			min, max = math.Min(min, v), math.Max(max, v)
+		}
+
+		if len(ds.Values) > n {
+			n = len(ds.Values)
+		}
+	}
+
+	if n == 0 {
+		return
+	}
+
+	ticks, decimals := axisTicks(min, max)
+	min, max = ticks[0], ticks[len(ticks)-1]
+
+	var (
+		left float64
+		tl   = make([]string, len(ticks))
+	)
+
+	for t, v := range ticks {
+		tl[t] = strconv.FormatFloat(v, 'f', decimals, 64)
+		left = math.Max(left, textWidth(tl[t]))
+	}
+
+	var (
+		// plot area
+		px, py = x + left + padding/2, y + ascent/2
+		pw, ph = w - left - padding/2, h - ascent/2 - lineHeight - padding/2
+		group  = pw / float64(n)
+
+		valueY = func(v float64) float64 {
+			return py + ph - (v-min)/(max-min)*ph
+		}
+
+		colorOf = func(ds *Dataset) color.RGBA {
+			for i := range c.Datasets {
+				if c.Datasets[i] == ds {
+					return c.color(i, ds.Color)
+				}
+			}
+
+			return ds.Color
+		}
+	)
+
+	if pw <= 0 || ph <= 0 {
+		return
+	}
+
+	for t, v := range ticks {
+		cv.polyline(gridColor, 1, point{px, valueY(v)}, point{px + pw, valueY(v)})
+		cv.text(textColor, px-padding/2, valueY(v)+ascent/2, anchorEnd, tl[t])
+	}
+
+	cv.polyline(axisColor, 1, point{px, py}, point{px, py + ph})
+	cv.polyline(axisColor, 1, point{px, valueY(0)}, point{px + pw, valueY(0)})
+
+	// Skip category labels that would overlap
+	var (
+		labelW float64
+		step   = 1
+	)
+
+	for _, l := range c.Labels {
+		labelW = math.Max(labelW, textWidth(l)+padding)
+	}
+
+	if labelW > group {
+		step = int(math.Ceil(labelW / group))
+	}
+
+	for i := 0; i < len(c.Labels); i += step {
+		cv.text(textColor, px+(float64(i)+0.5)*group, py+ph+lineHeight, anchorMiddle, c.Labels[i])
+	}
+
+	if len(bars) > 0 {
+		bw := group * 0.8 / float64(len(bars))
+		for b, ds := range bars {
+			for i, v := range ds.Values {
+				bx := px + float64(i)*group + group*0.1 + float64(b)*bw
+				top, bottom := valueY(math.Max(0, v)), valueY(math.Min(0, v))
+				cv.polygon(colorOf(ds), rect(bx, top, bw, bottom-top)...)
+			}
+		}
+	}
+
+	for _, ds := range lines {
+		pp := make([]point, len(ds.Values))
+		for i, v := range ds.Values {
+			pp[i] = point{px + (float64(i)+0.5)*group, valueY(v)}
+		}
+
+		cv.polyline(colorOf(ds), 2, pp...)
+
+		for _, p := range pp {
+			cv.polygon(colorOf(ds), rect(p.x-2, p.y-2, 4, 4)...)
+		}
+	}
+}
+
+// axisTicks splits value range into (up to 5) round steps
+//
+// Returns values of the ticks and number of decimals needed to format them
+func axisTicks(min, max float64) ([]float64, int) {
+	if max == min {
+		max = min + 1
+	}
+
+	var (
+		raw  = (max - min) / 5
+		mag  = math.Pow(10, math.Floor(math.Log10(raw)))
+		step = mag
+	)
+
+	for _, m := range []float64{1, 2, 2.5, 5, 10} {
+		if step = m * mag; step >= raw {
+			break
+		}
+	}
+
+	var (
+		ticks    []float64
+		decimals = int(math.Max(0, -math.Floor(math.Log10(step))))
+	)
+
+	if f := step * math.Pow(10, float64(decimals)); math.Abs(f-math.Round(f)) > 1e-9 {
+		// 2.5 steps need one more decimal
+		decimals++
+	}
+
+	for t, start := 0.0, math.Floor(min/step)*step; start+t*step < max+step/2; t++ {
+		ticks = append(ticks, start+t*step)
+	}
+
+	if len(ticks) == 1 {
+		ticks = append(ticks, ticks[0]+step)
+	}
+
+	return ticks, decimals
+}
+
+func textWidth(s string) float64 {
+	return float64(len([]rune(s))) * charWidth
+}
+
+func rect(x, y, w, h float64) []point {
+	return []point{{x, y}, {x + w, y}, {x + w, y + h}, {x, y + h}}
+}
+
+func polar(cx, cy, r, angle float64) point {
+	return point{cx + r*math.Cos(angle), cy + r*math.Sin(angle)}
+}
+
+// slice approximates ring (or pie) slice with a polygon
+func slice(cx, cy, inner, outer, start, sweep float64) []point {
+	var (
+		pp    []point
+		steps = int(math.Ceil(sweep/(math.Pi/90))) + 1
+	)
+
+	for s := 0; s <= steps; s++ {
+		pp = append(pp, polar(cx, cy, outer, start+sweep*float64(s)/float64(steps)))
+	}
+
+	if inner <= 0 {
+		return append(pp, point{cx, cy})
+	}
+
+	for s := steps; s >= 0; s-- {
+		pp = append(pp, polar(cx, cy, inner, start+sweep*float64(s)/float64(steps)))
+	}
+
+	return pp
+}
+
+// escape replaces characters that can not be drawn with the built-in font
+func escape(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < ' ' {
+			return ' '
+		}
+
+		return r
+	}, s)
+}
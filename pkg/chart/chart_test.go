@@ -0,0 +1,109 @@
+package chart
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testChart() *Chart {
+	return &Chart{
+		Title:  "Revenue",
+		Labels: []string{"2020-01", "2020-02", "2020-03"},
+		Datasets: []*Dataset{
+			{Label: "revenue", Kind: Bar, Values: []float64{10, 25.5, -4}},
+			{Label: "deals", Kind: Line, Values: []float64{3, 8, 6}, Color: color.RGBA{R: 0xff, A: 0xff}},
+		},
+	}
+}
+
+func TestRender_SVG(t *testing.T) {
+	var (
+		req = require.New(t)
+		buf = &bytes.Buffer{}
+	)
+
+	req.NoError(Render(buf, testChart(), SVG, 400, 200))
+
+	svg := buf.String()
+	req.True(strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" width="400" height="200"`))
+	req.True(strings.HasSuffix(svg, "</svg>\n"))
+	req.Contains(svg, ">Revenue</text>")
+	req.Contains(svg, ">2020-02</text>")
+	req.Contains(svg, ">deals</text>")
+	req.Contains(svg, `stroke="#ff0000"`)
+
+	// bars are filled with the first color of the default scheme
+	req.Contains(svg, `fill="#4e79a7"`)
+}
+
+func TestRender_PNG(t *testing.T) {
+	var (
+		req = require.New(t)
+		buf = &bytes.Buffer{}
+		c   = &Chart{
+			Labels:   []string{"a", "b"},
+			Datasets: []*Dataset{{Kind: Pie, Values: []float64{1, 1}}},
+			Colors:   Scheme("brewer.Set1-9"),
+		}
+	)
+
+	req.NoError(Render(buf, c, PNG, 200, 150))
+
+	img, err := png.Decode(buf)
+	req.NoError(err)
+	req.Equal(200, img.Bounds().Dx())
+	req.Equal(150, img.Bounds().Dy())
+
+	// left half of the pie is the second slice
+	r, g, b, _ := img.At(80, 60).RGBA()
+	req.Equal([]uint32{0x37, 0x7e, 0xb8}, []uint32{r >> 8, g >> 8, b >> 8})
+}
+
+func TestRender_errors(t *testing.T) {
+	req := require.New(t)
+
+	req.EqualError(Render(&bytes.Buffer{}, testChart(), "gif", 400, 200), `unsupported chart image format "gif"`)
+	req.Error(Render(&bytes.Buffer{}, testChart(), PNG, 10, 200))
+	req.Error(Render(&bytes.Buffer{}, testChart(), SVG, 400, MaxSize+1))
+}
+
+func TestAxisTicks(t *testing.T) {
+	tests := []struct {
+		min, max float64
+		ticks    []float64
+		decimals int
+	}{
+		{0, 0, []float64{0, 0.2, 0.4, 0.6, 0.8, 1}, 1},
+		{0, 40, []float64{0, 10, 20, 30, 40}, 0},
+		{-4, 25.5, []float64{-10, 0, 10, 20, 30}, 0},
+		{0, 12, []float64{0, 2.5, 5, 7.5, 10, 12.5}, 1},
+	}
+
+	for _, tt := range tests {
+		ticks, decimals := axisTicks(tt.min, tt.max)
+		require.InDeltaSlice(t, tt.ticks, ticks, 1e-9)
+		require.Equal(t, tt.decimals, decimals)
+	}
+}
+
+func TestParseColor(t *testing.T) {
+	req := require.New(t)
+
+	c, err := ParseColor("#4E79A7")
+	req.NoError(err)
+	req.Equal(color.RGBA{R: 0x4e, G: 0x79, B: 0xa7, A: 0xff}, c)
+
+	c, err = ParseColor("f00")
+	req.NoError(err)
+	req.Equal(color.RGBA{R: 0xff, A: 0xff}, c)
+
+	_, err = ParseColor("#ff00")
+	req.Error(err)
+
+	req.Len(Scheme("unknown"), len(Scheme(DefaultScheme)))
+}
@@ -0,0 +1,96 @@
+package chart
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+)
+
+type (
+	pngCanvas struct {
+		img *image.RGBA
+	}
+)
+
+func newPngCanvas(width, height int) *pngCanvas {
+	return &pngCanvas{img: image.NewRGBA(image.Rect(0, 0, width, height))}
+}
+
+func (cv *pngCanvas) polygon(c color.RGBA, pp ...point) {
+	if len(pp) < 3 {
+		return
+	}
+
+	r := cv.rasterizer()
+	r.MoveTo(float32(pp[0].x), float32(pp[0].y))
+	for _, p := range pp[1:] {
+		r.LineTo(float32(p.x), float32(p.y))
+	}
+
+	r.ClosePath()
+	r.Draw(cv.img, cv.img.Bounds(), image.NewUniform(c), image.Point{})
+}
+
+// polyline draws each segment of the line as a rectangle
+//
+// Segments are drawn separately; overlapping segments
+// of opposite directions would cancel each other out
+func (cv *pngCanvas) polyline(c color.RGBA, width float64, pp ...point) {
+	for i := 1; i < len(pp); i++ {
+		var (
+			a, b   = pp[i-1], pp[i]
+			dx, dy = b.x - a.x, b.y - a.y
+			l      = math.Hypot(dx, dy)
+		)
+
+		if l == 0 {
+			continue
+		}
+
+		// normal of the segment, half of the line width long
+		nx, ny := -dy/l*width/2, dx/l*width/2
+
+		cv.polygon(c,
+			point{a.x + nx, a.y + ny},
+			point{b.x + nx, b.y + ny},
+			point{b.x - nx, b.y - ny},
+			point{a.x - nx, a.y - ny},
+		)
+	}
+}
+
+func (cv *pngCanvas) text(c color.RGBA, x, y float64, a anchor, s string) {
+	d := &font.Drawer{
+		Dst:  cv.img,
+		Src:  image.NewUniform(c),
+		Face: basicfont.Face7x13,
+	}
+
+	s = escape(s)
+
+	switch a {
+	case anchorMiddle:
+		x -= float64(d.MeasureString(s).Ceil()) / 2
+	case anchorEnd:
+		x -= float64(d.MeasureString(s).Ceil())
+	}
+
+	d.Dot = fixed.P(int(math.Round(x)), int(math.Round(y)))
+	d.DrawString(s)
+}
+
+func (cv *pngCanvas) encode(w io.Writer) error {
+	return png.Encode(w, cv.img)
+}
+
+func (cv *pngCanvas) rasterizer() *vector.Rasterizer {
+	b := cv.img.Bounds()
+	return vector.NewRasterizer(b.Dx(), b.Dy())
+}
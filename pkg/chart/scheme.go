@@ -0,0 +1,63 @@
+package chart
+
+import (
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+)
+
+const (
+	DefaultScheme = "tableau.Tableau10"
+)
+
+var (
+	// schemes are named the same way as color schemes of the chart configuration
+	schemes = map[string][]string{
+		"tableau.Tableau10": {"#4e79a7", "#f28e2b", "#e15759", "#76b7b2", "#59a14f", "#edc948", "#b07aa1", "#ff9da7", "#9c755f", "#bab0ac"},
+		"tableau.Classic10": {"#1f77b4", "#ff7f0e", "#2ca02c", "#d62728", "#9467bd", "#8c564b", "#e377c2", "#7f7f7f", "#bcbd22", "#17becf"},
+		"brewer.Paired12":   {"#a6cee3", "#1f78b4", "#b2df8a", "#33a02c", "#fb9a99", "#e31a1c", "#fdbf6f", "#ff7f00", "#cab2d6", "#6a3d9a", "#ffff99", "#b15928"},
+		"brewer.Set1-9":     {"#e41a1c", "#377eb8", "#4daf4a", "#984ea3", "#ff7f00", "#ffff33", "#a65628", "#f781bf", "#999999"},
+		"brewer.Set2-8":     {"#66c2a5", "#fc8d62", "#8da0cb", "#e78ac3", "#a6d854", "#ffd92f", "#e5c494", "#b3b3b3"},
+		"office.Office6":    {"#4472c4", "#ed7d31", "#a5a5a5", "#ffc000", "#5b9bd5", "#70ad47"},
+	}
+)
+
+// Scheme returns colors of the named color scheme
+//
+// Colors of the default scheme are returned for unknown schemes
+func Scheme(name string) []color.RGBA {
+	hh, has := schemes[name]
+	if !has {
+		hh = schemes[DefaultScheme]
+	}
+
+	cc := make([]color.RGBA, len(hh))
+	for i, h := range hh {
+		cc[i], _ = ParseColor(h)
+	}
+
+	return cc
+}
+
+// ParseColor parses hex color (#rgb or #rrggbb)
+func ParseColor(s string) (color.RGBA, error) {
+	var (
+		h = strings.TrimPrefix(strings.TrimSpace(s), "#")
+	)
+
+	if len(h) == 3 {
+		h = string([]byte{h[0], h[0], h[1], h[1], h[2], h[2]})
+	}
+
+	if len(h) != 6 {
+		return color.RGBA{}, fmt.Errorf("invalid color %q", s)
+	}
+
+	v, err := strconv.ParseUint(h, 16, 32)
+	if err != nil {
+		return color.RGBA{}, fmt.Errorf("invalid color %q", s)
+	}
+
+	return color.RGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 0xff}, nil
+}
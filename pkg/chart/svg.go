@@ -0,0 +1,80 @@
+package chart
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"image/color"
+	"io"
+)
+
+type (
+	svgCanvas struct {
+		buf bytes.Buffer
+	}
+)
+
+func newSvgCanvas(width, height int) *svgCanvas {
+	cv := &svgCanvas{}
+	fmt.Fprintf(
+		&cv.buf,
+		`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		width, height, width, height,
+	)
+
+	return cv
+}
+
+func (cv *svgCanvas) polygon(c color.RGBA, pp ...point) {
+	fmt.Fprintf(&cv.buf, `<polygon points="%s" fill="%s"/>`+"\n", svgPoints(pp), svgColor(c))
+}
+
+func (cv *svgCanvas) polyline(c color.RGBA, width float64, pp ...point) {
+	fmt.Fprintf(
+		&cv.buf,
+		`<polyline points="%s" fill="none" stroke="%s" stroke-width="%.1f" stroke-linejoin="round"/>`+"\n",
+		svgPoints(pp), svgColor(c), width,
+	)
+}
+
+func (cv *svgCanvas) text(c color.RGBA, x, y float64, a anchor, s string) {
+	var ta = "start"
+	switch a {
+	case anchorMiddle:
+		ta = "middle"
+	case anchorEnd:
+		ta = "end"
+	}
+
+	fmt.Fprintf(
+		&cv.buf,
+		`<text x="%.1f" y="%.1f" text-anchor="%s" font-family="monospace" font-size="12" fill="%s">`,
+		x, y, ta, svgColor(c),
+	)
+
+	_ = xml.EscapeText(&cv.buf, []byte(escape(s)))
+	cv.buf.WriteString("</text>\n")
+}
+
+func (cv *svgCanvas) encode(w io.Writer) error {
+	cv.buf.WriteString("</svg>\n")
+	_, err := cv.buf.WriteTo(w)
+	return err
+}
+
+func svgPoints(pp []point) string {
+	var buf bytes.Buffer
+	for i, p := range pp {
+		if i > 0 {
+			buf.WriteByte(' ')
+		}
+
+		fmt.Fprintf(&buf, "%.1f,%.1f", p.x, p.y)
+	}
+
+	return buf.String()
+}
+
+func svgColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
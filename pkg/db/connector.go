@@ -2,7 +2,9 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -11,6 +13,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/cortezaproject/corteza-server/pkg/app/options"
+	"github.com/cortezaproject/corteza-server/pkg/db/dialect"
 	"github.com/cortezaproject/corteza-server/pkg/sentry"
 )
 
@@ -26,7 +29,28 @@ func TryToConnect(ctx context.Context, log *zap.Logger, opt options.DBOpt) (db *
 
 	name := "default"
 
-	factory.Database.Add(name, factory.DatabaseCredential{DSN: opt.DSN, DriverName: "mysql"})
+	dialectName, dsn := parseDSN(opt.DSN)
+	if err = dialect.Set(dialectName); err != nil {
+		return
+	}
+
+	switch dialectName {
+	case dialect.Postgres:
+		// Connector is used so that factory does not add MySQL options to the DSN
+		factory.Database.Add(name, factory.DatabaseCredential{
+			DriverName: postgresDriverName,
+			Connector:  connector(postgresDriverName, dsn),
+		})
+
+	case dialect.SQLite:
+		factory.Database.Add(name, factory.DatabaseCredential{
+			DriverName: sqliteDriverName,
+			Connector:  connector(sqliteDriverName, sqliteDSN(dsn)),
+		})
+
+	default:
+		factory.Database.Add(name, factory.DatabaseCredential{DSN: mysqlDSN(dsn), DriverName: "mysql"})
+	}
 
 	var (
 		connErrCh = make(chan error, 1)
@@ -42,6 +66,7 @@ func TryToConnect(ctx context.Context, log *zap.Logger, opt options.DBOpt) (db *
 
 	log.Debug("connecting to the database",
 		dsnField,
+		zap.String("dialect", dialectName),
 		zap.Int("tries", opt.MaxTries),
 		zap.Duration("delay", opt.Delay),
 		zap.Duration("timeout", opt.Timeout))
@@ -124,3 +149,56 @@ func TryToConnect(ctx context.Context, log *zap.Logger, opt options.DBOpt) (db *
 
 	return db, nil
 }
+
+// connector opens database with the given driver and checks the connection
+func connector(driverName, dsn string) func() (*sql.DB, error) {
+	return func() (*sql.DB, error) {
+		db, err := sql.Open(driverName, dsn)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = db.Ping(); err != nil {
+			_ = db.Close()
+			return nil, err
+		}
+
+		return db, nil
+	}
+}
+
+// parseDSN determines SQL dialect from the DSN scheme
+//
+// DSNs without scheme are considered MySQL DSNs for backward compatibility;
+// scheme is removed from MySQL and SQLite DSNs as drivers do not expect it
+func parseDSN(dsn string) (string, string) {
+	switch {
+	case strings.HasPrefix(dsn, "postgres://"), strings.HasPrefix(dsn, "postgresql://"):
+		return dialect.Postgres, dsn
+	case strings.HasPrefix(dsn, "sqlite3://"):
+		return dialect.SQLite, strings.TrimPrefix(dsn, "sqlite3://")
+	case strings.HasPrefix(dsn, "sqlite://"):
+		return dialect.SQLite, strings.TrimPrefix(dsn, "sqlite://")
+	case strings.HasPrefix(dsn, "mysql://"):
+		return dialect.MySQL, strings.TrimPrefix(dsn, "mysql://")
+	}
+
+	return dialect.MySQL, dsn
+}
+
+// mysqlDSN sets defaults for the database connection
+//
+// Percentiles in reports are calculated from the concatenated (ordered) values
+// so the limit for the length of the GROUP_CONCAT() result is raised (from 1024 bytes);
+// see dialect.MySQLPercentileMaxValues
+func mysqlDSN(dsn string) string {
+	if strings.Contains(dsn, "group_concat_max_len=") {
+		return dsn
+	}
+
+	if strings.Contains(dsn, "?") {
+		return dsn + "&group_concat_max_len=16777216"
+	}
+
+	return dsn + "?group_concat_max_len=16777216"
+}
@@ -0,0 +1,634 @@
+// Package dialect provides SQL fragments that differ between supported databases
+//
+// Repositories and query builders should use fragments from the current
+// dialect (set when connecting to the database) instead of hardcoding
+// database specific functions and casts.
+package dialect
+
+import (
+	"fmt"
+	"strings"
+)
+
+type (
+	Dialect interface {
+		// Name of the dialect (mysql, postgres, sqlite)
+		Name() string
+
+		// DecimalCast casts (string) expression into an exact decimal
+		DecimalCast(expr string, precision uint) string
+
+		// NumericCast casts numeric expression (result of an aggregation) into an exact decimal
+		NumericCast(expr string, precision uint) string
+
+		// DatetimeCast casts (string) expression into date & time
+		DatetimeCast(expr string) string
+
+		// Truthy checks if (string) expression holds a true-ish value
+		Truthy(expr string) string
+
+		// DateTimestamp returns unix timestamp of the date (without time) of the expression
+		DateTimestamp(expr string) string
+
+		// Like returns case insensitive LIKE (or NOT LIKE) operator
+		Like(not bool) string
+
+		// Interval returns interval expression with one placeholder for the value
+		Interval(unit string) string
+
+		// GroupConcat returns comma delimited list of expression values, ordered by value
+		GroupConcat(expr string) string
+
+		// Function renders function call from already rendered arguments
+		//
+		// Used to translate MySQL functions (ones that are supported in reports)
+		// into equivalents of the dialect
+		//
+		// PERCENTILE(expr, fraction) aggregate (continuous, with linear
+		// interpolation between values) is translated on all dialects
+		Function(name string, args []string) string
+
+		// DateFormat translates MySQL DATE_FORMAT format into format of the dialect
+		DateFormat(format string) string
+
+		// FullTextJoin returns join (table and condition) of the full-text index matches
+		// for the aliased table; empty when column is matched by FullTextMatch condition only
+		FullTextJoin(table, alias, column, terms string) (string, []interface{})
+
+		// FullTextMatch returns condition that matches column (of the aliased table)
+		// against free-text search terms using full-text index of the table
+		FullTextMatch(table, alias, column, terms string) (string, []interface{})
+
+		// FullTextRank returns relevance of the column value for the free-text search terms
+		FullTextRank(table, alias, column, terms string) (string, []interface{})
+
+		// OrderBy returns ORDER BY expression in the given direction
+		//
+		// NULL values are sorted first in ascending and last in descending order
+		OrderBy(expr string, desc bool) string
+
+		// Savepoint returns statements that create, roll back to and release the named savepoint
+		Savepoint(name string) (create, rollback, release string)
+
+		// InsertIgnore returns insert (with one placeholder per column) that skips
+		// rows conflicting with the existing ones on the primary key or unique index
+		InsertIgnore(table string, columns ...string) string
+
+		// InsertIgnoreSelect returns insert of the rows selected by the query
+		// that skips rows conflicting with the existing ones
+		InsertIgnoreSelect(table string, columns []string, query string) string
+
+		// Replace returns insert (with one placeholder per column) that replaces
+		// the existing row with the same values of the key (primary key) columns
+		Replace(table string, keys []string, columns ...string) string
+	}
+
+	mysql    struct{}
+	postgres struct{}
+	sqlite   struct{}
+)
+
+const (
+	MySQL    = "mysql"
+	Postgres = "postgres"
+	SQLite   = "sqlite"
+)
+
+const (
+	// MySQLPercentileMaxValues is the max number of values (rows in a group) percentile
+	// can be calculated from on MySQL; concatenated values, up to 68 bytes each,
+	// must fit into the GROUP_CONCAT() result (group_concat_max_len is set to 16MB on connect)
+	MySQLPercentileMaxValues = 200000
+)
+
+var (
+	current Dialect = mysql{}
+)
+
+// Current returns dialect of the connected database
+func Current() Dialect {
+	return current
+}
+
+// Set changes current dialect
+func Set(name string) error {
+	switch name {
+	case MySQL:
+		current = mysql{}
+	case Postgres:
+		current = postgres{}
+	case SQLite:
+		current = sqlite{}
+	default:
+		return fmt.Errorf("unsupported SQL dialect %q", name)
+	}
+
+	return nil
+}
+
+// Is checks if current dialect matches the name
+func Is(name string) bool {
+	return current.Name() == name
+}
+
+func (mysql) Name() string { return MySQL }
+
+func (mysql) DecimalCast(expr string, precision uint) string {
+	return fmt.Sprintf("CAST(%s AS DECIMAL(65,%d))", expr, precision)
+}
+
+func (d mysql) NumericCast(expr string, precision uint) string {
+	return d.DecimalCast(expr, precision)
+}
+
+func (mysql) DatetimeCast(expr string) string {
+	return fmt.Sprintf("CAST(%s AS DATETIME)", expr)
+}
+
+func (mysql) Truthy(expr string) string {
+	return fmt.Sprintf("(%s NOT IN ('', '0', 'false', 'f',  'FALSE', 'F', false))", expr)
+}
+
+func (mysql) DateTimestamp(expr string) string {
+	return fmt.Sprintf("UNIX_TIMESTAMP(DATE(%s))", expr)
+}
+
+func (mysql) Like(not bool) string {
+	if not {
+		return "COLLATE utf8_general_ci NOT LIKE"
+	}
+
+	return "COLLATE utf8_general_ci LIKE"
+}
+
+func (mysql) Interval(unit string) string {
+	return "INTERVAL ? " + unit
+}
+
+func (mysql) GroupConcat(expr string) string {
+	return fmt.Sprintf("GROUP_CONCAT(%s ORDER BY %s ASC SEPARATOR ',')", expr, expr)
+}
+
+// Function on MySQL
+//
+// There is no percentile aggregate function, values are concatenated
+// in order and the two nearest to the percentile are interpolated.
+//
+// Concatenated values are truncated when result exceeds group_concat_max_len;
+// callers must not calculate percentiles of more than MySQLPercentileMaxValues values
+func (d mysql) Function(name string, args []string) string {
+	if strings.ToUpper(name) == "PERCENTILE" && len(args) == 2 {
+		var (
+			list = d.GroupConcat(args[0])
+			pos  = fmt.Sprintf("(1 + %s * (COUNT(%s) - 1))", args[1], args[0])
+			nth  = func(n string) string {
+				return d.DecimalCast(fmt.Sprintf("SUBSTRING_INDEX(SUBSTRING_INDEX(%s, ',', %s), ',', -1)", list, n), 30)
+			}
+			lo = nth("FLOOR" + pos)
+			hi = nth("CEIL" + pos)
+		)
+
+		return fmt.Sprintf("(%s + (%s - %s) * (%s - FLOOR%s))", lo, hi, lo, pos, pos)
+	}
+
+	return fmt.Sprintf("%s(%s)", name, strings.Join(args, ", "))
+}
+
+func (mysql) DateFormat(format string) string {
+	return format
+}
+
+func (mysql) FullTextJoin(table, alias, column, terms string) (string, []interface{}) {
+	return "", nil
+}
+
+// FullTextMatch on MySQL
+//
+// Terms are matched in natural language mode against FULLTEXT index of the column
+func (mysql) FullTextMatch(table, alias, column, terms string) (string, []interface{}) {
+	return fmt.Sprintf("MATCH(%s.%s) AGAINST (? IN NATURAL LANGUAGE MODE)", alias, column), []interface{}{terms}
+}
+
+func (d mysql) FullTextRank(table, alias, column, terms string) (string, []interface{}) {
+	return d.FullTextMatch(table, alias, column, terms)
+}
+
+// OrderBy on MySQL
+//
+// NULL values are lower than any other value
+func (mysql) OrderBy(expr string, desc bool) string {
+	if desc {
+		return expr + " DESC"
+	}
+
+	return expr + " ASC"
+}
+
+// Savepoint on MySQL
+func (mysql) Savepoint(name string) (string, string, string) {
+	return "SAVEPOINT " + name, "ROLLBACK TO SAVEPOINT " + name, "RELEASE SAVEPOINT " + name
+}
+
+// InsertIgnore on MySQL
+func (d mysql) InsertIgnore(table string, columns ...string) string {
+	return d.InsertIgnoreSelect(table, columns, values(len(columns)))
+}
+
+func (mysql) InsertIgnoreSelect(table string, columns []string, query string) string {
+	return "INSERT IGNORE " + into(table, columns) + " " + query
+}
+
+// Replace on MySQL
+//
+// Rows conflicting on any unique index are replaced (deleted and inserted again)
+func (mysql) Replace(table string, keys []string, columns ...string) string {
+	return "REPLACE " + insertInto(table, columns)
+}
+
+func (postgres) Name() string { return Postgres }
+
+// DecimalCast on PostgreSQL
+//
+// Empty strings can not be cast into numeric and are cast as NULL
+func (postgres) DecimalCast(expr string, precision uint) string {
+	return fmt.Sprintf("CAST(NULLIF(%s, '') AS NUMERIC(65,%d))", expr, precision)
+}
+
+func (postgres) NumericCast(expr string, precision uint) string {
+	return fmt.Sprintf("CAST(%s AS NUMERIC(65,%d))", expr, precision)
+}
+
+func (postgres) DatetimeCast(expr string) string {
+	return fmt.Sprintf("CAST(NULLIF(%s, '') AS TIMESTAMP)", expr)
+}
+
+func (postgres) Truthy(expr string) string {
+	return fmt.Sprintf("(%s NOT IN ('', '0', 'false', 'f',  'FALSE', 'F'))", expr)
+}
+
+func (postgres) DateTimestamp(expr string) string {
+	return fmt.Sprintf("CAST(EXTRACT(EPOCH FROM DATE_TRUNC('day', %s)) AS BIGINT)", expr)
+}
+
+func (postgres) Like(not bool) string {
+	if not {
+		return "NOT ILIKE"
+	}
+
+	return "ILIKE"
+}
+
+func (postgres) Interval(unit string) string {
+	return "CAST(? || ' " + unit + "' AS INTERVAL)"
+}
+
+func (postgres) GroupConcat(expr string) string {
+	return fmt.Sprintf("STRING_AGG(CAST(%s AS TEXT), ',' ORDER BY %s ASC)", expr, expr)
+}
+
+// Function on PostgreSQL
+//
+// Values of record fields are stored as text and need to be cast into
+// timestamp before date & time functions are applied
+func (postgres) Function(name string, args []string) string {
+	var ts = func(expr string) string {
+		return fmt.Sprintf("CAST(%s AS TIMESTAMP)", expr)
+	}
+
+	switch n := strings.ToUpper(name); {
+	case (n == "QUARTER" || n == "YEAR") && len(args) == 1:
+		return fmt.Sprintf("EXTRACT(%s FROM %s)", n, ts(args[0]))
+
+	case n == "DATE" && len(args) == 1:
+		return fmt.Sprintf("CAST(%s AS DATE)", ts(args[0]))
+
+	case n == "DATE_FORMAT" && len(args) == 2:
+		return fmt.Sprintf("TO_CHAR(%s, %s)", ts(args[0]), args[1])
+
+	case n == "DATE_ADD" && len(args) == 2:
+		return fmt.Sprintf("(%s + %s)", ts(args[0]), args[1])
+
+	case n == "DATE_SUB" && len(args) == 2:
+		return fmt.Sprintf("(%s - %s)", ts(args[0]), args[1])
+
+	case n == "IF" && len(args) == 3:
+		return ifCase(args)
+
+	case n == "PERCENTILE" && len(args) == 2:
+		return fmt.Sprintf("PERCENTILE_CONT(%s) WITHIN GROUP (ORDER BY %s)", args[1], args[0])
+	}
+
+	return mysql{}.Function(name, args)
+}
+
+// DateFormat translates MySQL DATE_FORMAT specifiers into TO_CHAR patterns
+//
+// Literal text is quoted so that it is not interpreted as a pattern
+func (postgres) DateFormat(format string) string {
+	var (
+		sb    strings.Builder
+		specs = map[byte]string{
+			'Y': "YYYY",
+			'y': "YY",
+			'm': "MM",
+			'c': "FMMM",
+			'M': "FMMonth",
+			'b': "Mon",
+			'd': "DD",
+			'e': "FMDD",
+			'j': "DDD",
+			'W': "FMDay",
+			'a': "Dy",
+			'H': "HH24",
+			'k': "FMHH24",
+			'h': "HH12",
+			'I': "HH12",
+			'l': "FMHH12",
+			'i': "MI",
+			's': "SS",
+			'S': "SS",
+			'f': "US",
+			'p': "AM",
+			'u': "IW",
+			'v': "IW",
+			'x': "IYYY",
+		}
+	)
+
+	var literal []byte
+	flush := func() {
+		if len(literal) > 0 {
+			sb.WriteString(`"` + strings.ReplaceAll(string(literal), `"`, `\"`) + `"`)
+			literal = nil
+		}
+	}
+
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' || i == len(format)-1 {
+			literal = append(literal, format[i])
+			continue
+		}
+
+		i++
+		if p, ok := specs[format[i]]; ok {
+			flush()
+			sb.WriteString(p)
+		} else {
+			literal = append(literal, format[i])
+		}
+	}
+
+	flush()
+	return sb.String()
+}
+
+func (postgres) FullTextJoin(table, alias, column, terms string) (string, []interface{}) {
+	return "", nil
+}
+
+// FullTextMatch on PostgreSQL
+//
+// Language neutral (simple) text search configuration is used;
+// expression must match the one used for the GIN index of the column
+func (postgres) FullTextMatch(table, alias, column, terms string) (string, []interface{}) {
+	return fmt.Sprintf("%s @@ plainto_tsquery('simple', ?)", postgresTsvector(alias, column)), []interface{}{terms}
+}
+
+func (postgres) FullTextRank(table, alias, column, terms string) (string, []interface{}) {
+	return fmt.Sprintf("ts_rank(%s, plainto_tsquery('simple', ?))", postgresTsvector(alias, column)), []interface{}{terms}
+}
+
+// OrderBy on PostgreSQL
+//
+// NULL values are higher than any other value; placement is set explicitly
+func (postgres) OrderBy(expr string, desc bool) string {
+	if desc {
+		return expr + " DESC NULLS LAST"
+	}
+
+	return expr + " ASC NULLS FIRST"
+}
+
+// Savepoint on PostgreSQL
+//
+// Rolling back to the savepoint also recovers the transaction aborted by the failed statement
+func (postgres) Savepoint(name string) (string, string, string) {
+	return mysql{}.Savepoint(name)
+}
+
+// InsertIgnore on PostgreSQL
+func (d postgres) InsertIgnore(table string, columns ...string) string {
+	return d.InsertIgnoreSelect(table, columns, values(len(columns)))
+}
+
+func (postgres) InsertIgnoreSelect(table string, columns []string, query string) string {
+	return "INSERT " + into(table, columns) + " " + query + " ON CONFLICT DO NOTHING"
+}
+
+// Replace on PostgreSQL
+//
+// Conflicting row is updated in place; only conflicts on the key columns are resolved
+func (postgres) Replace(table string, keys []string, columns ...string) string {
+	var (
+		isKey = make(map[string]bool)
+		set   []string
+	)
+
+	for _, k := range keys {
+		isKey[k] = true
+	}
+
+	for _, c := range columns {
+		if !isKey[c] {
+			set = append(set, fmt.Sprintf("%s = EXCLUDED.%s", c, c))
+		}
+	}
+
+	var conflict = fmt.Sprintf(" ON CONFLICT (%s) DO ", strings.Join(keys, ", "))
+	if len(set) == 0 {
+		return "INSERT " + insertInto(table, columns) + conflict + "NOTHING"
+	}
+
+	return "INSERT " + insertInto(table, columns) + conflict + "UPDATE SET " + strings.Join(set, ", ")
+}
+
+func (sqlite) Name() string { return SQLite }
+
+// DecimalCast on SQLite
+//
+// There is no exact decimal type; values are cast into floating point
+// numbers and rounded to the given precision
+func (sqlite) DecimalCast(expr string, precision uint) string {
+	return fmt.Sprintf("ROUND(CAST(NULLIF(%s, '') AS REAL), %d)", expr, precision)
+}
+
+func (sqlite) NumericCast(expr string, precision uint) string {
+	return fmt.Sprintf("ROUND(%s, %d)", expr, precision)
+}
+
+func (sqlite) DatetimeCast(expr string) string {
+	return fmt.Sprintf("DATETIME(NULLIF(%s, ''))", expr)
+}
+
+func (sqlite) Truthy(expr string) string {
+	return postgres{}.Truthy(expr)
+}
+
+func (sqlite) DateTimestamp(expr string) string {
+	return fmt.Sprintf("CAST(STRFTIME('%%s', DATE(%s)) AS INTEGER)", expr)
+}
+
+// Like on SQLite
+//
+// LIKE is case insensitive (for ASCII characters) by default
+func (sqlite) Like(not bool) string {
+	if not {
+		return "NOT LIKE"
+	}
+
+	return "LIKE"
+}
+
+// Interval on SQLite
+//
+// Intervals are date & time function modifiers (eg: '3 DAY')
+func (sqlite) Interval(unit string) string {
+	return "(? || ' " + unit + "')"
+}
+
+// GroupConcat on SQLite
+//
+// Order of concatenated values can not be set explicitly; values are
+// concatenated in the order rows are scanned (by primary key)
+func (sqlite) GroupConcat(expr string) string {
+	return fmt.Sprintf("GROUP_CONCAT(%s, ',')", expr)
+}
+
+// Function on SQLite
+//
+// NOW(), CONCAT(), DATE_FORMAT() and PERCENTILE() are registered by the driver,
+// other date & time functions are translated into STRFTIME() and DATETIME() calls
+func (sqlite) Function(name string, args []string) string {
+	switch n := strings.ToUpper(name); {
+	case n == "QUARTER" && len(args) == 1:
+		return fmt.Sprintf("((CAST(STRFTIME('%%m', %s) AS INTEGER) + 2) / 3)", args[0])
+
+	case n == "YEAR" && len(args) == 1:
+		return fmt.Sprintf("CAST(STRFTIME('%%Y', %s) AS INTEGER)", args[0])
+
+	case n == "DATE_ADD" && len(args) == 2:
+		return fmt.Sprintf("DATETIME(%s, %s)", args[0], args[1])
+
+	case n == "DATE_SUB" && len(args) == 2:
+		return fmt.Sprintf("DATETIME(%s, '-' || %s)", args[0], args[1])
+
+	case n == "IF" && len(args) == 3:
+		return ifCase(args)
+
+	case n == "PERCENTILE":
+		return fmt.Sprintf("%s(%s)", name, strings.Join(args, ", "))
+	}
+
+	return mysql{}.Function(name, args)
+}
+
+// DateFormat on SQLite
+//
+// Registered DATE_FORMAT() function uses MySQL format
+func (sqlite) DateFormat(format string) string {
+	return format
+}
+
+// FullTextJoin on SQLite
+//
+// Values are indexed in the FTS4 (<table>_fts) table that is kept in sync with the
+// content table by triggers; document IDs of the index are rowids of the content table.
+//
+// Matches are ranked in a subquery as match info can not be read once rows are
+// grouped or sorted; DISTINCT keeps SQLite from flattening it into the outer query
+func (sqlite) FullTextJoin(table, alias, column, terms string) (string, []interface{}) {
+	return fmt.Sprintf(
+		"(SELECT DISTINCT docid, BM25(MATCHINFO(%s_fts, 'pcnalx')) AS rank FROM %s_fts WHERE %s MATCH ?) AS %s_fts ON (%s_fts.docid = %s.rowid)",
+		table, table, column, alias, alias, alias,
+	), []interface{}{sqliteFtsQuery(terms)}
+}
+
+// FullTextMatch on SQLite
+//
+// Only matching values are joined; see FullTextJoin
+func (sqlite) FullTextMatch(table, alias, column, terms string) (string, []interface{}) {
+	return fmt.Sprintf("%s_fts.docid IS NOT NULL", alias), nil
+}
+
+// FullTextRank on SQLite
+//
+// FTS4 does not provide ranking function; BM25() is registered by the driver
+// and rank is calculated from the match info in the joined subquery
+func (sqlite) FullTextRank(table, alias, column, terms string) (string, []interface{}) {
+	return fmt.Sprintf("%s_fts.rank", alias), nil
+}
+
+// OrderBy on SQLite
+//
+// NULL values are lower than any other value (as on MySQL)
+func (sqlite) OrderBy(expr string, desc bool) string {
+	return mysql{}.OrderBy(expr, desc)
+}
+
+// Savepoint on SQLite
+func (sqlite) Savepoint(name string) (string, string, string) {
+	return mysql{}.Savepoint(name)
+}
+
+// InsertIgnore on SQLite
+func (d sqlite) InsertIgnore(table string, columns ...string) string {
+	return d.InsertIgnoreSelect(table, columns, values(len(columns)))
+}
+
+func (sqlite) InsertIgnoreSelect(table string, columns []string, query string) string {
+	return "INSERT OR IGNORE " + into(table, columns) + " " + query
+}
+
+// Replace on SQLite
+//
+// Conflicting rows are deleted (firing delete triggers) before the row is inserted, as on MySQL
+func (sqlite) Replace(table string, keys []string, columns ...string) string {
+	return mysql{}.Replace(table, keys, columns...)
+}
+
+// insertInto returns INTO clause of the insert with placeholders for values of all columns
+func insertInto(table string, columns []string) string {
+	return into(table, columns) + " " + values(len(columns))
+}
+
+// into returns INTO clause of the insert
+func into(table string, columns []string) string {
+	return fmt.Sprintf("INTO %s (%s)", table, strings.Join(columns, ", "))
+}
+
+// values returns VALUES clause with n placeholders
+func values(n int) string {
+	return "VALUES (" + strings.TrimSuffix(strings.Repeat("?, ", n), ", ") + ")"
+}
+
+// postgresTsvector returns text search vector of the column
+func postgresTsvector(alias, column string) string {
+	return fmt.Sprintf("to_tsvector('simple', COALESCE(%s.%s, ''))", alias, column)
+}
+
+// sqliteFtsQuery converts search terms into FTS query where all terms are matched as-is
+//
+// Each term is quoted so that FTS operators and special characters are not interpreted
+func sqliteFtsQuery(terms string) string {
+	var qq = strings.Fields(strings.ReplaceAll(terms, `"`, " "))
+	for i := range qq {
+		qq[i] = `"` + qq[i] + `"`
+	}
+
+	return strings.Join(qq, " ")
+}
+
+// ifCase translates MySQL IF(cond, then, else) into CASE expression
+func ifCase(args []string) string {
+	return fmt.Sprintf("CASE WHEN %s THEN %s ELSE %s END", args[0], args[1], args[2])
+}
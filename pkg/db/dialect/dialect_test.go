@@ -0,0 +1,110 @@
+package dialect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgres_DateFormat(t *testing.T) {
+	tests := []struct {
+		format string
+		out    string
+	}{
+		{"%Y-%m-%d", `YYYY"-"MM"-"DD`},
+		{"%Y-%m", `YYYY"-"MM`},
+		{"%H:%i", `HH24":"MI`},
+		{"Q%Y", `"Q"YYYY`},
+		{"%%", `"%"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			require.Equal(t, tt.out, postgres{}.DateFormat(tt.format))
+		})
+	}
+}
+
+func TestPostgres_Function(t *testing.T) {
+	require.Equal(t, "EXTRACT(YEAR FROM CAST(v AS TIMESTAMP))", postgres{}.Function("year", []string{"v"}))
+	require.Equal(t, "TO_CHAR(CAST(v AS TIMESTAMP), ?)", postgres{}.Function("DATE_FORMAT", []string{"v", "?"}))
+	require.Equal(t, "(CAST(v AS TIMESTAMP) - CAST(? || ' DAY' AS INTERVAL))", postgres{}.Function("DATE_SUB", []string{"v", postgres{}.Interval("DAY")}))
+	require.Equal(t, "COALESCE(a, b)", postgres{}.Function("COALESCE", []string{"a", "b"}))
+	require.Equal(t, "PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY v)", postgres{}.Function("percentile", []string{"v", "0.5"}))
+}
+
+func TestMysql_Function(t *testing.T) {
+	var (
+		list = "GROUP_CONCAT(v ORDER BY v ASC SEPARATOR ',')"
+		pos  = "(1 + 0.5 * (COUNT(v) - 1))"
+		lo   = "CAST(SUBSTRING_INDEX(SUBSTRING_INDEX(" + list + ", ',', FLOOR" + pos + "), ',', -1) AS DECIMAL(65,30))"
+		hi   = "CAST(SUBSTRING_INDEX(SUBSTRING_INDEX(" + list + ", ',', CEIL" + pos + "), ',', -1) AS DECIMAL(65,30))"
+	)
+
+	require.Equal(t, "YEAR(v)", mysql{}.Function("YEAR", []string{"v"}))
+	require.Equal(t, "("+lo+" + ("+hi+" - "+lo+") * ("+pos+" - FLOOR"+pos+"))", mysql{}.Function("PERCENTILE", []string{"v", "0.5"}))
+}
+
+func TestSqlite_Function(t *testing.T) {
+	require.Equal(t, "CAST(STRFTIME('%Y', v) AS INTEGER)", sqlite{}.Function("YEAR", []string{"v"}))
+	require.Equal(t, "DATETIME(v, '-' || (? || ' DAY'))", sqlite{}.Function("DATE_SUB", []string{"v", sqlite{}.Interval("DAY")}))
+	require.Equal(t, "DATE_FORMAT(v, ?)", sqlite{}.Function("DATE_FORMAT", []string{"v", "?"}))
+	require.Equal(t, "CASE WHEN a THEN b ELSE c END", sqlite{}.Function("IF", []string{"a", "b", "c"}))
+	require.Equal(t, "percentile(v, 0.5)", sqlite{}.Function("percentile", []string{"v", "0.5"}))
+}
+
+func TestSqlite_FullText(t *testing.T) {
+	join, args := sqlite{}.FullTextJoin("compose_record_value", "rv", "value", ` foo  "bar* `)
+	require.Equal(t, "(SELECT DISTINCT docid, BM25(MATCHINFO(compose_record_value_fts, 'pcnalx')) AS rank FROM compose_record_value_fts WHERE value MATCH ?) AS rv_fts ON (rv_fts.docid = rv.rowid)", join)
+	require.Equal(t, []interface{}{`"foo" "bar*"`}, args)
+
+	match, _ := sqlite{}.FullTextMatch("compose_record_value", "rv", "value", "foo")
+	require.Equal(t, "rv_fts.docid IS NOT NULL", match)
+
+	rank, _ := sqlite{}.FullTextRank("compose_record_value", "rv", "value", "foo")
+	require.Equal(t, "rv_fts.rank", rank)
+}
+
+func TestOrderBy(t *testing.T) {
+	require.Equal(t, "v ASC", mysql{}.OrderBy("v", false))
+	require.Equal(t, "v DESC", sqlite{}.OrderBy("v", true))
+	require.Equal(t, "v ASC NULLS FIRST", postgres{}.OrderBy("v", false))
+	require.Equal(t, "v DESC NULLS LAST", postgres{}.OrderBy("v", true))
+}
+
+func TestInsertIgnore(t *testing.T) {
+	require.Equal(t, "INSERT IGNORE INTO t (a, b) VALUES (?, ?)", mysql{}.InsertIgnore("t", "a", "b"))
+	require.Equal(t, "INSERT INTO t (a) VALUES (?) ON CONFLICT DO NOTHING", postgres{}.InsertIgnore("t", "a"))
+	require.Equal(t, "INSERT OR IGNORE INTO t (a, b) VALUES (?, ?)", sqlite{}.InsertIgnore("t", "a", "b"))
+
+	require.Equal(t, "INSERT INTO t (a) SELECT a FROM s ON CONFLICT DO NOTHING", postgres{}.InsertIgnoreSelect("t", []string{"a"}, "SELECT a FROM s"))
+}
+
+func TestReplace(t *testing.T) {
+	require.Equal(t, "REPLACE INTO t (id, a) VALUES (?, ?)", mysql{}.Replace("t", []string{"id"}, "id", "a"))
+	require.Equal(t, "REPLACE INTO t (id, a) VALUES (?, ?)", sqlite{}.Replace("t", []string{"id"}, "id", "a"))
+	require.Equal(t,
+		"INSERT INTO t (k1, k2, a, b) VALUES (?, ?, ?, ?) ON CONFLICT (k1, k2) DO UPDATE SET a = EXCLUDED.a, b = EXCLUDED.b",
+		postgres{}.Replace("t", []string{"k1", "k2"}, "k1", "k2", "a", "b"),
+	)
+	require.Equal(t,
+		"INSERT INTO t (k1, k2) VALUES (?, ?) ON CONFLICT (k1, k2) DO NOTHING",
+		postgres{}.Replace("t", []string{"k1", "k2"}, "k1", "k2"),
+	)
+}
+
+func TestRowColumns(t *testing.T) {
+	var (
+		req = require.New(t)
+		row = struct {
+			ID       uint64 `db:"id"`
+			Name     string `db:"name,omitempty"`
+			Skipped  string `db:"-"`
+			Untagged string
+		}{1, "n", "s", "u"}
+	)
+
+	cc, vv := rowColumns(&row)
+	req.Equal([]string{"id", "name"}, cc)
+	req.Equal([]interface{}{uint64(1), "n"}, vv)
+}
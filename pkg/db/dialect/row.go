@@ -0,0 +1,51 @@
+package dialect
+
+import (
+	"database/sql"
+	"reflect"
+	"strings"
+)
+
+type (
+	// Execer executes statements (factory.DB, sqlx.DB or sqlx.Tx)
+	Execer interface {
+		Exec(query string, args ...interface{}) (sql.Result, error)
+	}
+)
+
+// InsertIgnore inserts the row unless it conflicts with an existing one
+//
+// Columns and values are read from the db tags of the row (struct) fields,
+// the same way as by factory's Insert
+func InsertIgnore(db Execer, table string, row interface{}) error {
+	cc, vv := rowColumns(row)
+	_, err := db.Exec(current.InsertIgnore(table, cc...), vv...)
+	return err
+}
+
+// Replace inserts the row or replaces the existing one with the same values of the key columns
+//
+// Columns and values are read from the db tags of the row (struct) fields,
+// the same way as by factory's Insert
+func Replace(db Execer, table string, row interface{}, keys ...string) error {
+	cc, vv := rowColumns(row)
+	_, err := db.Exec(current.Replace(table, keys, cc...), vv...)
+	return err
+}
+
+// rowColumns returns db tags and values of the struct fields
+func rowColumns(row interface{}) (cc []string, vv []interface{}) {
+	var v = reflect.Indirect(reflect.ValueOf(row))
+
+	for i := 0; i < v.NumField(); i++ {
+		tag := strings.Split(v.Type().Field(i).Tag.Get("db"), ",")[0]
+		if tag == "" || tag == "-" {
+			continue
+		}
+
+		cc = append(cc, tag)
+		vv = append(vv, v.Field(i).Interface())
+	}
+
+	return
+}
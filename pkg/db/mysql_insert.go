@@ -0,0 +1,69 @@
+package db
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+type (
+	// mysqlInsert holds parts of MySQL specific insert statement
+	// that other dialects need to rewrite
+	mysqlInsert struct {
+		table  string
+		cols   []string
+		values []string
+	}
+)
+
+var (
+	// INSERT INTO tbl SET col = ?, ... (used by factory's Insert)
+	//
+	// Inserts that replace or ignore existing rows are built
+	// by the repositories through the current dialect
+	mysqlInsertSet = regexp.MustCompile("(?is)^\\s*insert\\s+into\\s+(`?\\w+`?)\\s+set\\s+(.+?)[\\s;]*$")
+
+	// optionally quoted column name
+	mysqlColumn = regexp.MustCompile("^`?(\\w+)`?$")
+)
+
+// parseMysqlInsert parses INSERT statement that uses MySQL specific SET syntax
+//
+// Returns nil for all other statements and for inserts
+// that set anything but (optionally quoted) columns
+func parseMysqlInsert(query string) *mysqlInsert {
+	var m = mysqlInsertSet.FindStringSubmatch(query)
+	if m == nil {
+		return nil
+	}
+
+	var ins = &mysqlInsert{table: unquoteMysql(m[1])}
+
+	for _, set := range strings.Split(m[2], ",") {
+		kv := strings.SplitN(set, "=", 2)
+		if len(kv) != 2 {
+			// Not a simple assignment list, leave it to the database
+			return nil
+		}
+
+		col := mysqlColumn.FindStringSubmatch(strings.TrimSpace(kv[0]))
+		if col == nil {
+			return nil
+		}
+
+		ins.cols = append(ins.cols, col[1])
+		ins.values = append(ins.values, strings.TrimSpace(kv[1]))
+	}
+
+	return ins
+}
+
+// String returns insert in the standard (INSERT INTO tbl (col, ...) VALUES (...)) form
+func (ins mysqlInsert) String() string {
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", ins.table, strings.Join(ins.cols, ", "), strings.Join(ins.values, ", "))
+}
+
+// removes backticks around MySQL identifier
+func unquoteMysql(ident string) string {
+	return strings.Trim(ident, "`")
+}
@@ -0,0 +1,114 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+type (
+	// postgresDriver wraps PostgreSQL driver and translates statements
+	// written for MySQL (by the factory package)
+	postgresDriver struct{}
+
+	postgresConn struct {
+		driver.Conn
+	}
+)
+
+const (
+	postgresDriverName = "corteza-postgres"
+)
+
+func init() {
+	sql.Register(postgresDriverName, &postgresDriver{})
+}
+
+func (postgresDriver) Open(dsn string) (driver.Conn, error) {
+	c, err := pq.Open(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &postgresConn{c}, nil
+}
+
+func (c *postgresConn) Prepare(query string) (driver.Stmt, error) {
+	return c.Conn.Prepare(postgresQuery(query))
+}
+
+func (c *postgresConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if e, ok := c.Conn.(driver.ExecerContext); ok {
+		return e.ExecContext(ctx, postgresQuery(query), args)
+	}
+
+	return nil, driver.ErrSkip
+}
+
+func (c *postgresConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if q, ok := c.Conn.(driver.QueryerContext); ok {
+		return q.QueryContext(ctx, postgresQuery(query), args)
+	}
+
+	return nil, driver.ErrSkip
+}
+
+func (c *postgresConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if b, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return b.BeginTx(ctx, opts)
+	}
+
+	return c.Conn.Begin()
+}
+
+func (c *postgresConn) Ping(ctx context.Context) error {
+	if p, ok := c.Conn.(driver.Pinger); ok {
+		return p.Ping(ctx)
+	}
+
+	return nil
+}
+
+// postgresQuery rewrites MySQL specific inserts
+// and converts ? placeholders into positional ($1, $2, ...) ones
+func postgresQuery(query string) string {
+	if ins := parseMysqlInsert(query); ins != nil {
+		query = ins.String()
+	}
+
+	return postgresRebind(query)
+}
+
+// postgresRebind replaces ? placeholders (outside of quoted strings and identifiers)
+func postgresRebind(query string) string {
+	var (
+		sb    strings.Builder
+		quote rune
+		n     = 0
+	)
+
+	for _, r := range query {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+
+		case r == '\'' || r == '"':
+			quote = r
+
+		case r == '?':
+			n++
+			sb.WriteString(fmt.Sprintf("$%d", n))
+			continue
+		}
+
+		sb.WriteRune(r)
+	}
+
+	return sb.String()
+}
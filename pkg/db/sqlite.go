@@ -0,0 +1,407 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+type (
+	// sqliteDriver wraps SQLite driver, translates statements written
+	// for MySQL and registers MySQL functions that SQLite does not have
+	sqliteDriver struct {
+		sqlite3.SQLiteDriver
+	}
+
+	sqliteConn struct {
+		driver.Conn
+	}
+)
+
+const (
+	sqliteDriverName = "corteza-sqlite"
+)
+
+var (
+	sqliteTimeLayouts = []string{
+		"2006-01-02T15:04:05.999999999-07:00",
+		"2006-01-02 15:04:05.999999999-07:00",
+		"2006-01-02T15:04:05.999999999",
+		"2006-01-02 15:04:05.999999999",
+		"2006-01-02T15:04",
+		"2006-01-02 15:04",
+		"2006-01-02",
+	}
+)
+
+func init() {
+	sql.Register(sqliteDriverName, &sqliteDriver{
+		SQLiteDriver: sqlite3.SQLiteDriver{
+			ConnectHook: sqliteFunctions,
+		},
+	})
+}
+
+func (d *sqliteDriver) Open(dsn string) (driver.Conn, error) {
+	c, err := d.SQLiteDriver.Open(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqliteConn{c}, nil
+}
+
+func (c *sqliteConn) Prepare(query string) (driver.Stmt, error) {
+	return c.Conn.Prepare(sqliteQuery(query))
+}
+
+func (c *sqliteConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if e, ok := c.Conn.(driver.ExecerContext); ok {
+		return e.ExecContext(ctx, sqliteQuery(query), sqliteArgs(args))
+	}
+
+	return nil, driver.ErrSkip
+}
+
+func (c *sqliteConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if q, ok := c.Conn.(driver.QueryerContext); ok {
+		return q.QueryContext(ctx, sqliteQuery(query), sqliteArgs(args))
+	}
+
+	return nil, driver.ErrSkip
+}
+
+func (c *sqliteConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if b, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return b.BeginTx(ctx, opts)
+	}
+
+	return c.Conn.Begin()
+}
+
+func (c *sqliteConn) Ping(ctx context.Context) error {
+	if p, ok := c.Conn.(driver.Pinger); ok {
+		return p.Ping(ctx)
+	}
+
+	return nil
+}
+
+// sqliteFunctions registers MySQL functions used by repositories and reports
+func sqliteFunctions(conn *sqlite3.SQLiteConn) error {
+	// Formatted the same way as time values passed as arguments
+	now := func() string {
+		return sqliteTime(time.Now())
+	}
+
+	if err := conn.RegisterFunc("now", now, false); err != nil {
+		return err
+	}
+
+	concat := func(args ...interface{}) string {
+		var sb strings.Builder
+		for _, a := range args {
+			switch v := a.(type) {
+			case nil:
+			case []byte:
+				sb.Write(v)
+			default:
+				sb.WriteString(fmt.Sprint(v))
+			}
+		}
+
+		return sb.String()
+	}
+
+	if err := conn.RegisterFunc("concat", concat, true); err != nil {
+		return err
+	}
+
+	if err := conn.RegisterAggregator("percentile", newSqlitePercentile, true); err != nil {
+		return err
+	}
+
+	if err := conn.RegisterFunc("bm25", sqliteBm25, true); err != nil {
+		return err
+	}
+
+	return conn.RegisterFunc("date_format", sqliteDateFormat, true)
+}
+
+// sqlitePercentile aggregates values for PERCENTILE(expr, fraction)
+//
+// Percentile is calculated the same way as PostgreSQL's PERCENTILE_CONT(),
+// by interpolating between the two nearest values; NULLs are ignored
+//
+// Result is returned as (textual) blob as the driver can return NULL only as a blob
+type sqlitePercentile struct {
+	values   []float64
+	fraction float64
+}
+
+func newSqlitePercentile() *sqlitePercentile {
+	return &sqlitePercentile{}
+}
+
+func (p *sqlitePercentile) Step(value interface{}, fraction float64) {
+	p.fraction = fraction
+
+	switch v := value.(type) {
+	case int64:
+		p.values = append(p.values, float64(v))
+	case float64:
+		p.values = append(p.values, v)
+	case string:
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			p.values = append(p.values, f)
+		}
+	case []byte:
+		if f, err := strconv.ParseFloat(string(v), 64); err == nil {
+			p.values = append(p.values, f)
+		}
+	}
+}
+
+func (p *sqlitePercentile) Done() []byte {
+	if len(p.values) == 0 {
+		return nil
+	}
+
+	sort.Float64s(p.values)
+
+	var (
+		pos = p.fraction * float64(len(p.values)-1)
+		lo  = int(math.Floor(pos))
+		hi  = int(math.Ceil(pos))
+	)
+
+	if lo < 0 || hi >= len(p.values) {
+		return nil
+	}
+
+	return []byte(strconv.FormatFloat(p.values[lo]+(p.values[hi]-p.values[lo])*(pos-float64(lo)), 'f', -1, 64))
+}
+
+// sqliteBm25 calculates Okapi BM25 rank of the full-text match
+//
+// Expects FTS4 match info in 'pcnalx' format: number of phrases and columns,
+// number of rows, average and current number of tokens in each column and
+// hits of each phrase in each column (in this row, in all rows, rows with hits).
+// Info is an array of 32-bit unsigned integers in the machine byte order
+// (little-endian on all supported platforms)
+func sqliteBm25(info []byte) float64 {
+	const (
+		k1 = 1.2
+		b  = 0.75
+	)
+
+	var ii = make([]float64, len(info)/4)
+	for i := range ii {
+		ii[i] = float64(binary.LittleEndian.Uint32(info[i*4:]))
+	}
+
+	if len(ii) < 3 {
+		return 0
+	}
+
+	var (
+		p, c, n = int(ii[0]), int(ii[1]), ii[2]
+		avg     = 3
+		cur     = avg + c
+		hits    = cur + c
+		rank    float64
+	)
+
+	if len(ii) < hits+3*p*c {
+		return 0
+	}
+
+	for i := 0; i < p; i++ {
+		for j := 0; j < c; j++ {
+			var (
+				x    = hits + 3*(i*c+j)
+				tf   = ii[x]
+				docs = ii[x+2]
+				idf  = math.Log(1 + (n-docs+0.5)/(docs+0.5))
+				norm = 1 - b
+			)
+
+			if ii[avg+j] > 0 {
+				norm += b * ii[cur+j] / ii[avg+j]
+			}
+
+			rank += idf * tf * (k1 + 1) / (tf + k1*norm)
+		}
+	}
+
+	return rank
+}
+
+// sqliteDateFormat formats date & time value the same way as MySQL's DATE_FORMAT()
+//
+// Values that can not be parsed are formatted as empty strings
+func sqliteDateFormat(value interface{}, format string) string {
+	var (
+		t   time.Time
+		err error
+		sb  strings.Builder
+	)
+
+	switch v := value.(type) {
+	case time.Time:
+		t = v
+	case string:
+		if t, err = sqliteParseTime(v); err != nil {
+			return ""
+		}
+	case []byte:
+		if t, err = sqliteParseTime(string(v)); err != nil {
+			return ""
+		}
+	default:
+		return ""
+	}
+
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' || i == len(format)-1 {
+			sb.WriteByte(format[i])
+			continue
+		}
+
+		i++
+		switch format[i] {
+		case 'Y':
+			sb.WriteString(t.Format("2006"))
+		case 'y':
+			sb.WriteString(t.Format("06"))
+		case 'm':
+			sb.WriteString(t.Format("01"))
+		case 'c':
+			sb.WriteString(t.Format("1"))
+		case 'M':
+			sb.WriteString(t.Format("January"))
+		case 'b':
+			sb.WriteString(t.Format("Jan"))
+		case 'd':
+			sb.WriteString(t.Format("02"))
+		case 'e':
+			sb.WriteString(t.Format("2"))
+		case 'j':
+			sb.WriteString(fmt.Sprintf("%03d", t.YearDay()))
+		case 'W':
+			sb.WriteString(t.Format("Monday"))
+		case 'a':
+			sb.WriteString(t.Format("Mon"))
+		case 'w':
+			sb.WriteString(fmt.Sprintf("%d", t.Weekday()))
+		case 'H':
+			sb.WriteString(t.Format("15"))
+		case 'k':
+			sb.WriteString(fmt.Sprintf("%d", t.Hour()))
+		case 'h', 'I':
+			sb.WriteString(t.Format("03"))
+		case 'l':
+			sb.WriteString(t.Format("3"))
+		case 'i':
+			sb.WriteString(t.Format("04"))
+		case 's', 'S':
+			sb.WriteString(t.Format("05"))
+		case 'f':
+			sb.WriteString(fmt.Sprintf("%06d", t.Nanosecond()/1000))
+		case 'p':
+			sb.WriteString(t.Format("PM"))
+		case 'u', 'v':
+			_, w := t.ISOWeek()
+			sb.WriteString(fmt.Sprintf("%02d", w))
+		case 'x':
+			y, _ := t.ISOWeek()
+			sb.WriteString(fmt.Sprintf("%04d", y))
+		default:
+			sb.WriteByte(format[i])
+		}
+	}
+
+	return sb.String()
+}
+
+// sqliteParseTime parses date & time values in formats SQLite recognizes
+func sqliteParseTime(value string) (t time.Time, err error) {
+	value = strings.TrimSuffix(value, "Z")
+	for _, layout := range sqliteTimeLayouts {
+		if t, err = time.ParseInLocation(layout, value, time.UTC); err == nil {
+			return
+		}
+	}
+
+	return
+}
+
+// sqliteArgs formats time values
+//
+// SQLite stores date & time as text; all values are stored in the same format
+// (in UTC, with seconds precision, as MySQL's DATETIME) so that they can be
+// compared with each other and with RFC3339 formatted filter values
+func sqliteArgs(args []driver.NamedValue) []driver.NamedValue {
+	for i := range args {
+		if t, ok := args[i].Value.(time.Time); ok {
+			args[i].Value = sqliteTime(t)
+		}
+	}
+
+	return args
+}
+
+func sqliteTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// sqliteQuery rewrites MySQL specific inserts
+func sqliteQuery(query string) string {
+	if ins := parseMysqlInsert(query); ins != nil {
+		return ins.String()
+	}
+
+	return query
+}
+
+// sqliteDSN sets defaults for the database connection
+//
+// Busy timeout (in ms) makes concurrent writers wait for the lock instead of failing
+// and write-ahead log lets readers work while database is written to.
+//
+// Recursive triggers are enabled so that rows deleted by REPLACE fire delete
+// triggers (used to keep full-text index in sync)
+func sqliteDSN(dsn string) string {
+	var params = []string{}
+
+	if !strings.Contains(dsn, "_busy_timeout=") {
+		params = append(params, "_busy_timeout=10000")
+	}
+
+	if !strings.Contains(dsn, "_journal_mode=") {
+		params = append(params, "_journal_mode=WAL")
+	}
+
+	if !strings.Contains(dsn, "_recursive_triggers=") {
+		params = append(params, "_recursive_triggers=1")
+	}
+
+	if len(params) == 0 {
+		return dsn
+	}
+
+	if strings.Contains(dsn, "?") {
+		return dsn + "&" + strings.Join(params, "&")
+	}
+
+	return dsn + "?" + strings.Join(params, "&")
+}
@@ -0,0 +1,121 @@
+package db
+
+import (
+	"encoding/binary"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSqliteQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		sql   string
+	}{
+		{
+			"select",
+			"SELECT * FROM t WHERE a = ?",
+			"SELECT * FROM t WHERE a = ?",
+		},
+		{
+			"insert",
+			"insert into t set a=?, b=?",
+			"INSERT INTO t (a, b) VALUES (?, ?)",
+		},
+		{
+			"insert with quoted identifiers",
+			"INSERT INTO `t` SET `a` = ?, b=?;",
+			"INSERT INTO t (a, b) VALUES (?, ?)",
+		},
+		{
+			"multi-row insert",
+			"INSERT INTO t (a, b) VALUES (?, ?), (?, ?)",
+			"INSERT INTO t (a, b) VALUES (?, ?), (?, ?)",
+		},
+		{
+			"replace built by the dialect",
+			"REPLACE INTO t (id, a) VALUES (?, ?)",
+			"REPLACE INTO t (id, a) VALUES (?, ?)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.sql, sqliteQuery(tt.query))
+		})
+	}
+}
+
+func TestSqliteDateFormat(t *testing.T) {
+	tests := []struct {
+		value  interface{}
+		format string
+		out    string
+	}{
+		{"2020-05-04T10:20:30Z", "%Y-%m-%d", "2020-05-04"},
+		{"2020-05-04 10:20:30", "%H:%i:%s", "10:20:30"},
+		{"2020-05-04", "%M %e, %Y", "May 4, 2020"},
+		{time.Date(2020, 12, 31, 0, 0, 0, 0, time.UTC), "%Y/%j 100%%", "2020/366 100%"},
+		{"not a date", "%Y", ""},
+		{nil, "%Y", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			require.Equal(t, tt.out, sqliteDateFormat(tt.value, tt.format))
+		})
+	}
+}
+
+func TestSqlitePercentile(t *testing.T) {
+	tests := []struct {
+		values   []interface{}
+		fraction float64
+		out      string
+	}{
+		{[]interface{}{int64(1), int64(2), int64(3), int64(4)}, 0.5, "2.5"},
+		{[]interface{}{"3", 1.0, []byte("2"), nil}, 0.5, "2"},
+		{[]interface{}{int64(10), int64(20), int64(30), int64(40), int64(50)}, 0.9, "46"},
+		{[]interface{}{int64(10), int64(20)}, 0, "10"},
+		{[]interface{}{nil}, 0.5, ""},
+		{[]interface{}{int64(10), int64(20)}, 1.5, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%v@%v", tt.values, tt.fraction), func(t *testing.T) {
+			p := newSqlitePercentile()
+			for _, v := range tt.values {
+				p.Step(v, tt.fraction)
+			}
+
+			require.Equal(t, tt.out, string(p.Done()))
+		})
+	}
+}
+
+func TestSqliteBm25(t *testing.T) {
+	// phrases, columns, rows, average tokens, tokens in row, hits (row, all, rows with hits)
+	info := func(ii ...uint32) []byte {
+		buf := make([]byte, 4*len(ii))
+		for i, v := range ii {
+			binary.LittleEndian.PutUint32(buf[i*4:], v)
+		}
+
+		return buf
+	}
+
+	var (
+		req  = require.New(t)
+		once = sqliteBm25(info(1, 1, 10, 4, 4, 1, 5, 3))
+	)
+
+	req.True(once > 0)
+	req.True(sqliteBm25(info(1, 1, 10, 4, 4, 3, 5, 3)) > once, "more hits rank higher")
+	req.True(sqliteBm25(info(1, 1, 10, 4, 2, 1, 5, 3)) > once, "shorter values rank higher")
+	req.True(sqliteBm25(info(1, 1, 10, 4, 4, 1, 9, 9)) < once, "common terms rank lower")
+	req.Equal(float64(0), sqliteBm25(info(1, 1, 10)))
+	req.Equal(float64(0), sqliteBm25(nil))
+}
@@ -0,0 +1,72 @@
+// Package lock provides in-process locks
+package lock
+
+import (
+	"sync"
+)
+
+type (
+	// Keyed locks arbitrary (string) keys
+	//
+	// Lock of the key is only kept while it is held or waited for,
+	// zero value is ready to use
+	Keyed struct {
+		mux   sync.Mutex
+		locks map[string]*keyLock
+	}
+
+	keyLock struct {
+		sync.Mutex
+
+		// number of holders and waiters
+		refs int
+	}
+)
+
+// Lock locks all given keys (in the given order) and returns function that unlocks them
+//
+// Keys should always be given in the same order to avoid deadlocks
+func (l *Keyed) Lock(keys ...string) func() {
+	var locked = make([]string, 0, len(keys))
+
+	for _, key := range keys {
+		l.acquire(key).Lock()
+		locked = append(locked, key)
+	}
+
+	return func() {
+		for i := len(locked) - 1; i >= 0; i-- {
+			l.release(locked[i])
+		}
+	}
+}
+
+// acquire returns lock of the key and adds a reference to it
+func (l *Keyed) acquire(key string) *keyLock {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	if l.locks == nil {
+		l.locks = make(map[string]*keyLock)
+	}
+
+	if l.locks[key] == nil {
+		l.locks[key] = &keyLock{}
+	}
+
+	l.locks[key].refs++
+	return l.locks[key]
+}
+
+// release unlocks the key and removes lock that is no longer referenced
+func (l *Keyed) release(key string) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	kl := l.locks[key]
+	kl.Unlock()
+
+	if kl.refs--; kl.refs == 0 {
+		delete(l.locks, key)
+	}
+}
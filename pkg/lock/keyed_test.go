@@ -0,0 +1,43 @@
+package lock
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyed(t *testing.T) {
+	var (
+		req = require.New(t)
+		l   = &Keyed{}
+		wg  = sync.WaitGroup{}
+
+		// guarded by the lock of the key
+		counter = 0
+	)
+
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer l.Lock("a", "b")()
+
+			c := counter
+			counter = c + 1
+		}()
+	}
+
+	wg.Wait()
+
+	req.Equal(50, counter)
+	req.Empty(l.locks, "expecting unlocked keys to be removed")
+
+	unlock := l.Lock("a")
+	other := l.Lock("b")
+	req.Len(l.locks, 2)
+
+	unlock()
+	other()
+	req.Empty(l.locks)
+}
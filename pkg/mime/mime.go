@@ -3,6 +3,7 @@ package mime
 import (
 	"bufio"
 	"io"
+	"net/http"
 
 	"github.com/gabriel-vasile/mimetype"
 )
@@ -17,6 +18,27 @@ func Type(file io.ReadSeeker) (mt string, ext string, err error) {
 	return mimetype.DetectReader(file)
 }
 
+// Detect detects content type of the file from its first 512 bytes
+// (see http.DetectContentType); file is rewound when done
+func Detect(file io.ReadSeeker) (mimetype string, err error) {
+	if _, err = file.Seek(0, 0); err != nil {
+		return
+	}
+
+	// Make sure we rewind when we're done
+	defer file.Seek(0, 0)
+
+	var buf = make([]byte, 512)
+	n, err := file.Read(buf)
+	if err != nil {
+		return
+	}
+
+	// Detect only from the bytes read, zero padding
+	// of the buffer makes small files look binary
+	return http.DetectContentType(buf[:n]), nil
+}
+
 func JsonL(file io.ReadSeeker) (bool, error) {
 	// ExtractMimetype fails to detect json if jsonl is used
 	// For now check if first rune is {
@@ -0,0 +1,34 @@
+package mime
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetect(t *testing.T) {
+	var (
+		req = require.New(t)
+	)
+
+	tests := map[string]string{
+		"hi":                               "text/plain; charset=utf-8",
+		strings.Repeat("lorem ipsum", 100): "text/plain; charset=utf-8",
+		"<html><body></body></html>":       "text/html; charset=utf-8",
+		"%PDF-1.4":                         "application/pdf",
+		"\x00\x01\x02":                     "application/octet-stream",
+	}
+
+	for content, mimetype := range tests {
+		file := strings.NewReader(content)
+
+		m, err := Detect(file)
+		req.NoError(err)
+		req.Equal(mimetype, m, "unexpected mimetype of %q", content)
+
+		pos, err := file.Seek(0, 1)
+		req.NoError(err)
+		req.Zero(pos, "expecting file to be rewound")
+	}
+}
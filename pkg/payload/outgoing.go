@@ -291,6 +291,22 @@ func Attachment(in *messagingTypes.Attachment, userID uint64) *outgoing.Attachme
 	}
 }
 
+func MessageAttachments(ctx context.Context, aa messagingTypes.MessageAttachmentSet) *outgoing.AttachmentSet {
+	var (
+		currentUserID = auth.GetIdentityFromContext(ctx).Identity()
+		out           = make([]*outgoing.Attachment, len(aa))
+	)
+
+	for k, a := range aa {
+		out[k] = Attachment(&a.Attachment, currentUserID)
+		out[k].MessageID = Uint64toa(a.MessageID)
+		out[k].ChannelID = Uint64toa(a.ChannelID)
+	}
+
+	retval := outgoing.AttachmentSet(out)
+	return &retval
+}
+
 func Command(cmd *messagingTypes.Command) *outgoing.Command {
 	if cmd == nil {
 		return nil
@@ -14,6 +14,10 @@ type (
 		Name       string      `json:"name"`
 		CreatedAt  time.Time   `json:"createdAt,omitempty"`
 		UpdatedAt  *time.Time  `json:"updatedAt,omitempty"`
+
+		// Message & channel the attachment was posted to (search results only)
+		MessageID string `json:"messageID,omitempty"`
+		ChannelID string `json:"channelID,omitempty"`
 	}
 
 	AttachmentSet []*Attachment
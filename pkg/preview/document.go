@@ -0,0 +1,117 @@
+package preview
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/cortezaproject/corteza-server/pkg/logger"
+	"github.com/cortezaproject/corteza-server/pkg/sentry"
+)
+
+const (
+	// max. number of document previews made at the same time
+	documentWorkers = 2
+
+	// max. number of documents waiting for a worker
+	documentQueueSize = 256
+
+	// max. time making of one document preview can take
+	documentTimeout = time.Minute
+)
+
+type (
+	// Document is a stored file preview is made of
+	Document struct {
+		// ID of the attachment, for logging
+		ID uint64
+
+		Mimetype  string
+		Extension string
+
+		// Preview is resized to fit into max. width & height
+		MaxWidth  int
+		MaxHeight int
+
+		// Open opens the stored file
+		Open func() (io.ReadSeeker, error)
+
+		// Save stores the preview (JPEG)
+		Save func(buf *bytes.Buffer, width, height int) error
+	}
+)
+
+var (
+	documents     = make(chan Document, documentQueueSize)
+	documentsOnce sync.Once
+)
+
+// Queue makes preview of the document in the background
+//
+// Previews are made by a fixed number of workers; documents are dropped
+// when too many of them are waiting. Images (previews are made when they are stored)
+// and documents none of the generators supports are skipped.
+//
+// Previews of documents are optional: failures are logged
+// and attachment is left without the preview
+func Queue(doc Document) {
+	if strings.HasPrefix(doc.Mimetype, "image/") || !Supports(doc.Mimetype, doc.Extension) {
+		return
+	}
+
+	documentsOnce.Do(func() {
+		for w := 0; w < documentWorkers; w++ {
+			go documentWorker()
+		}
+	})
+
+	select {
+	case documents <- doc:
+	default:
+		documentLogger(doc).Warn("too many queued document previews, preview skipped")
+	}
+}
+
+func documentWorker() {
+	for doc := range documents {
+		if err := makeDocument(doc); err != nil {
+			documentLogger(doc).Warn("could not make document preview", zap.Error(err))
+		}
+	}
+}
+
+// makeDocument makes and saves preview of one document
+func makeDocument(doc Document) error {
+	defer sentry.Recover()
+
+	ctx, cancel := context.WithTimeout(context.Background(), documentTimeout)
+	defer cancel()
+
+	original, err := doc.Open()
+	if err != nil {
+		return err
+	}
+
+	if c, ok := original.(io.Closer); ok {
+		defer c.Close()
+	}
+
+	buf, width, height, err := JPEG(ctx, original, doc.Mimetype, doc.Extension, doc.MaxWidth, doc.MaxHeight)
+	if err != nil || buf == nil {
+		return err
+	}
+
+	return doc.Save(buf, width, height)
+}
+
+func documentLogger(doc Document) *zap.Logger {
+	return logger.Default().Named("preview").With(
+		zap.Uint64("attachmentID", doc.ID),
+		zap.String("mimetype", doc.Mimetype),
+	)
+}
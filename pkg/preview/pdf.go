@@ -0,0 +1,81 @@
+package preview
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/jpeg"
+	"io"
+	"os/exec"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// size (of the longer side) of the rendered page
+	pdfPageSize = 640
+
+	pdfRenderTimeout = time.Second * 30
+)
+
+type (
+	pdfGenerator struct {
+		command string
+	}
+)
+
+// PDF renders first page of PDF documents to JPEG
+//
+// Pages are rendered with pdftoppm (poppler-utils) or compatible command;
+// PDF previews are not made when the command can not be found
+func PDF(command string) Generator {
+	return &pdfGenerator{command: command}
+}
+
+func (g pdfGenerator) Supports(mimetype, _ string) bool {
+	if mimetype != "application/pdf" {
+		return false
+	}
+
+	_, err := exec.LookPath(g.command)
+	return err == nil
+}
+
+func (g pdfGenerator) Generate(ctx context.Context, r io.ReadSeeker, _, _ string) (image.Image, error) {
+	var (
+		out    = &bytes.Buffer{}
+		stderr = &bytes.Buffer{}
+	)
+
+	ctx, cancel := context.WithTimeout(ctx, pdfRenderTimeout)
+
+	defer cancel()
+
+	// Reads PDF from stdin and writes the first page
+	// to stdout (no output file is given with -singlefile)
+	cmd := exec.CommandContext(ctx, g.command,
+		"-f", "1",
+		"-l", "1",
+		"-singlefile",
+		"-jpeg",
+		"-scale-to", strconv.Itoa(pdfPageSize),
+		"-",
+	)
+
+	cmd.Stdin = r
+	cmd.Stdout = out
+	cmd.Stderr = stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "could not render PDF page: %s", bytes.TrimSpace(stderr.Bytes()))
+	}
+
+	img, err := jpeg.Decode(out)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode rendered PDF page")
+	}
+
+	return img, nil
+}
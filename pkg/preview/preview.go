@@ -0,0 +1,103 @@
+package preview
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"io"
+	"strings"
+
+	"github.com/disintegration/imaging"
+)
+
+type (
+	// Generator makes preview image of a (non-image) file
+	Generator interface {
+		// Supports returns true if generator can make a preview of the file
+		Supports(mimetype, extension string) bool
+
+		// Generate makes preview image from the file
+		//
+		// Returned image can be of any size, it is resized
+		// when stored as attachment's preview; generators that
+		// run external commands stop them when context is done
+		Generate(ctx context.Context, r io.ReadSeeker, mimetype, extension string) (image.Image, error)
+	}
+)
+
+var (
+	generators []Generator
+)
+
+func init() {
+	Register(Text(), Office(), PDF("pdftoppm"))
+}
+
+// Register adds preview generators
+//
+// Generators registered later take precedence over
+// the existing ones (for the same type of the file).
+// Not safe for concurrent use, register generators on startup
+func Register(gg ...Generator) {
+	for _, g := range gg {
+		generators = append([]Generator{g}, generators...)
+	}
+}
+
+// Supports returns true if any of the generators can make a preview of the file
+func Supports(mimetype, extension string) bool {
+	extension = strings.ToLower(strings.Trim(extension, "."))
+
+	for _, g := range generators {
+		if g.Supports(mimetype, extension) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Generate makes preview image with the first generator that supports the file
+//
+// Nil image is returned when there is no generator for the file
+func Generate(ctx context.Context, r io.ReadSeeker, mimetype, extension string) (image.Image, error) {
+	extension = strings.ToLower(strings.Trim(extension, "."))
+
+	for _, g := range generators {
+		if !g.Supports(mimetype, extension) {
+			continue
+		}
+
+		if _, err := r.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+
+		// Make sure we rewind when we're done
+		defer r.Seek(0, io.SeekStart)
+
+		return g.Generate(ctx, r, mimetype, extension)
+	}
+
+	return nil, nil
+}
+
+// JPEG makes preview image with Generate and encodes it
+// to JPEG that fits into maxWidth x maxHeight
+//
+// Nil buffer is returned when there is no generator for the file
+// or when generator could not make a preview (ie. document without text)
+func JPEG(ctx context.Context, r io.ReadSeeker, mimetype, extension string, maxWidth, maxHeight int) (buf *bytes.Buffer, width, height int, err error) {
+	img, err := Generate(ctx, r, mimetype, extension)
+	if err != nil || img == nil {
+		return nil, 0, 0, err
+	}
+
+	img = imaging.Fit(img, maxWidth, maxHeight, imaging.Lanczos)
+
+	buf = &bytes.Buffer{}
+	if err = imaging.Encode(buf, img, imaging.JPEG, imaging.JPEGQuality(85)); err != nil {
+		return nil, 0, 0, err
+	}
+
+	return buf, img.Bounds().Dx(), img.Bounds().Dy(), nil
+}
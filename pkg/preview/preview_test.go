@@ -0,0 +1,198 @@
+package preview
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"image"
+	"image/jpeg"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	testGenerator struct{ img image.Image }
+)
+
+func (g testGenerator) Supports(mimetype, _ string) bool { return mimetype == "text/plain" }
+
+func (g testGenerator) Generate(context.Context, io.ReadSeeker, string, string) (image.Image, error) {
+	return g.img, nil
+}
+
+func zipped(t *testing.T, files map[string]string) *bytes.Reader {
+	var (
+		buf = &bytes.Buffer{}
+		zw  = zip.NewWriter(buf)
+	)
+
+	for name, content := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, zw.Close())
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestGenerate(t *testing.T) {
+	var req = require.New(t)
+
+	img, err := Generate(context.Background(), strings.NewReader("Lorem ipsum dolor sit amet"), "text/plain; charset=utf-8", "txt")
+	req.NoError(err)
+	req.NotNil(img)
+	req.Equal(image.Rect(0, 0, snippetWidth, snippetHeight), img.Bounds())
+
+	img, err = Generate(context.Background(), strings.NewReader("   \n  "), "text/plain; charset=utf-8", "txt")
+	req.NoError(err)
+	req.Nil(img, "no preview of empty text")
+
+	img, err = Generate(context.Background(), strings.NewReader("\x00\x01"), "application/octet-stream", "bin")
+	req.NoError(err)
+	req.Nil(img, "no generator for binary files")
+
+	defer func(gg []Generator) { generators = gg }(generators)
+
+	custom := image.NewRGBA(image.Rect(0, 0, 1, 1))
+	Register(testGenerator{img: custom})
+
+	img, err = Generate(context.Background(), strings.NewReader("Lorem ipsum"), "text/plain", "txt")
+	req.NoError(err)
+	req.Equal(custom, img, "expecting later registered generator to take precedence")
+}
+
+func TestJPEG(t *testing.T) {
+	var req = require.New(t)
+
+	buf, width, height, err := JPEG(context.Background(), strings.NewReader("Lorem ipsum dolor sit amet"), "text/plain", "txt", 160, 160)
+	req.NoError(err)
+	req.NotNil(buf)
+	req.Equal(160, width)
+	req.Equal(160*snippetHeight/snippetWidth, height)
+
+	img, err := jpeg.Decode(buf)
+	req.NoError(err)
+	req.Equal(image.Rect(0, 0, width, height), img.Bounds())
+
+	buf, _, _, err = JPEG(context.Background(), strings.NewReader("\x00\x01"), "application/octet-stream", "bin", 160, 160)
+	req.NoError(err)
+	req.Nil(buf, "no preview of binary files")
+
+	req.True(Supports("text/plain", "txt"))
+	req.True(Supports("application/zip", ".DOCX"))
+	req.False(Supports("application/octet-stream", "bin"))
+}
+
+func TestSnippetLines(t *testing.T) {
+	var req = require.New(t)
+
+	req.Equal([]string{"foo", "bar baz"}, snippetLines("  foo\r\nbar\tbaz\x00  "))
+	req.Nil(snippetLines(""))
+
+	lines := snippetLines(strings.Repeat("lorem ipsum ", 20))
+	req.Equal("lorem ipsum lorem ipsum lorem ipsum lorem", lines[0])
+	req.Equal("ipsum lorem ipsum lorem ipsum lorem ipsum", lines[1])
+
+	lines = snippetLines(strings.Repeat("x", 50))
+	req.Equal([]string{strings.Repeat("x", 43), strings.Repeat("x", 7)}, lines)
+
+	lines = snippetLines(strings.Repeat("line\n", 50))
+	req.Len(lines, 12)
+}
+
+func TestOffice(t *testing.T) {
+	doc := zipped(t, map[string]string{"word/document.xml": "<d><p><t>Hello</t></p></d>"})
+	img, err := Generate(context.Background(), doc, "application/zip", "DOCX")
+	require.NoError(t, err)
+	require.NotNil(t, img)
+
+	img, err = Generate(context.Background(), zipped(t, map[string]string{}), "application/zip", "docx")
+	require.NoError(t, err)
+	require.Nil(t, img, "no preview of document without text")
+}
+
+func TestPDF(t *testing.T) {
+	dir, err := ioutil.TempDir("", "preview")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var (
+		req = require.New(t)
+		cmd = filepath.Join(dir, "pdftoppm")
+		pg  = filepath.Join(dir, "page.jpg")
+		buf = &bytes.Buffer{}
+	)
+
+	req.False(PDF(filepath.Join(dir, "missing")).Supports("application/pdf", "pdf"))
+
+	// Fake renderer that checks the input and outputs prepared page
+	req.NoError(jpeg.Encode(buf, image.NewRGBA(image.Rect(0, 0, 64, 48)), nil))
+	req.NoError(ioutil.WriteFile(pg, buf.Bytes(), 0644))
+	req.NoError(ioutil.WriteFile(cmd, []byte("#!/bin/sh\ngrep -q '^%PDF' || exit 1\ncat "+pg+"\n"), 0755))
+
+	g := PDF(cmd)
+	req.True(g.Supports("application/pdf", "pdf"))
+	req.False(g.Supports("application/zip", "pdf"))
+
+	img, err := g.Generate(context.Background(), strings.NewReader("%PDF-1.4\n..."), "application/pdf", "pdf")
+	req.NoError(err)
+	req.Equal(image.Rect(0, 0, 64, 48), img.Bounds())
+
+	_, err = g.Generate(context.Background(), strings.NewReader("not a pdf"), "application/pdf", "pdf")
+	req.Error(err)
+
+	// Renderer is stopped when context is done
+	req.NoError(ioutil.WriteFile(cmd, []byte("#!/bin/sh\nexec sleep 10\n"), 0755))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	started := time.Now()
+	_, err = g.Generate(ctx, strings.NewReader("%PDF-1.4\n..."), "application/pdf", "pdf")
+	req.Error(err)
+	req.True(time.Since(started) < 5*time.Second)
+}
+
+func TestQueue(t *testing.T) {
+	var (
+		req   = require.New(t)
+		saved = make(chan image.Image, 1)
+	)
+
+	defer func(gg []Generator) { generators = gg }(generators)
+	Register(testGenerator{img: image.NewRGBA(image.Rect(0, 0, 64, 64))})
+
+	doc := Document{
+		Mimetype:  "text/plain",
+		Extension: "txt",
+		MaxWidth:  32,
+		MaxHeight: 32,
+		Open: func() (io.ReadSeeker, error) {
+			return strings.NewReader("Lorem ipsum"), nil
+		},
+		Save: func(buf *bytes.Buffer, width, height int) error {
+			img, err := jpeg.Decode(buf)
+			req.NoError(err)
+			saved <- img
+			return nil
+		},
+	}
+
+	Queue(doc)
+
+	select {
+	case img := <-saved:
+		req.Equal(image.Rect(0, 0, 32, 32), img.Bounds())
+	case <-time.After(5 * time.Second):
+		req.Fail("preview was not saved")
+	}
+}
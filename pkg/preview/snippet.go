@@ -0,0 +1,142 @@
+package preview
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"image/draw"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/cortezaproject/corteza-server/pkg/textract"
+)
+
+const (
+	// SnippetLength is max number of characters in the text snippet
+	SnippetLength = 600
+
+	snippetWidth  = 320
+	snippetHeight = 180
+	snippetMargin = 8
+)
+
+type (
+	// snippetGenerator renders beginning of the text
+	// extracted from the file by the text extractor
+	snippetGenerator struct {
+		textract.Extractor
+	}
+)
+
+// Text makes snippet previews of plain-text files
+func Text() Generator {
+	return &snippetGenerator{Extractor: textract.Text()}
+}
+
+// Office makes snippet previews of office documents (OOXML & OpenDocument)
+func Office() Generator {
+	return &snippetGenerator{Extractor: textract.Office()}
+}
+
+func (g snippetGenerator) Generate(_ context.Context, r io.ReadSeeker, mimetype, extension string) (image.Image, error) {
+	// Extract enough bytes for the snippet, even when all characters are multi-byte
+	text, err := g.Extract(r, mimetype, extension, SnippetLength*utf8.UTFMax)
+	if err != nil {
+		return nil, err
+	}
+
+	return Snippet(text), nil
+}
+
+// Snippet renders beginning of the text to an image
+//
+// Nil is returned when there is no text to render
+func Snippet(text string) image.Image {
+	var (
+		lines = snippetLines(text)
+		img   = image.NewRGBA(image.Rect(0, 0, snippetWidth, snippetHeight))
+		face  = basicfont.Face7x13
+	)
+
+	if len(lines) == 0 {
+		return nil
+	}
+
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+
+	d := &font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(color.RGBA{R: 0x33, G: 0x33, B: 0x33, A: 0xff}),
+		Face: face,
+	}
+
+	for i, line := range lines {
+		d.Dot = fixed.P(snippetMargin, snippetMargin+face.Ascent+i*face.Height)
+		d.DrawString(line)
+	}
+
+	return img
+}
+
+// snippetLines splits text into lines that fit the snippet image
+//
+// Long lines are wrapped at the last space (or cut when there is none)
+func snippetLines(text string) (lines []string) {
+	var (
+		face     = basicfont.Face7x13
+		maxChars = (snippetWidth - 2*snippetMargin) / face.Advance
+		maxLines = (snippetHeight - 2*snippetMargin) / face.Height
+	)
+
+	if utf8.RuneCountInString(text) > SnippetLength {
+		text = string([]rune(text)[:SnippetLength])
+	}
+
+	text = strings.Map(func(r rune) rune {
+		switch {
+		case r == '\n':
+			return r
+		case unicode.IsSpace(r):
+			return ' '
+		case unicode.IsControl(r):
+			return -1
+		}
+
+		return r
+	}, text)
+
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		var rr = []rune(strings.TrimRight(line, " "))
+
+		for len(rr) > maxChars {
+			cut := maxChars
+			for i := maxChars; i > 0; i-- {
+				if rr[i] == ' ' {
+					cut = i
+					break
+				}
+			}
+
+			lines = append(lines, strings.TrimRight(string(rr[:cut]), " "))
+			rr = []rune(strings.TrimLeft(string(rr[cut:]), " "))
+		}
+
+		lines = append(lines, string(rr))
+	}
+
+	if len(lines) > maxLines {
+		lines = lines[:maxLines]
+	}
+
+	if len(lines) == 1 && lines[0] == "" {
+		return nil
+	}
+
+	return lines
+}
@@ -59,6 +59,10 @@ type (
 	Function struct {
 		Name      string
 		Arguments ASTSet
+
+		// Aggregate over distinct values of the (only) argument
+		// Example: COUNT(DISTINCT foo)
+		Distinct bool
 	}
 )
 
@@ -117,8 +121,21 @@ func (n Keyword) String() string        { return n.Keyword }
 func (n Interval) Validate() (err error) { return }
 func (n Interval) String() string        { return fmt.Sprintf("INTERVAL %s %s", n.Value, n.Unit) }
 
-func (n Function) Validate() (err error) { return }
-func (n Function) String() string        { return fmt.Sprintf("%s(%s)", n.Name, n.Arguments) }
+func (n Function) Validate() (err error) {
+	if n.Distinct && len(n.Arguments) != 1 {
+		return fmt.Errorf("DISTINCT in function '%s' expects exactly one argument", n.Name)
+	}
+
+	return
+}
+
+func (n Function) String() string {
+	if n.Distinct {
+		return fmt.Sprintf("%s(DISTINCT %s)", n.Name, n.Arguments)
+	}
+
+	return fmt.Sprintf("%s(%s)", n.Name, n.Arguments)
+}
 
 func (n Ident) Validate() (err error) { return }
 func (n Ident) String() string        { return n.Value }
@@ -225,9 +225,22 @@ func (p *Parser) parseIdent(t Token) (list ASTNode, err error) {
 		f := Function{Name: t.literal}
 		if f.Arguments, err = p.parseSet(); err != nil {
 			return nil, err
-		} else {
-			return p.OnFunction(f)
 		}
+
+		// DISTINCT is parsed as a keyword in front of the first argument:
+		// <IDENT>(<KEYWORD:DISTINCT> <arg>)
+		if len(f.Arguments) > 0 {
+			if k, ok := f.Arguments[0].(Keyword); ok && strings.ToUpper(k.Keyword) == "DISTINCT" {
+				f.Distinct = true
+				f.Arguments = f.Arguments[1:]
+			}
+		}
+
+		if err = f.Validate(); err != nil {
+			return nil, err
+		}
+
+		return p.OnFunction(f)
 	}
 
 	i := Ident{Value: t.literal}
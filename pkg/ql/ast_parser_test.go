@@ -319,6 +319,34 @@ func TestAstParser_ColumnParser(t *testing.T) {
 				},
 			},
 		},
+		{
+			in: `count(DISTINCT value1) AS uniq, percentile(value2, 0.9)`,
+			cols: Columns{
+				Column{
+					Expr: ASTNodes{Function{
+						Name: "count",
+						Arguments: ASTSet{
+							Ident{Value: "value1"},
+						},
+						Distinct: true,
+					}},
+					Alias: "uniq",
+				},
+				Column{
+					Expr: ASTNodes{Function{
+						Name: "percentile",
+						Arguments: ASTSet{
+							Ident{Value: "value2"},
+							LNumber{Value: "0.9"},
+						},
+					}},
+				},
+			},
+		},
+		{
+			in:  `count(DISTINCT a, b)`,
+			err: "DISTINCT in function 'count' expects exactly one argument",
+		},
 		{
 			in: `a DESC`,
 			cols: Columns{
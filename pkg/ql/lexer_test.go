@@ -31,10 +31,12 @@ func TestScanner_ScanSimple(t *testing.T) {
 		{s: `'escaped \' quote'`, tok: LSTRING, lit: "escaped ' quote"},
 		{s: `'double \\ escape'`, tok: LSTRING, lit: "double \\ escape"},
 		{s: `12345`, tok: LNUMBER, lit: "12345"},
+		{s: `123.45`, tok: LNUMBER, lit: "123.45"},
 
 		// Identifiers
 		{s: `foo`, tok: IDENT, lit: `foo`},
 		{s: `Zx12_3U_-`, tok: IDENT, lit: `Zx12_3U_`},
+		{s: `account.industry`, tok: IDENT, lit: `account.industry`},
 
 		// Parenthesis
 		{s: `(`, tok: PARENTHESIS_OPEN, lit: `(`},
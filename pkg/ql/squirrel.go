@@ -4,9 +4,11 @@ package ql
 // This helps us to throw columns into squirrel's select builder
 
 import (
-	"fmt"
+	"strings"
 
 	"github.com/Masterminds/squirrel"
+
+	"github.com/cortezaproject/corteza-server/pkg/db/dialect"
 )
 
 // ToSql concatenates outputs and arguments from all nodes
@@ -80,13 +82,32 @@ func (n LBoolean) ToSql() (string, []interface{}, error) {
 	}
 }
 
+// ToSql renders function call in the current SQL dialect
 func (n Function) ToSql() (string, []interface{}, error) {
-	if paramsSql, args, err := n.Arguments.ToSql(); err != nil {
-		return "", nil, err
-	} else {
-		return fmt.Sprintf("%s(%s)", n.Name, paramsSql), args, nil
+	var (
+		params = make([]string, len(n.Arguments))
+		args   []interface{}
+	)
+
+	for i, a := range n.Arguments {
+		if f, is := a.(LString); is && i == 1 && strings.ToUpper(n.Name) == "DATE_FORMAT" {
+			f.Value = dialect.Current().DateFormat(f.Value)
+			a = f
+		}
+
+		if aSql, aArgs, err := a.ToSql(); err != nil {
+			return "", nil, err
+		} else {
+			params[i] = aSql
+			args = append(args, aArgs...)
+		}
+	}
+
+	if n.Distinct && len(params) > 0 {
+		params[0] = "DISTINCT " + params[0]
 	}
 
+	return dialect.Current().Function(n.Name, params), args, nil
 }
 
 func (n Keyword) ToSql() (string, []interface{}, error) {
@@ -94,7 +115,7 @@ func (n Keyword) ToSql() (string, []interface{}, error) {
 }
 
 func (n Interval) ToSql() (string, []interface{}, error) {
-	return fmt.Sprintf("INTERVAL ? %s", n.Unit), []interface{}{n.Value}, nil
+	return dialect.Current().Interval(n.Unit), []interface{}{n.Value}, nil
 }
 
 func (n Operator) ToSql() (string, []interface{}, error) {
@@ -103,7 +124,7 @@ func (n Operator) ToSql() (string, []interface{}, error) {
 	switch n.Kind {
 	case "LIKE", "NOT LIKE":
 		// Make sure we are doing case insensitive search
-		op = "COLLATE utf8_general_ci " + n.Kind
+		op = dialect.Current().Like(n.Kind == "NOT LIKE")
 	}
 
 	return " " + op + " ", nil, nil
@@ -71,10 +71,12 @@ func (TokenConsumerIdent) Consume(s RuneReader) Token {
 
 	// Read every subsequent ident character into the buffer.
 	// Non-ident characters and EOF will cause the loop to exit.
+	//
+	// Dots are part of the identifier (path to the field of the referenced record)
 	for {
 		if ch := s.read(); ch == eof {
 			break
-		} else if !isLetter(ch) && !isDigit(ch) && ch != '_' {
+		} else if !isLetter(ch) && !isDigit(ch) && ch != '_' && ch != '.' {
 			s.unread()
 			break
 		} else {
@@ -91,7 +93,7 @@ func (TokenConsumerIdent) Consume(s RuneReader) Token {
 		return Token{code: LBOOL, literal: lit}
 	case "IS", "LIKE", "NOT", "AND", "OR", "XOR":
 		return Token{code: OPERATOR, literal: lit}
-	case "DESC", "ASC", "INTERVAL":
+	case "DESC", "ASC", "INTERVAL", "DISTINCT":
 		return Token{code: KEYWORD, literal: lit}
 	}
 
@@ -135,11 +137,16 @@ func (str TokenConsumerNumber) Test(ch rune) bool {
 func (str TokenConsumerNumber) Consume(s RuneReader) Token {
 	// Create a buffer and read the current character into it.
 	var buf bytes.Buffer
+	var decimal bool
 	buf.WriteRune(s.read())
 
 	for {
 		if ch := s.read(); ch == eof {
 			break
+		} else if ch == '.' && !decimal {
+			// Allow one decimal point
+			decimal = true
+			_, _ = buf.WriteRune(ch)
 		} else if !isDigit(ch) {
 			s.unread()
 			break
@@ -0,0 +1,122 @@
+package retention
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/cortezaproject/corteza-server/pkg/sentry"
+	"github.com/cortezaproject/corteza-server/pkg/store"
+)
+
+type (
+	// Orphan is an attachment that is no longer referenced
+	// and can be removed together with its stored files
+	Orphan struct {
+		ID uint64
+
+		// Size of the original file
+		Size int64
+
+		// Stored files (original and preview); empty ones are skipped
+		Files []string
+
+		// Additional fields for log entries
+		Fields []zap.Field
+	}
+
+	// Report summarizes the applied retention
+	Report struct {
+		// Attachments orphaned before this time were included
+		Before time.Time
+
+		// Nothing was removed when true
+		DryRun bool
+
+		// Total size of the attachments (original files)
+		Size int64
+
+		// Number of attachments that could not be removed
+		Failed uint
+	}
+)
+
+// Watch periodically calls apply with the start of the retention period
+//
+// Nothing is done when retention is not configured
+func Watch(ctx context.Context, log *zap.Logger, days int, interval time.Duration, dryRun bool, apply func(ctx context.Context, before time.Time, dryRun bool)) {
+	if days <= 0 || interval <= 0 {
+		return
+	}
+
+	go func() {
+		defer sentry.Recover()
+
+		var ticker = time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				apply(ctx, time.Now().AddDate(0, 0, -days), dryRun)
+			}
+		}
+	}()
+
+	log.Debug("watcher initialized", zap.Int("days", days), zap.Bool("dryRun", dryRun))
+}
+
+// Apply removes stored files of the orphaned attachments and purges them with the given function
+//
+// Attachments are only reported (logged) in dry-run mode
+func Apply(log *zap.Logger, s store.Store, before time.Time, dryRun bool, oo []Orphan, purge func(ID uint64) error) (r Report) {
+	r = Report{Before: before, DryRun: dryRun}
+
+	for _, o := range oo {
+		r.Size += o.Size
+
+		log := log.With(zap.Uint64("attachmentID", o.ID), zap.Int64("size", o.Size)).With(o.Fields...)
+
+		if dryRun {
+			log.Info("orphaned attachment")
+			continue
+		}
+
+		if err := remove(s, o, purge); err != nil {
+			log.Error("could not remove orphaned attachment", zap.Error(err))
+			r.Failed++
+			continue
+		}
+
+		log.Info("orphaned attachment removed")
+	}
+
+	log.Info("attachment retention applied",
+		zap.Time("before", before),
+		zap.Bool("dryRun", dryRun),
+		zap.Int("count", len(oo)),
+		zap.Int64("size", r.Size),
+		zap.Uint("failed", r.Failed),
+	)
+
+	return r
+}
+
+// remove deletes stored files and purges the attachment itself
+func remove(s store.Store, o Orphan, purge func(ID uint64) error) error {
+	for _, f := range o.Files {
+		if f == "" {
+			continue
+		}
+
+		// File might already be gone
+		if err := s.Remove(f); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return purge(o.ID)
+}
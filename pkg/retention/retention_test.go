@@ -0,0 +1,58 @@
+package retention
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/cortezaproject/corteza-server/pkg/store/plain"
+)
+
+func TestApply(t *testing.T) {
+	var (
+		req    = require.New(t)
+		before = time.Now()
+		purged []uint64
+
+		purge = func(ID uint64) error {
+			if ID == 3 {
+				return errors.New("purge failed")
+			}
+
+			purged = append(purged, ID)
+			return nil
+		}
+	)
+
+	s, err := plain.NewWithAfero(afero.NewMemMapFs(), "test")
+	req.NoError(err)
+
+	oo := []Orphan{
+		{ID: 1, Size: 10, Files: []string{s.Original(1, "txt"), ""}},
+		// Files that are already gone are not an error
+		{ID: 2, Size: 20, Files: []string{s.Original(2, "txt")}},
+		{ID: 3, Size: 30, Files: []string{s.Original(3, "txt")}},
+	}
+
+	req.NoError(s.Save(s.Original(1, "txt"), bytes.NewBufferString("1")))
+	req.NoError(s.Save(s.Original(3, "txt"), bytes.NewBufferString("3")))
+
+	r := Apply(zap.NewNop(), s, before, true, oo, purge)
+	req.Equal(Report{Before: before, DryRun: true, Size: 60}, r)
+	req.Empty(purged)
+
+	_, err = s.Open(s.Original(1, "txt"))
+	req.NoError(err)
+
+	r = Apply(zap.NewNop(), s, before, false, oo, purge)
+	req.Equal(Report{Before: before, Size: 60, Failed: 1}, r)
+	req.Equal([]uint64{1, 2}, purged)
+
+	_, err = s.Open(s.Original(1, "txt"))
+	req.Error(err)
+}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/Masterminds/squirrel"
 	"github.com/titpetric/factory"
+
+	"github.com/cortezaproject/corteza-server/pkg/db/dialect"
 )
 
 // DailyMetrics aids repositories on simple stat building queries
@@ -19,7 +21,7 @@ func DailyMetrics(db *factory.DB, q squirrel.SelectBuilder, field string) (rval
 	)
 
 	q = q.
-		Column(fmt.Sprintf("UNIX_TIMESTAMP(DATE(%s)) timestamp", field)).
+		Column(fmt.Sprintf("%s AS timestamp", dialect.Current().DateTimestamp(field))).
 		Column("COUNT(*) AS value").
 		Where(fmt.Sprintf("%s IS NOT NULL", field)).
 		OrderBy("timestamp").
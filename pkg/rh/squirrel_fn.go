@@ -4,6 +4,8 @@ import (
 	"strings"
 
 	"github.com/Masterminds/squirrel"
+
+	"github.com/cortezaproject/corteza-server/pkg/db/dialect"
 )
 
 type (
@@ -21,30 +23,26 @@ func SquirrelFunction(name string, args ...squirrel.Sqlizer) *squirrelFunction {
 	return &squirrelFunction{name: name, fargs: args}
 }
 
+// ToSql renders function call in the current SQL dialect
 func (f squirrelFunction) ToSql() (sql string, args []interface{}, err error) {
 	var (
-		aSql  string
-		aArgs []interface{}
+		aSql   string
+		aArgs  []interface{}
+		params = make([]string, len(f.fargs))
 	)
 
-	sql = f.name + "("
 	args = make([]interface{}, 0)
 	for a := 0; a < len(f.fargs); a++ {
-		if a > 0 {
-			sql += ", "
-		}
-
 		aSql, aArgs, err = f.fargs[a].ToSql()
 		if err != nil {
 			return
 		}
 
-		sql += aSql
+		params[a] = aSql
 		args = append(args, aArgs...)
 	}
-	sql += ")"
 
-	return
+	return dialect.Current().Function(f.name, params), args, nil
 }
 
 type (
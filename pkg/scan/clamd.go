@@ -0,0 +1,121 @@
+package scan
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// size of the chunks file is streamed in
+	clamdChunkSize = 1 << 15
+)
+
+type (
+	clamd struct {
+		network string
+		address string
+		timeout time.Duration
+	}
+)
+
+// Clamd scans files for malware with ClamAV daemon
+//
+// Address is given as tcp://host:port, unix:///path/to/clamd.ctl or host:port.
+// Files are streamed to the daemon with INSTREAM command; make sure that
+// StreamMaxLength in clamd.conf is not smaller than the max upload size
+func Clamd(addr string, timeout time.Duration) Scanner {
+	c := &clamd{network: "tcp", address: addr, timeout: timeout}
+
+	switch {
+	case strings.HasPrefix(addr, "unix://"):
+		c.network, c.address = "unix", strings.TrimPrefix(addr, "unix://")
+	case strings.HasPrefix(addr, "tcp://"):
+		c.address = strings.TrimPrefix(addr, "tcp://")
+	}
+
+	return c
+}
+
+func (c clamd) Scan(r io.ReadSeeker, _, _ string) error {
+	conn, err := net.DialTimeout(c.network, c.address, c.timeout)
+	if err != nil {
+		return errors.Wrap(err, "could not connect to clamd")
+	}
+
+	defer conn.Close()
+
+	if c.timeout > 0 {
+		if err = conn.SetDeadline(time.Now().Add(c.timeout)); err != nil {
+			return err
+		}
+	}
+
+	if err = c.stream(conn, r); err != nil {
+		return errors.Wrap(err, "could not stream file to clamd")
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && err != io.EOF {
+		return errors.Wrap(err, "could not read clamd reply")
+	}
+
+	return clamdResult(reply)
+}
+
+// stream sends file to clamd in chunks, each prefixed with its size
+func (clamd) stream(w io.Writer, r io.Reader) (err error) {
+	var (
+		buf  = make([]byte, clamdChunkSize)
+		size = make([]byte, 4)
+		n    int
+	)
+
+	if _, err = w.Write([]byte("zINSTREAM\x00")); err != nil {
+		return
+	}
+
+	for {
+		n, err = r.Read(buf)
+		if n > 0 {
+			binary.BigEndian.PutUint32(size, uint32(n))
+			if _, err := w.Write(size); err != nil {
+				return err
+			}
+
+			if _, err := w.Write(buf[:n]); err != nil {
+				return err
+			}
+		}
+
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return
+		}
+	}
+
+	// Zero length chunk terminates the stream
+	binary.BigEndian.PutUint32(size, 0)
+	_, err = w.Write(size)
+	return
+}
+
+// clamdResult converts clamd reply (stream: OK, stream: <signature> FOUND, <message> ERROR)
+func clamdResult(reply string) error {
+	reply = strings.TrimPrefix(strings.TrimRight(reply, "\x00\n"), "stream: ")
+
+	switch {
+	case reply == "OK":
+		return nil
+	case strings.HasSuffix(reply, " FOUND"):
+		return Rejected("malware detected (%s)", strings.TrimSuffix(reply, " FOUND"))
+	}
+
+	return errors.Errorf("clamd: %s", reply)
+}
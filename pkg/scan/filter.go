@@ -0,0 +1,78 @@
+package scan
+
+import (
+	"io"
+	"mime"
+	"strings"
+)
+
+type (
+	// Rules for matching files by mimetype and extension
+	//
+	// Mimetypes can end with a wildcard (image/*) and
+	// extensions are given without the leading dot
+	Rules struct {
+		Mimetypes  []string
+		Extensions []string
+	}
+
+	filter struct {
+		allow Rules
+		deny  Rules
+	}
+)
+
+// Filter rejects files by their (sniffed) mimetype and extension
+//
+// Files matching deny rules are always rejected. When there are
+// allow rules, file needs to match them to be accepted
+func Filter(allow, deny Rules) Scanner {
+	return &filter{allow: allow, deny: deny}
+}
+
+func (f filter) Scan(_ io.ReadSeeker, mimetype, extension string) error {
+	if mt, _, err := mime.ParseMediaType(mimetype); err == nil {
+		// Strip parameters (charset...)
+		mimetype = mt
+	}
+
+	mimetype = strings.ToLower(mimetype)
+	extension = strings.ToLower(strings.Trim(extension, "."))
+
+	switch {
+	case matchMimetype(f.deny.Mimetypes, mimetype):
+		return Rejected("file type %s is not allowed", mimetype)
+
+	case matchExtension(f.deny.Extensions, extension):
+		return Rejected("file extension %s is not allowed", extension)
+
+	case len(f.allow.Mimetypes) > 0 && !matchMimetype(f.allow.Mimetypes, mimetype):
+		return Rejected("file type %s is not allowed", mimetype)
+
+	case len(f.allow.Extensions) > 0 && !matchExtension(f.allow.Extensions, extension):
+		return Rejected("file extension %s is not allowed", extension)
+	}
+
+	return nil
+}
+
+func matchMimetype(mm []string, mimetype string) bool {
+	for _, m := range mm {
+		m = strings.ToLower(m)
+		if m == mimetype || m == "*/*" || (strings.HasSuffix(m, "/*") && strings.HasPrefix(mimetype, m[:len(m)-1])) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchExtension(ee []string, extension string) bool {
+	for _, e := range ee {
+		if strings.ToLower(strings.Trim(e, ".")) == extension {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,99 @@
+package scan
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/cortezaproject/corteza-server/pkg/app/options"
+)
+
+type (
+	// Scanner inspects uploaded file before it is stored
+	Scanner interface {
+		// Scan returns RejectedError when file must not be stored
+		//
+		// Any other error means that the file could not be scanned
+		Scan(r io.ReadSeeker, mimetype, extension string) error
+	}
+
+	// RejectedError is returned by scanners for files that must not be stored
+	RejectedError struct {
+		Reason string
+	}
+
+	chain []Scanner
+)
+
+func (e RejectedError) Error() string {
+	return "upload rejected: " + e.Reason
+}
+
+// Rejected returns RejectedError with the formatted reason
+func Rejected(format string, a ...interface{}) error {
+	return RejectedError{Reason: fmt.Sprintf(format, a...)}
+}
+
+// IsRejected checks if (wrapped) error is RejectedError
+func IsRejected(err error) bool {
+	_, ok := errors.Cause(err).(RejectedError)
+	return ok
+}
+
+// New configures scanners from the options
+//
+// Files are checked against allow & deny lists first and
+// scanned with ClamAV daemon (when configured) after that
+func New(opt options.UploadScanOpt) Scanner {
+	var ss = []Scanner{
+		Filter(
+			Rules{Mimetypes: split(opt.AllowedMimetypes), Extensions: split(opt.AllowedExtensions)},
+			Rules{Mimetypes: split(opt.DeniedMimetypes), Extensions: split(opt.DeniedExtensions)},
+		),
+	}
+
+	if opt.ClamdAddr != "" {
+		ss = append(ss, Clamd(opt.ClamdAddr, opt.ClamdTimeout))
+	}
+
+	return Chain(ss...)
+}
+
+// Chain runs scanners one after another until one of them fails
+//
+// File is rewound before each scanner and when all are done
+func Chain(ss ...Scanner) Scanner {
+	return chain(ss)
+}
+
+func (cc chain) Scan(r io.ReadSeeker, mimetype, extension string) (err error) {
+	extension = strings.ToLower(strings.Trim(extension, "."))
+
+	// Make sure we rewind when we're done
+	defer r.Seek(0, io.SeekStart)
+
+	for _, s := range cc {
+		if _, err = r.Seek(0, io.SeekStart); err != nil {
+			return
+		}
+
+		if err = s.Scan(r, mimetype, extension); err != nil {
+			return
+		}
+	}
+
+	return nil
+}
+
+// split comma separated list and trims its items
+func split(list string) (out []string) {
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+
+	return
+}
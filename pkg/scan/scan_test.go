@@ -0,0 +1,172 @@
+package scan
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortezaproject/corteza-server/pkg/app/options"
+)
+
+type (
+	readingScanner struct{ read []string }
+)
+
+func (s *readingScanner) Scan(r io.ReadSeeker, _, _ string) error {
+	b, err := ioutil.ReadAll(r)
+	s.read = append(s.read, string(b))
+	return err
+}
+
+// fakeClamd accepts one INSTREAM command and replies with the given reply
+// when the streamed file contains the given signature
+func fakeClamd(t *testing.T, signature, reply string) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	go func() {
+		defer l.Close()
+
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+
+		defer conn.Close()
+
+		var (
+			r    = bufio.NewReader(conn)
+			file = &bytes.Buffer{}
+			size uint32
+		)
+
+		if cmd, err := r.ReadString(0); err != nil || cmd != "zINSTREAM\x00" {
+			conn.Write([]byte("UNKNOWN COMMAND\x00"))
+			return
+		}
+
+		for {
+			if err = binary.Read(r, binary.BigEndian, &size); err != nil || size == 0 {
+				break
+			}
+
+			if _, err = io.CopyN(file, r, int64(size)); err != nil {
+				return
+			}
+		}
+
+		if strings.Contains(file.String(), signature) {
+			conn.Write([]byte(reply + "\x00"))
+		} else {
+			conn.Write([]byte("stream: OK\x00"))
+		}
+	}()
+
+	return l.Addr().String()
+}
+
+func TestFilter(t *testing.T) {
+	var (
+		req = require.New(t)
+
+		f = Filter(
+			Rules{Mimetypes: []string{"image/*", "application/pdf", "text/plain"}},
+			Rules{Mimetypes: []string{"image/svg+xml"}, Extensions: []string{".exe", "JS"}},
+		)
+	)
+
+	req.NoError(f.Scan(nil, "image/png", "png"))
+	req.NoError(f.Scan(nil, "application/PDF", "pdf"))
+	req.NoError(f.Scan(nil, "text/plain; charset=utf-8", "txt"))
+
+	req.True(IsRejected(f.Scan(nil, "image/svg+xml", "svg")), "denied mimetype")
+	req.True(IsRejected(f.Scan(nil, "text/plain; charset=utf-8", "js")), "denied extension")
+	req.True(IsRejected(f.Scan(nil, "application/zip", "docx")), "mimetype not allowed")
+
+	f = Filter(Rules{Extensions: []string{"pdf"}}, Rules{})
+	req.NoError(f.Scan(nil, "application/pdf", ".PDF"))
+	req.True(IsRejected(f.Scan(nil, "application/pdf", "txt")), "extension not allowed")
+
+	req.NoError(Filter(Rules{}, Rules{}).Scan(nil, "application/octet-stream", ""), "allow all without rules")
+}
+
+func TestChain(t *testing.T) {
+	var (
+		req = require.New(t)
+
+		r  = strings.NewReader("file content")
+		s1 = &readingScanner{}
+		s2 = &readingScanner{}
+	)
+
+	req.NoError(Chain(s1, s2).Scan(r, "text/plain", "txt"))
+	req.Equal([]string{"file content"}, s1.read)
+	req.Equal([]string{"file content"}, s2.read, "expecting file to be rewound for each scanner")
+
+	pos, _ := r.Seek(0, io.SeekCurrent)
+	req.Zero(pos, "expecting file to be rewound when done")
+
+	s2.read = nil
+	err := Chain(Filter(Rules{}, Rules{Extensions: []string{"txt"}}), s2).Scan(r, "text/plain", "txt")
+	req.True(IsRejected(err))
+	req.Empty(s2.read, "expecting chain to stop on first rejection")
+}
+
+func TestRejected(t *testing.T) {
+	req := require.New(t)
+
+	req.EqualError(Rejected("file type %s is not allowed", "x/y"), "upload rejected: file type x/y is not allowed")
+	req.True(IsRejected(errors.Wrap(Rejected("wrapped"), "scan failed")))
+	req.False(IsRejected(errors.New("not a rejection")))
+	req.False(IsRejected(nil))
+}
+
+func TestClamd(t *testing.T) {
+	const eicar = `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+
+	var req = require.New(t)
+
+	addr := fakeClamd(t, "EICAR", "stream: Eicar-Test-Signature FOUND")
+	err := Clamd("tcp://"+addr, time.Second).Scan(strings.NewReader(eicar), "text/plain", "txt")
+	req.True(IsRejected(err))
+	req.Contains(err.Error(), "Eicar-Test-Signature")
+
+	// Large (multi-chunk) clean file
+	addr = fakeClamd(t, "EICAR", "stream: Eicar-Test-Signature FOUND")
+	req.NoError(Clamd(addr, time.Second).Scan(strings.NewReader(strings.Repeat("x", clamdChunkSize*3+1)), "", ""))
+
+	addr = fakeClamd(t, "x", "INSTREAM size limit exceeded. ERROR")
+	err = Clamd(addr, time.Second).Scan(strings.NewReader("x"), "", "")
+	req.Error(err)
+	req.False(IsRejected(err), "clamd errors are not rejections")
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	req.NoError(err)
+	req.NoError(l.Close())
+
+	err = Clamd(l.Addr().String(), time.Second).Scan(strings.NewReader("x"), "", "")
+	req.Error(err, "expecting error when clamd is not reachable")
+	req.False(IsRejected(err))
+}
+
+func TestNew(t *testing.T) {
+	var (
+		req = require.New(t)
+		opt = options.UploadScanOpt{DeniedExtensions: " exe, bat ,"}
+	)
+
+	req.NoError(New(opt).Scan(strings.NewReader(""), "text/plain", "txt"))
+	req.True(IsRejected(New(opt).Scan(strings.NewReader(""), "application/octet-stream", "bat")))
+
+	opt.ClamdAddr = fakeClamd(t, "EICAR", "stream: Eicar FOUND")
+	req.True(IsRejected(New(opt).Scan(strings.NewReader("EICAR"), "text/plain", "txt")))
+}
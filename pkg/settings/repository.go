@@ -8,6 +8,8 @@ import (
 	"github.com/Masterminds/squirrel"
 	"github.com/pkg/errors"
 	"github.com/titpetric/factory"
+
+	"github.com/cortezaproject/corteza-server/pkg/db/dialect"
 )
 
 type (
@@ -98,7 +100,7 @@ func (r repository) BulkSet(vv ValueSet) error {
 
 func (r *repository) Set(value *Value) error {
 	value.UpdatedAt = time.Now()
-	return r.db().Replace(r.dbTable, value)
+	return dialect.Replace(r.db(), r.dbTable, value, "name", "rel_owner")
 }
 
 func (r *repository) Delete(name string, ownedBy uint64) error {
@@ -24,3 +24,22 @@ type Store interface {
 	// Healthcheck checks health status of the store
 	Healthcheck(ctx context.Context) error
 }
+
+// Presigner is implemented by stores that can make pre-signed URLs
+// for transferring files directly between the client and the storage backend
+//
+// Files from stores without it are streamed through the server
+type Presigner interface {
+	// PresignedGet returns short-lived URL for downloading the file
+	//
+	// Disposition (when given) is used for Content-Disposition header of the response.
+	// Empty string is returned when file can not be downloaded directly
+	PresignedGet(filename, disposition string) (string, error)
+
+	// PresignedPut returns short-lived URL for uploading the file
+	//
+	// Files uploaded directly bypass upload scanning and storage quotas;
+	// attachment services do not hand these URLs out to clients.
+	// Empty string is returned when file can not be uploaded directly
+	PresignedPut(filename string) (string, error)
+}
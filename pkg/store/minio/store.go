@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net/url"
+	"time"
 
 	minio "github.com/minio/minio-go/v6"
 	"github.com/minio/minio-go/v6/pkg/encrypt"
@@ -21,6 +23,10 @@ type (
 		SecretAccessKey string
 
 		ServerSideEncryptKey []byte
+
+		// How long are pre-signed URLs valid,
+		// URLs are not pre-signed when 0
+		PresignTTL time.Duration
 	}
 
 	store struct {
@@ -29,6 +35,8 @@ type (
 		mc  *minio.Client
 		sse encrypt.ServerSide
 
+		presignTTL time.Duration
+
 		originalFn func(id uint64, ext string) string
 		previewFn  func(id uint64, ext string) string
 	}
@@ -49,6 +57,8 @@ func New(bucket string, opt Options) (s *store, err error) {
 		bucket: bucket,
 		mc:     nil,
 
+		presignTTL: opt.PresignTTL,
+
 		originalFn: defOriginalFn,
 		previewFn:  defPreviewFn,
 	}
@@ -93,12 +103,10 @@ func (s *store) check(name string) error {
 }
 
 func (s store) Original(id uint64, ext string) string {
-	// @todo presigned URL
 	return s.originalFn(id, ext)
 }
 
 func (s store) Preview(id uint64, ext string) string {
-	// @todo presigned URL
 	return s.previewFn(id, ext)
 
 }
@@ -121,6 +129,42 @@ func (s store) Open(name string) (io.ReadSeeker, error) {
 	})
 }
 
+// PresignedGet returns pre-signed URL for downloading the object
+//
+// Objects encrypted with customer provided key (SSE-C) can not be
+// downloaded without the key so URLs are not pre-signed for them
+func (s store) PresignedGet(name, disposition string) (string, error) {
+	if s.presignTTL <= 0 || s.sse != nil {
+		return "", nil
+	}
+
+	params := url.Values{}
+	if disposition != "" {
+		params.Set("response-content-disposition", disposition)
+	}
+
+	u, err := s.mc.PresignedGetObject(s.bucket, name, s.presignTTL, params)
+	if err != nil {
+		return "", err
+	}
+
+	return u.String(), nil
+}
+
+// PresignedPut returns pre-signed URL for uploading the object
+func (s store) PresignedPut(name string) (string, error) {
+	if s.presignTTL <= 0 || s.sse != nil {
+		return "", nil
+	}
+
+	u, err := s.mc.PresignedPutObject(s.bucket, name, s.presignTTL)
+	if err != nil {
+		return "", err
+	}
+
+	return u.String(), nil
+}
+
 func (s *store) Healthcheck(ctx context.Context) error {
 	return nil
 }
@@ -0,0 +1,56 @@
+package minio
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	minio "github.com/minio/minio-go/v6"
+	"github.com/minio/minio-go/v6/pkg/encrypt"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorePresigned(t *testing.T) {
+	var req = require.New(t)
+
+	// Client with region set does not need to contact the server for signing
+	mc, err := minio.NewWithRegion("localhost:9000", "key", "secret", false, "us-east-1")
+	req.NoError(err)
+
+	s := &store{bucket: "test", mc: mc, presignTTL: time.Minute}
+
+	raw, err := s.PresignedGet("123.jpg", "inline; filename=foo.jpg")
+	req.NoError(err)
+
+	u, err := url.Parse(raw)
+	req.NoError(err)
+	req.Equal("localhost:9000", u.Host)
+	req.Equal("/test/123.jpg", u.Path)
+	req.Equal("60", u.Query().Get("X-Amz-Expires"))
+	req.NotEmpty(u.Query().Get("X-Amz-Signature"))
+	req.Equal("inline; filename=foo.jpg", u.Query().Get("response-content-disposition"))
+
+	raw, err = s.PresignedPut("123.jpg")
+	req.NoError(err)
+	req.Contains(raw, "X-Amz-Signature=")
+
+	s.presignTTL = 0
+	raw, err = s.PresignedGet("123.jpg", "")
+	req.NoError(err)
+	req.Empty(raw, "expecting no pre-signed URL when disabled")
+
+	raw, err = s.PresignedPut("123.jpg")
+	req.NoError(err)
+	req.Empty(raw, "expecting no pre-signed upload URL when disabled")
+
+	s.presignTTL = time.Minute
+	s.sse, err = encrypt.NewSSEC([]byte("01234567890123456789012345678901"))
+	req.NoError(err)
+	raw, err = s.PresignedGet("123.jpg", "")
+	req.NoError(err)
+	req.Empty(raw, "expecting no pre-signed URL for SSE-C encrypted objects")
+
+	raw, err = s.PresignedPut("123.jpg")
+	req.NoError(err)
+	req.Empty(raw, "expecting no pre-signed upload URL for SSE-C encrypted objects")
+}
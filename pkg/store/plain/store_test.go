@@ -7,6 +7,8 @@ import (
 
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/require"
+
+	pkgstore "github.com/cortezaproject/corteza-server/pkg/store"
 )
 
 func TestStore(t *testing.T) {
@@ -71,3 +73,12 @@ func TestStoreCheckFunc(t *testing.T) {
 	// Should not cause panic
 	require.True(t, (&store{}).check("") != nil, "Expecting an error to be returned on empty filename check")
 }
+
+func TestStorePresigned(t *testing.T) {
+	s, err := NewWithAfero(afero.NewMemMapFs(), "test")
+	require.NoError(t, err)
+
+	u, err := pkgstore.PresignedGet(s, "test/123.jpg", "")
+	require.NoError(t, err)
+	require.Empty(t, u, "Expecting plain store files to be streamed")
+}
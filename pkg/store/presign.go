@@ -0,0 +1,36 @@
+package store
+
+import (
+	"net/url"
+)
+
+// PresignedGet returns pre-signed URL for downloading the file directly from the store
+//
+// Empty string is returned when store does not support pre-signed URLs
+func PresignedGet(s Store, filename, disposition string) (string, error) {
+	if p, ok := s.(Presigner); ok {
+		return p.PresignedGet(filename, disposition)
+	}
+
+	return "", nil
+}
+
+// PresignedPut returns pre-signed URL for uploading the file directly to the store
+//
+// Empty string is returned when store does not support pre-signed URLs
+func PresignedPut(s Store, filename string) (string, error) {
+	if p, ok := s.(Presigner); ok {
+		return p.PresignedPut(filename)
+	}
+
+	return "", nil
+}
+
+// ContentDisposition returns value for the Content-Disposition header of the served file
+func ContentDisposition(name string, download bool) string {
+	if download {
+		return "attachment; filename=" + url.QueryEscape(name)
+	}
+
+	return "inline; filename=" + url.QueryEscape(name)
+}
@@ -0,0 +1,174 @@
+package textract
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+)
+
+type (
+	officeExtractor struct{}
+
+	// officeFormat describes where text of the (zipped XML) office document is
+	officeFormat struct {
+		// document parts with text, in order
+		parts func(files []*zip.File) []*zip.File
+
+		// text is collected from the elements with these (local) names
+		text map[string]bool
+
+		// these (local) names end a paragraph
+		paragraph map[string]bool
+	}
+)
+
+var (
+	ooxmlText = map[string]bool{"t": true}
+	odfText   = map[string]bool{"p": true, "h": true}
+
+	officeFormats = map[string]officeFormat{
+		"docx": {parts: officeParts("word/document.xml"), text: ooxmlText, paragraph: map[string]bool{"p": true}},
+		"xlsx": {parts: officeParts("xl/sharedStrings.xml"), text: ooxmlText, paragraph: map[string]bool{"si": true}},
+		"pptx": {parts: officeParts("ppt/slides/slide*.xml"), text: ooxmlText, paragraph: map[string]bool{"p": true}},
+		"odt":  {parts: officeParts("content.xml"), text: odfText, paragraph: odfText},
+		"ods":  {parts: officeParts("content.xml"), text: odfText, paragraph: odfText},
+		"odp":  {parts: officeParts("content.xml"), text: odfText, paragraph: odfText},
+	}
+)
+
+// Office extracts text from office documents (OOXML & OpenDocument)
+func Office() Extractor {
+	return &officeExtractor{}
+}
+
+func (officeExtractor) Supports(mimetype, extension string) bool {
+	_, ok := officeFormats[extension]
+	return ok && mimetype == "application/zip"
+}
+
+func (officeExtractor) Extract(r io.ReadSeeker, _, extension string, limit int) (string, error) {
+	return OfficeText(r, extension, limit)
+}
+
+// OfficeText extracts text from the office document
+//
+// Paragraphs are separated with a new line;
+// extraction stops after limit bytes of text (when limit > 0)
+func OfficeText(r io.Reader, extension string, limit int) (string, error) {
+	format, ok := officeFormats[strings.ToLower(extension)]
+	if !ok {
+		return "", nil
+	}
+
+	raw, err := ioutil.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(raw), int64(len(raw)))
+	if err != nil {
+		return "", err
+	}
+
+	var buf = &strings.Builder{}
+
+	for _, part := range format.parts(zr.File) {
+		if err = format.extract(buf, part, limit); err != nil {
+			return "", err
+		}
+
+		if limit > 0 && buf.Len() >= limit {
+			break
+		}
+	}
+
+	return strings.TrimSpace(buf.String()), nil
+}
+
+func (format officeFormat) extract(buf *strings.Builder, part *zip.File, limit int) error {
+	rc, err := part.Open()
+	if err != nil {
+		return err
+	}
+
+	defer rc.Close()
+
+	var (
+		dec = xml.NewDecoder(rc)
+
+		// depth of the elements we are collecting text from
+		depth int
+	)
+
+	for limit <= 0 || buf.Len() < limit {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if format.text[t.Name.Local] {
+				depth++
+			}
+
+		case xml.EndElement:
+			if format.text[t.Name.Local] {
+				depth--
+			}
+
+			if format.paragraph[t.Name.Local] && depth == 0 {
+				buf.WriteString("\n")
+			}
+
+		case xml.CharData:
+			if depth > 0 {
+				buf.Write(t)
+			}
+		}
+	}
+
+	return nil
+}
+
+// officeParts returns function that finds document parts by pattern
+//
+// Parts are sorted naturally (slide2.xml before slide10.xml)
+func officeParts(pattern string) func([]*zip.File) []*zip.File {
+	var (
+		prefix, suffix = pattern, ""
+		wildcard       = strings.Contains(pattern, "*")
+	)
+
+	if wildcard {
+		i := strings.Index(pattern, "*")
+		prefix, suffix = pattern[:i], pattern[i+1:]
+	}
+
+	return func(files []*zip.File) (out []*zip.File) {
+		for _, f := range files {
+			switch {
+			case !wildcard && f.Name == pattern:
+				out = append(out, f)
+			case wildcard && strings.HasPrefix(f.Name, prefix) && strings.HasSuffix(f.Name, suffix) && !strings.Contains(f.Name[len(prefix):], "/"):
+				out = append(out, f)
+			}
+		}
+
+		sort.SliceStable(out, func(i, j int) bool {
+			if len(out[i].Name) != len(out[j].Name) {
+				return len(out[i].Name) < len(out[j].Name)
+			}
+
+			return out[i].Name < out[j].Name
+		})
+
+		return out
+	}
+}
@@ -0,0 +1,68 @@
+package textract
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os/exec"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	pdfExtractTimeout = time.Minute
+)
+
+type (
+	pdfExtractor struct {
+		command string
+	}
+)
+
+// PDF extracts text from PDF documents
+//
+// Text is extracted with pdftotext (poppler-utils) or compatible command;
+// text is not extracted when the command can not be found
+func PDF(command string) Extractor {
+	return &pdfExtractor{command: command}
+}
+
+func (e pdfExtractor) Supports(mimetype, _ string) bool {
+	if mimetype != "application/pdf" {
+		return false
+	}
+
+	_, err := exec.LookPath(e.command)
+	return err == nil
+}
+
+func (e pdfExtractor) Extract(r io.ReadSeeker, _, _ string, limit int) (string, error) {
+	var (
+		out    = &bytes.Buffer{}
+		stderr = &bytes.Buffer{}
+
+		ctx, cancel = context.WithTimeout(context.Background(), pdfExtractTimeout)
+	)
+
+	defer cancel()
+
+	// Reads PDF from stdin and writes text to stdout
+	cmd := exec.CommandContext(ctx, e.command, "-q", "-enc", "UTF-8", "-", "-")
+
+	cmd.Stdin = r
+	cmd.Stdout = out
+	cmd.Stderr = stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", errors.Wrapf(err, "could not extract text from PDF: %s", bytes.TrimSpace(stderr.Bytes()))
+	}
+
+	// Pages are separated with form feeds
+	text := string(bytes.ReplaceAll(out.Bytes(), []byte("\f"), []byte("\n")))
+	if limit > 0 {
+		text = truncate(text, limit)
+	}
+
+	return text, nil
+}
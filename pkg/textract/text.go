@@ -0,0 +1,42 @@
+package textract
+
+import (
+	"io"
+	"io/ioutil"
+	"strings"
+	"unicode/utf8"
+)
+
+type (
+	textExtractor struct{}
+)
+
+// Text extracts text from plain-text files
+func Text() Extractor {
+	return &textExtractor{}
+}
+
+func (textExtractor) Supports(mimetype, _ string) bool {
+	return strings.HasPrefix(mimetype, "text/plain")
+}
+
+func (textExtractor) Extract(r io.ReadSeeker, _, _ string, limit int) (string, error) {
+	var rr io.Reader = r
+	if limit > 0 {
+		rr = io.LimitReader(r, int64(limit))
+	}
+
+	buf, err := ioutil.ReadAll(rr)
+	if err != nil {
+		return "", err
+	}
+
+	// Remove invalid characters (and partial character we might have cut off)
+	return strings.Map(func(r rune) rune {
+		if r == utf8.RuneError {
+			return -1
+		}
+
+		return r
+	}, string(buf)), nil
+}
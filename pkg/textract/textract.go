@@ -0,0 +1,88 @@
+package textract
+
+import (
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// MaxLength is max length (in bytes) of the extracted text
+	MaxLength = 1 << 20
+)
+
+type (
+	// Extractor extracts (plain) text from a file
+	Extractor interface {
+		// Supports returns true if extractor can extract text from the file
+		Supports(mimetype, extension string) bool
+
+		// Extract extracts text from the file
+		//
+		// Extraction can stop after limit bytes of text (when limit > 0)
+		Extract(r io.ReadSeeker, mimetype, extension string, limit int) (string, error)
+	}
+)
+
+var (
+	extractors []Extractor
+)
+
+func init() {
+	Register(Text(), Office(), PDF("pdftotext"))
+}
+
+// Register adds text extractors
+//
+// Extractors registered later take precedence over
+// the existing ones (for the same type of the file).
+// Not safe for concurrent use, register extractors on startup
+func Register(ee ...Extractor) {
+	for _, e := range ee {
+		extractors = append([]Extractor{e}, extractors...)
+	}
+}
+
+// Extract extracts text with the first extractor that supports the file
+//
+// Text is cut to MaxLength; empty string is returned
+// when there is no extractor for the file
+func Extract(r io.ReadSeeker, mimetype, extension string) (string, error) {
+	extension = strings.ToLower(strings.Trim(extension, "."))
+
+	for _, e := range extractors {
+		if !e.Supports(mimetype, extension) {
+			continue
+		}
+
+		if _, err := r.Seek(0, io.SeekStart); err != nil {
+			return "", err
+		}
+
+		// Make sure we rewind when we're done
+		defer r.Seek(0, io.SeekStart)
+
+		text, err := e.Extract(r, mimetype, extension, MaxLength)
+		if err != nil {
+			return "", err
+		}
+
+		return truncate(strings.TrimSpace(text), MaxLength), nil
+	}
+
+	return "", nil
+}
+
+// truncate cuts text to max bytes without splitting (multi-byte) characters
+func truncate(text string, max int) string {
+	if len(text) <= max {
+		return text
+	}
+
+	text = text[:max]
+	for len(text) > 0 && !utf8.ValidString(text) {
+		text = text[:len(text)-1]
+	}
+
+	return text
+}
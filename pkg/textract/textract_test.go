@@ -0,0 +1,147 @@
+package textract
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func zipped(t *testing.T, files map[string]string) *bytes.Reader {
+	var (
+		buf = &bytes.Buffer{}
+		zw  = zip.NewWriter(buf)
+	)
+
+	for name, content := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, zw.Close())
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestExtract(t *testing.T) {
+	var req = require.New(t)
+
+	text, err := Extract(strings.NewReader("  clause 7\xff applies \n"), "text/plain; charset=utf-8", "txt")
+	req.NoError(err)
+	req.Equal("clause 7 applies", text)
+
+	text, err = Extract(zipped(t, map[string]string{"word/document.xml": "<d><p><t>Hello</t></p></d>"}), "application/zip", ".DOCX")
+	req.NoError(err)
+	req.Equal("Hello", text)
+
+	text, err = Extract(strings.NewReader("\x00\x01"), "application/octet-stream", "bin")
+	req.NoError(err)
+	req.Empty(text, "no extractor for binary files")
+
+	text, err = Extract(strings.NewReader(strings.Repeat("x", MaxLength+10)), "text/plain", "txt")
+	req.NoError(err)
+	req.Len(text, MaxLength)
+}
+
+func TestTruncate(t *testing.T) {
+	require.Equal(t, "abc", truncate("abc", 5))
+	require.Equal(t, "ab", truncate("abc", 2))
+	require.Equal(t, "a", truncate("aš", 2), "multi-byte character is not split")
+}
+
+func TestOfficeText(t *testing.T) {
+	tests := []struct {
+		ext   string
+		files map[string]string
+		text  string
+	}{
+		{
+			ext: "docx",
+			files: map[string]string{
+				"word/document.xml": `<w:document xmlns:w="w"><w:body>` +
+					`<w:p><w:r><w:t>Hello</w:t></w:r><w:r><w:t xml:space="preserve"> world</w:t></w:r></w:p>` +
+					`<w:p><w:r><w:t>Second</w:t></w:r></w:p>` +
+					`</w:body></w:document>`,
+				"word/styles.xml": `<w:styles xmlns:w="w"><w:t>ignored</w:t></w:styles>`,
+			},
+			text: "Hello world\nSecond",
+		},
+		{
+			ext: "xlsx",
+			files: map[string]string{
+				"xl/sharedStrings.xml": `<sst><si><t>Name</t></si><si><r><t>Ac</t></r><r><t>me</t></r></si></sst>`,
+			},
+			text: "Name\nAcme",
+		},
+		{
+			ext: "pptx",
+			files: map[string]string{
+				"ppt/slides/slide10.xml":        `<p:sld xmlns:a="a" xmlns:p="p"><a:p><a:r><a:t>Ten</a:t></a:r></a:p></p:sld>`,
+				"ppt/slides/slide2.xml":         `<p:sld xmlns:a="a" xmlns:p="p"><a:p><a:r><a:t>Two</a:t></a:r></a:p></p:sld>`,
+				"ppt/slides/_rels/slide2.xml":   `<a:t>ignored</a:t>`,
+				"ppt/slideLayouts/slide1.xml":   `<a:t>ignored</a:t>`,
+				"ppt/notesSlides/notes1.xml":    `<a:t>ignored</a:t>`,
+				"ppt/slides/slide1.xml":         `<p:sld xmlns:a="a" xmlns:p="p"><a:p><a:r><a:t>One</a:t></a:r></a:p></p:sld>`,
+				"ppt/slides/slide1.xml.rels.xx": `<a:t>ignored</a:t>`,
+			},
+			text: "One\nTwo\nTen",
+		},
+		{
+			ext: "odt",
+			files: map[string]string{
+				"content.xml": `<office:document-content xmlns:office="o" xmlns:text="t"><office:body><office:text>` +
+					`<text:h>Title</text:h><text:p>Some <text:span>styled</text:span> text</text:p>` +
+					`</office:text></office:body></office:document-content>`,
+			},
+			text: "Title\nSome styled text",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.ext, func(t *testing.T) {
+			text, err := OfficeText(zipped(t, test.files), test.ext, 0)
+			require.NoError(t, err)
+			require.Equal(t, test.text, text)
+		})
+	}
+
+	t.Run("limit", func(t *testing.T) {
+		doc := zipped(t, map[string]string{"content.xml": "<d><p>" + strings.Repeat("x", 100) + "</p><p>next</p></d>"})
+		text, err := OfficeText(doc, "odt", 10)
+		require.NoError(t, err)
+		require.NotContains(t, text, "next")
+	})
+}
+
+func TestPDF(t *testing.T) {
+	dir, err := ioutil.TempDir("", "textract")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var (
+		req = require.New(t)
+		cmd = filepath.Join(dir, "pdftotext")
+	)
+
+	req.False(PDF(filepath.Join(dir, "missing")).Supports("application/pdf", "pdf"))
+
+	// Fake extractor that checks the input and outputs two pages
+	req.NoError(ioutil.WriteFile(cmd, []byte("#!/bin/sh\ngrep -q '^%PDF' || exit 1\nprintf 'page one\\fpage two'\n"), 0755))
+
+	e := PDF(cmd)
+	req.True(e.Supports("application/pdf", "pdf"))
+	req.False(e.Supports("text/plain", "pdf"))
+
+	text, err := e.Extract(strings.NewReader("%PDF-1.4\n..."), "application/pdf", "pdf", 0)
+	req.NoError(err)
+	req.Equal("page one\npage two", text)
+
+	_, err = e.Extract(strings.NewReader("not a pdf"), "application/pdf", "pdf", 0)
+	req.Error(err)
+}
@@ -62,8 +62,9 @@ func (app *App) Upgrade(ctx context.Context) (err error) {
 func (app *App) Initialize(ctx context.Context) (err error) {
 	// Connects to all services it needs to
 	err = service.Initialize(ctx, app.Log, service.Config{
-		ActionLog: app.Opts.ActionLog,
-		Storage:   app.Opts.Storage,
+		ActionLog:  app.Opts.ActionLog,
+		Storage:    app.Opts.Storage,
+		UploadScan: app.Opts.UploadScan,
 	})
 
 	if err != nil {
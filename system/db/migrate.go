@@ -13,7 +13,10 @@ import (
 	"github.com/titpetric/factory"
 	"go.uber.org/zap"
 
+	"github.com/cortezaproject/corteza-server/pkg/db/dialect"
 	"github.com/cortezaproject/corteza-server/system/db/mysql"
+	"github.com/cortezaproject/corteza-server/system/db/postgres"
+	"github.com/cortezaproject/corteza-server/system/db/sqlite"
 )
 
 func statements(contents []byte, err error) ([]string, error) {
@@ -26,7 +29,15 @@ func statements(contents []byte, err error) ([]string, error) {
 func Migrate(db *factory.DB, log *zap.Logger) error {
 	log = log.Named("database.migrations")
 
-	statikFS, err := fs.New(mysql.Asset)
+	var asset = mysql.Asset
+	switch dialect.Current().Name() {
+	case dialect.Postgres:
+		asset = postgres.Asset
+	case dialect.SQLite:
+		asset = sqlite.Asset
+	}
+
+	statikFS, err := fs.New(asset)
 	if err != nil {
 		return errors.Wrap(err, "error creating statik filesystem")
 	}
@@ -93,7 +104,7 @@ func Migrate(db *factory.DB, log *zap.Logger) error {
 			status.Status = err.Error()
 		}
 		if useLog {
-			if err := db.Replace("migrations", status); err != nil {
+			if err := dialect.Replace(db, "migrations", status, "project", "filename"); err != nil {
 				return errors.Wrap(err, "migration update failed")
 			}
 		}
@@ -104,8 +115,11 @@ func Migrate(db *factory.DB, log *zap.Logger) error {
 		return err
 	}
 
-	db.Exec("LOCK TABLE migrations WRITE;")
-	defer db.Exec("UNLOCK TABLES")
+	if dialect.Is(dialect.MySQL) {
+		// PostgreSQL locks tables only inside of a transaction, SQLite locks entire database
+		db.Exec("LOCK TABLE migrations WRITE;")
+		defer db.Exec("UNLOCK TABLES")
+	}
 
 	for _, filename := range files {
 		if err := migrate(filename, true); err != nil {
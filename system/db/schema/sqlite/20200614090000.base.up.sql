@@ -0,0 +1,261 @@
+-- SQLite schema, equivalent to the state of the MySQL schema after 20200508070000 migration
+
+CREATE TABLE IF NOT EXISTS sys_organisation (
+  id               BIGINT                   NOT NULL,
+  fqn              TEXT                     NOT NULL, -- fully qualified name of the organisation
+  name             TEXT                     NOT NULL, -- display name of the organisation
+
+  created_at       DATETIME                 NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at       DATETIME                     NULL,
+  archived_at      DATETIME                     NULL,
+  deleted_at       DATETIME                     NULL,
+
+  PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS sys_user (
+  id               BIGINT                   NOT NULL,
+  email            TEXT                     NOT NULL,
+  username         TEXT                     NOT NULL,
+  name             TEXT                     NOT NULL,
+  handle           TEXT                     NOT NULL,
+  kind             VARCHAR(8)               NOT NULL DEFAULT '',
+  meta             TEXT                     NOT NULL,
+  email_confirmed  BOOLEAN                  NOT NULL DEFAULT FALSE,
+
+  rel_organisation BIGINT                   NOT NULL,
+  rel_user_id      BIGINT                   NOT NULL,
+
+  created_at       DATETIME                 NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at       DATETIME                     NULL,
+  suspended_at     DATETIME                     NULL,
+  deleted_at       DATETIME                     NULL,
+
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX sys_user_rel_user_id ON sys_user (rel_user_id);
+
+CREATE TABLE IF NOT EXISTS sys_role (
+  id               BIGINT                   NOT NULL,
+  name             TEXT                     NOT NULL, -- display name of the role
+  handle           TEXT                     NOT NULL, -- role handle string
+
+  created_at       DATETIME                 NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at       DATETIME                     NULL,
+  archived_at      DATETIME                     NULL,
+  deleted_at       DATETIME                     NULL,
+
+  PRIMARY KEY (id)
+);
+
+INSERT OR IGNORE INTO sys_role (id, name, handle) VALUES
+  (1, 'Everyone', 'everyone'),
+  (2, 'Administrators', 'admins');
+
+CREATE TABLE IF NOT EXISTS sys_role_member (
+  rel_role         BIGINT                   NOT NULL,
+  rel_user         BIGINT                   NOT NULL,
+
+  PRIMARY KEY (rel_role, rel_user)
+);
+
+CREATE TABLE IF NOT EXISTS sys_credentials (
+  id               BIGINT                   NOT NULL,
+  rel_owner        BIGINT                   NOT NULL,
+  label            TEXT                     NOT NULL, -- something we can differentiate credentials by
+  kind             VARCHAR(128)             NOT NULL, -- hash, facebook, google, github, linkedin ...
+  credentials      TEXT                     NOT NULL, -- crypted/hashed passwords, secrets, social profile ID
+  meta             TEXT                     NOT NULL,
+  expires_at       DATETIME                     NULL,
+  last_used_at     DATETIME                     NULL,
+
+  created_at       DATETIME                 NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at       DATETIME                     NULL,
+  deleted_at       DATETIME                     NULL,
+
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX sys_credentials_owner ON sys_credentials (rel_owner);
+
+CREATE TABLE IF NOT EXISTS sys_application (
+  id               BIGINT                   NOT NULL,
+  rel_owner        BIGINT                   NOT NULL,
+  name             TEXT                     NOT NULL, -- something we can differentiate application by
+  enabled          BOOLEAN                  NOT NULL,
+
+  unify            TEXT                         NULL, -- unify specific settings
+
+  created_at       DATETIME                 NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at       DATETIME                     NULL,
+  deleted_at       DATETIME                     NULL,
+
+  PRIMARY KEY (id)
+);
+
+INSERT OR IGNORE INTO sys_application (id, name, enabled, rel_owner, unify) VALUES
+( 1, 'Crust Messaging', true, 0,
+  '{"logo": "/applications/crust.jpg", "icon": "/applications/crust_favicon.png", "url": "/messaging/", "listed": true}'
+),
+( 2, 'Crust Compose', true, 0,
+  '{"logo": "/applications/default_logo.jpg", "icon": "/applications/default_icon.png", "url": "/compose/", "listed": true}'
+),
+( 3, 'Crust Admin Area', true, 0,
+  '{"logo": "/applications/crust.jpg", "icon": "/applications/crust_favicon.png", "url": "/admin/", "listed": true}'
+),
+( 4, 'Corteza Jitsi Bridge', true, 0,
+  '{"logo": "/applications/jitsi.png", "icon": "/applications/jitsi_icon.png", "url": "/bridge/jitsi/", "listed": true}'
+),
+( 5, 'Google Maps', true, 0,
+  '{"logo": "/applications/google_maps.png", "icon": "/applications/google_maps_icon.png", "url": "/bridge/google-maps/", "listed": true}'
+);
+
+CREATE TABLE IF NOT EXISTS sys_settings (
+  rel_owner        BIGINT                   NOT NULL DEFAULT 0, -- value owner, 0 for global settings
+  name             VARCHAR(200)             NOT NULL,
+  value            TEXT,
+
+  updated_at       DATETIME                 NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_by       BIGINT                   NOT NULL DEFAULT 0,
+
+  PRIMARY KEY (name, rel_owner)
+);
+
+CREATE TABLE IF NOT EXISTS sys_permission_rules (
+  rel_role         BIGINT                   NOT NULL,
+  resource         VARCHAR(128)             NOT NULL,
+  operation        VARCHAR(128)             NOT NULL,
+  access           SMALLINT                 NOT NULL,
+
+  PRIMARY KEY (rel_role, resource, operation)
+);
+
+CREATE TABLE IF NOT EXISTS messaging_permission_rules (
+  rel_role         BIGINT                   NOT NULL,
+  resource         VARCHAR(128)             NOT NULL,
+  operation        VARCHAR(128)             NOT NULL,
+  access           SMALLINT                 NOT NULL,
+
+  PRIMARY KEY (rel_role, resource, operation)
+);
+
+CREATE TABLE IF NOT EXISTS compose_permission_rules (
+  rel_role         BIGINT                   NOT NULL,
+  resource         VARCHAR(128)             NOT NULL,
+  operation        VARCHAR(128)             NOT NULL,
+  access           SMALLINT                 NOT NULL,
+
+  PRIMARY KEY (rel_role, resource, operation)
+);
+
+CREATE TABLE IF NOT EXISTS sys_automation_script (
+  id               BIGINT                   NOT NULL,
+  rel_namespace    BIGINT                   NOT NULL DEFAULT 0, -- for compatibility only, not used
+  name             VARCHAR(64)              NOT NULL DEFAULT 'unnamed',
+  source           TEXT                     NOT NULL,
+  source_ref       VARCHAR(200)             NOT NULL, -- where is the script located (if remote)
+  async            BOOLEAN                  NOT NULL DEFAULT FALSE,
+  rel_runner       BIGINT                   NOT NULL DEFAULT 0, -- who is running the script? 0 for invoker
+  run_in_ua        BOOLEAN                  NOT NULL DEFAULT FALSE,
+  timeout          INTEGER                  NOT NULL DEFAULT 0,
+  critical         BOOLEAN                  NOT NULL DEFAULT TRUE,
+  enabled          BOOLEAN                  NOT NULL DEFAULT TRUE,
+
+  created_by       BIGINT                   NOT NULL DEFAULT 0,
+  created_at       DATETIME                 NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_by       BIGINT                   NOT NULL DEFAULT 0,
+  updated_at       DATETIME                     NULL,
+  deleted_by       BIGINT                   NOT NULL DEFAULT 0,
+  deleted_at       DATETIME                     NULL,
+
+  PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS sys_automation_trigger (
+  id               BIGINT                   NOT NULL,
+  rel_script       BIGINT                   NOT NULL REFERENCES sys_automation_script (id),
+
+  resource         VARCHAR(128)             NOT NULL,
+  event            VARCHAR(128)             NOT NULL,
+  event_condition  TEXT                     NOT NULL,
+  enabled          BOOLEAN                  NOT NULL DEFAULT TRUE,
+
+  weight           INTEGER                  NOT NULL DEFAULT 0,
+
+  created_by       BIGINT                   NOT NULL DEFAULT 0,
+  created_at       DATETIME                 NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_by       BIGINT                   NOT NULL DEFAULT 0,
+  updated_at       DATETIME                     NULL,
+  deleted_by       BIGINT                   NOT NULL DEFAULT 0,
+  deleted_at       DATETIME                     NULL,
+
+  PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS sys_reminder (
+  id               BIGINT                   NOT NULL,
+  resource         VARCHAR(128)             NOT NULL, -- resource this reminder is bound to
+  payload          TEXT                     NOT NULL,
+  snooze_count     INTEGER                  NOT NULL DEFAULT 0,
+
+  assigned_to      BIGINT                   NOT NULL DEFAULT 0,
+  assigned_by      BIGINT                   NOT NULL DEFAULT 0,
+  assigned_at      DATETIME                 NOT NULL,
+
+  dismissed_by     BIGINT                   NOT NULL DEFAULT 0,
+  dismissed_at     DATETIME                     NULL,
+
+  remind_at        DATETIME                     NULL,
+
+  created_by       BIGINT                   NOT NULL DEFAULT 0,
+  created_at       DATETIME                 NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_by       BIGINT                   NOT NULL DEFAULT 0,
+  updated_at       DATETIME                     NULL,
+  deleted_by       BIGINT                   NOT NULL DEFAULT 0,
+  deleted_at       DATETIME                     NULL,
+
+  PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS sys_attachment (
+  id               BIGINT                   NOT NULL,
+  rel_owner        BIGINT                   NOT NULL,
+
+  kind             VARCHAR(32)              NOT NULL,
+
+  url              VARCHAR(512),
+  preview_url      VARCHAR(512),
+
+  size             INTEGER,
+  mimetype         VARCHAR(255),
+  name             TEXT,
+
+  meta             TEXT,
+
+  created_at       DATETIME                 NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at       DATETIME                     NULL,
+  deleted_at       DATETIME                     NULL,
+
+  PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS sys_actionlog (
+  ts               DATETIME                 NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  actor_ip_addr    VARCHAR(15)              NOT NULL,
+  actor_id         BIGINT,
+  request_origin   VARCHAR(32)              NOT NULL,
+  request_id       VARCHAR(64)              NOT NULL,
+  resource         VARCHAR(128)             NOT NULL,
+  action           VARCHAR(64)              NOT NULL,
+  error            VARCHAR(64)              NOT NULL,
+  severity         SMALLINT                 NOT NULL,
+  description      TEXT,
+  meta             TEXT
+);
+
+CREATE INDEX sys_actionlog_ts             ON sys_actionlog (ts DESC);
+CREATE INDEX sys_actionlog_request_origin ON sys_actionlog (request_origin);
+CREATE INDEX sys_actionlog_actor_id       ON sys_actionlog (actor_id);
+CREATE INDEX sys_actionlog_resource       ON sys_actionlog (resource);
+CREATE INDEX sys_actionlog_action         ON sys_actionlog (action);
@@ -7,6 +7,7 @@ import (
 	"github.com/Masterminds/squirrel"
 	"github.com/titpetric/factory"
 
+	"github.com/cortezaproject/corteza-server/pkg/db/dialect"
 	"github.com/cortezaproject/corteza-server/pkg/rh"
 	"github.com/cortezaproject/corteza-server/system/types"
 )
@@ -139,7 +140,7 @@ func (r *application) Create(mod *types.Application) (*types.Application, error)
 func (r *application) Update(mod *types.Application) (*types.Application, error) {
 	rh.SetCurrentTimeRounded(&mod.UpdatedAt)
 
-	return mod, r.db().Replace(r.table(), mod)
+	return mod, dialect.Replace(r.db(), r.table(), mod, "id")
 }
 
 func (r *application) DeleteByID(id uint64) error {
@@ -19,6 +19,7 @@ type (
 		Find(filter types.AttachmentFilter) (types.AttachmentSet, types.AttachmentFilter, error)
 		FindByID(attachmentID uint64) (*types.Attachment, error)
 		Create(mod *types.Attachment) (*types.Attachment, error)
+		UpdatePreview(mod *types.Attachment) error
 		DeleteByID(attachmentID uint64) error
 	}
 
@@ -129,6 +130,18 @@ func (r attachment) Create(mod *types.Attachment) (*types.Attachment, error) {
 	return mod, r.db().Insert(r.table(), mod)
 }
 
+// UpdatePreview stores preview (url & meta) of the attachment
+func (r attachment) UpdatePreview(mod *types.Attachment) error {
+	now := time.Now()
+	mod.UpdatedAt = &now
+
+	return rh.UpdateColumns(r.db(), r.table(), rh.Set{
+		"preview_url": mod.PreviewUrl,
+		"meta":        mod.Meta,
+		"updated_at":  mod.UpdatedAt,
+	}, squirrel.Eq{"id": mod.ID})
+}
+
 func (r attachment) DeleteByID(attachmentID uint64) error {
 	_, err := r.db().Exec(
 		"UPDATE "+r.table()+" SET deleted_at = NOW() WHERE id = ?",
@@ -7,6 +7,7 @@ import (
 
 	"github.com/titpetric/factory"
 
+	"github.com/cortezaproject/corteza-server/pkg/db/dialect"
 	"github.com/cortezaproject/corteza-server/pkg/rh"
 	"github.com/cortezaproject/corteza-server/system/types"
 )
@@ -101,7 +102,7 @@ func (r *credentials) Create(c *types.Credentials) (*types.Credentials, error) {
 func (r *credentials) Update(c *types.Credentials) (*types.Credentials, error) {
 	updatedAt := time.Now()
 	c.UpdatedAt = &updatedAt
-	return c, r.db().Replace(r.tblname, c)
+	return c, dialect.Replace(r.db(), r.tblname, c, "id")
 }
 
 func (r *credentials) DeleteByID(id uint64) error {
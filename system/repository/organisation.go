@@ -6,6 +6,7 @@ import (
 
 	"github.com/titpetric/factory"
 
+	"github.com/cortezaproject/corteza-server/pkg/db/dialect"
 	"github.com/cortezaproject/corteza-server/pkg/rh"
 	"github.com/cortezaproject/corteza-server/system/types"
 )
@@ -83,7 +84,7 @@ func (r *organisation) Create(mod *types.Organisation) (*types.Organisation, err
 func (r *organisation) Update(mod *types.Organisation) (*types.Organisation, error) {
 	rh.SetCurrentTimeRounded(&mod.UpdatedAt)
 
-	return mod, r.db().Replace(r.organisations, mod)
+	return mod, dialect.Replace(r.db(), r.organisations, mod, "id")
 }
 
 func (r *organisation) ArchiveByID(id uint64) error {
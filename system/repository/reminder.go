@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/cortezaproject/corteza-server/pkg/db/dialect"
 	"github.com/cortezaproject/corteza-server/pkg/rh"
 
 	squirrel "github.com/Masterminds/squirrel"
@@ -159,7 +160,7 @@ func (r reminder) Create(mod *types.Reminder) (rm *types.Reminder, err error) {
 
 func (r reminder) Update(mod *types.Reminder) (*types.Reminder, error) {
 	rh.SetCurrentTimeRounded(&mod.UpdatedAt)
-	return mod, r.db().Replace(r.table(), mod)
+	return mod, dialect.Replace(r.db(), r.table(), mod, "id")
 }
 
 func (r reminder) Delete(ID uint64) error {
@@ -7,6 +7,7 @@ import (
 	"github.com/Masterminds/squirrel"
 	"github.com/titpetric/factory"
 
+	"github.com/cortezaproject/corteza-server/pkg/db/dialect"
 	"github.com/cortezaproject/corteza-server/pkg/rh"
 	"github.com/cortezaproject/corteza-server/system/types"
 )
@@ -183,7 +184,7 @@ func (r *role) Create(mod *types.Role) (*types.Role, error) {
 func (r *role) Update(mod *types.Role) (*types.Role, error) {
 	rh.SetCurrentTimeRounded(&mod.UpdatedAt)
 
-	return mod, r.db().Replace(r.table(), mod)
+	return mod, dialect.Replace(r.db(), r.table(), mod, "id")
 }
 
 func (r *role) ArchiveByID(id uint64) error {
@@ -227,7 +228,7 @@ func (r *role) MemberAddByID(roleID, userID uint64) error {
 		RoleID: roleID,
 		UserID: userID,
 	}
-	return r.db().Replace(r.tableMember(), mod)
+	return dialect.Replace(r.db(), r.tableMember(), mod, "rel_role", "rel_user")
 }
 
 func (r *role) MemberRemoveByID(roleID, userID uint64) error {
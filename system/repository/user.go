@@ -8,6 +8,7 @@ import (
 	"github.com/Masterminds/squirrel"
 	"github.com/titpetric/factory"
 
+	"github.com/cortezaproject/corteza-server/pkg/db/dialect"
 	"github.com/cortezaproject/corteza-server/pkg/permissions"
 	"github.com/cortezaproject/corteza-server/pkg/rh"
 	"github.com/cortezaproject/corteza-server/system/types"
@@ -223,7 +224,7 @@ func (r *user) Create(mod *types.User) (*types.User, error) {
 
 func (r *user) Update(mod *types.User) (*types.User, error) {
 	rh.SetCurrentTimeRounded(&mod.UpdatedAt)
-	return mod, r.db().Replace(r.table(), mod)
+	return mod, dialect.Replace(r.db(), r.table(), mod, "id")
 }
 
 func (r *user) BindAvatar(user *types.User, avatar io.Reader) (*types.User, error) {
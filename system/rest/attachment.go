@@ -10,6 +10,7 @@ import (
 	"github.com/titpetric/factory/resputil"
 
 	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/store"
 	"github.com/cortezaproject/corteza-server/system/rest/request"
 	"github.com/cortezaproject/corteza-server/system/service"
 	"github.com/cortezaproject/corteza-server/system/types"
@@ -112,6 +113,15 @@ func (ctrl Attachment) serve(ctx context.Context, attachmentID uint64, preview,
 			return
 		}
 
+		// Let client download the file directly from the store when possible
+		if u, err := ctrl.attachment.PresignedURL(att, preview, download); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		} else if u != "" {
+			http.Redirect(w, req, u, http.StatusFound)
+			return
+		}
+
 		var fh io.ReadSeeker
 
 		if preview {
@@ -125,15 +135,9 @@ func (ctrl Attachment) serve(ctx context.Context, attachmentID uint64, preview,
 			return
 		}
 
-		name := url.QueryEscape(att.Name)
-
-		if download {
-			w.Header().Add("Content-Disposition", "attachment; filename="+name)
-		} else {
-			w.Header().Add("Content-Disposition", "inline; filename="+name)
-		}
+		w.Header().Add("Content-Disposition", store.ContentDisposition(att.Name, download))
 
-		http.ServeContent(w, req, name, att.CreatedAt, fh)
+		http.ServeContent(w, req, url.QueryEscape(att.Name), att.CreatedAt, fh)
 	}, nil
 }
 
@@ -6,7 +6,6 @@ import (
 	"image"
 	"image/gif"
 	"io"
-	"net/http"
 	"path"
 	"strings"
 
@@ -19,6 +18,9 @@ import (
 	"github.com/titpetric/factory"
 
 	intAuth "github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/mime"
+	"github.com/cortezaproject/corteza-server/pkg/preview"
+	"github.com/cortezaproject/corteza-server/pkg/scan"
 	"github.com/cortezaproject/corteza-server/pkg/store"
 	"github.com/cortezaproject/corteza-server/system/repository"
 	"github.com/cortezaproject/corteza-server/system/types"
@@ -36,7 +38,8 @@ type (
 
 		actionlog actionlog.Recorder
 
-		store store.Store
+		store   store.Store
+		scanner scan.Scanner
 
 		ac attachmentAccessController
 
@@ -57,13 +60,15 @@ type (
 		CreateSettingsAttachment(name string, size int64, fh io.ReadSeeker, labels map[string]string) (*types.Attachment, error)
 		OpenOriginal(att *types.Attachment) (io.ReadSeeker, error)
 		OpenPreview(att *types.Attachment) (io.ReadSeeker, error)
+		PresignedURL(att *types.Attachment, preview, download bool) (string, error)
 		DeleteByID(ID uint64) error
 	}
 )
 
 func Attachment(store store.Store) AttachmentService {
 	return (&attachment{
-		store: store,
+		store:   store,
+		scanner: DefaultUploadScanner,
 
 		actionlog: DefaultActionlog,
 		ac:        DefaultAccessControl,
@@ -83,7 +88,8 @@ func (svc attachment) With(ctx context.Context) AttachmentService {
 
 		settingsSvc: svc.settingsSvc,
 
-		store: svc.store,
+		store:   svc.store,
+		scanner: svc.scanner,
 
 		attachment: repository.Attachment(ctx, db),
 	}
@@ -159,6 +165,22 @@ func (svc attachment) OpenPreview(att *types.Attachment) (io.ReadSeeker, error)
 	return svc.store.Open(att.PreviewUrl)
 }
 
+// PresignedURL returns short-lived URL for downloading the file directly from the store
+//
+// Empty string is returned when store does not support it and file needs to be streamed
+func (svc attachment) PresignedURL(att *types.Attachment, preview, download bool) (string, error) {
+	var filename = att.Url
+	if preview {
+		filename = att.PreviewUrl
+	}
+
+	if len(filename) == 0 {
+		return "", nil
+	}
+
+	return store.PresignedGet(svc.store, filename, store.ContentDisposition(att.Name, download))
+}
+
 func (svc attachment) CreateSettingsAttachment(name string, size int64, fh io.ReadSeeker, labels map[string]string) (att *types.Attachment, err error) {
 	var (
 		aaProps       = &attachmentActionProps{}
@@ -190,6 +212,10 @@ func (svc attachment) CreateSettingsAttachment(name string, size int64, fh io.Re
 		return err
 	})
 
+	if err == nil {
+		svc.processDocument(att)
+	}
+
 	return att, svc.recordAction(svc.ctx, aaProps, AttachmentActionCreate, err)
 }
 
@@ -209,10 +235,20 @@ func (svc attachment) create(name string, size int64, fh io.ReadSeeker, att *typ
 	att.Meta.Original.Extension = strings.Trim(path.Ext(strings.Trim(name, ".")), ".")
 
 	att.Meta.Original.Size = size
-	if att.Meta.Original.Mimetype, err = svc.extractMimetype(fh); err != nil {
+	if att.Meta.Original.Mimetype, err = mime.Detect(fh); err != nil {
 		return AttachmentErrFailedToExtractMimeType(aaProps).Wrap(err)
 	}
 
+	if svc.scanner != nil {
+		// Inspect the file before it is stored
+		err = svc.scanner.Scan(fh, att.Meta.Original.Mimetype, att.Meta.Original.Extension)
+		if scan.IsRejected(err) {
+			return AttachmentErrRejected(aaProps).Wrap(err)
+		} else if err != nil {
+			return AttachmentErrFailedToScanFile(aaProps).Wrap(err)
+		}
+	}
+
 	att.Url = svc.store.Original(att.ID, att.Meta.Original.Extension)
 	aaProps.setUrl(att.Url)
 
@@ -233,27 +269,10 @@ func (svc attachment) create(name string, size int64, fh io.ReadSeeker, att *typ
 	return nil
 }
 
-func (svc attachment) extractMimetype(file io.ReadSeeker) (mimetype string, err error) {
-	if _, err = file.Seek(0, 0); err != nil {
-		return
-	}
-
-	// Make sure we rewind when we're done
-	defer file.Seek(0, 0)
-
-	// See http.DetectContentType about 512 bytes
-	var buf = make([]byte, 512)
-	if _, err = file.Read(buf); err != nil {
-		return
-	}
-
-	return http.DetectContentType(buf), nil
-}
-
 func (svc attachment) processImage(original io.ReadSeeker, att *types.Attachment) (err error) {
 	if !strings.HasPrefix(att.Meta.Original.Mimetype, "image/") {
-		// Only supporting previews from images (for now)
-		return
+		// Previews of documents are made in the background (see processDocument)
+		return nil
 	}
 
 	var (
@@ -347,3 +366,40 @@ func (svc attachment) processImage(original io.ReadSeeker, att *types.Attachment
 
 	return svc.store.Save(att.PreviewUrl, buf)
 }
+
+// processDocument makes preview of PDFs (first page), plain-text & office documents (text snippet)
+//
+// Previews of documents are made in the background, after attachment is stored (see preview.Queue)
+func (svc attachment) processDocument(att *types.Attachment) {
+	// Attachment is returned to the caller, preview is set on a copy
+	var doc = *att
+
+	preview.Queue(preview.Document{
+		ID:        doc.ID,
+		Mimetype:  doc.Meta.Original.Mimetype,
+		Extension: doc.Meta.Original.Extension,
+		MaxWidth:  attachmentPreviewMaxWidth,
+		MaxHeight: attachmentPreviewMaxHeight,
+
+		Open: func() (io.ReadSeeker, error) {
+			return svc.store.Open(doc.Url)
+		},
+
+		Save: func(buf *bytes.Buffer, width, height int) error {
+			meta := doc.SetPreviewImageMeta(width, height, false)
+			meta.Size = int64(buf.Len())
+			meta.Mimetype = "image/jpeg"
+			meta.Extension = "jpg"
+
+			doc.PreviewUrl = svc.store.Preview(doc.ID, meta.Extension)
+
+			if err := svc.store.Save(doc.PreviewUrl, buf); err != nil {
+				return err
+			}
+
+			// Request that stored the attachment is done by now
+			ctx := context.Background()
+			return repository.Attachment(ctx, repository.DB(ctx)).UpdatePreview(&doc)
+		},
+	})
+}
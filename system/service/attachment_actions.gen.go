@@ -677,6 +677,66 @@ func AttachmentErrFailedToProcessImage(props ...*attachmentActionProps) *attachm
 
 }
 
+// AttachmentErrFailedToScanFile returns "system:attachment.failedToScanFile" audit event as actionlog.Alert
+//
+//
+// This function is auto-generated.
+//
+func AttachmentErrFailedToScanFile(props ...*attachmentActionProps) *attachmentError {
+	var e = &attachmentError{
+		timestamp: time.Now(),
+		resource:  "system:attachment",
+		error:     "failedToScanFile",
+		action:    "error",
+		message:   "could not scan file",
+		log:       "could not scan file",
+		severity:  actionlog.Alert,
+		props: func() *attachmentActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
+// AttachmentErrRejected returns "system:attachment.rejected" audit event as actionlog.Alert
+//
+//
+// This function is auto-generated.
+//
+func AttachmentErrRejected(props ...*attachmentActionProps) *attachmentError {
+	var e = &attachmentError{
+		timestamp: time.Now(),
+		resource:  "system:attachment",
+		error:     "rejected",
+		action:    "error",
+		message:   "{err}",
+		log:       "rejected upload of {name}: {err}",
+		severity:  actionlog.Alert,
+		props: func() *attachmentActionProps {
+			if len(props) > 0 {
+				return props[0]
+			}
+			return nil
+		}(),
+	}
+
+	if len(props) > 0 {
+		e.props = props[0]
+	}
+
+	return e
+
+}
+
 // *********************************************************************************************************************
 // *********************************************************************************************************************
 
@@ -11,6 +11,7 @@ import (
 	intAuth "github.com/cortezaproject/corteza-server/pkg/auth"
 	"github.com/cortezaproject/corteza-server/pkg/eventbus"
 	"github.com/cortezaproject/corteza-server/pkg/permissions"
+	"github.com/cortezaproject/corteza-server/pkg/scan"
 	"github.com/cortezaproject/corteza-server/pkg/settings"
 	"github.com/cortezaproject/corteza-server/pkg/store"
 	"github.com/cortezaproject/corteza-server/pkg/store/minio"
@@ -33,6 +34,7 @@ type (
 		ActionLog        options.ActionLogOpt
 		Storage          options.StorageOpt
 		GRPCClientSystem options.GRPCServerOpt
+		UploadScan       options.UploadScanOpt
 	}
 
 	permitChecker interface {
@@ -50,6 +52,9 @@ type (
 var (
 	DefaultStore store.Store
 
+	// DefaultUploadScanner inspects uploaded files before they are stored
+	DefaultUploadScanner scan.Scanner
+
 	DefaultLogger *zap.Logger
 
 	// CurrentSubscription holds current subscription info,
@@ -148,6 +153,7 @@ func Initialize(ctx context.Context, log *zap.Logger, c Config) (err error) {
 				SecretAccessKey: c.Storage.MinioSecretKey,
 
 				ServerSideEncryptKey: []byte(c.Storage.MinioSSECKey),
+				PresignTTL:           c.Storage.MinioPresignTTL,
 			})
 
 			log.Info("initializing minio",
@@ -169,6 +175,12 @@ func Initialize(ctx context.Context, log *zap.Logger, c Config) (err error) {
 
 	hcd.Add(store.Healthcheck(DefaultStore), "Store/System")
 
+	if DefaultUploadScanner == nil {
+		// Do not override scanner stored under DefaultUploadScanner
+		// to allow integration tests to inject own scanner
+		DefaultUploadScanner = scan.New(c.UploadScan)
+	}
+
 	DefaultAuthNotification = AuthNotification(ctx)
 	DefaultAuth = Auth(ctx)
 	DefaultUser = User(ctx)
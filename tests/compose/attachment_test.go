@@ -0,0 +1,218 @@
+package compose
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steinfletcher/apitest"
+
+	"github.com/cortezaproject/corteza-server/compose/repository"
+	"github.com/cortezaproject/corteza-server/compose/service"
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/tests/helpers"
+)
+
+func (h helper) repoAttachment() repository.AttachmentRepository {
+	return repository.Attachment(context.Background(), db())
+}
+
+func (h helper) apiInitRecordAttachment(module *types.Module, fieldName, name, content string) *apitest.Response {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	h.a.NoError(writer.WriteField("fieldName", fieldName))
+
+	part, err := writer.CreateFormFile("upload", name)
+	h.a.NoError(err)
+
+	_, err = part.Write([]byte(content))
+	h.a.NoError(err)
+	h.a.NoError(writer.Close())
+
+	return h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/module/%d/record/attachment", module.NamespaceID, module.ID)).
+		Body(body.String()).
+		ContentType(writer.FormDataContentType()).
+		Expect(h.t).
+		Status(http.StatusOK)
+}
+
+func TestAttachmentQuota(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.create")
+
+	defer func(q service.AttachmentQuota) {
+		*service.DefaultAttachmentQuota = q
+	}(*service.DefaultAttachmentQuota)
+
+	var (
+		field   = &types.ModuleField{Name: "file", Kind: "File"}
+		module  = h.repoMakeRecordModuleWithFields("attachment quota module", field)
+		other   = h.repoMakeRecordModuleWithFields("attachment quota module", field)
+		content = strings.Repeat("x", 60)
+	)
+
+	*service.DefaultAttachmentQuota = service.AttachmentQuota{Namespace: 100}
+
+	h.apiUploadRecordAttachment(module, "file", "first.txt", content)
+	h.apiInitRecordAttachment(module, "file", "second.txt", content).
+		Assert(helpers.AssertError("namespace storage quota exceeded")).
+		End()
+
+	// Other namespace has its own quota
+	h.apiUploadRecordAttachment(other, "file", "first.txt", content)
+
+	*service.DefaultAttachmentQuota = service.AttachmentQuota{User: 150}
+
+	// User already stored 120 bytes (in both namespaces)
+	h.apiInitRecordAttachment(other, "file", "second.txt", content).
+		Assert(helpers.AssertError("user storage quota exceeded")).
+		End()
+}
+
+func TestAttachmentLockUsage(t *testing.T) {
+	var (
+		h       = newHelper(t)
+		first   = repository.DB(context.Background())
+		second  = repository.DB(context.Background())
+		locked  = make(chan struct{})
+		waited  = make(chan time.Time)
+		release time.Time
+	)
+
+	h.a.NoError(first.Begin())
+	h.a.NoError(repository.Attachment(context.Background(), first).LockUsage("namespace:lock-test"))
+
+	go func() {
+		close(locked)
+		_ = second.Transaction(func() error {
+			err := repository.Attachment(context.Background(), second).LockUsage("namespace:lock-test")
+			waited <- time.Now()
+			return err
+		})
+	}()
+
+	<-locked
+	time.Sleep(100 * time.Millisecond)
+	release = time.Now()
+	h.a.NoError(first.Commit())
+
+	h.a.True((<-waited).After(release), "expecting second transaction to wait for the usage lock")
+}
+
+func TestAttachmentRetention(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.create")
+
+	var (
+		module = h.repoMakeRecordModuleWithFields("attachment retention module", &types.ModuleField{Name: "file", Kind: "File"})
+
+		used      = h.apiUploadRecordAttachment(module, "file", "used.txt", "used")
+		unused    = h.apiUploadRecordAttachment(module, "file", "unused.txt", "unused")
+		ofDeleted = h.apiUploadRecordAttachment(module, "file", "deleted.txt", "of deleted record")
+
+		found = func(r *service.AttachmentRetentionReport) map[uint64]bool {
+			ff := map[uint64]bool{}
+			for _, att := range r.Attachments {
+				ff[att.ID] = true
+			}
+			return ff
+		}
+	)
+
+	h.repoMakeRecord(module, &types.RecordValue{Name: "file", Value: fmt.Sprintf("%d", used), Ref: used})
+	deleted := h.repoMakeRecord(module, &types.RecordValue{Name: "file", Value: fmt.Sprintf("%d", ofDeleted), Ref: ofDeleted})
+
+	now := time.Now()
+	deleted.DeletedAt = &now
+	h.a.NoError(h.repoRecord().Delete(deleted))
+	h.a.NoError(h.repoRecord().DeleteValues(deleted))
+
+	att, err := h.repoAttachment().FindByID(module.NamespaceID, unused)
+	h.a.NoError(err)
+
+	// Nothing is orphaned long enough
+	r, err := service.DefaultAttachmentRetention.Apply(context.Background(), now.Add(-time.Hour), true)
+	h.a.NoError(err)
+	h.a.False(found(r)[unused])
+	h.a.False(found(r)[ofDeleted])
+
+	r, err = service.DefaultAttachmentRetention.Apply(context.Background(), now.Add(time.Second), true)
+	h.a.NoError(err)
+	h.a.True(r.DryRun)
+	h.a.True(found(r)[unused])
+	h.a.True(found(r)[ofDeleted])
+	h.a.False(found(r)[used])
+
+	// Dry run does not remove anything
+	_, err = h.repoAttachment().FindByID(module.NamespaceID, unused)
+	h.a.NoError(err)
+
+	r, err = service.DefaultAttachmentRetention.Apply(context.Background(), now.Add(time.Second), false)
+	h.a.NoError(err)
+	h.a.Zero(r.Failed)
+	h.a.True(found(r)[unused])
+
+	_, err = h.repoAttachment().FindByID(module.NamespaceID, unused)
+	h.a.True(repository.ErrAttachmentNotFound.Eq(err))
+	_, err = h.repoAttachment().FindByID(module.NamespaceID, ofDeleted)
+	h.a.True(repository.ErrAttachmentNotFound.Eq(err))
+	_, err = h.repoAttachment().FindByID(module.NamespaceID, used)
+	h.a.NoError(err)
+
+	_, err = service.DefaultStore.Open(att.Url)
+	h.a.Error(err, "expecting stored file to be removed")
+}
+
+func TestAttachmentDocumentPreview(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.create")
+
+	var (
+		module = h.repoMakeRecordModuleWithFields("attachment preview module", &types.ModuleField{Name: "file", Kind: "File"})
+		doc    = h.apiUploadRecordAttachment(module, "file", "notes.txt", "Lorem ipsum dolor sit amet")
+	)
+
+	att, err := h.repoAttachment().FindByID(module.NamespaceID, doc)
+	h.a.NoError(err)
+	h.a.NotNil(att.Meta.Preview)
+	h.a.Equal("image/jpeg", att.Meta.Preview.Mimetype)
+
+	_, err = service.DefaultStore.Open(att.PreviewUrl)
+	h.a.NoError(err)
+}
+
+func TestAttachmentRetentionOfUnlinked(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.create")
+
+	var (
+		module   = h.repoMakeRecordModuleWithFields("attachment retention module", &types.ModuleField{Name: "file", Kind: "File"})
+		unlinked = h.apiUploadRecordAttachment(module, "file", "unlinked.txt", "unlinked")
+		rec      = h.repoMakeRecord(module, &types.RecordValue{Name: "file", Value: fmt.Sprintf("%d", unlinked), Ref: unlinked})
+
+		found = func(before time.Time) bool {
+			r, err := service.DefaultAttachmentRetention.Apply(context.Background(), before, true)
+			h.a.NoError(err)
+			for _, att := range r.Attachments {
+				if att.ID == unlinked {
+					return true
+				}
+			}
+			return false
+		}
+	)
+
+	// Attachment was uploaded long before it was removed from the record
+	_, err := db().Exec("UPDATE compose_attachment SET created_at = ? WHERE id = ?", time.Now().Add(-48*time.Hour), unlinked)
+	h.a.NoError(err)
+
+	h.a.NoError(h.repoRecord().UpdateValues(rec.ID, types.RecordValueSet{}))
+	h.a.False(found(time.Now().Add(-time.Hour)), "expecting attachment to be kept since it was unlinked")
+	h.a.True(found(time.Now().Add(time.Second)))
+}
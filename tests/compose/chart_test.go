@@ -3,7 +3,10 @@ package compose
 import (
 	"context"
 	"fmt"
+	"image/png"
+	"io/ioutil"
 	"net/http"
+	"strings"
 	"testing"
 
 	jsonpath "github.com/steinfletcher/apitest-jsonpath"
@@ -199,3 +202,99 @@ func TestChartDelete(t *testing.T) {
 	m, err := h.repoChart().FindByID(ns.ID, m.ID)
 	h.a.Error(err, "compose.repository.ChartNotFound")
 }
+
+func TestChartRender(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.ChartPermissionResource.AppendWildcard(), "read")
+
+	module := h.repoMakeRecordModuleWithFields("chart render module",
+		&types.ModuleField{Name: "amount", Kind: "Number"},
+		&types.ModuleField{Name: "region"},
+	)
+
+	for _, r := range [][]string{{"100", "EMEA"}, {"50", "EMEA"}, {"20", "APAC"}} {
+		h.repoMakeRecord(module,
+			&types.RecordValue{Name: "amount", Value: r[0]},
+			&types.RecordValue{Name: "region", Value: r[1]},
+		)
+	}
+
+	c, err := h.repoChart().Create(&types.Chart{
+		Name:        "Revenue by region",
+		Handle:      "revenue",
+		NamespaceID: module.NamespaceID,
+		Config: types.ChartConfig{
+			ColorScheme: "brewer.Paired12",
+			Reports: []*types.ChartConfigReport{{
+				ModuleID: module.ID,
+				Metrics: []map[string]interface{}{
+					{"field": "amount", "aggregate": "SUM", "label": "Revenue", "type": "bar"},
+					{"field": "count", "label": "Invoices", "type": "line", "backgroundColor": "#ff0000"},
+				},
+				Dimensions: []map[string]interface{}{{"field": "region"}},
+			}},
+		},
+	})
+	h.a.NoError(err)
+
+	h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/chart/%d/render.svg", c.NamespaceID, c.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Header("Content-Type", "image/svg+xml").
+		Assert(func(res *http.Response, _ *http.Request) error {
+			body, err := ioutil.ReadAll(res.Body)
+			h.a.NoError(err)
+
+			svg := string(body)
+			h.a.True(strings.HasPrefix(svg, "<svg"))
+			h.a.Contains(svg, ">Revenue by region</text>")
+			h.a.Contains(svg, ">Revenue</text>")
+			h.a.Contains(svg, ">APAC</text>")
+			h.a.Contains(svg, ">EMEA</text>")
+			h.a.Contains(svg, `stroke="#ff0000"`)
+			return nil
+		}).
+		End()
+
+	h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/chart/%d/render.png", c.NamespaceID, c.ID)).
+		Query("width", "300").
+		Query("height", "200").
+		Expect(t).
+		Status(http.StatusOK).
+		Header("Content-Type", "image/png").
+		Assert(func(res *http.Response, _ *http.Request) error {
+			img, err := png.Decode(res.Body)
+			h.a.NoError(err)
+			h.a.Equal(300, img.Bounds().Dx())
+			h.a.Equal(200, img.Bounds().Dy())
+			return nil
+		}).
+		End()
+
+	h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/chart/%d/render.gif", c.NamespaceID, c.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("unsupported chart image format")).
+		End()
+
+	h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/chart/%d/render.png", c.NamespaceID, c.ID)).
+		Query("width", "10").
+		Query("height", "10").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("invalid chart image size")).
+		End()
+
+	h.deny(types.ChartPermissionResource.AppendWildcard(), "read")
+
+	h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/chart/%d/render.svg", c.NamespaceID, c.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("not allowed to read this chart")).
+		End()
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/cortezaproject/corteza-server/pkg/eventbus"
 	"os"
+	"sync"
 	"testing"
 
 	_ "github.com/joho/godotenv/autoload"
@@ -19,11 +20,13 @@ import (
 	"github.com/cortezaproject/corteza-server/compose/service"
 	"github.com/cortezaproject/corteza-server/compose/types"
 	"github.com/cortezaproject/corteza-server/corteza"
+	"github.com/cortezaproject/corteza-server/pkg/actionlog"
 	"github.com/cortezaproject/corteza-server/pkg/api"
 	"github.com/cortezaproject/corteza-server/pkg/app"
 	"github.com/cortezaproject/corteza-server/pkg/auth"
 	"github.com/cortezaproject/corteza-server/pkg/logger"
 	"github.com/cortezaproject/corteza-server/pkg/permissions"
+	"github.com/cortezaproject/corteza-server/pkg/scan"
 	"github.com/cortezaproject/corteza-server/pkg/store/plain"
 	sysTypes "github.com/cortezaproject/corteza-server/system/types"
 	"github.com/cortezaproject/corteza-server/tests/helpers"
@@ -41,6 +44,13 @@ type (
 	TestApp struct {
 		helpers.TestApp
 	}
+
+	// actionlogKeeper keeps recorded actions in memory;
+	// there is no (system) action log table in the compose test database
+	actionlogKeeper struct {
+		mux     sync.Mutex
+		actions actionlog.ActionSet
+	}
 )
 
 var (
@@ -48,6 +58,8 @@ var (
 	r       chi.Router
 
 	eventBus = eventbus.New()
+
+	actions = &actionlogKeeper{}
 )
 
 func init() {
@@ -61,6 +73,17 @@ func db() *factory.DB {
 func (app *TestApp) Initialize(ctx context.Context) (err error) {
 	service.DefaultPermissions = permissions.NewTestService(ctx, app.Log, db(), "compose_permission_rules")
 	service.DefaultStore, err = plain.NewWithAfero(afero.NewMemMapFs(), "test")
+	service.DefaultActionlog = actionlog.NewService(actions, app.Log, nil, actionlog.MakeDebugPolicy())
+	service.DefaultUploadScanner = scan.Filter(scan.Rules{}, scan.Rules{Mimetypes: []string{"text/html"}, Extensions: []string{"exe"}})
+
+	// Quotas are enabled by individual tests
+	app.Opt.Attachment.NamespaceQuota = 0
+	app.Opt.Attachment.ComposeUserQuota = 0
+
+	// Report cache is disabled by default; it is enabled here
+	// so that API tests cover cached reports and their invalidation
+	// (see TestRecordReportCache_disabled for reports without cache)
+	app.Opt.ReportCache.Enabled = true
 
 	eventBus = eventbus.New()
 	eventbus.Set(eventBus)
@@ -68,6 +91,31 @@ func (app *TestApp) Initialize(ctx context.Context) (err error) {
 	return
 }
 
+func (k *actionlogKeeper) Record(_ context.Context, a *actionlog.Action) error {
+	k.mux.Lock()
+	defer k.mux.Unlock()
+	k.actions = append(k.actions, a)
+	return nil
+}
+
+func (k *actionlogKeeper) Find(_ context.Context, f actionlog.Filter) (set actionlog.ActionSet, _ actionlog.Filter, _ error) {
+	k.mux.Lock()
+	defer k.mux.Unlock()
+	for _, a := range k.actions {
+		if (f.Resource == "" || f.Resource == a.Resource) && (f.Action == "" || f.Action == a.Action) {
+			set = append(set, a)
+		}
+	}
+
+	return set, f, nil
+}
+
+func (k *actionlogKeeper) clear() {
+	k.mux.Lock()
+	defer k.mux.Unlock()
+	k.actions = nil
+}
+
 func (app *TestApp) Activate(ctx context.Context) (err error) {
 	service.DefaultPermissions.(*permissions.TestService).Reload(ctx)
 	return
@@ -93,7 +141,9 @@ func InitTestApp() {
 
 func TestMain(m *testing.M) {
 	InitTestApp()
-	os.Exit(m.Run())
+	code := m.Run()
+	helpers.Cleanup()
+	os.Exit(code)
 }
 
 func newHelper(t *testing.T) helper {
@@ -110,10 +160,18 @@ func newHelper(t *testing.T) helper {
 
 	service.DefaultPermissions.(*permissions.TestService).ClearGrants()
 	h.mockPermissionsWithAccess()
+	actions.clear()
 
 	return h
 }
 
+// Returns actions recorded (by the current test) on the resource
+func (h helper) recordedActions(resource, action string) actionlog.ActionSet {
+	set, _, err := actions.Find(context.Background(), actionlog.Filter{Resource: resource, Action: action})
+	h.a.NoError(err)
+	return set
+}
+
 // Returns context w/ security details
 func (h helper) secCtx() context.Context {
 	return auth.SetIdentityToContext(context.Background(), h.cUser)
@@ -224,6 +224,23 @@ func TestModuleFieldsUpdate(t *testing.T) {
 	h.a.Equal(ff[1].Kind, "DateTime")
 }
 
+func TestModuleFieldsUpdateInvalidFormula(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.NamespacePermissionResource.AppendWildcard(), "read")
+	ns := h.repoMakeNamespace("some-namespace")
+	m := h.repoMakeModule(ns, "some-module", &types.ModuleField{Kind: "Number", Name: "price"})
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "update")
+
+	fjs := fmt.Sprintf(`{ "name": "%s", "fields": [{ "fieldID": "%d", "name": "price", "kind": "Number" }, { "name": "total", "kind": "Formula", "options": { "expression": "price * quantity" } }] }`, m.Name, m.Fields[0].ID)
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/module/%d", ns.ID, m.ID)).
+		JSON(fjs).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("invalid formula expression in field total: unknown field \"quantity\"")).
+		End()
+}
+
 func TestModuleFieldsPreventUpdate_ifRecordExists(t *testing.T) {
 	h := newHelper(t)
 	h.allow(types.NamespacePermissionResource.AppendWildcard(), "read")
@@ -255,6 +272,138 @@ func TestModuleFieldsPreventUpdate_ifRecordExists(t *testing.T) {
 	h.a.Equal(ff[1].Kind, "DateTime")
 }
 
+func TestModuleFieldsUpdateRollupBackfill(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "update")
+
+	var (
+		ns     = h.repoMakeNamespace("some-namespace")
+		parent = h.repoMakeRecordModuleWithFieldsOnNs("parent module", ns, &types.ModuleField{Kind: "String", Name: "name"})
+		child  = h.repoMakeRecordModuleWithFieldsOnNs("child module", ns,
+			&types.ModuleField{Kind: "Record", Name: "parent"},
+			&types.ModuleField{Kind: "Number", Name: "amount"},
+		)
+
+		record = h.repoMakeRecord(parent, &types.RecordValue{Name: "name", Value: "p"})
+
+		total = func() string {
+			rvs, err := h.repoRecord().LoadValues([]string{"total"}, []uint64{record.ID})
+			h.a.NoError(err)
+			if len(rvs) == 0 {
+				return ""
+			}
+
+			return rvs[0].Value
+		}
+
+		update = func(aggregate string) {
+			ff, err := h.repoModule().FindFields(parent.ID)
+			h.a.NoError(err)
+
+			var totalID uint64
+			if total := ff.FindByName("total"); total != nil {
+				totalID = total.ID
+			}
+
+			fjs := fmt.Sprintf(
+				`{ "fieldID": "%d", "name": "name", "kind": "String" }, `+
+					`{ "fieldID": "%d", "name": "total", "kind": "Rollup", "options": { "moduleID": "%d", "refField": "parent", "valueField": "amount", "aggregate": "%s" } }`,
+				ff.FindByName("name").ID, totalID, child.ID, aggregate,
+			)
+
+			h.apiInit().
+				Post(fmt.Sprintf("/namespace/%d/module/%d", ns.ID, parent.ID)).
+				JSON(fmt.Sprintf(`{ "name": "%s", "fields": [%s] }`, parent.Name, fjs)).
+				Expect(t).
+				Status(http.StatusOK).
+				Assert(helpers.AssertNoErrors).
+				End()
+		}
+	)
+
+	for _, amount := range []string{"10", "32"} {
+		h.repoMakeRecord(child,
+			&types.RecordValue{Name: "parent", Value: fmt.Sprintf("%d", record.ID), Ref: record.ID},
+			&types.RecordValue{Name: "amount", Value: amount},
+		)
+	}
+
+	// New rollup field is calculated for existing records
+	update("SUM")
+	h.a.Equal("42", total())
+
+	// and recalculated when reconfigured
+	update("COUNT")
+	h.a.Equal("2", total())
+}
+
+func TestModuleFieldsUpdateFormulaBackfill(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "update")
+
+	var (
+		ns = h.repoMakeNamespace("some-namespace")
+		m  = h.repoMakeRecordModuleWithFieldsOnNs("formula module", ns,
+			&types.ModuleField{Kind: "Number", Name: "price"},
+			&types.ModuleField{Kind: "Number", Name: "quantity"},
+		)
+
+		record = h.repoMakeRecord(m,
+			&types.RecordValue{Name: "price", Value: "0.1"},
+			&types.RecordValue{Name: "quantity", Value: "3"},
+		)
+
+		total = func() string {
+			rvs, err := h.repoRecord().LoadValues([]string{"total"}, []uint64{record.ID})
+			h.a.NoError(err)
+			if len(rvs) == 0 {
+				return ""
+			}
+
+			return rvs[0].Value
+		}
+
+		update = func(expr string) {
+			ff, err := h.repoModule().FindFields(m.ID)
+			h.a.NoError(err)
+
+			var totalID uint64
+			if total := ff.FindByName("total"); total != nil {
+				totalID = total.ID
+			}
+
+			fjs := fmt.Sprintf(
+				`{ "fieldID": "%d", "name": "price", "kind": "Number" }, `+
+					`{ "fieldID": "%d", "name": "quantity", "kind": "Number" }, `+
+					`{ "fieldID": "%d", "name": "total", "kind": "Formula", "options": { "expression": "%s" } }`,
+				ff.FindByName("price").ID, ff.FindByName("quantity").ID, totalID, expr,
+			)
+
+			h.apiInit().
+				Post(fmt.Sprintf("/namespace/%d/module/%d", ns.ID, m.ID)).
+				JSON(fmt.Sprintf(`{ "name": "%s", "fields": [%s] }`, m.Name, fjs)).
+				Expect(t).
+				Status(http.StatusOK).
+				Assert(helpers.AssertNoErrors).
+				End()
+		}
+	)
+
+	// New formula field is computed for existing records
+	update("price * quantity")
+	h.a.Equal("0.3", total())
+
+	// and recomputed when expression is changed
+	update("price + quantity")
+	h.a.Equal("3.1", total())
+
+	// Computed values can be filtered on
+	m.Fields, _ = h.repoModule().FindFields(m.ID)
+	rr, _, err := h.repoRecord().Find(m, types.RecordFilter{Query: "total > 3"})
+	h.a.NoError(err)
+	h.a.Len(rr, 1)
+}
+
 func TestModuleDeleteForbidden(t *testing.T) {
 	h := newHelper(t)
 
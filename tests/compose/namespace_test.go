@@ -174,3 +174,41 @@ func TestNamespaceDelete(t *testing.T) {
 	ns, err := h.repoNamespace().FindByID(ns.ID)
 	h.a.Error(err, "compose.repository.NamespaceNotFound")
 }
+
+func TestNamespaceSearch(t *testing.T) {
+	h := newHelper(t)
+
+	ns := h.repoMakeNamespace("namespace search")
+	accounts := h.repoMakeRecordModuleWithFieldsOnNs("accounts", ns,
+		&types.ModuleField{Name: "name", Kind: "String"},
+	)
+	contacts := h.repoMakeRecordModuleWithFieldsOnNs("contacts", ns,
+		&types.ModuleField{Name: "company", Kind: "Select"},
+	)
+	secrets := h.repoMakeRecordModuleWithFieldsOnNs("secrets", ns,
+		&types.ModuleField{Name: "name", Kind: "String"},
+	)
+
+	h.deny(secrets.PermissionResource(), "record.read")
+
+	h.repoMakeRecord(accounts, &types.RecordValue{Name: "name", Value: "Wayne enterprises"})
+	h.repoMakeRecord(contacts, &types.RecordValue{Name: "company", Value: "Wayne"})
+	h.repoMakeRecord(secrets, &types.RecordValue{Name: "name", Value: "Wayne manor"})
+
+	h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/search", ns.ID)).
+		Query("q", "wayne").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.filter.count`, float64(2))).
+		Assert(jsonpath.Len(`$.response.set`, 2)).
+		End()
+
+	h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/search", ns.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("free-text search terms are required")).
+		End()
+}
@@ -0,0 +1,141 @@
+package compose
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	jsonpath "github.com/steinfletcher/apitest-jsonpath"
+
+	"github.com/cortezaproject/corteza-server/compose/repository"
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/tests/helpers"
+)
+
+func (h helper) repoRecordRevision() repository.RecordRevisionRepository {
+	return repository.RecordRevision(context.Background(), db())
+}
+
+func (h helper) repoMakeRecordRevision(record *types.Record, rvs ...*types.RecordValue) *types.RecordRevision {
+	rev, err := h.
+		repoRecordRevision().
+		Create(types.NewRecordRevision(types.RecordRevisionUpdate, record, nil, rvs))
+	h.a.NoError(err)
+
+	return rev
+}
+
+func TestRecordRevisions(t *testing.T) {
+	h := newHelper(t)
+
+	module := h.repoMakeRecordModuleWithFields("record testing module")
+	record := h.repoMakeRecord(module, &types.RecordValue{Name: "name", Value: "initial-val"})
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.update")
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/module/%d/record/%d", module.NamespaceID, module.ID, record.ID)).
+		JSON(`{"values": [{"name": "name", "value": "changed-val"}]}`).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		End()
+
+	h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/module/%d/record/%d/revisions", module.NamespaceID, module.ID, record.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Len(`$.response.set`, 1)).
+		Assert(jsonpath.Equal(`$.response.set[0].operation`, "update")).
+		Assert(jsonpath.Equal(`$.response.set[0].changes[0].name`, "name")).
+		Assert(jsonpath.Equal(`$.response.set[0].changes[0].old[0]`, "initial-val")).
+		Assert(jsonpath.Equal(`$.response.set[0].changes[0].new[0]`, "changed-val")).
+		End()
+}
+
+func TestRecordRevisionsDiff(t *testing.T) {
+	h := newHelper(t)
+
+	module := h.repoMakeRecordModuleWithFields("record testing module")
+	record := h.repoMakeRecord(module)
+	from := h.repoMakeRecordRevision(record, &types.RecordValue{Name: "name", Value: "from-val"})
+	to := h.repoMakeRecordRevision(record, &types.RecordValue{Name: "name", Value: "to-val"})
+
+	h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/module/%d/record/%d/revisions/diff", module.NamespaceID, module.ID, record.ID)).
+		QueryParams(map[string]string{"from": fmt.Sprintf("%d", from.ID), "to": fmt.Sprintf("%d", to.ID)}).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Len(`$.response`, 1)).
+		Assert(jsonpath.Equal(`$.response[0].old[0]`, "from-val")).
+		Assert(jsonpath.Equal(`$.response[0].new[0]`, "to-val")).
+		End()
+}
+
+func TestRecordRevisionRestoreForbidden(t *testing.T) {
+	h := newHelper(t)
+
+	module := h.repoMakeRecordModuleWithFields("record testing module")
+	record := h.repoMakeRecord(module)
+	rev := h.repoMakeRecordRevision(record, &types.RecordValue{Name: "name", Value: "restored-val"})
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/module/%d/record/%d/revisions/%d/restore", module.NamespaceID, module.ID, record.ID, rev.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("not allowed to update this record")).
+		End()
+}
+
+func TestRecordRevisionRestore(t *testing.T) {
+	h := newHelper(t)
+
+	module := h.repoMakeRecordModuleWithFields("record testing module")
+	record := h.repoMakeRecord(module, &types.RecordValue{Name: "name", Value: "current-val"})
+	rev := h.repoMakeRecordRevision(record, &types.RecordValue{Name: "name", Value: "restored-val"})
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.update")
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/module/%d/record/%d/revisions/%d/restore", module.NamespaceID, module.ID, record.ID, rev.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.values[0].value`, "restored-val")).
+		End()
+}
+
+func TestRecordRevisionsOfDeletedRecord(t *testing.T) {
+	h := newHelper(t)
+
+	module := h.repoMakeRecordModuleWithFields("record testing module")
+	record := h.repoMakeRecord(module, &types.RecordValue{Name: "name", Value: "deleted-val"})
+	rev := h.repoMakeRecordRevision(record, &types.RecordValue{Name: "name", Value: "deleted-val"})
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.update")
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.delete")
+
+	h.apiInit().
+		Delete(fmt.Sprintf("/namespace/%d/module/%d/record/%d", module.NamespaceID, module.ID, record.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		End()
+
+	h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/module/%d/record/%d/revisions", module.NamespaceID, module.ID, record.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Len(`$.response.set`, 2)).
+		Assert(jsonpath.Equal(`$.response.set[0].operation`, "delete")).
+		Assert(jsonpath.Equal(`$.response.set[0].changes[0].old[0]`, "deleted-val")).
+		End()
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/module/%d/record/%d/revisions/%d/restore", module.NamespaceID, module.ID, record.ID, rev.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("can not restore revision of deleted record")).
+		End()
+}
@@ -7,14 +7,21 @@ import (
 	"io/ioutil"
 	"mime/multipart"
 	"net/http"
+	"path"
+	"strconv"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/360EntSecGroup-Skylar/excelize/v2"
 	"github.com/steinfletcher/apitest"
 	jsonpath "github.com/steinfletcher/apitest-jsonpath"
 
 	"github.com/cortezaproject/corteza-server/compose/repository"
+	"github.com/cortezaproject/corteza-server/compose/service"
 	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/actionlog"
+	"github.com/cortezaproject/corteza-server/pkg/eventbus"
 	"github.com/cortezaproject/corteza-server/tests/helpers"
 )
 
@@ -22,6 +29,9 @@ type (
 	rImportSession struct {
 		Response struct {
 			SessionID string `json:"sessionID"`
+			Progress  struct {
+				FinishedAt *time.Time `json:"finishedAt"`
+			} `json:"progress"`
 		} `json:"response"`
 	}
 )
@@ -143,6 +153,173 @@ func TestRecordList(t *testing.T) {
 		End()
 }
 
+func TestRecordListFilterByRefField(t *testing.T) {
+	h := newHelper(t)
+
+	namespace := h.repoMakeNamespace("record ref filter namespace")
+	account := h.repoMakeRecordModuleWithFieldsOnNs("account", namespace,
+		&types.ModuleField{Name: "industry"},
+	)
+	deal := h.repoMakeRecordModuleWithFieldsOnNs("deal", namespace,
+		&types.ModuleField{Name: "name"},
+		&types.ModuleField{Name: "account", Kind: "Record", Options: types.ModuleFieldOptions{"moduleID": strconv.FormatUint(account.ID, 10)}},
+	)
+
+	ref := func(r *types.Record) *types.RecordValue {
+		return &types.RecordValue{Name: "account", Value: strconv.FormatUint(r.ID, 10), Ref: r.ID}
+	}
+
+	retail := h.repoMakeRecord(account, &types.RecordValue{Name: "industry", Value: "Retail"})
+	tech := h.repoMakeRecord(account, &types.RecordValue{Name: "industry", Value: "Tech"})
+	h.repoMakeRecord(deal, &types.RecordValue{Name: "name", Value: "d1"}, ref(retail))
+	h.repoMakeRecord(deal, &types.RecordValue{Name: "name", Value: "d2"}, ref(tech))
+
+	url := fmt.Sprintf("/namespace/%d/module/%d/record/", deal.NamespaceID, deal.ID)
+
+	h.apiInit().
+		Get(url).
+		Query("query", "account.industry = 'Retail'").
+		Query("sort", "account.industry DESC").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.filter.count`, float64(1))).
+		Assert(jsonpath.Equal(`$.response.set[0].values[? @.name=="name"].value`, []interface{}{"d1"})).
+		End()
+
+	h.deny(account.Fields.FindByName("industry").PermissionResource(), "record.value.read")
+
+	h.apiInit().
+		Get(url).
+		Query("query", "account.industry = 'Retail'").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("not allowed to read value of field account.industry")).
+		End()
+}
+
+func TestRecordListPageCursor(t *testing.T) {
+	h := newHelper(t)
+
+	module := h.repoMakeRecordModuleWithFields("record testing module")
+
+	h.repoMakeRecord(module, &types.RecordValue{Name: "name", Value: "a"})
+	h.repoMakeRecord(module, &types.RecordValue{Name: "name", Value: "b"})
+	h.repoMakeRecord(module, &types.RecordValue{Name: "name", Value: "c"})
+
+	var (
+		rsp = struct {
+			Response struct {
+				Filter struct {
+					NextPage string `json:"nextPage"`
+				} `json:"filter"`
+			} `json:"response"`
+		}{}
+
+		url = fmt.Sprintf("/namespace/%d/module/%d/record/", module.NamespaceID, module.ID)
+	)
+
+	h.apiInit().
+		Get(url).
+		QueryParams(map[string]string{"sort": "name DESC", "limit": "2"}).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Len(`$.response.set`, 2)).
+		End().
+		JSON(&rsp)
+
+	h.a.NotEmpty(rsp.Response.Filter.NextPage)
+
+	h.apiInit().
+		Get(url).
+		QueryParams(map[string]string{"sort": "name DESC", "limit": "2", "pageCursor": rsp.Response.Filter.NextPage}).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Len(`$.response.set`, 1)).
+		Assert(jsonpath.Equal(`$.response.set[0].values[0].value`, "a")).
+		Assert(jsonpath.NotPresent(`$.response.filter.nextPage`)).
+		End()
+}
+
+func TestRecordListFullTextSearch(t *testing.T) {
+	h := newHelper(t)
+
+	module := h.repoMakeRecordModuleWithFields("record full-text search module",
+		&types.ModuleField{Name: "name", Kind: "String"},
+		&types.ModuleField{Name: "notes", Kind: "String"},
+		&types.ModuleField{Name: "secret", Kind: "String"},
+		&types.ModuleField{Name: "amount", Kind: "Number"},
+	)
+
+	h.deny(module.Fields.FindByName("secret").PermissionResource(), "record.value.read")
+
+	acme := h.repoMakeRecord(module,
+		&types.RecordValue{Name: "name", Value: "Acme corporation"},
+		&types.RecordValue{Name: "notes", Value: "acme is acme"},
+	)
+	h.repoMakeRecord(module,
+		&types.RecordValue{Name: "name", Value: "Globex"},
+		&types.RecordValue{Name: "notes", Value: "supplier of Acme"},
+	)
+	h.repoMakeRecord(module,
+		&types.RecordValue{Name: "name", Value: "Initech"},
+		&types.RecordValue{Name: "secret", Value: "acme"},
+	)
+
+	url := fmt.Sprintf("/namespace/%d/module/%d/record/", module.NamespaceID, module.ID)
+
+	h.apiInit().
+		Get(url).
+		Query("q", "acme").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.filter.count`, float64(2))).
+		Assert(jsonpath.Equal(`$.response.set[0].recordID`, fmt.Sprintf("%d", acme.ID))).
+		End()
+
+	h.apiInit().
+		Get(url).
+		Query("q", "initech").
+		Query("query", "amount > 0").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.filter.count`, float64(0))).
+		End()
+}
+
+func TestRecordListFullTextSearchRank(t *testing.T) {
+	h := newHelper(t)
+
+	module := h.repoMakeRecordModuleWithFields("record full-text rank module",
+		&types.ModuleField{Name: "notes", Kind: "String"},
+	)
+
+	mention := h.repoMakeRecord(module,
+		&types.RecordValue{Name: "notes", Value: "met someone from acme at the fair last year"},
+	)
+	supplier := h.repoMakeRecord(module,
+		&types.RecordValue{Name: "notes", Value: "acme supplies acme parts"},
+	)
+	h.repoMakeRecord(module,
+		&types.RecordValue{Name: "notes", Value: "initech"},
+	)
+
+	h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/module/%d/record/", module.NamespaceID, module.ID)).
+		Query("q", "acme").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.filter.count`, float64(2))).
+		Assert(jsonpath.Equal(`$.response.set[0].recordID`, fmt.Sprintf("%d", supplier.ID))).
+		Assert(jsonpath.Equal(`$.response.set[1].recordID`, fmt.Sprintf("%d", mention.ID))).
+		End()
+}
+
 func TestRecordCreateForbidden(t *testing.T) {
 	h := newHelper(t)
 
@@ -172,6 +349,164 @@ func TestRecordCreate(t *testing.T) {
 		End()
 }
 
+func TestRecordCreateWithFormula(t *testing.T) {
+	h := newHelper(t)
+
+	fields := types.ModuleFieldSet{
+		&types.ModuleField{Name: "price", Kind: "Number"},
+		&types.ModuleField{Name: "quantity", Kind: "Number"},
+		&types.ModuleField{Name: "total", Kind: "Formula", Options: types.ModuleFieldOptions{"expression": "price * quantity", "resultKind": "Number"}},
+	}
+	module := h.repoMakeRecordModuleWithFields("record testing module", fields...)
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.create")
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/module/%d/record/", module.NamespaceID, module.ID)).
+		JSON(`{"values": [{"name": "price", "value": "3"}, {"name": "quantity", "value": "4"}, {"name": "total", "value": "1"}]}`).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.values[2].name`, "total")).
+		Assert(jsonpath.Equal(`$.response.values[2].value`, "12")).
+		End()
+}
+
+func TestRecordCreateWithValidatorViolation(t *testing.T) {
+	h := newHelper(t)
+
+	module := h.repoMakeRecordModuleWithFields("record testing module",
+		&types.ModuleField{Name: "start", Kind: "Number"},
+		&types.ModuleField{Name: "end", Kind: "Number"},
+	)
+	module.Validators = types.ModuleValidatorSet{{Expression: "end >= start", Message: "end before start", Field: "end"}}
+	_, err := h.repoModule().Update(module)
+	h.a.NoError(err)
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.create")
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/module/%d/record/", module.NamespaceID, module.ID)).
+		JSON(`{"values": [{"name": "start", "value": "3"}, {"name": "end", "value": "2"}]}`).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("1 issue(s) found")).
+		Assert(helpers.AssertRecordValueError(
+			&types.RecordValueError{
+				Kind:    "ruleViolation",
+				Message: "end before start",
+				Meta:    map[string]interface{}{"field": "end"},
+			},
+		)).
+		End()
+}
+
+func TestRecordRollup(t *testing.T) {
+	h := newHelper(t)
+
+	var (
+		ns    = h.repoMakeNamespace("record testing namespace")
+		child = h.repoMakeRecordModuleWithFieldsOnNs("child module", ns,
+			&types.ModuleField{Name: "parent", Kind: "Record"},
+			&types.ModuleField{Name: "amount", Kind: "Number"},
+		)
+		parent = h.repoMakeRecordModuleWithFieldsOnNs("parent module", ns,
+			&types.ModuleField{Name: "total", Kind: "Rollup", Options: types.ModuleFieldOptions{
+				"moduleID":   fmt.Sprintf("%d", child.ID),
+				"refField":   "parent",
+				"valueField": "amount",
+				"aggregate":  "SUM",
+			}},
+		)
+
+		record = h.repoMakeRecord(parent)
+
+		total = func() string {
+			rvs, err := h.repoRecord().LoadValues([]string{"total"}, []uint64{record.ID})
+			h.a.NoError(err)
+			if len(rvs) == 0 {
+				return ""
+			}
+
+			return rvs[0].Value
+		}
+	)
+
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.create")
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.delete")
+
+	for _, amount := range []string{"10", "32"} {
+		h.apiInit().
+			Post(fmt.Sprintf("/namespace/%d/module/%d/record/", ns.ID, child.ID)).
+			JSON(fmt.Sprintf(`{"values": [{"name": "parent", "value": "%d"}, {"name": "amount", "value": "%s"}]}`, record.ID, amount)).
+			Expect(t).
+			Status(http.StatusOK).
+			Assert(helpers.AssertNoErrors).
+			End()
+	}
+
+	h.a.Equal("42", total())
+
+	rr, _, err := h.repoRecord().Find(child, types.RecordFilter{Query: "amount = 10"})
+	h.a.NoError(err)
+	h.a.Len(rr, 1)
+
+	h.apiInit().
+		Delete(fmt.Sprintf("/namespace/%d/module/%d/record/%d", ns.ID, child.ID, rr[0].ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		End()
+
+	h.a.Equal("32", total())
+
+	rr, _, err = h.repoRecord().Find(parent, types.RecordFilter{Query: "total > 30"})
+	h.a.NoError(err)
+	h.a.Len(rr, 1)
+}
+
+func TestRecordRollup_countMultiValue(t *testing.T) {
+	h := newHelper(t)
+
+	var (
+		ns    = h.repoMakeNamespace("record testing namespace")
+		child = h.repoMakeRecordModuleWithFieldsOnNs("child module", ns,
+			&types.ModuleField{Name: "parent", Kind: "Record"},
+			&types.ModuleField{Name: "tags", Kind: "String", Multi: true},
+		)
+		parent = h.repoMakeRecordModuleWithFieldsOnNs("parent module", ns,
+			&types.ModuleField{Name: "children", Kind: "Rollup", Options: types.ModuleFieldOptions{
+				"moduleID":   fmt.Sprintf("%d", child.ID),
+				"refField":   "parent",
+				"valueField": "tags",
+				"aggregate":  "COUNT",
+			}},
+		)
+
+		record = h.repoMakeRecord(parent)
+	)
+
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.create")
+
+	for _, tags := range [][]string{{"a", "b", "c"}, {"d", "e"}} {
+		values := fmt.Sprintf(`{"name": "parent", "value": "%d"}`, record.ID)
+		for _, tag := range tags {
+			values += fmt.Sprintf(`, {"name": "tags", "value": "%s"}`, tag)
+		}
+
+		h.apiInit().
+			Post(fmt.Sprintf("/namespace/%d/module/%d/record/", ns.ID, child.ID)).
+			JSON(`{"values": [` + values + `]}`).
+			Expect(t).
+			Status(http.StatusOK).
+			Assert(helpers.AssertNoErrors).
+			End()
+	}
+
+	rvs, err := h.repoRecord().LoadValues([]string{"children"}, []uint64{record.ID})
+	h.a.NoError(err)
+	h.a.Len(rvs, 1)
+	h.a.Equal("2", rvs[0].Value)
+}
+
 func TestRecordCreateWithErrors(t *testing.T) {
 	h := newHelper(t)
 
@@ -270,6 +605,171 @@ func TestRecordDelete(t *testing.T) {
 	h.a.Error(err, "record does not exist")
 }
 
+func (h helper) repoMakeReferencingRecords(onDelete string) (parent *types.Module, parentRecord *types.Record, child *types.Module, childRecord *types.Record) {
+	ns := h.repoMakeNamespace("record testing namespace")
+	parent = h.repoMakeRecordModuleWithFieldsOnNs("parent module", ns, &types.ModuleField{Name: "name"})
+	child = h.repoMakeRecordModuleWithFieldsOnNs("child module", ns,
+		&types.ModuleField{Name: "parent", Kind: "Record", Options: types.ModuleFieldOptions{
+			"moduleID": fmt.Sprintf("%d", parent.ID),
+			"onDelete": onDelete,
+		}},
+		&types.ModuleField{Name: "name"},
+	)
+
+	parentRecord = h.repoMakeRecord(parent)
+	childRecord = h.repoMakeRecord(child,
+		&types.RecordValue{Name: "parent", Value: fmt.Sprintf("%d", parentRecord.ID), Ref: parentRecord.ID},
+		&types.RecordValue{Name: "name", Value: "child"},
+	)
+
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.delete")
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.update")
+	return
+}
+
+func TestRecordDeleteRestricted(t *testing.T) {
+	h := newHelper(t)
+	parent, parentRecord, _, _ := h.repoMakeReferencingRecords("restrict")
+
+	h.apiInit().
+		Delete(fmt.Sprintf("/namespace/%d/module/%d/record/%d", parent.NamespaceID, parent.ID, parentRecord.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("can not delete record referenced through field parent")).
+		End()
+
+	_, err := h.repoRecord().FindByID(parent.NamespaceID, parentRecord.ID)
+	h.a.NoError(err)
+}
+
+func TestRecordDeleteCascade(t *testing.T) {
+	h := newHelper(t)
+	parent, parentRecord, _, childRecord := h.repoMakeReferencingRecords("cascade")
+
+	h.apiInit().
+		Delete(fmt.Sprintf("/namespace/%d/module/%d/record/%d", parent.NamespaceID, parent.ID, parentRecord.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		End()
+
+	_, err := h.repoRecord().FindByID(parent.NamespaceID, childRecord.ID)
+	h.a.Error(err, "record does not exist")
+
+	aa := h.recordedActions("compose:record", "cascadeDelete")
+	h.a.Len(aa, 1)
+	h.a.Equal(childRecord.ID, aa[0].Meta["record.ID"])
+	h.a.Equal("parent", aa[0].Meta["field"])
+}
+
+func TestRecordDeleteSetNull(t *testing.T) {
+	h := newHelper(t)
+	parent, parentRecord, child, childRecord := h.repoMakeReferencingRecords("setNull")
+
+	h.apiInit().
+		Delete(fmt.Sprintf("/namespace/%d/module/%d/record/%d", parent.NamespaceID, parent.ID, parentRecord.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		End()
+
+	rvs, err := h.repoRecord().LoadValues(child.Fields.Names(), []uint64{childRecord.ID})
+	h.a.NoError(err)
+	h.a.Len(rvs, 1)
+	h.a.Equal("name", rvs[0].Name)
+
+	aa := h.recordedActions("compose:record", "referenceClear")
+	h.a.Len(aa, 1)
+	h.a.Equal(childRecord.ID, aa[0].Meta["record.ID"])
+	h.a.Equal("parent", aa[0].Meta["field"])
+}
+
+// recordEvents collects IDs of records from the (after) events of compose records
+func (h helper) recordEvents() (events map[string][]uint64, unregister func()) {
+	var (
+		mux = sync.Mutex{}
+		ptr = eventBus.Register(
+			func(_ context.Context, ev eventbus.Event) error {
+				mux.Lock()
+				defer mux.Unlock()
+
+				if e, ok := ev.(interface{ Record() *types.Record }); ok && e.Record() != nil {
+					events[ev.EventType()] = append(events[ev.EventType()], e.Record().ID)
+				} else if e, ok := ev.(interface{ OldRecord() *types.Record }); ok && e.OldRecord() != nil {
+					events[ev.EventType()] = append(events[ev.EventType()], e.OldRecord().ID)
+				}
+
+				return nil
+			},
+			eventbus.For("compose:record"),
+			eventbus.On("afterUpdate", "afterDelete"),
+		)
+	)
+
+	events = map[string][]uint64{}
+	return events, func() { eventBus.Unregister(ptr) }
+}
+
+func TestRecordDeleteReferentialRulesEvents(t *testing.T) {
+	h := newHelper(t)
+
+	events, unregister := h.recordEvents()
+	defer unregister()
+
+	parent, parentRecord, _, childRecord := h.repoMakeReferencingRecords("cascade")
+	h.apiInit().
+		Delete(fmt.Sprintf("/namespace/%d/module/%d/record/%d", parent.NamespaceID, parent.ID, parentRecord.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		End()
+
+	h.a.Equal([]uint64{parentRecord.ID, childRecord.ID}, events["afterDelete"])
+
+	parent, parentRecord, _, childRecord = h.repoMakeReferencingRecords("setNull")
+	h.apiInit().
+		Delete(fmt.Sprintf("/namespace/%d/module/%d/record/%d", parent.NamespaceID, parent.ID, parentRecord.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		End()
+
+	h.a.Equal([]uint64{childRecord.ID}, events["afterUpdate"])
+}
+
+func TestRecordDeleteReferentialRulesRollback(t *testing.T) {
+	h := newHelper(t)
+
+	events, unregister := h.recordEvents()
+	defer unregister()
+
+	var (
+		parent, parentRecord, child, childRecord = h.repoMakeReferencingRecords("cascade")
+
+		// records of this module prevent deletion of cascade-deleted child records
+		restricted = h.repoMakeRecordModuleWithFieldsOnNs("restricted module", &types.Namespace{ID: parent.NamespaceID},
+			&types.ModuleField{Name: "child", Kind: "Record", Options: types.ModuleFieldOptions{
+				"moduleID": fmt.Sprintf("%d", child.ID),
+				"onDelete": "restrict",
+			}},
+		)
+	)
+
+	h.repoMakeRecord(restricted, &types.RecordValue{Name: "child", Value: fmt.Sprintf("%d", childRecord.ID), Ref: childRecord.ID})
+
+	h.apiInit().
+		Delete(fmt.Sprintf("/namespace/%d/module/%d/record/%d", parent.NamespaceID, parent.ID, parentRecord.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("can not delete record referenced through field child")).
+		End()
+
+	_, err := h.repoRecord().FindByID(parent.NamespaceID, childRecord.ID)
+	h.a.NoError(err)
+	h.a.Empty(events)
+	h.a.Empty(h.recordedActions("compose:record", "cascadeDelete"))
+}
+
 func TestRecordExport(t *testing.T) {
 	h := newHelper(t)
 
@@ -318,6 +818,67 @@ func (h helper) apiRunRecordImport(api *apitest.APITest, url, b string) *apitest
 		Status(http.StatusOK)
 }
 
+// makeXlsx prepares spreadsheet with the given rows on the first sheet
+func (h helper) makeXlsx(rows [][]interface{}) string {
+	f := excelize.NewFile()
+	for r, row := range rows {
+		for c, v := range row {
+			cell, err := excelize.CoordinatesToCellName(c+1, r+1)
+			h.a.NoError(err)
+			h.a.NoError(f.SetCellValue("Sheet1", cell, v))
+		}
+	}
+
+	buf, err := f.WriteToBuffer()
+	h.a.NoError(err)
+	return buf.String()
+}
+
+// apiWaitRecordImport waits for the import (running in background) to finish
+//
+// Session is looked up by the ID from the end of the URL
+func (h helper) apiWaitRecordImport(api *apitest.APITest, url string) *apitest.Response {
+	sessionID, err := strconv.ParseUint(path.Base(url), 10, 64)
+	h.a.NoError(err)
+
+	h.a.Eventually(func() bool {
+		ses, err := repository.RecordImportSession(context.Background(), db()).FindByID(sessionID)
+		return err == nil && ses.Progress.FinishedAt != nil
+	}, 10*time.Second, 10*time.Millisecond, "import did not finish")
+
+	return api.
+		Get(url).
+		Expect(h.t).
+		Status(http.StatusOK)
+}
+
+func TestRecordImportHeartbeat(t *testing.T) {
+	h := newHelper(t)
+
+	var (
+		repo  = repository.RecordImportSession(context.Background(), db())
+		stale = time.Now().Add(-time.Hour)
+	)
+
+	running, err := repo.Create(&types.RecordImportSession{Fields: types.RecordImportFields{}, HeartbeatAt: &stale})
+	h.a.NoError(err)
+	finished, err := repo.Create(&types.RecordImportSession{Fields: types.RecordImportFields{}})
+	h.a.NoError(err)
+
+	h.a.NoError(repo.Heartbeat(running.SessionID))
+	h.a.NoError(repo.Heartbeat(finished.SessionID))
+
+	// Running import is no longer stale and can not be claimed
+	claimed, err := repo.Claim(running.SessionID, time.Now().Add(-time.Minute))
+	h.a.NoError(err)
+	h.a.False(claimed)
+
+	// Finished import is not brought back to life
+	ses, err := repo.FindByID(finished.SessionID)
+	h.a.NoError(err)
+	h.a.Nil(ses.HeartbeatAt)
+}
+
 func TestRecordImportInit(t *testing.T) {
 	h := newHelper(t)
 
@@ -334,6 +895,10 @@ func TestRecordImportInit(t *testing.T) {
 			Name:    "f1.json",
 			Content: `{"name":"v1","email":"v2"}` + "\n",
 		},
+		{
+			Name:    "f1.xlsx",
+			Content: h.makeXlsx([][]interface{}{{"name", "email"}, {"v1", "v2"}}),
+		},
 	}
 
 	for _, test := range tests {
@@ -393,6 +958,188 @@ func TestRecordImportRun(t *testing.T) {
 	}
 }
 
+func TestRecordImportRunUpsert(t *testing.T) {
+	h := newHelper(t)
+
+	module := h.repoMakeRecordModuleWithFields("record import upsert module",
+		&types.ModuleField{Name: "code", Options: types.ModuleFieldOptions{"isUnique": true}},
+		&types.ModuleField{Name: "name"},
+	)
+	h.repoMakeRecord(module, &types.RecordValue{Name: "code", Value: "a"}, &types.RecordValue{Name: "name", Value: "old"})
+	h.repoMakeRecord(module, &types.RecordValue{Name: "code", Value: "b"}, &types.RecordValue{Name: "name", Value: "same"})
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.create")
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.update")
+
+	url := fmt.Sprintf("/namespace/%d/module/%d/record/import", module.NamespaceID, module.ID)
+	rsp := &rImportSession{}
+	api := h.apiInit()
+
+	r := h.apiInitRecordImport(api, url, "f1.csv", []byte("fcode,fname\na,new\nb,same\nc,fresh\n")).End()
+	r.JSON(rsp)
+
+	h.apiRunRecordImport(api, fmt.Sprintf("%s/%s", url, rsp.Response.SessionID), `{"fields":{"fcode":"code","fname":"name"},"onError":"fail","mode":"UPSERT","matchField":"code"}`).
+		Assert(helpers.AssertNoErrors).
+		End()
+
+	h.apiWaitRecordImport(api, fmt.Sprintf("%s/%s", url, rsp.Response.SessionID)).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.progress.created`, float64(1))).
+		Assert(jsonpath.Equal(`$.response.progress.updated`, float64(1))).
+		Assert(jsonpath.Equal(`$.response.progress.skipped`, float64(1))).
+		End()
+}
+
+func TestRecordImportRunUpsert_invalidMatchField(t *testing.T) {
+	h := newHelper(t)
+
+	module := h.repoMakeRecordModuleWithFields("record import upsert module")
+	url := fmt.Sprintf("/namespace/%d/module/%d/record/import", module.NamespaceID, module.ID)
+	rsp := &rImportSession{}
+	api := h.apiInit()
+
+	r := h.apiInitRecordImport(api, url, "f1.csv", []byte("fname\nv1\n")).End()
+	r.JSON(rsp)
+
+	h.apiRunRecordImport(api, fmt.Sprintf("%s/%s", url, rsp.Response.SessionID), `{"fields":{"fname":"name"},"onError":"fail","mode":"UPSERT","matchField":"name"}`).
+		Assert(helpers.AssertError("invalid import match field name, expecting recordID or a unique field")).
+		End()
+}
+
+func TestRecordImportRunDryRun(t *testing.T) {
+	h := newHelper(t)
+
+	module := h.repoMakeRecordModuleWithFields("record import dry-run module",
+		&types.ModuleField{Name: "name", Required: true},
+	)
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.create")
+
+	url := fmt.Sprintf("/namespace/%d/module/%d/record/import", module.NamespaceID, module.ID)
+	rsp := &rImportSession{}
+	api := h.apiInit()
+
+	r := h.apiInitRecordImport(api, url, "f1.csv", []byte("fname\nv1\n\"\"\n")).End()
+	r.JSON(rsp)
+
+	h.apiRunRecordImport(api, fmt.Sprintf("%s/%s", url, rsp.Response.SessionID), `{"fields":{"fname":"name"},"onError":"skip","dryRun":true}`).
+		Assert(helpers.AssertNoErrors).
+		End()
+
+	h.apiWaitRecordImport(api, fmt.Sprintf("%s/%s", url, rsp.Response.SessionID)).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.progress.completed`, float64(1))).
+		Assert(jsonpath.Equal(`$.response.progress.failed`, float64(1))).
+		End()
+
+	rr, _, err := h.repoRecord().Find(module, types.RecordFilter{})
+	h.a.NoError(err)
+	h.a.Len(rr, 0)
+
+	rep := h.apiInit().Get(fmt.Sprintf("%s/%s/report", url, rsp.Response.SessionID)).
+		Expect(t).
+		Status(http.StatusOK).
+		End()
+
+	b, err := ioutil.ReadAll(rep.Response.Body)
+	h.a.NoError(err)
+	h.a.Equal("row,field,kind,message\n2,name,empty,\n", string(b))
+}
+
+func TestRecordImportRunDryRun_failOnError(t *testing.T) {
+	h := newHelper(t)
+
+	module := h.repoMakeRecordModuleWithFields("record import dry-run fail module",
+		&types.ModuleField{Name: "name", Required: true},
+	)
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.create")
+
+	url := fmt.Sprintf("/namespace/%d/module/%d/record/import", module.NamespaceID, module.ID)
+	rsp := &rImportSession{}
+	api := h.apiInit()
+
+	r := h.apiInitRecordImport(api, url, "f1.csv", []byte("fname\nv1\n\"\"\nv3\n")).End()
+	r.JSON(rsp)
+
+	h.apiRunRecordImport(api, fmt.Sprintf("%s/%s", url, rsp.Response.SessionID), `{"fields":{"fname":"name"},"onError":"FAIL","dryRun":true}`).
+		Assert(helpers.AssertNoErrors).
+		End()
+
+	h.apiWaitRecordImport(api, fmt.Sprintf("%s/%s", url, rsp.Response.SessionID)).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.progress.completed`, float64(1))).
+		Assert(jsonpath.Equal(`$.response.progress.failed`, float64(1))).
+		End()
+
+	rr, _, err := h.repoRecord().Find(module, types.RecordFilter{})
+	h.a.NoError(err)
+	h.a.Len(rr, 0)
+}
+
+func TestRecordImportRun_skipFailed(t *testing.T) {
+	h := newHelper(t)
+
+	module := h.repoMakeRecordModuleWithFields("record import skip module",
+		&types.ModuleField{Name: "name", Required: true},
+	)
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.create")
+
+	url := fmt.Sprintf("/namespace/%d/module/%d/record/import", module.NamespaceID, module.ID)
+	rsp := &rImportSession{}
+	api := h.apiInit()
+
+	r := h.apiInitRecordImport(api, url, "f1.csv", []byte("fname\nv1\n\"\"\nv3\n")).End()
+	r.JSON(rsp)
+
+	h.apiRunRecordImport(api, fmt.Sprintf("%s/%s", url, rsp.Response.SessionID), `{"fields":{"fname":"name"},"onError":"skip"}`).
+		Assert(helpers.AssertNoErrors).
+		End()
+
+	h.apiWaitRecordImport(api, fmt.Sprintf("%s/%s", url, rsp.Response.SessionID)).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.progress.completed`, float64(2))).
+		Assert(jsonpath.Equal(`$.response.progress.failed`, float64(1))).
+		End()
+
+	rr, _, err := h.repoRecord().Find(module, types.RecordFilter{})
+	h.a.NoError(err)
+	h.a.Len(rr, 2)
+}
+
+func TestRecordImportRunSpreadsheet(t *testing.T) {
+	h := newHelper(t)
+
+	module := h.repoMakeRecordModuleWithFields("record import spreadsheet module",
+		&types.ModuleField{Name: "name"},
+		&types.ModuleField{Name: "born", Kind: "DateTime", Options: types.ModuleFieldOptions{"onlyDate": true}},
+	)
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.create")
+
+	url := fmt.Sprintf("/namespace/%d/module/%d/record/import", module.NamespaceID, module.ID)
+	rsp := &rImportSession{}
+	api := h.apiInit()
+
+	xlsx := h.makeXlsx([][]interface{}{{"fname", "fborn"}, {"John", time.Date(1980, 5, 4, 0, 0, 0, 0, time.UTC)}})
+	r := h.apiInitRecordImport(api, url, "f1.xlsx", []byte(xlsx)).End()
+	r.JSON(rsp)
+
+	h.apiRunRecordImport(api, fmt.Sprintf("%s/%s", url, rsp.Response.SessionID), `{"fields":{"fname":"name","fborn":"born"},"onError":"fail"}`).
+		Assert(helpers.AssertNoErrors).
+		End()
+
+	h.apiWaitRecordImport(api, fmt.Sprintf("%s/%s", url, rsp.Response.SessionID)).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.progress.completed`, float64(1))).
+		End()
+
+	rr, _, err := h.repoRecord().Find(module, types.RecordFilter{})
+	h.a.NoError(err)
+	h.a.Len(rr, 1)
+
+	rvs, err := h.repoRecord().LoadValues([]string{"born"}, []uint64{rr[0].ID})
+	h.a.NoError(err)
+	h.a.Len(rvs, 1)
+	h.a.Equal("1980-05-04", rvs[0].Value)
+}
+
 func TestRecordImportRun_sessionNotFound(t *testing.T) {
 	h := newHelper(t)
 
@@ -520,3 +1267,267 @@ func TestRecordFieldModulePermissionCheck(t *testing.T) {
 	}
 
 }
+
+func TestRecordReportFunctions(t *testing.T) {
+	h := newHelper(t)
+
+	module := h.repoMakeRecordModuleWithFields("record report module",
+		&types.ModuleField{Name: "amount", Kind: "Number", Options: types.ModuleFieldOptions{"precision": 2}},
+		&types.ModuleField{Name: "customer", Kind: "String"},
+		&types.ModuleField{Name: "closed", Kind: "DateTime"},
+	)
+
+	for _, r := range [][]string{
+		{"10", "acme", "2020-01-01T10:00:00Z"},
+		{"20", "acme", "2020-01-01T12:00:00Z"},
+		{"30", "umbrella", "2020-01-02T10:00:00Z"},
+		{"40.5", "initech", "2020-01-03T10:00:00Z"},
+	} {
+		h.repoMakeRecord(module,
+			&types.RecordValue{Name: "amount", Value: r[0]},
+			&types.RecordValue{Name: "customer", Value: r[1]},
+			&types.RecordValue{Name: "closed", Value: r[2]},
+		)
+	}
+
+	h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/module/%d/record/report", module.NamespaceID, module.ID)).
+		Query("metrics", "COUNT(DISTINCT customer) AS customers, MEDIAN(amount) AS median, CUMULATIVE(SUM(amount)) AS revenue").
+		Query("dimensions", "DATE_FORMAT(closed, '%Y-%m-%d') AS day").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Len(`$.response`, 3)).
+		Assert(jsonpath.Equal(`$.response[0].day`, "2020-01-01")).
+		Assert(jsonpath.Equal(`$.response[0].customers`, "1")).
+		Assert(jsonpath.Equal(`$.response[0].median`, "15")).
+		Assert(jsonpath.Equal(`$.response[0].revenue`, "30")).
+		Assert(jsonpath.Equal(`$.response[1].revenue`, "60")).
+		Assert(jsonpath.Equal(`$.response[2].revenue`, "100.5")).
+		End()
+
+	h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/module/%d/record/report", module.NamespaceID, module.ID)).
+		Query("metrics", "PERCENTILE(amount, 0.75) AS p75, COUNTD(customer) AS customers").
+		Query("dimensions", "YEAR(closed)").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response[0].p75`, "32.625")).
+		Assert(jsonpath.Equal(`$.response[0].customers`, "3")).
+		End()
+}
+
+func TestRecordReportJoins(t *testing.T) {
+	h := newHelper(t)
+
+	namespace := h.repoMakeNamespace("record report joins namespace")
+	account := h.repoMakeRecordModuleWithFieldsOnNs("account", namespace,
+		&types.ModuleField{Name: "region"},
+		&types.ModuleField{Name: "employees", Kind: "Number"},
+	)
+	invoice := h.repoMakeRecordModuleWithFieldsOnNs("invoice", namespace,
+		&types.ModuleField{Name: "amount", Kind: "Number"},
+		&types.ModuleField{Name: "account", Kind: "Record", Options: types.ModuleFieldOptions{"moduleID": strconv.FormatUint(account.ID, 10)}},
+	)
+
+	ref := func(r *types.Record) *types.RecordValue {
+		return &types.RecordValue{Name: "account", Value: strconv.FormatUint(r.ID, 10), Ref: r.ID}
+	}
+
+	emea := h.repoMakeRecord(account, &types.RecordValue{Name: "region", Value: "EMEA"}, &types.RecordValue{Name: "employees", Value: "10"})
+	apac := h.repoMakeRecord(account, &types.RecordValue{Name: "region", Value: "APAC"}, &types.RecordValue{Name: "employees", Value: "5"})
+	h.repoMakeRecord(invoice, &types.RecordValue{Name: "amount", Value: "100"}, ref(emea))
+	h.repoMakeRecord(invoice, &types.RecordValue{Name: "amount", Value: "50"}, ref(emea))
+	h.repoMakeRecord(invoice, &types.RecordValue{Name: "amount", Value: "20"}, ref(apac))
+
+	// Revenue from invoices, grouped by account's region
+	h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/module/%d/record/report", invoice.NamespaceID, invoice.ID)).
+		Query("metrics", "SUM(amount) AS revenue").
+		Query("dimensions", "acc.region AS region").
+		Query("joins", fmt.Sprintf(`[{"alias": "acc", "moduleID": "%d", "field": "account"}]`, account.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Len(`$.response`, 2)).
+		Assert(jsonpath.Equal(`$.response[0].region`, "APAC")).
+		Assert(jsonpath.Equal(`$.response[0].revenue`, "20")).
+		Assert(jsonpath.Equal(`$.response[1].region`, "EMEA")).
+		Assert(jsonpath.Equal(`$.response[1].revenue`, "150")).
+		End()
+
+	// Same, from the accounts (joined through the field of invoice module)
+	h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/module/%d/record/report", account.NamespaceID, account.ID)).
+		Query("metrics", "SUM(inv.amount) AS revenue, COUNT(DISTINCT inv.recordID) AS invoices").
+		Query("dimensions", "region").
+		Query("joins", fmt.Sprintf(`[{"alias": "inv", "moduleID": "%d", "field": "account"}]`, invoice.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response[1].revenue`, "150")).
+		Assert(jsonpath.Equal(`$.response[1].invoices`, "2")).
+		End()
+
+	// Account with two invoices is counted once
+	h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/module/%d/record/report", account.NamespaceID, account.ID)).
+		Query("metrics", "MAX(employees) AS employees, COUNTD(recordID) AS accounts").
+		Query("dimensions", "region AS region").
+		Query("joins", fmt.Sprintf(`[{"alias": "inv", "moduleID": "%d", "field": "account"}]`, invoice.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response[1].region`, "EMEA")).
+		Assert(jsonpath.Equal(`$.response[1].count`, float64(1))).
+		Assert(jsonpath.Equal(`$.response[1].employees`, "10")).
+		Assert(jsonpath.Equal(`$.response[1].accounts`, "1")).
+		End()
+
+	h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/module/%d/record/report", invoice.NamespaceID, invoice.ID)).
+		Query("metrics", "SUM(amount)").
+		Query("dimensions", "acc.region").
+		Query("joins", fmt.Sprintf(`[{"alias": "acc", "moduleID": "%d", "field": "amount"}]`, account.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("invalid report join acc")).
+		End()
+
+	h.deny(account.PermissionResource(), "record.read")
+
+	h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/module/%d/record/report", invoice.NamespaceID, invoice.ID)).
+		Query("metrics", "SUM(amount)").
+		Query("dimensions", "acc.region").
+		Query("joins", fmt.Sprintf(`[{"alias": "acc", "moduleID": "%d", "field": "account"}]`, account.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("not allowed to read this record")).
+		End()
+}
+
+func TestRecordReportCache(t *testing.T) {
+	h := newHelper(t)
+
+	namespace := h.repoMakeNamespace("record report cache namespace")
+	account := h.repoMakeRecordModuleWithFieldsOnNs("account", namespace,
+		&types.ModuleField{Name: "region"},
+	)
+	invoice := h.repoMakeRecordModuleWithFieldsOnNs("invoice", namespace,
+		&types.ModuleField{Name: "amount", Kind: "Number"},
+		&types.ModuleField{Name: "account", Kind: "Record", Options: types.ModuleFieldOptions{"moduleID": strconv.FormatUint(account.ID, 10)}},
+	)
+
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.create")
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.update")
+
+	emea := h.repoMakeRecord(account, &types.RecordValue{Name: "region", Value: "EMEA"})
+	ref := &types.RecordValue{Name: "account", Value: strconv.FormatUint(emea.ID, 10), Ref: emea.ID}
+	h.repoMakeRecord(invoice, &types.RecordValue{Name: "amount", Value: "100"}, ref)
+
+	report := func(count, region string) {
+		h.apiInit().
+			Get(fmt.Sprintf("/namespace/%d/module/%d/record/report", invoice.NamespaceID, invoice.ID)).
+			Query("metrics", "COUNT(*) AS total").
+			Query("dimensions", "account.region AS region").
+			Expect(t).
+			Status(http.StatusOK).
+			Assert(helpers.AssertNoErrors).
+			Assert(jsonpath.Len(`$.response`, 1)).
+			Assert(jsonpath.Equal(`$.response[0].total`, count)).
+			Assert(jsonpath.Equal(`$.response[0].region`, region)).
+			End()
+	}
+
+	report("1", "EMEA")
+
+	// Records stored directly (without events) are not seen
+	// until cached report is invalidated
+	h.repoMakeRecord(invoice, &types.RecordValue{Name: "amount", Value: "50"}, ref)
+	report("1", "EMEA")
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/module/%d/record/", invoice.NamespaceID, invoice.ID)).
+		JSON(fmt.Sprintf(`{"values": [{"name": "amount", "value": "20"}, {"name": "account", "value": "%d"}]}`, emea.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		End()
+
+	report("3", "EMEA")
+
+	// Changes of the referenced module invalidate the report as well
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/module/%d/record/%d", account.NamespaceID, account.ID, emea.ID)).
+		JSON(`{"values": [{"name": "region", "value": "APAC"}]}`).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		End()
+
+	report("3", "APAC")
+}
+
+func TestRecordReportCache_disabled(t *testing.T) {
+	h := newHelper(t)
+
+	namespace := h.repoMakeNamespace("record report without cache namespace")
+	invoice := h.repoMakeRecordModuleWithFieldsOnNs("invoice", namespace,
+		&types.ModuleField{Name: "amount", Kind: "Number"},
+	)
+
+	cache := service.DefaultRecordReportCache
+	defer func() { service.DefaultRecordReportCache = cache }()
+	service.DefaultRecordReportCache = nil
+
+	var (
+		svc    = service.Record().With(h.secCtx())
+		report = func() interface{} {
+			out, err := svc.Report(invoice.NamespaceID, invoice.ID, nil, "COUNT(*) AS total", "amount", "")
+			h.a.NoError(err)
+			return out
+		}
+	)
+
+	h.repoMakeRecord(invoice, &types.RecordValue{Name: "amount", Value: "100"})
+	h.a.Len(report(), 1)
+
+	// Without cache, records stored directly (without events) are seen immediately
+	h.repoMakeRecord(invoice, &types.RecordValue{Name: "amount", Value: "50"})
+	h.a.Len(report(), 2)
+}
+
+func TestRecordAttachmentRejected(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.create")
+
+	var (
+		module = h.repoMakeRecordModuleWithFields("rejected attachment module", &types.ModuleField{Name: "file", Kind: "File"})
+		body   = &bytes.Buffer{}
+		writer = multipart.NewWriter(body)
+	)
+
+	h.a.NoError(writer.WriteField("fieldName", "file"))
+	part, err := writer.CreateFormFile("upload", "setup.exe")
+	h.a.NoError(err)
+	_, err = part.Write([]byte("MZ"))
+	h.a.NoError(err)
+	h.a.NoError(writer.Close())
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/module/%d/record/attachment", module.NamespaceID, module.ID)).
+		Body(body.String()).
+		ContentType(writer.FormDataContentType()).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("upload rejected: file extension exe is not allowed")).
+		End()
+
+	aa := h.recordedActions("compose:attachment", "create")
+	h.a.Len(aa, 1)
+	h.a.Equal(actionlog.Alert, aa[0].Severity)
+	h.a.Equal("upload rejected: file extension exe is not allowed", aa[0].Error)
+	h.a.Equal("setup.exe", aa[0].Meta["name"])
+}
@@ -0,0 +1,379 @@
+package compose
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	jsonpath "github.com/steinfletcher/apitest-jsonpath"
+
+	"github.com/cortezaproject/corteza-server/compose/repository"
+	"github.com/cortezaproject/corteza-server/compose/service"
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/tests/helpers"
+)
+
+func (h helper) repoRecordView() repository.RecordViewRepository {
+	return repository.RecordView(context.Background(), db())
+}
+
+func (h helper) repoMakeRecordView(m *types.Module, name, filter, sort string, columns ...string) *types.RecordView {
+	v, err := h.
+		repoRecordView().
+		Create(&types.RecordView{
+			Name:        name,
+			Handle:      name,
+			NamespaceID: m.NamespaceID,
+			ModuleID:    m.ID,
+			Filter:      filter,
+			Sort:        sort,
+			Columns:     columns,
+		})
+	h.a.NoError(err)
+
+	return v
+}
+
+func TestRecordViewRead(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.RecordViewPermissionResource.AppendWildcard(), "read")
+
+	m := h.repoMakeRecordModuleWithFields("record view module")
+	v := h.repoMakeRecordView(m, "some_view", "name = 'foo'", "name DESC", "name", "email")
+
+	h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/record-view/%d", m.NamespaceID, v.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.name`, v.Name)).
+		Assert(jsonpath.Equal(`$.response.viewID`, fmt.Sprintf("%d", v.ID))).
+		Assert(jsonpath.Equal(`$.response.moduleID`, fmt.Sprintf("%d", m.ID))).
+		Assert(jsonpath.Equal(`$.response.filter`, "name = 'foo'")).
+		Assert(jsonpath.Equal(`$.response.sort`, "name DESC")).
+		Assert(jsonpath.Len(`$.response.columns`, 2)).
+		End()
+}
+
+func TestRecordViewReadByHandle(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.RecordViewPermissionResource.AppendWildcard(), "read")
+
+	m := h.repoMakeRecordModuleWithFields("record view module")
+	v := h.repoMakeRecordView(m, "some_view", "", "")
+
+	vbh, err := service.DefaultRecordView.With(h.secCtx()).FindByAny(m.NamespaceID, v.Handle)
+
+	h.a.NoError(err)
+	h.a.NotNil(vbh)
+	h.a.Equal(vbh.ID, v.ID)
+}
+
+func TestRecordViewList_filterForbidden(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.RecordViewPermissionResource.AppendWildcard(), "read")
+
+	m := h.repoMakeRecordModuleWithFields("record view module")
+	h.repoMakeRecordView(m, "view", "", "")
+	f := h.repoMakeRecordView(m, "view_forbidden", "", "")
+
+	h.deny(types.RecordViewPermissionResource.AppendID(f.ID), "read")
+
+	h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/record-view/", m.NamespaceID)).
+		Query("moduleID", fmt.Sprintf("%d", m.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Len(`$.response.set`, 1)).
+		Assert(jsonpath.NotPresent(`$.response.set[? @.name=="view_forbidden"]`)).
+		End()
+}
+
+func TestRecordViewCreateForbidden(t *testing.T) {
+	h := newHelper(t)
+
+	m := h.repoMakeRecordModuleWithFields("record view module")
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/record-view/", m.NamespaceID)).
+		FormData("name", "some-view").
+		FormData("moduleID", fmt.Sprintf("%d", m.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("not allowed to create record views")).
+		End()
+}
+
+func TestRecordViewCreate(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.NamespacePermissionResource.AppendWildcard(), "record-view.create")
+
+	m := h.repoMakeRecordModuleWithFields("record view module")
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/record-view/", m.NamespaceID)).
+		FormData("name", "some-view").
+		FormData("moduleID", fmt.Sprintf("%d", m.ID)).
+		FormData("filter", "name = 'foo'").
+		FormData("columns", "name").
+		FormData("columns", "createdAt").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.filter`, "name = 'foo'")).
+		Assert(jsonpath.Len(`$.response.columns`, 2)).
+		End()
+}
+
+func TestRecordViewCreateInvalid(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.NamespacePermissionResource.AppendWildcard(), "record-view.create")
+
+	m := h.repoMakeRecordModuleWithFields("record view module")
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/record-view/", m.NamespaceID)).
+		FormData("name", "some-view").
+		FormData("moduleID", fmt.Sprintf("%d", m.ID)).
+		FormData("columns", "nonexisting").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("invalid column")).
+		End()
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/record-view/", m.NamespaceID)).
+		FormData("name", "some-view").
+		FormData("moduleID", fmt.Sprintf("%d", m.ID)).
+		FormData("filter", "name = ").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("invalid filter")).
+		End()
+}
+
+func TestRecordViewUpdateForbidden(t *testing.T) {
+	h := newHelper(t)
+
+	m := h.repoMakeRecordModuleWithFields("record view module")
+	v := h.repoMakeRecordView(m, "some_view", "", "")
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/record-view/%d", m.NamespaceID, v.ID)).
+		FormData("name", "changed-name").
+		FormData("moduleID", fmt.Sprintf("%d", m.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("not allowed to update this record view")).
+		End()
+}
+
+func TestRecordViewUpdate(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.RecordViewPermissionResource.AppendWildcard(), "update")
+
+	m := h.repoMakeRecordModuleWithFields("record view module")
+	v := h.repoMakeRecordView(m, "some_view", "", "")
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/record-view/%d", m.NamespaceID, v.ID)).
+		FormData("name", "changed-name").
+		FormData("moduleID", fmt.Sprintf("%d", m.ID)).
+		FormData("sort", "name DESC").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		End()
+
+	v, err := h.repoRecordView().FindByID(m.NamespaceID, v.ID)
+	h.a.NoError(err)
+	h.a.NotNil(v)
+	h.a.Equal("changed-name", v.Name)
+	h.a.Equal("name DESC", v.Sort)
+}
+
+func TestRecordViewDeleteForbidden(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.RecordViewPermissionResource.AppendWildcard(), "read")
+
+	m := h.repoMakeRecordModuleWithFields("record view module")
+	v := h.repoMakeRecordView(m, "some_view", "", "")
+
+	h.apiInit().
+		Delete(fmt.Sprintf("/namespace/%d/record-view/%d", m.NamespaceID, v.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("not allowed to delete this record view")).
+		End()
+}
+
+func TestRecordViewDelete(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.RecordViewPermissionResource.AppendWildcard(), "delete")
+
+	m := h.repoMakeRecordModuleWithFields("record view module")
+	v := h.repoMakeRecordView(m, "some_view", "", "")
+
+	h.apiInit().
+		Delete(fmt.Sprintf("/namespace/%d/record-view/%d", m.NamespaceID, v.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		End()
+
+	_, err := h.repoRecordView().FindByID(m.NamespaceID, v.ID)
+	h.a.Error(err, "compose.repository.RecordViewNotFound")
+}
+
+func TestRecordListWithView(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.RecordViewPermissionResource.AppendWildcard(), "read")
+
+	m := h.repoMakeRecordModuleWithFields("record view module")
+	for _, n := range []string{"a", "b", "c", "d"} {
+		h.repoMakeRecord(m,
+			&types.RecordValue{Name: "name", Value: n},
+			&types.RecordValue{Name: "email", Value: n + "@example.tld"},
+		)
+	}
+
+	v := h.repoMakeRecordView(m, "some_view", "name <> 'a'", "name DESC")
+	url := fmt.Sprintf("/namespace/%d/module/%d/record/", m.NamespaceID, m.ID)
+
+	h.apiInit().
+		Get(url).
+		Query("viewID", fmt.Sprintf("%d", v.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.filter.count`, float64(3))).
+		Assert(jsonpath.Equal(`$.response.set[0].values[? @.name=="name"].value`, []interface{}{"d"})).
+		End()
+
+	// Query is combined with view's filter, sort overrides view's sort
+	h.apiInit().
+		Get(url).
+		Query("viewID", fmt.Sprintf("%d", v.ID)).
+		Query("query", "name <> 'd'").
+		Query("sort", "name ASC").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.filter.count`, float64(2))).
+		Assert(jsonpath.Equal(`$.response.set[0].values[? @.name=="name"].value`, []interface{}{"b"})).
+		End()
+}
+
+func TestRecordListWithViewHandle(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.RecordViewPermissionResource.AppendWildcard(), "read")
+
+	m := h.repoMakeRecordModuleWithFields("record view module")
+	for _, n := range []string{"a", "b", "c"} {
+		h.repoMakeRecord(m,
+			&types.RecordValue{Name: "name", Value: n},
+			&types.RecordValue{Name: "email", Value: n + "@example.tld"},
+		)
+	}
+
+	v := h.repoMakeRecordView(m, "handle_view", "name <> 'a'", "name DESC")
+
+	h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/module/%d/record/", m.NamespaceID, m.ID)).
+		Query("viewHandle", v.Handle).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.filter.count`, float64(2))).
+		Assert(jsonpath.Equal(`$.response.set[0].values[? @.name=="name"].value`, []interface{}{"c"})).
+		End()
+}
+
+func TestRecordListWithViewForbidden(t *testing.T) {
+	h := newHelper(t)
+
+	m := h.repoMakeRecordModuleWithFields("record view module")
+	v := h.repoMakeRecordView(m, "some_view", "", "")
+
+	h.deny(types.RecordViewPermissionResource.AppendID(v.ID), "read")
+
+	h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/module/%d/record/", m.NamespaceID, m.ID)).
+		Query("viewID", fmt.Sprintf("%d", v.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("not allowed to read record view")).
+		End()
+}
+
+func TestRecordListWithViewOfAnotherModule(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.RecordViewPermissionResource.AppendWildcard(), "read")
+
+	m := h.repoMakeRecordModuleWithFields("record view module")
+	other := h.repoMakeRecordModuleWithFieldsOnNs("other module", &types.Namespace{ID: m.NamespaceID})
+	v := h.repoMakeRecordView(other, "some_view", "", "")
+
+	h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/module/%d/record/", m.NamespaceID, m.ID)).
+		Query("viewID", fmt.Sprintf("%d", v.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("record view does not belong to this module")).
+		End()
+}
+
+func TestRecordExportWithView(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.RecordViewPermissionResource.AppendWildcard(), "read")
+
+	m := h.repoMakeRecordModuleWithFields("record view export module")
+	for i := 0; i < 5; i++ {
+		h.repoMakeRecord(m,
+			&types.RecordValue{Name: "name", Value: fmt.Sprintf("d%d", i)},
+			&types.RecordValue{Name: "email", Value: fmt.Sprintf("d%d@example.tld", i)},
+		)
+	}
+
+	v := h.repoMakeRecordView(m, "some_view", "name <> 'd2'", "name DESC", "name", "email")
+
+	r := h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/module/%d/record/export.csv", m.NamespaceID, m.ID)).
+		Query("viewID", fmt.Sprintf("%d", v.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		End()
+
+	b, err := ioutil.ReadAll(r.Response.Body)
+	h.a.NoError(err)
+	h.a.Equal("name,email\nd4,d4@example.tld\nd3,d3@example.tld\nd1,d1@example.tld\nd0,d0@example.tld\n", string(b))
+}
+
+func TestRecordExportWithViewHandle(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.RecordViewPermissionResource.AppendWildcard(), "read")
+
+	m := h.repoMakeRecordModuleWithFields("record view export module")
+	for i := 0; i < 3; i++ {
+		h.repoMakeRecord(m,
+			&types.RecordValue{Name: "name", Value: fmt.Sprintf("d%d", i)},
+			&types.RecordValue{Name: "email", Value: fmt.Sprintf("d%d@example.tld", i)},
+		)
+	}
+
+	v := h.repoMakeRecordView(m, "handle_view", "name <> 'd1'", "name DESC", "name")
+
+	r := h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/module/%d/record/export.csv", m.NamespaceID, m.ID)).
+		Query("viewHandle", v.Handle).
+		Expect(t).
+		Status(http.StatusOK).
+		End()
+
+	b, err := ioutil.ReadAll(r.Response.Body)
+	h.a.NoError(err)
+	h.a.Equal("name\nd2\nd0\n", string(b))
+}
@@ -0,0 +1,213 @@
+package compose
+
+import (
+	"bytes"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	jsonpath "github.com/steinfletcher/apitest-jsonpath"
+
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/tests/helpers"
+)
+
+// apiUploadRecordAttachment uploads file to the record field and returns ID of the new attachment
+func (h helper) apiUploadRecordAttachment(module *types.Module, fieldName, name, content string) uint64 {
+	return h.apiUploadAttachment(
+		module.NamespaceID,
+		fmt.Sprintf("/namespace/%d/module/%d/record/attachment", module.NamespaceID, module.ID),
+		map[string]string{"fieldName": fieldName},
+		name,
+		content,
+	)
+}
+
+// apiUploadPageAttachment uploads file to the page and returns ID of the new attachment
+func (h helper) apiUploadPageAttachment(page *types.Page, name, content string) uint64 {
+	return h.apiUploadAttachment(
+		page.NamespaceID,
+		fmt.Sprintf("/namespace/%d/page/%d/attachment", page.NamespaceID, page.ID),
+		nil,
+		name,
+		content,
+	)
+}
+
+// apiUploadAttachment uploads file and returns ID of the new attachment
+//
+// Waits for the preview of the file; previews of documents are stored in the
+// background and would otherwise interfere with the (SQLite) transactions of the test
+func (h helper) apiUploadAttachment(namespaceID uint64, path string, fields map[string]string, name, content string) uint64 {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for k, v := range fields {
+		h.a.NoError(writer.WriteField(k, v))
+	}
+
+	part, err := writer.CreateFormFile("upload", name)
+	h.a.NoError(err)
+
+	_, err = part.Write([]byte(content))
+	h.a.NoError(err)
+	h.a.NoError(writer.Close())
+
+	var rsp = struct {
+		Response struct {
+			AttachmentID string `json:"attachmentID"`
+		} `json:"response"`
+	}{}
+
+	h.apiInit().
+		Post(path).
+		Body(body.String()).
+		ContentType(writer.FormDataContentType()).
+		Expect(h.t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		End().
+		JSON(&rsp)
+
+	ID, err := strconv.ParseUint(rsp.Response.AttachmentID, 10, 64)
+	h.a.NoError(err)
+
+	h.a.Eventually(func() bool {
+		att, err := h.repoAttachment().FindByID(namespaceID, ID)
+		return err == nil && att.PreviewUrl != ""
+	}, 10*time.Second, 10*time.Millisecond)
+
+	return ID
+}
+
+func TestSearchAttachments(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.create")
+
+	var (
+		module = h.repoMakeRecordModuleWithFields("attachment search module", &types.ModuleField{Name: "contract", Kind: "File"})
+		term   = fmt.Sprintf("clause%d", time.Now().UnixNano())
+
+		attachmentID = h.apiUploadRecordAttachment(module, "contract", "contract.txt", "Parties agree to terms in "+term+" of this contract")
+		strID        = strconv.FormatUint(attachmentID, 10)
+	)
+
+	h.apiUploadRecordAttachment(module, "contract", "other.txt", "Nothing to see here")
+	h.repoMakeRecord(module, &types.RecordValue{Name: "contract", Value: strID, Ref: attachmentID})
+
+	h.apiInit().
+		Get("/search/attachments").
+		Query("query", term).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Len(`$.response.set`, 1)).
+		Assert(jsonpath.Equal(`$.response.set[0].attachmentID`, strID)).
+		Assert(jsonpath.Equal(`$.response.filter.count`, float64(1))).
+		End()
+
+	h.apiInit().
+		Get("/search/attachments").
+		Query("query", term).
+		Query("namespaceID", strconv.FormatUint(module.NamespaceID+1, 10)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("namespace not found")).
+		End()
+
+	h.deny(module.PermissionResource(), "record.read")
+
+	h.apiInit().
+		Get("/search/attachments").
+		Query("query", term).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Len(`$.response.set`, 0)).
+		End()
+}
+
+// Record attachments are found only through the fields with readable values
+func TestSearchAttachmentsRecordValueRead(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.create")
+
+	var (
+		module = h.repoMakeRecordModuleWithFields("attachment search module",
+			&types.ModuleField{Name: "public", Kind: "File"},
+			&types.ModuleField{Name: "secret", Kind: "File"},
+		)
+
+		term = fmt.Sprintf("clause%d", time.Now().UnixNano())
+
+		public = h.apiUploadRecordAttachment(module, "public", "public.txt", "Public "+term)
+		secret = h.apiUploadRecordAttachment(module, "secret", "secret.txt", "Secret "+term)
+	)
+
+	h.repoMakeRecord(module,
+		&types.RecordValue{Name: "public", Value: strconv.FormatUint(public, 10), Ref: public},
+		&types.RecordValue{Name: "secret", Value: strconv.FormatUint(secret, 10), Ref: secret},
+	)
+
+	h.deny(module.Fields.FindByName("secret").PermissionResource(), "record.value.read")
+
+	h.apiInit().
+		Get("/search/attachments").
+		Query("query", term).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Len(`$.response.set`, 1)).
+		Assert(jsonpath.Equal(`$.response.set[0].attachmentID`, strconv.FormatUint(public, 10))).
+		End()
+}
+
+// Page attachments are found only when page is readable
+func TestSearchAttachmentsPageRead(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.NamespacePermissionResource.AppendWildcard(), "read")
+	h.allow(types.PagePermissionResource.AppendWildcard(), "read")
+	h.allow(types.PagePermissionResource.AppendWildcard(), "update")
+
+	var (
+		ns   = h.repoMakeNamespace("attachment search namespace")
+		page = h.repoMakePage(ns, "attachment search page")
+		term = fmt.Sprintf("clause%d", time.Now().UnixNano())
+
+		attachmentID = h.apiUploadPageAttachment(page, "notes.txt", "Page notes "+term)
+	)
+
+	h.apiInit().
+		Get("/search/attachments").
+		Query("query", term).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Len(`$.response.set`, 1)).
+		Assert(jsonpath.Equal(`$.response.set[0].attachmentID`, strconv.FormatUint(attachmentID, 10))).
+		End()
+
+	h.deny(page.PermissionResource(), "read")
+
+	h.apiInit().
+		Get("/search/attachments").
+		Query("query", term).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Len(`$.response.set`, 0)).
+		End()
+}
+
+func TestSearchAttachmentsWithoutQuery(t *testing.T) {
+	h := newHelper(t)
+
+	h.apiInit().
+		Get("/search/attachments").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("search query is required")).
+		End()
+}
@@ -2,6 +2,9 @@ package helpers
 
 import (
 	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"time"
 
 	"go.uber.org/zap"
@@ -21,12 +24,23 @@ type (
 
 var _ app.Runnable = &TestApp{}
 
+var (
+	// temp directories with SQLite databases, removed by Cleanup
+	tempDirs []string
+)
+
 func NewIntegrationTestApp(service string, parts ...app.Runnable) app.Runnable {
 	opt := app.NewOptions(service)
 
 	// When running integration tests, we want to upgrade the db. Always.
 	opt.Upgrade.Always = true
 
+	if _, has := os.LookupEnv("DB_DSN"); !has {
+		// Without explicitly configured database,
+		// tests run against SQLite database in a temp file
+		opt.DB.DSN = "sqlite3://" + tempDatabase(service)
+	}
+
 	// Create a new JWT secret (to prevent any security weirdness)
 	opt.Auth.Secret = string(rand.Bytes(32))
 	opt.Auth.Expiry = time.Minute
@@ -62,3 +76,23 @@ func (app *TestApp) Activate(ctx context.Context) (err error) {
 func (app *TestApp) Provision(ctx context.Context) (err error) {
 	return
 }
+
+// tempDatabase returns path to new database file in temp directory
+func tempDatabase(service string) string {
+	dir, err := ioutil.TempDir("", "corteza-test-")
+	cli.HandleError(err)
+
+	tempDirs = append(tempDirs, dir)
+	return filepath.Join(dir, service+".db")
+}
+
+// Cleanup removes temporary databases created for the tests
+//
+// Should be called (from TestMain) after all tests are done
+func Cleanup() {
+	for _, dir := range tempDirs {
+		_ = os.RemoveAll(dir)
+	}
+
+	tempDirs = nil
+}
@@ -2,10 +2,13 @@ package messaging
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"mime/multipart"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -13,10 +16,16 @@ import (
 	jsonpath "github.com/steinfletcher/apitest-jsonpath"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/cortezaproject/corteza-server/messaging/repository"
+	"github.com/cortezaproject/corteza-server/messaging/service"
 	"github.com/cortezaproject/corteza-server/messaging/types"
 	"github.com/cortezaproject/corteza-server/tests/helpers"
 )
 
+func (h helper) repoAttachment() repository.AttachmentRepository {
+	return repository.Attachment(context.Background(), db())
+}
+
 func (h helper) apiChAttach(ch *types.Channel, file []byte) *apitest.Response {
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
@@ -35,6 +44,33 @@ func (h helper) apiChAttach(ch *types.Channel, file []byte) *apitest.Response {
 		Status(http.StatusOK)
 }
 
+// Attaches file to the channel and waits for the preview of the file
+//
+// Previews of documents are stored in the background and would
+// otherwise interfere with the (SQLite) transactions of the test
+func (h helper) chAttach(ch *types.Channel, file []byte) uint64 {
+	var rval = struct {
+		Response struct {
+			AttachmentID string
+		}
+	}{}
+
+	h.apiChAttach(ch, file).
+		Assert(helpers.AssertNoErrors).
+		End().
+		JSON(&rval)
+
+	ID, err := strconv.ParseUint(rval.Response.AttachmentID, 10, 64)
+	h.a.NoError(err)
+
+	h.a.Eventually(func() bool {
+		att, err := h.repoAttachment().FindAttachmentByID(ID)
+		return err == nil && att.PreviewUrl != ""
+	}, 10*time.Second, 10*time.Millisecond)
+
+	return ID
+}
+
 // Non members should not be able to attach files to non-public channels
 func TestChannelAttachNotMember(t *testing.T) {
 	h := newHelper(t)
@@ -87,9 +123,7 @@ func TestChannelAttachAndDelete(t *testing.T) {
 	ch := h.repoMakePublicCh()
 	h.repoMakeMember(ch, h.cUser)
 
-	h.apiChAttach(ch, []byte("dummy")).
-		Assert(helpers.AssertNoErrors).
-		End()
+	h.chAttach(ch, []byte("dummy"))
 
 	var rval = struct {
 		Response []struct {
@@ -115,3 +149,138 @@ func TestChannelAttachAndDelete(t *testing.T) {
 		End()
 
 }
+
+func TestChannelAttachSearch(t *testing.T) {
+	h := newHelper(t)
+
+	var (
+		term = fmt.Sprintf("clause%d", time.Now().UnixNano())
+
+		public  = h.repoMakePublicCh()
+		private = h.repoMakePrivateCh()
+	)
+
+	h.repoMakeMember(public, h.cUser)
+
+	h.chAttach(public, []byte("contract mentioning "+term))
+	h.chAttach(public, []byte("unrelated document"))
+
+	h.apiInit().
+		Get("/search/attachments").
+		Query("query", term).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Len(`$.response`, 1)).
+		Assert(jsonpath.Equal(`$.response[0].channelID`, fmt.Sprintf("%d", public.ID))).
+		Assert(jsonpath.Present(`$.response[0].messageID`)).
+		End()
+
+	// Attachments from channels user can not read are not found
+	h.apiInit().
+		Get("/search/attachments").
+		Query("query", term).
+		Query("channelID", fmt.Sprintf("%d", private.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Len(`$.response`, 0)).
+		End()
+
+	h.apiInit().
+		Get("/search/attachments").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("search query is required")).
+		End()
+}
+
+// Files with content of a denied type are rejected regardless of the extension
+func TestChannelAttachRejected(t *testing.T) {
+	h := newHelper(t)
+
+	ch := h.repoMakePublicCh()
+	h.repoMakeMember(ch, h.cUser)
+
+	h.apiChAttach(ch, []byte("<html><body>not really a text file</body></html>")).
+		Assert(helpers.AssertError("upload rejected: file type text/html is not allowed")).
+		End()
+}
+
+func TestChannelAttachQuota(t *testing.T) {
+	h := newHelper(t)
+
+	defer func(q service.AttachmentQuota) {
+		*service.DefaultAttachmentQuota = q
+	}(*service.DefaultAttachmentQuota)
+
+	var (
+		ch      = h.repoMakePublicCh()
+		other   = h.repoMakePublicCh()
+		content = []byte(strings.Repeat("x", 60))
+	)
+
+	h.repoMakeMember(ch, h.cUser)
+	h.repoMakeMember(other, h.cUser)
+
+	*service.DefaultAttachmentQuota = service.AttachmentQuota{Channel: 100}
+
+	h.chAttach(ch, content)
+	h.apiChAttach(ch, content).Assert(helpers.AssertError("channel storage quota exceeded")).End()
+
+	// Other channel has its own quota
+	h.chAttach(other, content)
+
+	*service.DefaultAttachmentQuota = service.AttachmentQuota{User: 150}
+
+	// User already stored 120 bytes (in both channels)
+	h.apiChAttach(other, content).Assert(helpers.AssertError("user storage quota exceeded")).End()
+}
+
+func TestChannelAttachRetention(t *testing.T) {
+	h := newHelper(t)
+
+	var (
+		kept    = h.repoMakePublicCh()
+		deleted = h.repoMakePublicCh()
+
+		found = func(r *service.AttachmentRetentionReport) map[uint64]bool {
+			ff := map[uint64]bool{}
+			for _, att := range r.Attachments {
+				ff[att.ID] = true
+			}
+			return ff
+		}
+	)
+
+	h.repoMakeMember(kept, h.cUser)
+	h.repoMakeMember(deleted, h.cUser)
+
+	keptID := h.chAttach(kept, []byte("dummy"))
+	deletedID := h.chAttach(deleted, []byte("dummy"))
+
+	mm, _, err := h.repoMessage().Find(types.MessageFilter{ChannelID: []uint64{deleted.ID}})
+	h.a.NoError(err)
+	h.a.Len(mm, 1)
+	h.a.NoError(h.repoMessage().DeleteByID(mm[0].ID))
+
+	before := time.Now().Add(time.Second)
+
+	r, err := service.DefaultAttachmentRetention.Apply(context.Background(), before, true)
+	h.a.NoError(err)
+	h.a.True(found(r)[deletedID])
+	h.a.False(found(r)[keptID])
+
+	// Dry run does not remove anything
+	_, err = h.repoAttachment().FindAttachmentByID(deletedID)
+	h.a.NoError(err)
+
+	r, err = service.DefaultAttachmentRetention.Apply(context.Background(), before, false)
+	h.a.NoError(err)
+	h.a.Zero(r.Failed)
+
+	_, err = h.repoAttachment().FindAttachmentByID(deletedID)
+	h.a.True(repository.ErrAttachmentNotFound.Eq(err))
+	_, err = h.repoAttachment().FindAttachmentByID(keptID)
+	h.a.NoError(err)
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/cortezaproject/corteza-server/pkg/auth"
 	"github.com/cortezaproject/corteza-server/pkg/logger"
 	"github.com/cortezaproject/corteza-server/pkg/permissions"
+	"github.com/cortezaproject/corteza-server/pkg/scan"
 	"github.com/cortezaproject/corteza-server/pkg/store/plain"
 	sysTypes "github.com/cortezaproject/corteza-server/system/types"
 	"github.com/cortezaproject/corteza-server/tests/helpers"
@@ -58,6 +59,12 @@ func db() *factory.DB {
 func (app *TestApp) Initialize(ctx context.Context) (err error) {
 	service.DefaultPermissions = permissions.NewTestService(ctx, app.Log, db(), "messaging_permission_rules")
 	service.DefaultStore, err = plain.NewWithAfero(afero.NewMemMapFs(), "test")
+	service.DefaultUploadScanner = scan.Filter(scan.Rules{}, scan.Rules{Mimetypes: []string{"text/html"}, Extensions: []string{"exe"}})
+
+	// Quotas are enabled by individual tests
+	app.Opt.Attachment.ChannelQuota = 0
+	app.Opt.Attachment.MessagingUserQuota = 0
+
 	return
 }
 
@@ -86,7 +93,9 @@ func InitTestApp() {
 
 func TestMain(m *testing.M) {
 	InitTestApp()
-	os.Exit(m.Run())
+	code := m.Run()
+	helpers.Cleanup()
+	os.Exit(code)
 }
 
 func newHelper(t *testing.T) helper {
@@ -92,7 +92,9 @@ func InitTestApp() {
 
 func TestMain(m *testing.M) {
 	InitTestApp()
-	os.Exit(m.Run())
+	code := m.Run()
+	helpers.Cleanup()
+	os.Exit(code)
 }
 
 func newHelper(t *testing.T) helper {